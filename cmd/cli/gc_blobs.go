@@ -0,0 +1,55 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"task-engine/config"
+	"task-engine/internal/application/services"
+	"task-engine/internal/infrastructure/database"
+	"task-engine/internal/infrastructure/repositories/postgres"
+	"task-engine/internal/infrastructure/storage"
+	"time"
+)
+
+// defaultBlobGrace keeps blobs younger than this, their upload may still be in flight
+const defaultBlobGrace = time.Hour
+
+var gcBlobsCommand = command{
+	usage:       "gc-blobs [grace]",
+	description: "Delete stored files no attachment refers to, skipping files newer than grace (default 1h)",
+	run:         runGCBlobs,
+}
+
+func runGCBlobs(ctx context.Context, cfg *config.Config, args []string) error {
+	if len(args) > 1 {
+		return errors.New("usage: gc-blobs [grace]")
+	}
+	grace := defaultBlobGrace
+	if len(args) == 1 {
+		var err error
+		if grace, err = time.ParseDuration(args[0]); err != nil || grace < 0 {
+			return errors.New("grace must be a non-negative duration such as 30m")
+		}
+	}
+
+	blobs, err := storage.NewLocalBlobStore(cfg.Storage.Path)
+	if err != nil {
+		return err
+	}
+	db := postgres.NewDB(database.DB)
+	attachments := services.NewAttachmentService(
+		postgres.NewTaskRepository(db),
+		postgres.NewCommentRepository(db),
+		postgres.NewAttachmentRepository(db),
+		blobs,
+		cfg.Storage.MaxAttachmentBytes,
+	)
+
+	deleted, err := attachments.SweepOrphans(ctx, grace)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Deleted %d unreferenced blobs from %s\n", deleted, cfg.Storage.Path)
+	return nil
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"task-engine/config"
+	"task-engine/internal/application/services"
+	"task-engine/internal/domain/entities"
+	"task-engine/internal/domain/entities/common"
+	"task-engine/internal/infrastructure/database"
+	"task-engine/internal/infrastructure/repositories/postgres"
+)
+
+const (
+	importTrelloUsage = "usage: import-trello [--status list=status]... <owner_id> <board.json>"
+	importJiraUsage   = "usage: import-jira [--status name=status]... <owner_id> <file.xml|file.csv>"
+)
+
+var importTrelloCommand = command{
+	usage:       "import-trello [--status list=status]... <owner_id> <board.json>",
+	description: "Import a Trello board export owned by owner_id, updating what an earlier import of the board created",
+	run: func(ctx context.Context, cfg *config.Config, args []string) error {
+		return runExternalImport(ctx, importTrelloUsage, args, func(path string, r io.Reader) ([]*entities.ExternalProject, error) {
+			project, err := services.ParseTrelloBoard(r)
+			if err != nil {
+				return nil, err
+			}
+			return []*entities.ExternalProject{project}, nil
+		})
+	},
+}
+
+var importJiraCommand = command{
+	usage:       "import-jira [--status name=status]... <owner_id> <file.xml|file.csv>",
+	description: "Import a Jira XML or CSV export owned by owner_id, updating what an earlier import of the issues created",
+	run: func(ctx context.Context, cfg *config.Config, args []string) error {
+		return runExternalImport(ctx, importJiraUsage, args, func(path string, r io.Reader) ([]*entities.ExternalProject, error) {
+			switch strings.ToLower(filepath.Ext(path)) {
+			case ".xml":
+				return services.ParseJiraXML(r)
+			case ".csv":
+				return services.ParseJiraCSV(r)
+			}
+			return nil, errors.New("the file must end in .xml or .csv")
+		})
+	},
+}
+
+// runExternalImport reads the export with parse and imports it, mapping the statuses named
+// by --status flags
+func runExternalImport(ctx context.Context, usage string, args []string, parse func(path string, r io.Reader) ([]*entities.ExternalProject, error)) error {
+	statusMap := make(map[string]common.TaskStatus)
+	for len(args) > 0 && strings.HasPrefix(args[0], "--") {
+		if args[0] != "--status" || len(args) < 2 {
+			return errors.New(usage)
+		}
+		from, status, ok := strings.Cut(args[1], "=")
+		if !ok {
+			return errors.New("--status takes name=status, such as \"In Review\"=review")
+		}
+		statusMap[from] = common.TaskStatus(status)
+		args = args[2:]
+	}
+	if len(args) != 2 {
+		return errors.New(usage)
+	}
+	ownerID, err := strconv.ParseInt(args[0], 10, 64)
+	if err != nil || ownerID <= 0 {
+		return errors.New("owner_id must be a positive integer")
+	}
+
+	file, err := os.Open(args[1])
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	projects, err := parse(args[1], file)
+	if err != nil {
+		return err
+	}
+
+	db := postgres.NewDB(database.DB)
+	projectRepo := postgres.NewProjectRepository(db)
+	taskRepo := postgres.NewTaskRepository(db)
+	workflows := services.NewWorkflowService(db, projectRepo, postgres.NewWorkflowRepository(db), taskRepo)
+	imports := services.NewExternalImportService(db, postgres.NewImportMappingRepository(db), projectRepo, taskRepo,
+		postgres.NewTagRepository(db), postgres.NewAssignmentRepository(db), postgres.NewCommentRepository(db), postgres.NewUserRepository(db), postgres.NewCustomFieldRepository(db), workflows)
+
+	report, err := imports.Import(ctx, services.ExternalImportInput{OwnerID: ownerID, Projects: projects, StatusMap: statusMap})
+	var validationErrs common.ValidationErrors
+	if errors.As(err, &validationErrs) {
+		printImportErrors(validationErrs)
+		return fmt.Errorf("nothing was imported, %d errors", len(validationErrs))
+	} else if err != nil {
+		return err
+	}
+
+	fmt.Printf("Projects: %d created, %d updated. Tasks: %d created, %d updated. Comments: %d created.\n",
+		report.ProjectsCreated, report.ProjectsUpdated, report.TasksCreated, report.TasksUpdated, report.CommentsCreated)
+	if len(report.UnknownUsers) > 0 {
+		fmt.Printf("No account found for: %s\n", strings.Join(report.UnknownUsers, ", "))
+	}
+	return nil
+}
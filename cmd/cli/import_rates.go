@@ -0,0 +1,40 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"task-engine/config"
+	"task-engine/internal/application/services"
+	"task-engine/internal/infrastructure/database"
+	"task-engine/internal/infrastructure/repositories/postgres"
+)
+
+var importRatesCommand = command{
+	usage:       "import-rates <file.csv>",
+	description: "Load exchange rates from a date,base,quote,rate CSV file",
+	run:         runImportRates,
+}
+
+func runImportRates(ctx context.Context, _ *config.Config, args []string) error {
+	if len(args) != 1 {
+		return errors.New("usage: import-rates <file.csv>")
+	}
+
+	file, err := os.Open(args[0])
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	db := postgres.NewDB(database.DB)
+	rates := services.NewExchangeRateService(db, postgres.NewExchangeRateRepository(db))
+	imported, err := rates.ImportCSV(ctx, file)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Imported %d exchange rates from %s\n", imported, args[0])
+	return nil
+}
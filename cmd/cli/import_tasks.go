@@ -0,0 +1,106 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"task-engine/config"
+	"task-engine/internal/application/services"
+	"task-engine/internal/domain/entities/common"
+	"task-engine/internal/infrastructure/database"
+	"task-engine/internal/infrastructure/repositories/postgres"
+)
+
+const importUsage = "usage: import [--dry-run] [--map field=header]... <owner_id> <file.csv|file.json>"
+
+var importCommand = command{
+	usage:       "import [--dry-run] [--map field=header]... <owner_id> <file>",
+	description: "Load projects and tasks from a CSV or JSON file, owned by owner_id; --dry-run only reports the invalid rows",
+	run:         runImport,
+}
+
+func runImport(ctx context.Context, _ *config.Config, args []string) error {
+	var (
+		dryRun  bool
+		mapping = make(map[string]string)
+	)
+	for len(args) > 0 && strings.HasPrefix(args[0], "--") {
+		switch args[0] {
+		case "--dry-run":
+			dryRun = true
+			args = args[1:]
+		case "--map":
+			if len(args) < 2 {
+				return errors.New(importUsage)
+			}
+			field, header, ok := strings.Cut(args[1], "=")
+			if !ok {
+				return errors.New("--map takes field=header, such as title=Summary")
+			}
+			mapping[field] = header
+			args = args[2:]
+		default:
+			return errors.New(importUsage)
+		}
+	}
+	if len(args) != 2 {
+		return errors.New(importUsage)
+	}
+	ownerID, err := strconv.ParseInt(args[0], 10, 64)
+	if err != nil || ownerID <= 0 {
+		return errors.New("owner_id must be a positive integer")
+	}
+
+	file, err := os.Open(args[1])
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	var projects []*services.ImportProject
+	switch strings.ToLower(filepath.Ext(args[1])) {
+	case ".json":
+		projects, err = services.ParseImportJSON(file)
+	case ".csv":
+		projects, err = services.ParseImportCSV(file, mapping)
+	default:
+		return errors.New("the file must end in .csv or .json")
+	}
+	if err != nil {
+		return err
+	}
+
+	db := postgres.NewDB(database.DB)
+	projectRepo := postgres.NewProjectRepository(db)
+	workflows := services.NewWorkflowService(db, projectRepo, postgres.NewWorkflowRepository(db), postgres.NewTaskRepository(db))
+	imports := services.NewImportService(db, postgres.NewImportRepository(db), projectRepo, postgres.NewUserRepository(db), postgres.NewCustomFieldRepository(db), workflows)
+
+	report, err := imports.Import(ctx, services.ImportInput{OwnerID: ownerID, Projects: projects, DryRun: dryRun})
+	var rowErrors common.ValidationErrors
+	if errors.As(err, &rowErrors) {
+		printImportErrors(rowErrors)
+		return fmt.Errorf("nothing was imported, %d errors", len(rowErrors))
+	} else if err != nil {
+		return err
+	}
+
+	if report.DryRun {
+		printImportErrors(report.Errors)
+		fmt.Printf("Dry run: %d tasks would be imported into %d new and %d existing projects, %d errors\n",
+			report.TasksImported, report.ProjectsCreated, report.ProjectsExisting, len(report.Errors))
+		return nil
+	}
+	fmt.Printf("Imported %d tasks into %d new and %d existing projects from %s\n",
+		report.TasksImported, report.ProjectsCreated, report.ProjectsExisting, args[1])
+	return nil
+}
+
+func printImportErrors(rowErrors common.ValidationErrors) {
+	for _, e := range rowErrors {
+		fmt.Fprintf(os.Stderr, "%s: %s\n", e.Field, e.Message)
+	}
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"task-engine/config"
+	"task-engine/internal/domain/entities/common"
+	"task-engine/pkg/auth"
+)
+
+var issueTokenCommand = command{
+	usage:       "issue-token <user_id> <role>",
+	description: "Print an API access token for a user (development only)",
+	run:         runIssueToken,
+}
+
+func runIssueToken(_ context.Context, cfg *config.Config, args []string) error {
+	if len(args) != 2 {
+		return errors.New("usage: issue-token <user_id> <role>")
+	}
+
+	userID, err := strconv.ParseInt(args[0], 10, 64)
+	if err != nil || userID <= 0 {
+		return errors.New("user_id must be a positive integer")
+	}
+	if err := common.ValidateUserRole("role", common.UserRole(args[1])); err != nil {
+		return err
+	}
+
+	token, err := auth.GenerateToken(cfg.JWT.Secret, userID, args[1], cfg.JWT.Expiration)
+	if err != nil {
+		return err
+	}
+	fmt.Println(token)
+	return nil
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"task-engine/config"
+	"task-engine/internal/infrastructure/database"
+	"task-engine/pkg/logger"
+)
+
+// command is a CLI subcommand. It receives the arguments that follow its name.
+type command struct {
+	usage       string
+	description string
+	run         func(ctx context.Context, cfg *config.Config, args []string) error
+}
+
+var commands = map[string]command{
+	"gc-blobs":       gcBlobsCommand,
+	"import":         importCommand,
+	"import-jira":    importJiraCommand,
+	"import-rates":   importRatesCommand,
+	"import-trello":  importTrelloCommand,
+	"issue-token":    issueTokenCommand,
+	"query-tasks":    queryTasksCommand,
+	"reindex-search": reindexSearchCommand,
+	"scan-git":       scanGitCommand,
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		printUsage()
+		os.Exit(2)
+	}
+
+	cmd, ok := commands[os.Args[1]]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "unknown command: %s\n\n", os.Args[1])
+		printUsage()
+		os.Exit(2)
+	}
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error loading configuration: ", err)
+		os.Exit(1)
+	}
+
+	logger.Init()
+	defer logger.Sync()
+
+	if err := database.Connect(cfg); err != nil {
+		fmt.Fprintln(os.Stderr, "Error connecting to database: ", err)
+		os.Exit(1)
+	}
+
+	if err := cmd.run(context.Background(), cfg, os.Args[2:]); err != nil {
+		fmt.Fprintln(os.Stderr, "Error: ", err)
+		os.Exit(1)
+	}
+}
+
+func printUsage() {
+	fmt.Fprintln(os.Stderr, "Usage: task-engine-cli <command> [arguments]")
+	fmt.Fprintln(os.Stderr, "Commands:")
+
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(os.Stderr, "  %-40s %s\n", commands[name].usage, commands[name].description)
+	}
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"task-engine/config"
+	"task-engine/internal/application/services"
+	"task-engine/internal/domain/entities"
+	"task-engine/internal/infrastructure/database"
+	"task-engine/internal/infrastructure/repositories/postgres"
+	"text/tabwriter"
+)
+
+var queryTasksCommand = command{
+	usage:       "query-tasks [--sql] <project_id> <query> [user_id]",
+	description: "List the tasks matching a task query such as \"status:todo priority>=high due<7d\", in every project when project_id is 0; user_id is who assignee:me stands for, --sql prints the query run instead",
+	run:         runQueryTasks,
+}
+
+func runQueryTasks(ctx context.Context, cfg *config.Config, args []string) error {
+	printSQL := len(args) > 0 && args[0] == "--sql"
+	if printSQL {
+		args = args[1:]
+	}
+	if len(args) < 2 || len(args) > 3 {
+		return errors.New("usage: query-tasks [--sql] <project_id> <query> [user_id]")
+	}
+	projectID, err := strconv.ParseInt(args[0], 10, 64)
+	if err != nil || projectID < 0 {
+		return errors.New("project_id must be a positive integer, or 0 for every project")
+	}
+	input := services.ListTasksInput{ProjectID: projectID, Query: args[1]}
+	if len(args) == 3 {
+		if input.UserID, err = strconv.ParseInt(args[2], 10, 64); err != nil || input.UserID <= 0 {
+			return errors.New("user_id must be a positive integer")
+		}
+	}
+
+	db := postgres.NewDB(database.DB)
+	projects := postgres.NewProjectRepository(db)
+	tasks := postgres.NewTaskRepository(db)
+	workflows := services.NewWorkflowService(db, projects, postgres.NewWorkflowRepository(db), tasks)
+	// listing tasks neither stores attachments nor publishes events
+	taskService := services.NewTaskService(db, projects, tasks, postgres.NewCustomFieldRepository(db), postgres.NewTagRepository(db), postgres.NewAssignmentRepository(db), postgres.NewChecklistRepository(db), postgres.NewUserRepository(db), workflows, nil, nil, cfg.JWT.Secret)
+
+	if printSQL {
+		filter, err := taskService.ListFilter(ctx, input)
+		if err != nil {
+			return queryError(input.Query, err)
+		}
+		query, queryArgs, err := postgres.TaskListSQL(filter)
+		if err != nil {
+			return err
+		}
+		fmt.Println(strings.TrimSpace(query))
+		for i, arg := range queryArgs {
+			fmt.Printf("-- $%d = %v\n", i+1, arg)
+		}
+		return nil
+	}
+
+	found, err := taskService.ListTasks(ctx, input)
+	if err != nil {
+		return queryError(input.Query, err)
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tPROJECT\tSTATUS\tPRIORITY\tDUE\tTITLE")
+	for _, task := range found {
+		due := ""
+		if !task.DueDate.IsZero() {
+			due = task.DueDate.Format("2006-01-02")
+		}
+		fmt.Fprintf(w, "%d\t%d\t%s\t%s\t%s\t%s\n", task.ID, task.ProjectID, task.Status, task.Priority, due, task.Title)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	fmt.Printf("%d tasks\n", len(found))
+	return nil
+}
+
+// queryError points at the mistake of a task query under the query
+func queryError(query string, err error) error {
+	var queryErr *entities.TaskQueryError
+	if errors.As(err, &queryErr) {
+		fmt.Fprintf(os.Stderr, "  %s\n  %s^\n", query, strings.Repeat(" ", queryErr.Column-1))
+	}
+	return err
+}
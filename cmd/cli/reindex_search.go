@@ -0,0 +1,40 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"task-engine/config"
+	"task-engine/internal/application/services"
+	"task-engine/internal/infrastructure/database"
+	"task-engine/internal/infrastructure/repositories/postgres"
+)
+
+var reindexSearchCommand = command{
+	usage:       "reindex-search [project_id]",
+	description: "Index tasks and comments again in their project's search language, after the language of a project changed",
+	run:         runReindexSearch,
+}
+
+func runReindexSearch(ctx context.Context, cfg *config.Config, args []string) error {
+	if len(args) > 1 {
+		return errors.New("usage: reindex-search [project_id]")
+	}
+	var projectID int64
+	if len(args) == 1 {
+		var err error
+		if projectID, err = strconv.ParseInt(args[0], 10, 64); err != nil || projectID <= 0 {
+			return errors.New("project_id must be a positive integer")
+		}
+	}
+
+	db := postgres.NewDB(database.DB)
+	search := services.NewSearchService(postgres.NewProjectRepository(db), postgres.NewSearchRepository(db))
+	reindexed, err := search.Reindex(ctx, projectID)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Reindexed %d tasks and comments\n", reindexed)
+	return nil
+}
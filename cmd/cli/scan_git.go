@@ -0,0 +1,144 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"task-engine/config"
+	"task-engine/internal/application/services"
+	"task-engine/internal/domain/entities"
+	"task-engine/internal/infrastructure/database"
+	"task-engine/internal/infrastructure/mail"
+	"task-engine/internal/infrastructure/repositories/postgres"
+	"task-engine/internal/infrastructure/storage"
+	"task-engine/internal/infrastructure/webhook"
+	"time"
+)
+
+// gitLogFormat separates the fields of a commit with unit separators and commits with
+// record separators, neither appears in commit messages
+const gitLogFormat = "%H%x1f%an%x1f%ae%x1f%cI%x1f%B%x1e"
+
+var scanGitCommand = command{
+	usage:       "scan-git <repository> <branch> <user_id> [since]",
+	description: "Link the commits of a local repository branch to the tasks they reference, optionally only those after revision since",
+	run:         runScanGit,
+}
+
+func runScanGit(ctx context.Context, cfg *config.Config, args []string) error {
+	if len(args) < 3 || len(args) > 4 {
+		return errors.New("usage: scan-git <repository> <branch> <user_id> [since]")
+	}
+	path, branch := args[0], args[1]
+	userID, err := strconv.ParseInt(args[2], 10, 64)
+	if err != nil || userID <= 0 {
+		return errors.New("user_id must be a positive integer")
+	}
+	revisions := branch
+	if len(args) == 4 {
+		revisions = args[3] + ".." + branch
+	}
+
+	commits, err := readGitLog(ctx, path, revisions)
+	if err != nil {
+		return err
+	}
+	absolute, err := filepath.Abs(path)
+	if err != nil {
+		return err
+	}
+	push, err := entities.NewGitPush(filepath.Base(absolute), branch, commits)
+	if err != nil {
+		return err
+	}
+
+	git, users, err := newGitIntegrationService(cfg)
+	if err != nil {
+		return err
+	}
+	user, err := users.FindByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("user %d: %w", userID, err)
+	}
+
+	result, err := git.ProcessPush(ctx, push, entities.TransitionActor{UserID: user.ID, Role: user.Role})
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Scanned %d commits of %s: %d linked, %d tasks completed\n", result.Commits, branch, len(result.Linked), len(result.Completed))
+	for _, skipped := range result.Skipped {
+		fmt.Printf("  skipped %s in %.7s: %s\n", skipped.Reference, skipped.SHA, skipped.Reason)
+	}
+	return nil
+}
+
+// readGitLog returns the commits of the revision range, oldest first
+func readGitLog(ctx context.Context, path, revisions string) ([]*entities.GitCommit, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "git", "-C", path, "log", "--reverse", "--format="+gitLogFormat, revisions, "--")
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("git log: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	var commits []*entities.GitCommit
+	for _, record := range strings.Split(stdout.String(), "\x1e") {
+		fields := strings.SplitN(strings.TrimLeft(record, "\n"), "\x1f", 5)
+		if len(fields) != 5 {
+			continue
+		}
+		committedAt, err := time.Parse(time.RFC3339, fields[3])
+		if err != nil {
+			return nil, fmt.Errorf("git log: commit %s: %w", fields[0], err)
+		}
+		commits = append(commits, &entities.GitCommit{
+			SHA:         fields[0],
+			AuthorName:  fields[1],
+			AuthorEmail: fields[2],
+			CommittedAt: committedAt,
+			Message:     strings.TrimSpace(fields[4]),
+		})
+	}
+	return commits, nil
+}
+
+// newGitIntegrationService wires the services like the server does, so comments and
+// completed tasks are notified and sent to webhooks
+func newGitIntegrationService(cfg *config.Config) (*services.GitIntegrationService, *postgres.UserRepository, error) {
+	db := postgres.NewDB(database.DB)
+	projects := postgres.NewProjectRepository(db)
+	tasks := postgres.NewTaskRepository(db)
+	users := postgres.NewUserRepository(db)
+	comments := postgres.NewCommentRepository(db)
+	attachments := postgres.NewAttachmentRepository(db)
+
+	blobs, err := storage.NewLocalBlobStore(cfg.Storage.Path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	publisher := services.NewEventPublisher(db, postgres.NewEventRepository(db))
+	subscriptions := services.NewSubscriptionService(projects, tasks, postgres.NewSubscriptionRepository(db))
+	publisher.Subscribe(subscriptions.HandleEvent)
+	var channels []services.NotificationChannel
+	if cfg.Mail.Host != "" {
+		channels = append(channels, services.NewEmailChannel(mail.NewSMTPMailer(cfg.Mail.Host, cfg.Mail.Port, cfg.Mail.Username, cfg.Mail.Password, cfg.Mail.From)))
+	}
+	notifications := services.NewNotificationService(db, postgres.NewNotificationRepository(db), postgres.NewNotificationPreferenceRepository(db), users, projects, tasks, comments, subscriptions, postgres.NewDigestScheduleRepository(db), cfg.Notification.MaxAttempts, channels...)
+	publisher.Subscribe(notifications.HandleEvent)
+	webhooks := services.NewWebhookService(db, postgres.NewWebhookRepository(db), projects, webhook.NewHTTPSender(cfg.Webhook.Timeout), cfg.Webhook.MaxAttempts, cfg.Webhook.Backoff)
+	publisher.Subscribe(webhooks.HandleEvent)
+
+	workflows := services.NewWorkflowService(db, projects, postgres.NewWorkflowRepository(db), tasks)
+	attachmentService := services.NewAttachmentService(tasks, comments, attachments, blobs, cfg.Storage.MaxAttachmentBytes)
+	taskService := services.NewTaskService(db, projects, tasks, postgres.NewCustomFieldRepository(db), postgres.NewTagRepository(db), postgres.NewAssignmentRepository(db), postgres.NewChecklistRepository(db), users, workflows, attachmentService, publisher, cfg.JWT.Secret)
+	commentService := services.NewCommentService(db, projects, tasks, comments, attachments, users, postgres.NewTeamRepository(db), publisher)
+
+	return services.NewGitIntegrationService(projects, tasks, postgres.NewTaskCommitRepository(db), taskService, commentService), users, nil
+}
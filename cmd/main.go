@@ -1,22 +1,154 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"task-engine/config"
+	"task-engine/internal/application/services"
+	"task-engine/internal/infrastructure/database"
+	"task-engine/internal/infrastructure/mail"
+	"task-engine/internal/infrastructure/repositories/postgres"
+	"task-engine/internal/infrastructure/storage"
+	"task-engine/internal/infrastructure/webhook"
+	"task-engine/internal/interfaces/http/handlers"
+	"task-engine/pkg/auth"
 	"task-engine/pkg/logger"
 
 	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
 )
 
 func main() {
 	cfg, err := config.LoadConfig()
 	if err != nil {
 		fmt.Println("Error loading configuration: ", err)
+		return
 	}
 
 	fmt.Println("Database URL:", cfg.GetDatabaseURL())
 	fmt.Println("Redis URL:", cfg.GetRedisURL())
 
+	logger.Init()
+	defer logger.Sync()
+
+	if err := database.Connect(cfg); err != nil {
+		logger.Fatal("failed to connect to database", zap.Error(err))
+	}
+
+	// Repositories
+	db := postgres.NewDB(database.DB)
+	projectRepo := postgres.NewProjectRepository(db)
+	expenseRepo := postgres.NewExpenseRepository(db)
+	eventRepo := postgres.NewEventRepository(db)
+	exchangeRateRepo := postgres.NewExchangeRateRepository(db)
+	taskRepo := postgres.NewTaskRepository(db)
+	userRepo := postgres.NewUserRepository(db)
+	timeEntryRepo := postgres.NewTimeEntryRepository(db)
+	hourlyRateRepo := postgres.NewHourlyRateRepository(db)
+	customFieldRepo := postgres.NewCustomFieldRepository(db)
+	workflowRepo := postgres.NewWorkflowRepository(db)
+	tagRepo := postgres.NewTagRepository(db)
+	assignmentRepo := postgres.NewAssignmentRepository(db)
+	projectTemplateRepo := postgres.NewProjectTemplateRepository(db)
+	checklistRepo := postgres.NewChecklistRepository(db)
+	commentRepo := postgres.NewCommentRepository(db)
+	attachmentRepo := postgres.NewAttachmentRepository(db)
+	subscriptionRepo := postgres.NewSubscriptionRepository(db)
+	teamRepo := postgres.NewTeamRepository(db)
+	notificationRepo := postgres.NewNotificationRepository(db)
+	notificationPreferenceRepo := postgres.NewNotificationPreferenceRepository(db)
+	digestScheduleRepo := postgres.NewDigestScheduleRepository(db)
+	webhookRepo := postgres.NewWebhookRepository(db)
+	taskCommitRepo := postgres.NewTaskCommitRepository(db)
+	taskEmailRepo := postgres.NewTaskEmailRepository(db)
+	searchRepo := postgres.NewSearchRepository(db)
+	savedViewRepo := postgres.NewSavedViewRepository(db)
+	importRepo := postgres.NewImportRepository(db)
+	importMappingRepo := postgres.NewImportMappingRepository(db)
+
+	blobStore, err := storage.NewLocalBlobStore(cfg.Storage.Path)
+	if err != nil {
+		logger.Fatal("failed to open blob storage", zap.Error(err))
+	}
+
+	// Services
+	projectService := services.NewProjectService(projectRepo)
+	eventPublisher := services.NewEventPublisher(db, eventRepo)
+	subscriptionService := services.NewSubscriptionService(projectRepo, taskRepo, subscriptionRepo)
+	eventPublisher.Subscribe(subscriptionService.HandleEvent)
+	var notificationChannels []services.NotificationChannel
+	var mailer *mail.SMTPMailer
+	if cfg.Mail.Host != "" {
+		mailer = mail.NewSMTPMailer(cfg.Mail.Host, cfg.Mail.Port, cfg.Mail.Username, cfg.Mail.Password, cfg.Mail.From)
+		notificationChannels = append(notificationChannels, services.NewEmailChannel(mailer))
+	}
+	notificationService := services.NewNotificationService(db, notificationRepo, notificationPreferenceRepo, userRepo, projectRepo, taskRepo, commentRepo, subscriptionService, digestScheduleRepo, cfg.Notification.MaxAttempts, notificationChannels...)
+	// Registered after the subscription handler so users watched by an event are notified of it
+	eventPublisher.Subscribe(notificationService.HandleEvent)
+	go notificationService.Run(context.Background(), cfg.Notification.DispatchInterval)
+	digestService := services.NewDigestService(db, digestScheduleRepo, notificationRepo, userRepo, taskRepo, assignmentRepo, mailer)
+	if mailer != nil {
+		go digestService.Run(context.Background(), cfg.Notification.DigestInterval)
+	}
+	webhookService := services.NewWebhookService(db, webhookRepo, projectRepo, webhook.NewHTTPSender(cfg.Webhook.Timeout), cfg.Webhook.MaxAttempts, cfg.Webhook.Backoff)
+	eventPublisher.Subscribe(webhookService.HandleEvent)
+	go webhookService.Run(context.Background(), cfg.Webhook.DispatchInterval)
+	exchangeRateService := services.NewExchangeRateService(db, exchangeRateRepo)
+	budgetService := services.NewBudgetService(db, projectRepo, expenseRepo, exchangeRateService, eventPublisher, cfg.Budget.AlertThresholds)
+	costService := services.NewCostService(projectRepo, taskRepo, userRepo, timeEntryRepo, hourlyRateRepo, exchangeRateService)
+	customFieldService := services.NewCustomFieldService(projectRepo, customFieldRepo)
+	workflowService := services.NewWorkflowService(db, projectRepo, workflowRepo, taskRepo)
+	attachmentService := services.NewAttachmentService(taskRepo, commentRepo, attachmentRepo, blobStore, cfg.Storage.MaxAttachmentBytes)
+	taskService := services.NewTaskService(db, projectRepo, taskRepo, customFieldRepo, tagRepo, assignmentRepo, checklistRepo, userRepo, workflowService, attachmentService, eventPublisher, cfg.JWT.Secret)
+	projectTemplateService := services.NewProjectTemplateService(db, projectTemplateRepo, projectRepo, workflowRepo, customFieldRepo, userRepo, taskService)
+	checklistService := services.NewChecklistService(taskRepo, checklistRepo)
+	commentService := services.NewCommentService(db, projectRepo, taskRepo, commentRepo, attachmentRepo, userRepo, teamRepo, eventPublisher)
+	teamService := services.NewTeamService(teamRepo, userRepo)
+	gitIntegrationService := services.NewGitIntegrationService(projectRepo, taskRepo, taskCommitRepo, taskService, commentService)
+	assignmentService := services.NewAssignmentService(taskRepo, userRepo, assignmentRepo, eventPublisher)
+	taskBulkService := services.NewTaskBulkService(db, taskService, assignmentService)
+	importService := services.NewImportService(db, importRepo, projectRepo, userRepo, customFieldRepo, workflowService)
+	externalImportService := services.NewExternalImportService(db, importMappingRepo, projectRepo, taskRepo, tagRepo, assignmentRepo, commentRepo, userRepo, customFieldRepo, workflowService)
+	searchService := services.NewSearchService(projectRepo, searchRepo)
+	savedViewService := services.NewSavedViewService(savedViewRepo, projectRepo, teamRepo, taskService)
+	if cfg.MailIngest.Path != "" {
+		maildir, err := mail.NewMaildir(cfg.MailIngest.Path)
+		if err != nil {
+			logger.Fatal("failed to open maildir", zap.Error(err))
+		}
+		mailIngestionService := services.NewMailIngestionService(db, maildir, projectRepo, userRepo, taskEmailRepo, taskService, commentService, attachmentService, cfg.MailIngest.FallbackUserID)
+		go mailIngestionService.Run(context.Background(), cfg.MailIngest.Interval)
+	}
+
 	r := gin.New()
 	r.Use(logger.GinLogger())
+
+	api := r.Group("/api/v1")
+	api.Use(auth.GinAuth(cfg.JWT.Secret))
+	handlers.NewProjectHandler(projectService).RegisterRoutes(api)
+	handlers.NewBudgetHandler(budgetService).RegisterRoutes(api)
+	handlers.NewExchangeRateHandler(exchangeRateService).RegisterRoutes(api)
+	handlers.NewCostHandler(costService).RegisterRoutes(api)
+	handlers.NewCustomFieldHandler(customFieldService).RegisterRoutes(api)
+	handlers.NewTaskHandler(taskService).RegisterRoutes(api)
+	handlers.NewTaskBulkHandler(taskBulkService).RegisterRoutes(api)
+	handlers.NewImportHandler(importService, externalImportService).RegisterRoutes(api)
+	handlers.NewWorkflowHandler(workflowService).RegisterRoutes(api)
+	handlers.NewProjectTemplateHandler(projectTemplateService).RegisterRoutes(api)
+	handlers.NewChecklistHandler(checklistService).RegisterRoutes(api)
+	handlers.NewCommentHandler(commentService).RegisterRoutes(api)
+	handlers.NewAttachmentHandler(attachmentService).RegisterRoutes(api)
+	handlers.NewAssignmentHandler(assignmentService).RegisterRoutes(api)
+	handlers.NewSubscriptionHandler(subscriptionService).RegisterRoutes(api)
+	handlers.NewTeamHandler(teamService).RegisterRoutes(api)
+	handlers.NewNotificationHandler(notificationService).RegisterRoutes(api)
+	handlers.NewDigestHandler(digestService).RegisterRoutes(api)
+	handlers.NewWebhookHandler(webhookService).RegisterRoutes(api)
+	handlers.NewGitHandler(gitIntegrationService).RegisterRoutes(api)
+	handlers.NewSearchHandler(searchService).RegisterRoutes(api)
+	handlers.NewSavedViewHandler(savedViewService).RegisterRoutes(api)
+
+	if err := r.Run(cfg.Server.Host + ":" + cfg.Server.Port); err != nil {
+		logger.Fatal("server stopped", zap.Error(err))
+	}
 }
@@ -4,16 +4,23 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/joho/godotenv"
 )
 
 type Config struct {
-	Server   ServerConfig
-	Database DatabaseConfig
-	Redis    RedisConfig
-	JWT      JWTConfig
+	Server       ServerConfig
+	Database     DatabaseConfig
+	Redis        RedisConfig
+	JWT          JWTConfig
+	Budget       BudgetConfig
+	Storage      StorageConfig
+	Mail         MailConfig
+	Notification NotificationConfig
+	Webhook      WebhookConfig
+	MailIngest   MailIngestConfig
 }
 
 type ServerConfig struct {
@@ -46,6 +53,54 @@ type JWTConfig struct {
 	RefreshExpiration time.Duration
 }
 
+// BudgetConfig holds the spend percentages that raise a budget burn alert.
+type BudgetConfig struct {
+	AlertThresholds []int
+}
+
+// StorageConfig holds where attachment contents are kept and how large an upload may be.
+type StorageConfig struct {
+	Path               string
+	MaxAttachmentBytes int64
+}
+
+// MailConfig holds the SMTP server used for email notifications. Email is disabled when
+// no host is set.
+type MailConfig struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	From     string
+}
+
+// NotificationConfig holds how often queued deliveries are sent, how many times a failed
+// delivery is retried and how often digest schedules are checked.
+type NotificationConfig struct {
+	DispatchInterval time.Duration
+	MaxAttempts      int
+	DigestInterval   time.Duration
+}
+
+// WebhookConfig holds how often due webhook deliveries are sent, how long a receiver has to
+// answer and how failed deliveries are retried: after Backoff, doubling each time, until
+// MaxAttempts have failed.
+type WebhookConfig struct {
+	DispatchInterval time.Duration
+	Timeout          time.Duration
+	MaxAttempts      int
+	Backoff          time.Duration
+}
+
+// MailIngestConfig holds the Maildir whose emails become tasks and how often it is read.
+// Ingestion is disabled when no path is set. Emails from unknown senders are attributed to
+// FallbackUserID, or rejected when it is zero.
+type MailIngestConfig struct {
+	Path           string
+	Interval       time.Duration
+	FallbackUserID int64
+}
+
 var AppConfig *Config
 
 func LoadConfig() (*Config, error) {
@@ -77,6 +132,36 @@ func LoadConfig() (*Config, error) {
 			Expiration:        getEnvDuration("JWT_EXPIRATION"),
 			RefreshExpiration: getEnvDuration("JWT_REFRESH_EXPIRATION"),
 		},
+		Budget: BudgetConfig{
+			AlertThresholds: getEnvIntList("BUDGET_ALERT_THRESHOLDS", "80,100"),
+		},
+		Storage: StorageConfig{
+			Path:               getEnvOrDefault("STORAGE_PATH", "./data/blobs"),
+			MaxAttachmentBytes: getEnvInt64OrDefault("ATTACHMENT_MAX_BYTES", 25<<20),
+		},
+		Mail: MailConfig{
+			Host:     os.Getenv("SMTP_HOST"),
+			Port:     int(getEnvInt64OrDefault("SMTP_PORT", 1025)),
+			Username: os.Getenv("SMTP_USERNAME"),
+			Password: os.Getenv("SMTP_PASSWORD"),
+			From:     getEnvOrDefault("SMTP_FROM", "task-engine@localhost"),
+		},
+		Notification: NotificationConfig{
+			DispatchInterval: getEnvDurationOrDefault("NOTIFICATION_DISPATCH_INTERVAL", 30*time.Second),
+			MaxAttempts:      int(getEnvInt64OrDefault("NOTIFICATION_MAX_ATTEMPTS", 5)),
+			DigestInterval:   getEnvDurationOrDefault("DIGEST_CHECK_INTERVAL", time.Minute),
+		},
+		Webhook: WebhookConfig{
+			DispatchInterval: getEnvDurationOrDefault("WEBHOOK_DISPATCH_INTERVAL", 10*time.Second),
+			Timeout:          getEnvDurationOrDefault("WEBHOOK_TIMEOUT", 10*time.Second),
+			MaxAttempts:      int(getEnvInt64OrDefault("WEBHOOK_MAX_ATTEMPTS", 8)),
+			Backoff:          getEnvDurationOrDefault("WEBHOOK_BACKOFF", 30*time.Second),
+		},
+		MailIngest: MailIngestConfig{
+			Path:           os.Getenv("MAILDIR_PATH"),
+			Interval:       getEnvDurationOrDefault("MAIL_INGEST_INTERVAL", time.Minute),
+			FallbackUserID: getEnvInt64OrDefault("MAIL_INGEST_USER_ID", 0),
+		},
 	}
 
 	if err := validateConfig(AppConfig); err != nil {
@@ -121,6 +206,13 @@ func getEnv(key string) string {
 	return val
 }
 
+func getEnvOrDefault(key, defaultValue string) string {
+	if val := os.Getenv(key); val != "" {
+		return val
+	}
+	return defaultValue
+}
+
 func getEnvInt(key string) int {
 	val := getEnv(key)
 	i, err := strconv.Atoi(val)
@@ -139,6 +231,42 @@ func getEnvDuration(key string) time.Duration {
 	return d
 }
 
+func getEnvDurationOrDefault(key string, defaultValue time.Duration) time.Duration {
+	val := os.Getenv(key)
+	if val == "" {
+		return defaultValue
+	}
+	d, err := time.ParseDuration(val)
+	if err != nil {
+		panic(fmt.Sprintf("Variable %s must be a valid duration", key))
+	}
+	return d
+}
+
+func getEnvInt64OrDefault(key string, defaultValue int64) int64 {
+	val := os.Getenv(key)
+	if val == "" {
+		return defaultValue
+	}
+	i, err := strconv.ParseInt(val, 10, 64)
+	if err != nil {
+		panic(fmt.Sprintf("Variable %s must be a valid integer", key))
+	}
+	return i
+}
+
+func getEnvIntList(key, defaultValue string) []int {
+	var list []int
+	for _, part := range strings.Split(getEnvOrDefault(key, defaultValue), ",") {
+		i, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil {
+			panic(fmt.Sprintf("Variable %s must be a comma-separated list of integers", key))
+		}
+		list = append(list, i)
+	}
+	return list
+}
+
 func validateConfig(config *Config) error {
 	if config.JWT.Secret == "" {
 		return fmt.Errorf("JWT_SECRET is required")
@@ -146,5 +274,29 @@ func validateConfig(config *Config) error {
 	if config.Database.Password == "" {
 		return fmt.Errorf("DB_PASSWORD is required")
 	}
+	if config.Storage.MaxAttachmentBytes <= 0 {
+		return fmt.Errorf("ATTACHMENT_MAX_BYTES must be positive")
+	}
+	if config.Notification.DispatchInterval <= 0 {
+		return fmt.Errorf("NOTIFICATION_DISPATCH_INTERVAL must be positive")
+	}
+	if config.Notification.MaxAttempts <= 0 {
+		return fmt.Errorf("NOTIFICATION_MAX_ATTEMPTS must be positive")
+	}
+	if config.Notification.DigestInterval <= 0 {
+		return fmt.Errorf("DIGEST_CHECK_INTERVAL must be positive")
+	}
+	if config.Webhook.DispatchInterval <= 0 {
+		return fmt.Errorf("WEBHOOK_DISPATCH_INTERVAL must be positive")
+	}
+	if config.Webhook.MaxAttempts <= 0 {
+		return fmt.Errorf("WEBHOOK_MAX_ATTEMPTS must be positive")
+	}
+	if config.Webhook.Backoff <= 0 {
+		return fmt.Errorf("WEBHOOK_BACKOFF must be positive")
+	}
+	if config.MailIngest.Interval <= 0 {
+		return fmt.Errorf("MAIL_INGEST_INTERVAL must be positive")
+	}
 	return nil
 }
@@ -0,0 +1,55 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"task-engine/internal/domain/entities"
+	"task-engine/internal/domain/entities/common"
+	"task-engine/internal/domain/repositories"
+)
+
+type AssignmentService struct {
+	tasks       repositories.TaskRepository
+	users       repositories.UserRepository
+	assignments repositories.AssignmentRepository
+	publisher   *EventPublisher
+}
+
+func NewAssignmentService(tasks repositories.TaskRepository, users repositories.UserRepository, assignments repositories.AssignmentRepository, publisher *EventPublisher) *AssignmentService {
+	return &AssignmentService{tasks: tasks, users: users, assignments: assignments, publisher: publisher}
+}
+
+// Assign adds the user to the task assignees, publishing task.assigned when they were not assigned yet
+func (s *AssignmentService) Assign(ctx context.Context, taskID, userID, actorID int64) error {
+	task, err := s.tasks.FindByID(ctx, taskID)
+	if err != nil {
+		return err
+	}
+	if _, err := s.users.FindByID(ctx, userID); errors.Is(err, repositories.ErrNotFound) {
+		return &common.FieldValidationError{Field: "user_id", Message: "user does not exist"}
+	} else if err != nil {
+		return err
+	}
+
+	assigned, err := s.assignments.Assign(ctx, taskID, userID)
+	if err != nil || !assigned {
+		return err
+	}
+	return s.publisher.PublishTaskEvent(ctx, common.EventTypeTaskAssigned, task, actorID, entities.TaskAssignedPayload{
+		AssigneeID: userID,
+	})
+}
+
+// Unassign removes the user from the task assignees, publishing task.unassigned
+func (s *AssignmentService) Unassign(ctx context.Context, taskID, userID, actorID int64) error {
+	task, err := s.tasks.FindByID(ctx, taskID)
+	if err != nil {
+		return err
+	}
+	if err := s.assignments.Unassign(ctx, taskID, userID); err != nil {
+		return err
+	}
+	return s.publisher.PublishTaskEvent(ctx, common.EventTypeTaskUnassigned, task, actorID, entities.TaskUnassignedPayload{
+		AssigneeID: userID,
+	})
+}
@@ -0,0 +1,232 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"task-engine/internal/domain/entities"
+	"task-engine/internal/domain/entities/common"
+	"task-engine/internal/domain/repositories"
+	"time"
+)
+
+// sniffLength is how much of an upload http.DetectContentType looks at
+const sniffLength = 512
+
+// blobSweepBatch bounds the number of keys checked against the database at once
+const blobSweepBatch = 500
+
+// blobCollectGrace keeps the blobs written this recently when collecting garbage. An upload
+// that hit an existing blob refreshes its time before its attachment is committed, so a
+// concurrent deletion does not remove content that is about to be referenced again. Such
+// blobs are left to SweepOrphans.
+const blobCollectGrace = time.Hour
+
+type AttachmentService struct {
+	tasks       repositories.TaskRepository
+	comments    repositories.CommentRepository
+	attachments repositories.AttachmentRepository
+	blobs       repositories.BlobStore
+	maxBytes    int64
+}
+
+func NewAttachmentService(tasks repositories.TaskRepository, comments repositories.CommentRepository, attachments repositories.AttachmentRepository, blobs repositories.BlobStore, maxBytes int64) *AttachmentService {
+	return &AttachmentService{
+		tasks:       tasks,
+		comments:    comments,
+		attachments: attachments,
+		blobs:       blobs,
+		maxBytes:    maxBytes,
+	}
+}
+
+type UploadInput struct {
+	Filename   string
+	UploadedBy int64
+	Content    io.Reader
+}
+
+// MaxBytes is the largest accepted upload
+func (s *AttachmentService) MaxBytes() int64 {
+	return s.maxBytes
+}
+
+func (s *AttachmentService) UploadToTask(ctx context.Context, taskID int64, input UploadInput) (*entities.Attachment, error) {
+	if _, err := s.tasks.FindByID(ctx, taskID); err != nil {
+		return nil, err
+	}
+	return s.upload(ctx, taskID, 0, input)
+}
+
+func (s *AttachmentService) UploadToComment(ctx context.Context, commentID int64, input UploadInput) (*entities.Attachment, error) {
+	comment, err := s.comments.FindByID(ctx, commentID)
+	if err != nil {
+		return nil, err
+	}
+	if comment.IsDeleted() {
+		return nil, &common.FieldValidationError{Field: "comment_id", Message: "cannot attach files to a deleted comment"}
+	}
+	return s.upload(ctx, comment.TaskID, comment.ID, input)
+}
+
+// ListByTask returns the attachments of the task, including those of its comments
+func (s *AttachmentService) ListByTask(ctx context.Context, taskID int64) ([]*entities.Attachment, error) {
+	if _, err := s.tasks.FindByID(ctx, taskID); err != nil {
+		return nil, err
+	}
+	return s.attachments.ListByTask(ctx, taskID)
+}
+
+// Open returns the attachment and its content, the caller closes the reader
+func (s *AttachmentService) Open(ctx context.Context, attachmentID int64) (*entities.Attachment, io.ReadCloser, error) {
+	attachment, err := s.attachments.FindByID(ctx, attachmentID)
+	if err != nil {
+		return nil, nil, err
+	}
+	content, err := s.blobs.Open(ctx, attachment.BlobKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	return attachment, content, nil
+}
+
+func (s *AttachmentService) Delete(ctx context.Context, attachmentID, userID int64, role common.UserRole) error {
+	attachment, err := s.attachments.FindByID(ctx, attachmentID)
+	if err != nil {
+		return err
+	}
+	if !attachment.CanDelete(userID, role) {
+		return &entities.ForbiddenError{Reason: "only the uploader or a manager can delete this attachment"}
+	}
+	if err := s.attachments.Delete(ctx, attachment.ID); err != nil {
+		return err
+	}
+	return s.collectGarbage(ctx, []string{attachment.BlobKey})
+}
+
+// SweepOrphans deletes the blobs no attachment refers to. Blobs written within grace are
+// kept since their attachment may not be committed yet. It returns how many were deleted.
+func (s *AttachmentService) SweepOrphans(ctx context.Context, grace time.Duration) (int, error) {
+	cutoff := time.Now().Add(-grace)
+
+	var candidates []string
+	err := s.blobs.Walk(ctx, func(key string, modified time.Time) error {
+		if modified.Before(cutoff) {
+			candidates = append(candidates, key)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	deleted := 0
+	for start := 0; start < len(candidates); start += blobSweepBatch {
+		end := min(start+blobSweepBatch, len(candidates))
+		n, err := s.deleteUnreferenced(ctx, candidates[start:end])
+		deleted += n
+		if err != nil {
+			return deleted, err
+		}
+	}
+	return deleted, nil
+}
+
+func (s *AttachmentService) upload(ctx context.Context, taskID, commentID int64, input UploadInput) (*entities.Attachment, error) {
+	// the stored content type comes from the bytes, whatever the client claims
+	head := make([]byte, sniffLength)
+	n, err := io.ReadFull(input.Content, head)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return nil, err
+	}
+	if n == 0 {
+		return nil, &common.FieldValidationError{Field: "file", Message: "file is empty"}
+	}
+	head = head[:n]
+	contentType := http.DetectContentType(head)
+
+	content := &sizeLimitedReader{r: io.MultiReader(bytes.NewReader(head), input.Content), limit: s.maxBytes}
+	key, size, err := s.blobs.Put(ctx, content)
+	if err != nil {
+		return nil, err
+	}
+
+	attachment, err := entities.NewAttachment(taskID, commentID, input.Filename, contentType, key, size, input.UploadedBy)
+	if err == nil {
+		err = s.attachments.Create(ctx, attachment)
+	}
+	if err != nil {
+		// the blob was just written, SweepOrphans removes it once it stays unused
+		return nil, err
+	}
+	return attachment, nil
+}
+
+// blobKeysOf returns the blobs used by the task and its subtasks, to be collected once it is deleted
+func (s *AttachmentService) blobKeysOf(ctx context.Context, taskID int64) ([]string, error) {
+	return s.attachments.BlobKeysByTask(ctx, taskID)
+}
+
+// collectGarbage deletes the blobs among keys that no attachment uses anymore and that were
+// not written within blobCollectGrace
+func (s *AttachmentService) collectGarbage(ctx context.Context, keys []string) error {
+	cutoff := time.Now().Add(-blobCollectGrace)
+	var candidates []string
+	for _, key := range keys {
+		modified, err := s.blobs.Modified(ctx, key)
+		if errors.Is(err, repositories.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if modified.Before(cutoff) {
+			candidates = append(candidates, key)
+		}
+	}
+	if len(candidates) == 0 {
+		return nil
+	}
+	_, err := s.deleteUnreferenced(ctx, candidates)
+	return err
+}
+
+func (s *AttachmentService) deleteUnreferenced(ctx context.Context, keys []string) (int, error) {
+	referenced, err := s.attachments.ReferencedKeys(ctx, keys)
+	if err != nil {
+		return 0, err
+	}
+
+	deleted := 0
+	for _, key := range keys {
+		if referenced[key] {
+			continue
+		}
+		if err := s.blobs.Delete(ctx, key); err != nil {
+			return deleted, err
+		}
+		deleted++
+	}
+	return deleted, nil
+}
+
+// sizeLimitedReader fails with an AttachmentTooLargeError once more than limit bytes were read
+type sizeLimitedReader struct {
+	r     io.Reader
+	limit int64
+	read  int64
+}
+
+func (l *sizeLimitedReader) Read(p []byte) (int, error) {
+	if remaining := l.limit - l.read + 1; int64(len(p)) > remaining {
+		p = p[:remaining]
+	}
+	n, err := l.r.Read(p)
+	l.read += int64(n)
+	if l.read > l.limit {
+		return n, &entities.AttachmentTooLargeError{Limit: l.limit}
+	}
+	return n, err
+}
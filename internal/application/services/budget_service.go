@@ -0,0 +1,203 @@
+package services
+
+import (
+	"context"
+	"task-engine/internal/domain/entities"
+	"task-engine/internal/domain/entities/common"
+	"task-engine/internal/domain/repositories"
+	"time"
+)
+
+type BudgetService struct {
+	tx         repositories.Transactor
+	projects   repositories.ProjectRepository
+	expenses   repositories.ExpenseRepository
+	rates      *ExchangeRateService
+	publisher  *EventPublisher
+	thresholds []int
+}
+
+func NewBudgetService(tx repositories.Transactor, projects repositories.ProjectRepository, expenses repositories.ExpenseRepository, rates *ExchangeRateService, publisher *EventPublisher, thresholds []int) *BudgetService {
+	if len(thresholds) == 0 {
+		thresholds = entities.DefaultBurnAlertThresholds
+	}
+	return &BudgetService{
+		tx:         tx,
+		projects:   projects,
+		expenses:   expenses,
+		rates:      rates,
+		publisher:  publisher,
+		thresholds: thresholds,
+	}
+}
+
+type RecordExpenseInput struct {
+	Kind        common.ExpenseKind
+	Category    common.ExpenseCategory
+	Description string
+	Amount      common.Money
+	IncurredOn  time.Time
+	CreatedBy   int64
+}
+
+// GetLedger returns the ledger in the project budget currency, converting expenses at today's rates
+func (s *BudgetService) GetLedger(ctx context.Context, projectID int64) (*entities.BudgetLedger, error) {
+	project, err := s.projects.FindByID(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	return s.buildLedger(ctx, project)
+}
+
+// GetReport returns the ledger converted into the reporting currency with the rates dated on or before asOf.
+// A zero currency keeps the budget currency and a zero asOf means today.
+func (s *BudgetService) GetReport(ctx context.Context, projectID int64, currency common.Currency, asOf time.Time) (*entities.BudgetLedger, error) {
+	project, err := s.projects.FindByID(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	if currency == "" {
+		currency = project.Budget.Currency
+	}
+	if err := common.ValidateCurrency("currency", currency); err != nil {
+		return nil, err
+	}
+	if asOf.IsZero() {
+		asOf = time.Now()
+	}
+
+	return s.buildLedgerIn(ctx, project, currency, asOf)
+}
+
+func (s *BudgetService) ListExpenses(ctx context.Context, projectID int64) ([]*entities.Expense, error) {
+	if _, err := s.projects.FindByID(ctx, projectID); err != nil {
+		return nil, err
+	}
+	return s.expenses.ListByProject(ctx, projectID)
+}
+
+// RecordExpense stores the expense and raises the burn alerts it crosses. The project row is
+// locked so that concurrent expenses compare their ledgers one after the other and each
+// threshold is alerted once.
+func (s *BudgetService) RecordExpense(ctx context.Context, projectID int64, input RecordExpenseInput) (*entities.Expense, error) {
+	expense, err := entities.NewExpense(projectID, input.Kind, input.Category, input.Description, input.Amount, input.IncurredOn, input.CreatedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		project, err := s.projects.FindByIDForUpdate(ctx, projectID)
+		if err != nil {
+			return err
+		}
+		// fail before storing anything when the expense cannot be priced in the budget currency
+		if _, err := s.rates.Conversion(project.Budget.Currency, time.Now()).Convert(ctx, expense.Amount); err != nil {
+			return err
+		}
+
+		before, err := s.buildLedger(ctx, project)
+		if err != nil {
+			return err
+		}
+		if err := s.expenses.Create(ctx, expense); err != nil {
+			return err
+		}
+
+		if !expense.IsActual() {
+			return nil
+		}
+		after, err := s.buildLedger(ctx, project)
+		if err != nil {
+			return err
+		}
+		return s.raiseBurnAlerts(ctx, before, after, expense.ID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return expense, nil
+}
+
+func (s *BudgetService) DeleteExpense(ctx context.Context, projectID, expenseID int64) error {
+	expense, err := s.expenses.FindByID(ctx, expenseID)
+	if err != nil {
+		return err
+	}
+	if expense.ProjectID != projectID {
+		return repositories.ErrNotFound
+	}
+	return s.expenses.Delete(ctx, expenseID)
+}
+
+// UpdateBudget changes the budget and raises the burn alerts the new amount crosses, under
+// the same project lock as RecordExpense
+func (s *BudgetService) UpdateBudget(ctx context.Context, projectID int64, budget common.Money) (*entities.Project, error) {
+	var project *entities.Project
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		if project, err = s.projects.FindByIDForUpdate(ctx, projectID); err != nil {
+			return err
+		}
+
+		before, err := s.buildLedger(ctx, project)
+		if err != nil {
+			return err
+		}
+		if err := project.UpdateBudget(budget); err != nil {
+			return err
+		}
+		if err := s.projects.Update(ctx, project); err != nil {
+			return err
+		}
+
+		after, err := s.buildLedger(ctx, project)
+		if err != nil {
+			return err
+		}
+		return s.raiseBurnAlerts(ctx, before, after, 0)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return project, nil
+}
+
+func (s *BudgetService) buildLedger(ctx context.Context, project *entities.Project) (*entities.BudgetLedger, error) {
+	return s.buildLedgerIn(ctx, project, project.Budget.Currency, time.Now())
+}
+
+func (s *BudgetService) buildLedgerIn(ctx context.Context, project *entities.Project, currency common.Currency, asOf time.Time) (*entities.BudgetLedger, error) {
+	expenses, err := s.expenses.ListByProject(ctx, project.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	conversion := s.rates.Conversion(currency, asOf)
+	ledger, err := entities.NewBudgetLedger(project, expenses, conversion.Converter(ctx))
+	if err != nil {
+		return nil, err
+	}
+	ledger.Rates = conversion.AppliedRates()
+	return ledger, nil
+}
+
+func (s *BudgetService) raiseBurnAlerts(ctx context.Context, before, after *entities.BudgetLedger, expenseID int64) error {
+	for _, threshold := range after.CrossedThresholds(before, s.thresholds) {
+		event, err := entities.NewEvent(common.EventTypeBudgetBurnAlert, after.ProjectID, 0, entities.BudgetBurnAlertPayload{
+			Threshold:   threshold,
+			Budget:      after.Budget,
+			Actual:      after.Actual,
+			Remaining:   after.Remaining,
+			BurnPercent: after.BurnPercent,
+			ExpenseID:   expenseID,
+		})
+		if err != nil {
+			return err
+		}
+		if err := s.publisher.Publish(ctx, event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+package services
+
+import (
+	"context"
+	"task-engine/internal/domain/entities"
+	"task-engine/internal/domain/repositories"
+)
+
+type ChecklistService struct {
+	tasks      repositories.TaskRepository
+	checklists repositories.ChecklistRepository
+}
+
+func NewChecklistService(tasks repositories.TaskRepository, checklists repositories.ChecklistRepository) *ChecklistService {
+	return &ChecklistService{tasks: tasks, checklists: checklists}
+}
+
+func (s *ChecklistService) ListItems(ctx context.Context, taskID int64) ([]*entities.ChecklistItem, error) {
+	if _, err := s.tasks.FindByID(ctx, taskID); err != nil {
+		return nil, err
+	}
+	return s.checklists.ListByTask(ctx, taskID)
+}
+
+func (s *ChecklistService) AddItem(ctx context.Context, taskID int64, text string) (*entities.ChecklistItem, error) {
+	if _, err := s.tasks.FindByID(ctx, taskID); err != nil {
+		return nil, err
+	}
+
+	item, err := entities.NewChecklistItem(taskID, text)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checklists.Create(ctx, item); err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+func (s *ChecklistService) UpdateItem(ctx context.Context, taskID, itemID int64, text string, position int) (*entities.ChecklistItem, error) {
+	item, err := s.findItem(ctx, taskID, itemID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := item.Update(text, position); err != nil {
+		return nil, err
+	}
+	if err := s.checklists.Update(ctx, item); err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+func (s *ChecklistService) CheckItem(ctx context.Context, taskID, itemID, userID int64) (*entities.ChecklistItem, error) {
+	item, err := s.findItem(ctx, taskID, itemID)
+	if err != nil {
+		return nil, err
+	}
+
+	item.Check(userID)
+	if err := s.checklists.Update(ctx, item); err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+func (s *ChecklistService) UncheckItem(ctx context.Context, taskID, itemID int64) (*entities.ChecklistItem, error) {
+	item, err := s.findItem(ctx, taskID, itemID)
+	if err != nil {
+		return nil, err
+	}
+
+	item.Uncheck()
+	if err := s.checklists.Update(ctx, item); err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+func (s *ChecklistService) DeleteItem(ctx context.Context, taskID, itemID int64) error {
+	if _, err := s.findItem(ctx, taskID, itemID); err != nil {
+		return err
+	}
+	return s.checklists.Delete(ctx, itemID)
+}
+
+func (s *ChecklistService) findItem(ctx context.Context, taskID, itemID int64) (*entities.ChecklistItem, error) {
+	item, err := s.checklists.FindByID(ctx, itemID)
+	if err != nil {
+		return nil, err
+	}
+	if item.TaskID != taskID {
+		return nil, repositories.ErrNotFound
+	}
+	return item, nil
+}
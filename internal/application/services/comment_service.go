@@ -0,0 +1,348 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"task-engine/internal/domain/entities"
+	"task-engine/internal/domain/entities/common"
+	"task-engine/internal/domain/repositories"
+)
+
+type CommentService struct {
+	tx          repositories.Transactor
+	projects    repositories.ProjectRepository
+	tasks       repositories.TaskRepository
+	comments    repositories.CommentRepository
+	attachments repositories.AttachmentRepository
+	users       repositories.UserRepository
+	teams       repositories.TeamRepository
+	publisher   *EventPublisher
+}
+
+func NewCommentService(tx repositories.Transactor, projects repositories.ProjectRepository, tasks repositories.TaskRepository, comments repositories.CommentRepository, attachments repositories.AttachmentRepository, users repositories.UserRepository, teams repositories.TeamRepository, publisher *EventPublisher) *CommentService {
+	return &CommentService{
+		tx:          tx,
+		projects:    projects,
+		tasks:       tasks,
+		comments:    comments,
+		attachments: attachments,
+		users:       users,
+		teams:       teams,
+		publisher:   publisher,
+	}
+}
+
+// AddCommentInput holds a new comment, ParentID is set for replies
+type AddCommentInput struct {
+	TaskID   int64
+	UserID   int64
+	ParentID int64
+	Content  string
+}
+
+// AddComment stores the comment with the users and teams it mentions. Mentioned users, team
+// members included, are told through comment.mentioned and left out of comment.added.
+func (s *CommentService) AddComment(ctx context.Context, input AddCommentInput) (*entities.Comment, error) {
+	task, err := s.tasks.FindByID(ctx, input.TaskID)
+	if err != nil {
+		return nil, err
+	}
+
+	comment, err := entities.NewComment(input.TaskID, input.UserID, input.Content)
+	if err != nil {
+		return nil, err
+	}
+	if input.ParentID != 0 {
+		parent, err := s.comments.FindByID(ctx, input.ParentID)
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, &common.FieldValidationError{Field: "parent_id", Message: "parent comment does not exist"}
+		} else if err != nil {
+			return nil, err
+		}
+		if err := comment.ReplyTo(parent); err != nil {
+			return nil, err
+		}
+	}
+	mentions, err := s.resolveMentions(ctx, comment.Content)
+	if err != nil {
+		return nil, err
+	}
+	mentioned, err := s.mentionedUsers(ctx, mentions, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.comments.Create(ctx, comment); err != nil {
+			return err
+		}
+		return s.saveMentions(ctx, comment.ID, mentions)
+	})
+	if err != nil {
+		return nil, err
+	}
+	comment.Mentions = mentions
+
+	if err := s.publisher.PublishTaskEvent(ctx, common.EventTypeCommentAdded, task, input.UserID, entities.CommentAddedPayload{
+		CommentID:        comment.ID,
+		MentionedUserIDs: mentioned,
+	}); err != nil {
+		return nil, err
+	}
+	if err := s.publishMentions(ctx, task, comment, input.UserID, mentioned); err != nil {
+		return nil, err
+	}
+	return comment, nil
+}
+
+// EditComment replaces the content of the comment, keeping the previous one as a revision.
+// Only users mentioned for the first time are notified.
+func (s *CommentService) EditComment(ctx context.Context, commentID, userID int64, role common.UserRole, content string) (*entities.Comment, error) {
+	comment, err := s.comments.FindByID(ctx, commentID)
+	if err != nil {
+		return nil, err
+	}
+	revision, err := comment.Edit(content, userID, role)
+	if err != nil {
+		return nil, err
+	}
+
+	previous, err := s.comments.ListMentions(ctx, []int64{comment.ID})
+	if err != nil {
+		return nil, err
+	}
+	before, err := s.mentionedUsers(ctx, previous[comment.ID], comment.UserID)
+	if err != nil {
+		return nil, err
+	}
+	mentions, err := s.resolveMentions(ctx, comment.Content)
+	if err != nil {
+		return nil, err
+	}
+	after, err := s.mentionedUsers(ctx, mentions, comment.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.comments.Update(ctx, comment); err != nil {
+			return err
+		}
+		if err := s.comments.CreateRevision(ctx, revision); err != nil {
+			return err
+		}
+		if err := s.comments.DeleteMentions(ctx, comment.ID); err != nil {
+			return err
+		}
+		return s.saveMentions(ctx, comment.ID, mentions)
+	})
+	if err != nil {
+		return nil, err
+	}
+	comment.Mentions = mentions
+
+	task, err := s.tasks.FindByID(ctx, comment.TaskID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.publishMentions(ctx, task, comment, userID, newlyMentioned(before, after)); err != nil {
+		return nil, err
+	}
+	return comment, nil
+}
+
+// DeleteComment leaves a tombstone in place of the comment, its replies are kept
+func (s *CommentService) DeleteComment(ctx context.Context, commentID, userID int64, role common.UserRole) error {
+	comment, err := s.comments.FindByID(ctx, commentID)
+	if err != nil {
+		return err
+	}
+	if err := comment.Delete(userID, role); err != nil {
+		return err
+	}
+	return s.comments.Update(ctx, comment)
+}
+
+// ListRevisions returns the earlier contents of the comment, oldest first. The history of a
+// deleted comment is not shown.
+func (s *CommentService) ListRevisions(ctx context.Context, commentID int64) ([]*entities.CommentRevision, error) {
+	comment, err := s.comments.FindByID(ctx, commentID)
+	if err != nil {
+		return nil, err
+	}
+	if comment.IsDeleted() {
+		return []*entities.CommentRevision{}, nil
+	}
+	return s.comments.ListRevisions(ctx, commentID)
+}
+
+// React adds the user's emoji to the comment and returns its reactions
+func (s *CommentService) React(ctx context.Context, commentID, userID int64, emoji string) ([]*entities.ReactionSummary, error) {
+	comment, err := s.comments.FindByID(ctx, commentID)
+	if err != nil {
+		return nil, err
+	}
+	if comment.IsDeleted() {
+		return nil, &common.FieldValidationError{Field: "emoji", Message: "cannot react to a deleted comment"}
+	}
+	reaction, err := entities.NewCommentReaction(comment.ID, userID, emoji)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.comments.AddReaction(ctx, reaction); err != nil {
+		return nil, err
+	}
+	return s.reactions(ctx, comment.ID)
+}
+
+// Unreact removes the user's emoji from the comment and returns its reactions
+func (s *CommentService) Unreact(ctx context.Context, commentID, userID int64, emoji string) ([]*entities.ReactionSummary, error) {
+	if _, err := s.comments.FindByID(ctx, commentID); err != nil {
+		return nil, err
+	}
+	if err := s.comments.RemoveReaction(ctx, commentID, userID, emoji); err != nil {
+		return nil, err
+	}
+	return s.reactions(ctx, commentID)
+}
+
+// ListComments returns the threads of the task, oldest first, with replies nested under their
+// top comment. Comments carry their attachments, mentions and reactions, tombstones are redacted.
+// ListComments returns the comments of the task when the user sees its project
+func (s *CommentService) ListComments(ctx context.Context, taskID, userID int64, role common.UserRole) ([]*entities.Comment, error) {
+	task, err := s.tasks.FindByID(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkProjectVisible(ctx, s.projects, task.ProjectID, userID, role); err != nil {
+		return nil, err
+	}
+
+	comments, err := s.comments.ListByTask(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	attachments, err := s.attachments.ListByTask(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	byComment := make(map[int64][]*entities.Attachment)
+	for _, attachment := range attachments {
+		if attachment.IsOnComment() {
+			byComment[attachment.CommentID] = append(byComment[attachment.CommentID], attachment)
+		}
+	}
+	ids := make([]int64, len(comments))
+	for i, comment := range comments {
+		ids[i] = comment.ID
+	}
+	mentions, err := s.comments.ListMentions(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	reactions, err := s.comments.ListReactions(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, comment := range comments {
+		comment.Attachments = byComment[comment.ID]
+		comment.Mentions = mentions[comment.ID]
+		comment.Reactions = entities.SummarizeReactions(reactions[comment.ID])
+		comment.Redact()
+	}
+	return entities.BuildThreads(comments), nil
+}
+
+func (s *CommentService) reactions(ctx context.Context, commentID int64) ([]*entities.ReactionSummary, error) {
+	reactions, err := s.comments.ListReactions(ctx, []int64{commentID})
+	if err != nil {
+		return nil, err
+	}
+	summaries := entities.SummarizeReactions(reactions[commentID])
+	if summaries == nil {
+		summaries = []*entities.ReactionSummary{}
+	}
+	return summaries, nil
+}
+
+func (s *CommentService) saveMentions(ctx context.Context, commentID int64, mentions []*entities.CommentMention) error {
+	for _, mention := range mentions {
+		mention.CommentID = commentID
+	}
+	return s.comments.CreateMentions(ctx, mentions)
+}
+
+func (s *CommentService) publishMentions(ctx context.Context, task *entities.Task, comment *entities.Comment, actorID int64, userIDs []int64) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+	return s.publisher.PublishTaskEvent(ctx, common.EventTypeCommentMentioned, task, actorID, entities.CommentMentionedPayload{
+		CommentID: comment.ID,
+		UserIDs:   userIDs,
+	})
+}
+
+// resolveMentions looks up the @handles of the content among usernames and team handles
+func (s *CommentService) resolveMentions(ctx context.Context, content string) ([]*entities.CommentMention, error) {
+	tokens := entities.ParseMentions(content)
+	if len(tokens) == 0 {
+		return nil, nil
+	}
+
+	handles := make([]string, len(tokens))
+	for i, token := range tokens {
+		handles[i] = token.Handle
+	}
+	users, err := s.users.FindByUsernames(ctx, handles)
+	if err != nil {
+		return nil, err
+	}
+	teams, err := s.teams.FindByHandles(ctx, handles)
+	if err != nil {
+		return nil, err
+	}
+	return entities.ResolveMentions(tokens, users, teams), nil
+}
+
+// mentionedUsers returns who the mentions reach, without the author
+func (s *CommentService) mentionedUsers(ctx context.Context, mentions []*entities.CommentMention, authorID int64) ([]int64, error) {
+	var teamIDs []int64
+	for _, mention := range mentions {
+		if mention.Kind == common.MentionKindTeam {
+			teamIDs = append(teamIDs, mention.TeamID)
+		}
+	}
+	var members map[int64][]int64
+	if len(teamIDs) > 0 {
+		var err error
+		if members, err = s.teams.ListMembers(ctx, teamIDs); err != nil {
+			return nil, err
+		}
+	}
+
+	var userIDs []int64
+	for _, userID := range entities.MentionedUserIDs(mentions, members) {
+		if userID != authorID {
+			userIDs = append(userIDs, userID)
+		}
+	}
+	return userIDs, nil
+}
+
+// newlyMentioned returns the users of after who are not in before
+func newlyMentioned(before, after []int64) []int64 {
+	known := make(map[int64]bool, len(before))
+	for _, userID := range before {
+		known[userID] = true
+	}
+	var added []int64
+	for _, userID := range after {
+		if !known[userID] {
+			added = append(added, userID)
+		}
+	}
+	return added
+}
@@ -0,0 +1,145 @@
+package services
+
+import (
+	"context"
+	"task-engine/internal/domain/entities"
+	"task-engine/internal/domain/entities/common"
+	"task-engine/internal/domain/repositories"
+	"time"
+)
+
+type CostService struct {
+	projects    repositories.ProjectRepository
+	tasks       repositories.TaskRepository
+	users       repositories.UserRepository
+	timeEntries repositories.TimeEntryRepository
+	hourlyRates repositories.HourlyRateRepository
+	rates       *ExchangeRateService
+}
+
+func NewCostService(projects repositories.ProjectRepository, tasks repositories.TaskRepository, users repositories.UserRepository, timeEntries repositories.TimeEntryRepository, hourlyRates repositories.HourlyRateRepository, rates *ExchangeRateService) *CostService {
+	return &CostService{
+		projects:    projects,
+		tasks:       tasks,
+		users:       users,
+		timeEntries: timeEntries,
+		hourlyRates: hourlyRates,
+		rates:       rates,
+	}
+}
+
+type LogTimeInput struct {
+	UserID      int64
+	Minutes     int64
+	SpentOn     time.Time
+	Description string
+}
+
+type SetHourlyRateInput struct {
+	UserID        int64
+	Role          common.UserRole
+	Rate          common.Money
+	EffectiveFrom time.Time
+}
+
+func (s *CostService) LogTime(ctx context.Context, taskID int64, input LogTimeInput) (*entities.TimeEntry, error) {
+	if _, err := s.tasks.FindByID(ctx, taskID); err != nil {
+		return nil, err
+	}
+
+	entry, err := entities.NewTimeEntry(taskID, input.UserID, input.Minutes, input.SpentOn, input.Description)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.timeEntries.Create(ctx, entry); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+func (s *CostService) ListTimeEntries(ctx context.Context, taskID int64) ([]*entities.TimeEntry, error) {
+	if _, err := s.tasks.FindByID(ctx, taskID); err != nil {
+		return nil, err
+	}
+	return s.timeEntries.ListByTask(ctx, taskID)
+}
+
+func (s *CostService) SetHourlyRate(ctx context.Context, input SetHourlyRateInput) (*entities.HourlyRate, error) {
+	var (
+		rate *entities.HourlyRate
+		err  error
+	)
+	if input.UserID != 0 {
+		if _, err := s.users.FindByID(ctx, input.UserID); err != nil {
+			return nil, err
+		}
+		rate, err = entities.NewUserHourlyRate(input.UserID, input.Rate, input.EffectiveFrom)
+	} else {
+		rate, err = entities.NewRoleHourlyRate(input.Role, input.Rate, input.EffectiveFrom)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.hourlyRates.Create(ctx, rate); err != nil {
+		return nil, err
+	}
+	return rate, nil
+}
+
+func (s *CostService) ListHourlyRates(ctx context.Context) ([]*entities.HourlyRate, error) {
+	return s.hourlyRates.List(ctx)
+}
+
+// GetCostReport prices the project's logged time in currency (the budget currency when empty)
+// using exchange rates dated on or before asOf (today when zero).
+func (s *CostService) GetCostReport(ctx context.Context, projectID int64, currency common.Currency, asOf time.Time) (*entities.CostReport, error) {
+	project, err := s.projects.FindByID(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	if currency == "" {
+		currency = project.Budget.Currency
+	}
+	if err := common.ValidateCurrency("currency", currency); err != nil {
+		return nil, err
+	}
+	if asOf.IsZero() {
+		asOf = time.Now()
+	}
+
+	tasks, err := s.tasks.ListByProject(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := s.timeEntries.ListByProject(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	hourlyRates, err := s.hourlyRates.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	userIDs := make([]int64, 0, len(entries))
+	seen := make(map[int64]bool)
+	for _, entry := range entries {
+		if !seen[entry.UserID] {
+			seen[entry.UserID] = true
+			userIDs = append(userIDs, entry.UserID)
+		}
+	}
+	users, err := s.users.FindByIDs(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	conversion := s.rates.Conversion(currency, asOf)
+	report, err := entities.NewCostReport(project, tasks, entries, users, entities.NewRateCard(hourlyRates), conversion.Converter(ctx))
+	if err != nil {
+		return nil, err
+	}
+	report.Rates = conversion.AppliedRates()
+	return report, nil
+}
@@ -0,0 +1,87 @@
+package services
+
+import (
+	"context"
+	"task-engine/internal/domain/entities"
+	"task-engine/internal/domain/entities/common"
+	"task-engine/internal/domain/repositories"
+)
+
+type CustomFieldService struct {
+	projects     repositories.ProjectRepository
+	customFields repositories.CustomFieldRepository
+}
+
+func NewCustomFieldService(projects repositories.ProjectRepository, customFields repositories.CustomFieldRepository) *CustomFieldService {
+	return &CustomFieldService{projects: projects, customFields: customFields}
+}
+
+type CreateCustomFieldInput struct {
+	Key      string
+	Name     string
+	Type     common.CustomFieldType
+	Options  []string
+	Required bool
+}
+
+type UpdateCustomFieldInput struct {
+	Name     string
+	Options  []string
+	Required bool
+	Position int
+}
+
+func (s *CustomFieldService) ListDefinitions(ctx context.Context, projectID int64) ([]*entities.CustomFieldDefinition, error) {
+	if _, err := s.projects.FindByID(ctx, projectID); err != nil {
+		return nil, err
+	}
+	return s.customFields.ListDefinitions(ctx, projectID)
+}
+
+func (s *CustomFieldService) CreateDefinition(ctx context.Context, projectID int64, input CreateCustomFieldInput) (*entities.CustomFieldDefinition, error) {
+	if _, err := s.projects.FindByID(ctx, projectID); err != nil {
+		return nil, err
+	}
+
+	definition, err := entities.NewCustomFieldDefinition(projectID, input.Key, input.Name, input.Type, input.Options, input.Required)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.customFields.CreateDefinition(ctx, definition); err != nil {
+		return nil, err
+	}
+	return definition, nil
+}
+
+func (s *CustomFieldService) UpdateDefinition(ctx context.Context, projectID, fieldID int64, input UpdateCustomFieldInput) (*entities.CustomFieldDefinition, error) {
+	definition, err := s.findDefinition(ctx, projectID, fieldID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := definition.Update(input.Name, input.Options, input.Required, input.Position); err != nil {
+		return nil, err
+	}
+	if err := s.customFields.UpdateDefinition(ctx, definition); err != nil {
+		return nil, err
+	}
+	return definition, nil
+}
+
+func (s *CustomFieldService) DeleteDefinition(ctx context.Context, projectID, fieldID int64) error {
+	if _, err := s.findDefinition(ctx, projectID, fieldID); err != nil {
+		return err
+	}
+	return s.customFields.DeleteDefinition(ctx, fieldID)
+}
+
+func (s *CustomFieldService) findDefinition(ctx context.Context, projectID, fieldID int64) (*entities.CustomFieldDefinition, error) {
+	definition, err := s.customFields.FindDefinition(ctx, fieldID)
+	if err != nil {
+		return nil, err
+	}
+	if definition.ProjectID != projectID {
+		return nil, repositories.ErrNotFound
+	}
+	return definition, nil
+}
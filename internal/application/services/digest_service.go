@@ -0,0 +1,205 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"task-engine/internal/domain/entities"
+	"task-engine/internal/domain/entities/common"
+	"task-engine/internal/domain/repositories"
+	"task-engine/pkg/logger"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const digestBatchSize = 50
+
+// DigestService sends users who have a digest schedule one summary email per period instead
+// of an email per event. Their email deliveries stay pending until the digest includes them.
+type DigestService struct {
+	tx            repositories.Transactor
+	schedules     repositories.DigestScheduleRepository
+	notifications repositories.NotificationRepository
+	users         repositories.UserRepository
+	tasks         repositories.TaskRepository
+	assignments   repositories.AssignmentRepository
+	mailer        repositories.Mailer
+}
+
+func NewDigestService(tx repositories.Transactor, schedules repositories.DigestScheduleRepository, notifications repositories.NotificationRepository, users repositories.UserRepository, tasks repositories.TaskRepository, assignments repositories.AssignmentRepository, mailer repositories.Mailer) *DigestService {
+	return &DigestService{
+		tx:            tx,
+		schedules:     schedules,
+		notifications: notifications,
+		users:         users,
+		tasks:         tasks,
+		assignments:   assignments,
+		mailer:        mailer,
+	}
+}
+
+type UpdateDigestScheduleInput struct {
+	Frequency common.DigestFrequency
+	Hour      int
+	Weekday   time.Weekday
+}
+
+func (s *DigestService) GetSchedule(ctx context.Context, userID int64) (*entities.DigestSchedule, error) {
+	return s.schedules.Find(ctx, userID)
+}
+
+// UpdateSchedule creates or changes the user's schedule, the next digest is sent at the
+// first scheduled time from now
+func (s *DigestService) UpdateSchedule(ctx context.Context, userID int64, input UpdateDigestScheduleInput) (*entities.DigestSchedule, error) {
+	user, err := s.users.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	schedule, err := s.schedules.Find(ctx, userID)
+	switch {
+	case errors.Is(err, repositories.ErrNotFound):
+		if schedule, err = entities.NewDigestSchedule(userID, input.Frequency, input.Hour, input.Weekday); err != nil {
+			return nil, err
+		}
+	case err != nil:
+		return nil, err
+	default:
+		if err := schedule.Update(input.Frequency, input.Hour, input.Weekday); err != nil {
+			return nil, err
+		}
+	}
+
+	schedule.Reschedule(time.Now(), user.Location())
+	if err := s.schedules.Save(ctx, schedule); err != nil {
+		return nil, err
+	}
+	return schedule, nil
+}
+
+// DisableSchedule goes back to an email per event, pending emails are sent on the next dispatch
+func (s *DigestService) DisableSchedule(ctx context.Context, userID int64) error {
+	return s.schedules.Delete(ctx, userID)
+}
+
+// SendDue sends the digests whose time has come and returns how many were sent. A digest
+// that fails to send is skipped, its notifications stay pending for the next one. Each
+// schedule is claimed, sent and moved to its next run in its own transaction, so a later
+// failure never sends the digests that already went out again.
+func (s *DigestService) SendDue(ctx context.Context) (int, error) {
+	sent := 0
+	now := time.Now()
+	for i := 0; i < digestBatchSize; i++ {
+		mailed := false
+		err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+			schedule, err := s.schedules.ClaimDue(ctx, now)
+			if err != nil {
+				return err
+			}
+			user, err := s.users.FindByID(ctx, schedule.UserID)
+			if err != nil {
+				return err
+			}
+
+			if user.IsActive() {
+				if mailed, err = s.send(ctx, user, schedule, now); err != nil {
+					logger.Warn("digest not sent", zap.Int64("user_id", user.ID), zap.Error(err))
+					mailed = false
+				}
+			}
+			schedule.MarkSent(now, user.Location())
+			return s.schedules.Save(ctx, schedule)
+		})
+		if errors.Is(err, repositories.ErrNotFound) {
+			return sent, nil
+		}
+		if err != nil {
+			return sent, err
+		}
+		if mailed {
+			sent++
+		}
+	}
+	return sent, nil
+}
+
+// Run sends due digests every interval until ctx is cancelled
+func (s *DigestService) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := s.SendDue(ctx); err != nil {
+				logger.Error("digest run failed", zap.Error(err))
+			}
+		}
+	}
+}
+
+// send builds and mails the user's digest and marks the email deliveries it replaces as sent.
+// It reports whether an email went out, nothing is mailed when the digest is empty.
+func (s *DigestService) send(ctx context.Context, user *entities.User, schedule *entities.DigestSchedule, now time.Time) (bool, error) {
+	deliveries, err := s.notifications.ListPendingDeliveriesByUser(ctx, user.ID, common.NotificationChannelEmail)
+	if err != nil {
+		return false, err
+	}
+	ids := make([]int64, len(deliveries))
+	for i, delivery := range deliveries {
+		ids[i] = delivery.NotificationID
+	}
+	found, err := s.notifications.FindByIDs(ctx, ids)
+	if err != nil {
+		return false, err
+	}
+	notifications := make([]*entities.Notification, 0, len(found))
+	taskIDs := make([]int64, 0, len(found))
+	for _, id := range ids {
+		if notification, ok := found[id]; ok {
+			notifications = append(notifications, notification)
+			taskIDs = append(taskIDs, notification.TaskID)
+		}
+	}
+
+	assignees, err := s.assignments.ListByTasks(ctx, taskIDs)
+	if err != nil {
+		return false, err
+	}
+	assignedTaskIDs := make(map[int64]bool)
+	for taskID, userIDs := range assignees {
+		for _, userID := range userIDs {
+			if userID == user.ID {
+				assignedTaskIDs[taskID] = true
+			}
+		}
+	}
+
+	dueSoonBefore := now.Add(dueSoonWindow(schedule.Frequency))
+	tasks, err := s.tasks.ListDueByAssignee(ctx, user.ID, dueSoonBefore)
+	if err != nil {
+		return false, err
+	}
+
+	digest := entities.NewDigest(user, schedule, now, notifications, tasks, assignedTaskIDs, dueSoonBefore)
+	mailed := !digest.IsEmpty()
+	if mailed {
+		message, err := renderDigest(digest)
+		if err != nil {
+			return false, err
+		}
+		if err := s.mailer.Send(ctx, message); err != nil {
+			return false, err
+		}
+	}
+
+	for _, delivery := range deliveries {
+		delivery.MarkSent()
+		if err := s.notifications.UpdateDelivery(ctx, delivery); err != nil {
+			return false, err
+		}
+	}
+	return mailed, nil
+}
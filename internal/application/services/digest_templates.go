@@ -0,0 +1,177 @@
+package services
+
+import (
+	htmltemplate "html/template"
+	"strings"
+	"task-engine/internal/domain/entities"
+	"task-engine/internal/domain/entities/common"
+	"text/template"
+	"time"
+)
+
+// digestLabels are the texts of one locale used around the digest sections
+type digestLabels struct {
+	DailySubject  string
+	WeeklySubject string
+	Greeting      string
+	Intro         string
+	Overdue       string
+	DueSoon       string
+	Assigned      string
+	Comments      string
+	Other         string
+	Due           string
+	DateFormat    string
+}
+
+var digestLocales = map[string]digestLabels{
+	"en": {
+		DailySubject:  "Your daily summary",
+		WeeklySubject: "Your weekly summary",
+		Greeting:      "Hi",
+		Intro:         "Here is what happened since",
+		Overdue:       "Overdue",
+		DueSoon:       "Due soon",
+		Assigned:      "Assigned to you",
+		Comments:      "Comments on tasks you watch",
+		Other:         "Other updates",
+		Due:           "due",
+		DateFormat:    "Mon Jan 2",
+	},
+	"es": {
+		DailySubject:  "Tu resumen diario",
+		WeeklySubject: "Tu resumen semanal",
+		Greeting:      "Hola",
+		Intro:         "Esto es lo que ha pasado desde el",
+		Overdue:       "Vencidas",
+		DueSoon:       "Vencen pronto",
+		Assigned:      "Asignadas a ti",
+		Comments:      "Comentarios en tareas que sigues",
+		Other:         "Otras novedades",
+		Due:           "vence el",
+		DateFormat:    "02/01",
+	},
+}
+
+// digestItem is one line of a digest section
+type digestItem struct {
+	Title  string
+	Detail string
+}
+
+type digestSection struct {
+	Heading string
+	Items   []digestItem
+}
+
+type digestView struct {
+	Labels   digestLabels
+	Name     string
+	Since    string
+	Sections []digestSection
+}
+
+var digestTextTemplate = template.Must(template.New("digest").Parse(
+	`{{.Labels.Greeting}} {{.Name}},
+
+{{.Labels.Intro}} {{.Since}}.
+{{range .Sections}}
+{{.Heading}}
+{{range .Items}}- {{.Title}}{{if .Detail}}: {{.Detail}}{{end}}
+{{end}}{{end}}`))
+
+var digestHTMLTemplate = htmltemplate.Must(htmltemplate.New("digest").Parse(
+	`<!DOCTYPE html>
+<html>
+<body style="font-family: sans-serif; color: #222;">
+<p>{{.Labels.Greeting}} {{.Name}},</p>
+<p>{{.Labels.Intro}} {{.Since}}.</p>
+{{range .Sections}}<h3>{{.Heading}}</h3>
+<ul>
+{{range .Items}}<li><strong>{{.Title}}</strong>{{if .Detail}}<br>{{.Detail}}{{end}}</li>
+{{end}}</ul>
+{{end}}</body>
+</html>
+`))
+
+// digestLabelsFor picks the labels like templatesFor picks notification templates
+func digestLabelsFor(locale string) digestLabels {
+	if labels, ok := digestLocales[locale]; ok {
+		return labels
+	}
+	if language, _, found := strings.Cut(locale, "-"); found {
+		if labels, ok := digestLocales[language]; ok {
+			return labels
+		}
+	}
+	return digestLocales[common.DefaultLocale]
+}
+
+// renderDigest returns the email of a digest in the recipient's locale, with dates shown
+// in the recipient's timezone
+func renderDigest(digest *entities.Digest) (*entities.EmailMessage, error) {
+	labels := digestLabelsFor(digest.User.Locale)
+	location := digest.User.Location()
+
+	view := digestView{
+		Labels: labels,
+		Name:   digest.User.Name,
+		Since:  digest.PeriodStart.In(location).Format(labels.DateFormat + " 15:04"),
+	}
+	taskSection := func(heading string, tasks []*entities.Task) {
+		if len(tasks) == 0 {
+			return
+		}
+		section := digestSection{Heading: heading}
+		for _, task := range tasks {
+			section.Items = append(section.Items, digestItem{
+				Title:  task.Title,
+				Detail: labels.Due + " " + task.DueDate.In(location).Format(labels.DateFormat),
+			})
+		}
+		view.Sections = append(view.Sections, section)
+	}
+	notificationSection := func(heading string, notifications []*entities.Notification) {
+		if len(notifications) == 0 {
+			return
+		}
+		section := digestSection{Heading: heading}
+		for _, notification := range notifications {
+			section.Items = append(section.Items, digestItem{Title: notification.Title, Detail: notification.Body})
+		}
+		view.Sections = append(view.Sections, section)
+	}
+
+	taskSection(labels.Overdue, digest.Overdue)
+	taskSection(labels.DueSoon, digest.DueSoon)
+	notificationSection(labels.Assigned, digest.Assigned)
+	notificationSection(labels.Comments, digest.Comments)
+	notificationSection(labels.Other, digest.Other)
+
+	var text, html strings.Builder
+	if err := digestTextTemplate.Execute(&text, view); err != nil {
+		return nil, err
+	}
+	if err := digestHTMLTemplate.Execute(&html, view); err != nil {
+		return nil, err
+	}
+
+	subject := labels.DailySubject
+	if digest.Frequency == common.DigestFrequencyWeekly {
+		subject = labels.WeeklySubject
+	}
+	return &entities.EmailMessage{
+		To:      digest.User.Email,
+		Subject: subject,
+		Text:    text.String(),
+		HTML:    html.String(),
+	}, nil
+}
+
+// dueSoonWindow is how far ahead a digest lists upcoming due dates
+func dueSoonWindow(frequency common.DigestFrequency) time.Duration {
+	if frequency == common.DigestFrequencyWeekly {
+		return 7 * 24 * time.Hour
+	}
+	return 48 * time.Hour
+}
@@ -0,0 +1,75 @@
+package services
+
+import (
+	"context"
+	"sync"
+	"task-engine/internal/domain/entities"
+	"task-engine/internal/domain/entities/common"
+	"task-engine/internal/domain/repositories"
+	"task-engine/pkg/logger"
+
+	"go.uber.org/zap"
+)
+
+// EventHandler reacts to a published event. Handlers must not block for long,
+// slow work (e.g. network delivery) should be queued.
+type EventHandler func(ctx context.Context, event *entities.Event) error
+
+// EventPublisher persists domain events and fans them out to the registered handlers.
+type EventPublisher struct {
+	tx     repositories.Transactor
+	events repositories.EventRepository
+
+	mu       sync.RWMutex
+	handlers []EventHandler
+}
+
+func NewEventPublisher(tx repositories.Transactor, events repositories.EventRepository) *EventPublisher {
+	return &EventPublisher{tx: tx, events: events}
+}
+
+func (p *EventPublisher) Subscribe(handler EventHandler) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.handlers = append(p.handlers, handler)
+}
+
+// PublishTaskEvent builds an event about the task caused by actorID and publishes it
+func (p *EventPublisher) PublishTaskEvent(ctx context.Context, eventType common.EventType, task *entities.Task, actorID int64, payload interface{}) error {
+	event, err := entities.NewEvent(eventType, task.ProjectID, task.ID, payload)
+	if err != nil {
+		return err
+	}
+	event.ActorID = actorID
+	return p.Publish(ctx, event)
+}
+
+// Publish stores the event and notifies every handler once the transaction of ctx is
+// committed, so handlers neither see changes that are rolled back nor abort the caller's
+// transaction. Handler failures are logged and do not fail the publication.
+func (p *EventPublisher) Publish(ctx context.Context, event *entities.Event) error {
+	if err := p.events.Create(ctx, event); err != nil {
+		return err
+	}
+	p.tx.AfterCommit(ctx, func(ctx context.Context) {
+		p.notify(ctx, event)
+	})
+	return nil
+}
+
+// notify runs the handlers in the order they subscribed
+func (p *EventPublisher) notify(ctx context.Context, event *entities.Event) {
+	p.mu.RLock()
+	handlers := append([]EventHandler(nil), p.handlers...)
+	p.mu.RUnlock()
+
+	for _, handler := range handlers {
+		if err := handler(ctx, event); err != nil {
+			logger.Error("event handler failed",
+				zap.Int64("event_id", event.ID),
+				zap.String("event_type", string(event.Type)),
+				zap.Error(err),
+			)
+		}
+	}
+}
@@ -0,0 +1,197 @@
+package services
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"strings"
+	"task-engine/internal/domain/entities"
+	"task-engine/internal/domain/entities/common"
+	"task-engine/internal/domain/repositories"
+	"time"
+)
+
+// exchangeRatesCSVHeader is the expected column layout of rate files
+var exchangeRatesCSVHeader = []string{"date", "base", "quote", "rate"}
+
+type ExchangeRateService struct {
+	tx    repositories.Transactor
+	rates repositories.ExchangeRateRepository
+}
+
+func NewExchangeRateService(tx repositories.Transactor, rates repositories.ExchangeRateRepository) *ExchangeRateService {
+	return &ExchangeRateService{tx: tx, rates: rates}
+}
+
+func (s *ExchangeRateService) List(ctx context.Context, base, quote common.Currency) ([]*entities.ExchangeRate, error) {
+	return s.rates.List(ctx, base, quote)
+}
+
+// ImportCSV loads a "date,base,quote,rate" file in a single transaction. Nothing is stored
+// when any row is invalid, the returned ValidationErrors name the offending rows.
+func (s *ExchangeRateService) ImportCSV(ctx context.Context, r io.Reader) (int, error) {
+	rates, err := ParseExchangeRatesCSV(r)
+	if err != nil {
+		return 0, err
+	}
+
+	err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		for _, rate := range rates {
+			if err := s.rates.Upsert(ctx, rate); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return len(rates), nil
+}
+
+func ParseExchangeRatesCSV(r io.Reader) ([]*entities.ExchangeRate, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("reading csv header: %w", err)
+	}
+	for i, column := range exchangeRatesCSVHeader {
+		if i >= len(header) || strings.ToLower(strings.TrimSpace(header[i])) != column {
+			return nil, fmt.Errorf("csv header must be %q", strings.Join(exchangeRatesCSVHeader, ","))
+		}
+	}
+
+	var (
+		rates     []*entities.ExchangeRate
+		rowErrors common.ValidationErrors
+	)
+	for row := 2; ; row++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading csv row %d: %w", row, err)
+		}
+
+		rateDate, err := time.Parse("2006-01-02", strings.TrimSpace(record[0]))
+		if err != nil {
+			rowErrors = append(rowErrors, common.FieldValidationError{
+				Field:   fmt.Sprintf("row %d: date", row),
+				Message: "field must use the YYYY-MM-DD format",
+			})
+			continue
+		}
+
+		rate, err := entities.NewExchangeRate(
+			common.Currency(strings.ToUpper(strings.TrimSpace(record[1]))),
+			common.Currency(strings.ToUpper(strings.TrimSpace(record[2]))),
+			strings.TrimSpace(record[3]),
+			rateDate,
+		)
+		if err != nil {
+			rowErrors = append(rowErrors, prefixValidationErrors(fmt.Sprintf("row %d: ", row), err)...)
+			continue
+		}
+		rates = append(rates, rate)
+	}
+
+	if len(rowErrors) > 0 {
+		return nil, rowErrors
+	}
+	return rates, nil
+}
+
+// prefixValidationErrors flattens err into ValidationErrors whose field names start with prefix
+func prefixValidationErrors(prefix string, err error) common.ValidationErrors {
+	var (
+		validationErrs common.ValidationErrors
+		fieldErr       *common.FieldValidationError
+		result         common.ValidationErrors
+	)
+
+	switch {
+	case errors.As(err, &validationErrs):
+		for _, e := range validationErrs {
+			result = append(result, common.FieldValidationError{Field: prefix + e.Field, Message: e.Message})
+		}
+	case errors.As(err, &fieldErr):
+		result = append(result, common.FieldValidationError{Field: prefix + fieldErr.Field, Message: fieldErr.Message})
+	default:
+		result = append(result, common.FieldValidationError{Field: strings.TrimSuffix(prefix, ": "), Message: err.Error()})
+	}
+	return result
+}
+
+// Conversion builds a converter into currency "to" using the latest rates dated on or before asOf
+func (s *ExchangeRateService) Conversion(to common.Currency, asOf time.Time) *CurrencyConversion {
+	return &CurrencyConversion{
+		rates:  s.rates,
+		to:     to,
+		asOf:   asOf,
+		ratios: make(map[common.Currency]*big.Rat),
+	}
+}
+
+// CurrencyConversion converts amounts into a single currency and remembers which rates it applied.
+// It is not safe for concurrent use.
+type CurrencyConversion struct {
+	rates   repositories.ExchangeRateRepository
+	to      common.Currency
+	asOf    time.Time
+	ratios  map[common.Currency]*big.Rat
+	applied []entities.AppliedRate
+}
+
+func (c *CurrencyConversion) Converter(ctx context.Context) entities.MoneyConverter {
+	return func(m common.Money) (common.Money, error) {
+		return c.Convert(ctx, m)
+	}
+}
+
+func (c *CurrencyConversion) Convert(ctx context.Context, m common.Money) (common.Money, error) {
+	if m.Currency == c.to {
+		return m, nil
+	}
+
+	ratio, ok := c.ratios[m.Currency]
+	if !ok {
+		var err error
+		if ratio, err = c.lookup(ctx, m.Currency); err != nil {
+			return common.Money{}, err
+		}
+		c.ratios[m.Currency] = ratio
+	}
+	return m.Convert(ratio, c.to), nil
+}
+
+// lookup finds the direct rate from -> to, falling back to the inverse of to -> from
+func (c *CurrencyConversion) lookup(ctx context.Context, from common.Currency) (*big.Rat, error) {
+	rate, err := c.rates.FindLatest(ctx, from, c.to, c.asOf)
+	if err == nil {
+		c.applied = append(c.applied, entities.AppliedRate{From: from, To: c.to, Rate: rate.Rate, RateDate: rate.RateDate})
+		return rate.Ratio(), nil
+	}
+	if !errors.Is(err, repositories.ErrNotFound) {
+		return nil, err
+	}
+
+	rate, err = c.rates.FindLatest(ctx, c.to, from, c.asOf)
+	if errors.Is(err, repositories.ErrNotFound) {
+		return nil, &entities.MissingExchangeRateError{From: from, To: c.to, AsOf: c.asOf}
+	}
+	if err != nil {
+		return nil, err
+	}
+	c.applied = append(c.applied, entities.AppliedRate{From: from, To: c.to, Rate: rate.Rate, RateDate: rate.RateDate, Inverted: true})
+	return new(big.Rat).Inv(rate.Ratio()), nil
+}
+
+func (c *CurrencyConversion) AppliedRates() []entities.AppliedRate {
+	return c.applied
+}
@@ -0,0 +1,480 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"task-engine/internal/domain/entities"
+	"task-engine/internal/domain/entities/common"
+	"task-engine/internal/domain/repositories"
+)
+
+// ExternalImportService imports Trello boards and Jira projects. The id every board, card,
+// issue and comment had in its tool is mapped to what it was imported as, so importing a
+// newer export of the same data updates the projects and tasks instead of copying them.
+// Imported records do not publish events.
+type ExternalImportService struct {
+	tx           repositories.Transactor
+	mappings     repositories.ImportMappingRepository
+	projects     repositories.ProjectRepository
+	tasks        repositories.TaskRepository
+	tags         repositories.TagRepository
+	assignments  repositories.AssignmentRepository
+	comments     repositories.CommentRepository
+	users        repositories.UserRepository
+	customFields repositories.CustomFieldRepository
+	workflows    *WorkflowService
+}
+
+func NewExternalImportService(tx repositories.Transactor, mappings repositories.ImportMappingRepository, projects repositories.ProjectRepository, tasks repositories.TaskRepository, tags repositories.TagRepository, assignments repositories.AssignmentRepository, comments repositories.CommentRepository, users repositories.UserRepository, customFields repositories.CustomFieldRepository, workflows *WorkflowService) *ExternalImportService {
+	return &ExternalImportService{
+		tx:           tx,
+		mappings:     mappings,
+		projects:     projects,
+		tasks:        tasks,
+		tags:         tags,
+		assignments:  assignments,
+		comments:     comments,
+		users:        users,
+		customFields: customFields,
+		workflows:    workflows,
+	}
+}
+
+// ExternalImportInput holds the projects read from an export. StatusMap sends Trello list
+// or Jira status names, matched case-insensitively, to workflow statuses. Other names go to
+// the status with the same key or name, then to a status of the category the name suggests,
+// then to the initial status.
+type ExternalImportInput struct {
+	OwnerID   int64
+	Projects  []*entities.ExternalProject
+	StatusMap map[string]common.TaskStatus
+}
+
+// ExternalImportReport counts what the import changed. UnknownUsers lists the members and
+// comment authors without an account of the same username, their comments are posted by
+// the importing user.
+type ExternalImportReport struct {
+	ProjectsCreated int
+	ProjectsUpdated int
+	TasksCreated    int
+	TasksUpdated    int
+	CommentsCreated int
+	UnknownUsers    []string
+}
+
+// Import creates or updates the projects with their tasks and adds the comments that were
+// not imported yet, in a single transaction. Records whose entity was deleted since the
+// last import are created again.
+func (s *ExternalImportService) Import(ctx context.Context, input ExternalImportInput) (*ExternalImportReport, error) {
+	if err := common.ValidateFields(common.ValidatePositiveInt("owner_id", input.OwnerID)); err != nil {
+		return nil, err
+	}
+	statusMap := make(map[string]common.TaskStatus, len(input.StatusMap))
+	for name, status := range input.StatusMap {
+		statusMap[strings.ToLower(strings.TrimSpace(name))] = status
+	}
+
+	report := &ExternalImportReport{}
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		run := &externalImport{
+			service:      s,
+			input:        input,
+			statusMap:    statusMap,
+			report:       report,
+			workflows:    make(map[int64]*entities.Workflow),
+			definitions:  make(map[int64][]*entities.CustomFieldDefinition),
+			liveProjects: make(map[int64]bool),
+			unknownUsers: make(map[string]bool),
+		}
+		if err := run.load(ctx); err != nil {
+			return err
+		}
+		for i, project := range input.Projects {
+			if err := run.importProject(ctx, fmt.Sprintf("projects[%d]", i), project); err != nil {
+				return err
+			}
+		}
+		for username := range run.unknownUsers {
+			report.UnknownUsers = append(report.UnknownUsers, username)
+		}
+		sort.Strings(report.UnknownUsers)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// externalImport holds what a single import looked up ahead of importing the projects
+type externalImport struct {
+	service      *ExternalImportService
+	input        ExternalImportInput
+	statusMap    map[string]common.TaskStatus
+	report       *ExternalImportReport
+	users        map[string]*entities.User
+	existingKeys map[string]*entities.Project
+	mappings     map[common.ImportEntityKind]map[string]*entities.ImportMapping
+	workflows    map[int64]*entities.Workflow
+	definitions  map[int64][]*entities.CustomFieldDefinition
+	liveProjects map[int64]bool
+	unknownUsers map[string]bool
+}
+
+// load reads the users, project keys and mappings the projects refer to
+func (r *externalImport) load(ctx context.Context) error {
+	var usernames, keys []string
+	ids := make(map[common.ImportEntityKind][]string)
+	for _, project := range r.input.Projects {
+		ids[common.ImportEntityProject] = append(ids[common.ImportEntityProject], project.ID)
+		if key := strings.ToUpper(strings.TrimSpace(project.Key)); key != "" {
+			keys = append(keys, key)
+		}
+		for _, task := range project.Tasks {
+			ids[common.ImportEntityTask] = append(ids[common.ImportEntityTask], task.ID)
+			usernames = append(usernames, task.Members...)
+			for _, comment := range task.Comments {
+				ids[common.ImportEntityComment] = append(ids[common.ImportEntityComment], comment.ID)
+				usernames = append(usernames, comment.Author)
+			}
+		}
+	}
+
+	var err error
+	if r.users, err = r.service.users.FindByUsernames(ctx, usernames); err != nil {
+		return err
+	}
+	if r.existingKeys, err = r.service.projects.FindByKeys(ctx, keys); err != nil {
+		return err
+	}
+	r.mappings = make(map[common.ImportEntityKind]map[string]*entities.ImportMapping, len(ids))
+	for _, kind := range []common.ImportEntityKind{common.ImportEntityProject, common.ImportEntityTask, common.ImportEntityComment} {
+		if r.mappings[kind], err = r.service.mappings.FindByExternalIDs(ctx, r.source(), kind, ids[kind]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *externalImport) source() common.ImportSource {
+	if len(r.input.Projects) == 0 {
+		return common.ImportSourceTrello
+	}
+	return r.input.Projects[0].Source
+}
+
+func (r *externalImport) importProject(ctx context.Context, field string, imported *entities.ExternalProject) error {
+	if imported.Source != r.source() {
+		return &common.FieldValidationError{Field: field + ".source", Message: "projects of an import must come from the same tool"}
+	}
+
+	project, err := r.mappedProject(ctx, imported.ID)
+	if err != nil {
+		return err
+	}
+	if project != nil {
+		var projectErrors common.ValidationErrors
+		for _, err := range []error{
+			project.UpdateName(strings.TrimSpace(imported.Name)),
+			project.UpdateDescription(strings.TrimSpace(imported.Description)),
+		} {
+			if err != nil {
+				projectErrors = append(projectErrors, prefixValidationErrors(field+".", err)...)
+			}
+		}
+		if len(projectErrors) > 0 {
+			return projectErrors
+		}
+		if err := r.service.projects.Update(ctx, project); err != nil {
+			return err
+		}
+		r.report.ProjectsUpdated++
+	} else {
+		project, err = entities.NewProject(strings.TrimSpace(imported.Name), strings.TrimSpace(imported.Description), r.input.OwnerID)
+		if err != nil {
+			return prefixValidationErrors(field+".", err)
+		}
+		// the project keeps no key when the one of its tool is invalid or already taken
+		key := strings.ToUpper(strings.TrimSpace(imported.Key))
+		if _, taken := r.existingKeys[key]; key != "" && !taken && project.SetKey(key) == nil {
+			r.existingKeys[key] = project
+		}
+		if err := r.service.projects.Create(ctx, project); err != nil {
+			return err
+		}
+		if err := r.saveMapping(ctx, common.ImportEntityProject, imported.ID, project.ID); err != nil {
+			return err
+		}
+		r.report.ProjectsCreated++
+	}
+
+	for i, task := range imported.Tasks {
+		if err := r.importTask(ctx, fmt.Sprintf("%s.tasks[%d]", field, i), project.ID, task); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// mappedProject returns the project the external project was imported as, or nil when it
+// was never imported or the project was deleted since
+func (r *externalImport) mappedProject(ctx context.Context, externalID string) (*entities.Project, error) {
+	mapping, ok := r.mappings[common.ImportEntityProject][externalID]
+	if !ok {
+		return nil, nil
+	}
+	project, err := r.service.projects.FindByID(ctx, mapping.EntityID)
+	if errors.Is(err, repositories.ErrNotFound) || (err == nil && project.IsDeleted()) {
+		return nil, nil
+	}
+	return project, err
+}
+
+func (r *externalImport) importTask(ctx context.Context, field string, projectID int64, imported *entities.ExternalTask) error {
+	task, err := r.mappedTask(ctx, imported.ID, projectID)
+	if err != nil {
+		return err
+	}
+
+	created := task == nil
+	if created {
+		if task, err = entities.NewTask(projectID, strings.TrimSpace(imported.Title), imported.Description); err != nil {
+			return prefixValidationErrors(field+".", err)
+		}
+		// imported tasks have no custom field values, as in ImportService
+		definitions, err := r.customFieldDefinitions(ctx, projectID)
+		if err != nil {
+			return err
+		}
+		if err := entities.ValidateCustomFieldValues(definitions, nil); err != nil {
+			return prefixValidationErrors(field+".", err)
+		}
+		if !imported.CreatedAt.IsZero() {
+			task.CreatedAt = imported.CreatedAt
+		}
+	} else if err := task.UpdateTitle(strings.TrimSpace(imported.Title)); err != nil {
+		return prefixValidationErrors(field+".", err)
+	}
+
+	// a task moved to another project since the last import takes a status of its workflow
+	status, err := r.resolveStatus(ctx, task.ProjectID, imported.Status)
+	if err != nil {
+		return prefixValidationErrors(field+".", err)
+	}
+	changes := []error{
+		task.UpdateDescription(imported.Description),
+		task.UpdateStatus(status),
+		task.UpdateDueDate(imported.DueDate),
+		task.UpdateTags(imported.Labels),
+	}
+	if imported.Priority != "" {
+		changes = append(changes, task.UpdatePriority(imported.Priority))
+	}
+	var taskErrors common.ValidationErrors
+	for _, err := range changes {
+		if err != nil {
+			taskErrors = append(taskErrors, prefixValidationErrors(field+".", err)...)
+		}
+	}
+	if len(taskErrors) > 0 {
+		return taskErrors
+	}
+
+	if created {
+		if err := r.service.tasks.Create(ctx, task); err != nil {
+			return err
+		}
+		if err := r.saveMapping(ctx, common.ImportEntityTask, imported.ID, task.ID); err != nil {
+			return err
+		}
+		r.report.TasksCreated++
+	} else {
+		if err := r.service.tasks.Update(ctx, task); err != nil {
+			return err
+		}
+		r.report.TasksUpdated++
+	}
+	if err := r.service.tags.SetTaskTags(ctx, task.ID, task.Tags); err != nil {
+		return err
+	}
+
+	for _, username := range imported.Members {
+		user, ok := r.user(username)
+		if !ok {
+			continue
+		}
+		if _, err := r.service.assignments.Assign(ctx, task.ID, user.ID); err != nil {
+			return err
+		}
+	}
+	for i, comment := range imported.Comments {
+		if err := r.importComment(ctx, fmt.Sprintf("%s.comments[%d]", field, i), task.ID, comment, created); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// mappedTask returns the task the external task was imported as, or nil when it was never
+// imported or was deleted since. A task left in a deleted project counts as deleted, the
+// project it is imported into was created again. A task moved to another live project
+// since the last import stays there.
+func (r *externalImport) mappedTask(ctx context.Context, externalID string, projectID int64) (*entities.Task, error) {
+	mapping, ok := r.mappings[common.ImportEntityTask][externalID]
+	if !ok {
+		return nil, nil
+	}
+	task, err := r.service.tasks.FindByID(ctx, mapping.EntityID)
+	if errors.Is(err, repositories.ErrNotFound) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	if task.ProjectID == projectID {
+		return task, nil
+	}
+
+	live, ok := r.liveProjects[task.ProjectID]
+	if !ok {
+		project, err := r.service.projects.FindByID(ctx, task.ProjectID)
+		if err != nil && !errors.Is(err, repositories.ErrNotFound) {
+			return nil, err
+		}
+		live = err == nil && !project.IsDeleted()
+		r.liveProjects[task.ProjectID] = live
+	}
+	if !live {
+		return nil, nil
+	}
+	return task, nil
+}
+
+// customFieldDefinitions returns the custom fields of the project, read once per import
+func (r *externalImport) customFieldDefinitions(ctx context.Context, projectID int64) ([]*entities.CustomFieldDefinition, error) {
+	definitions, ok := r.definitions[projectID]
+	if !ok {
+		var err error
+		if definitions, err = r.service.customFields.ListDefinitions(ctx, projectID); err != nil {
+			return nil, err
+		}
+		r.definitions[projectID] = definitions
+	}
+	return definitions, nil
+}
+
+// importComment adds a comment that was not imported yet. Comments imported before are
+// left as they are, edited or deleted ones included, unless their task was created again.
+func (r *externalImport) importComment(ctx context.Context, field string, taskID int64, imported *entities.ExternalComment, taskCreated bool) error {
+	if _, ok := r.mappings[common.ImportEntityComment][imported.ID]; ok && !taskCreated {
+		return nil
+	}
+	content := strings.TrimSpace(imported.Content)
+	if content == "" {
+		return nil
+	}
+
+	authorID := r.input.OwnerID
+	if author, ok := r.user(imported.Author); ok {
+		authorID = author.ID
+	} else {
+		content = fmt.Sprintf("Originally by %s:\n\n%s", imported.Author, content)
+	}
+	comment, err := entities.NewComment(taskID, authorID, content)
+	if err != nil {
+		return prefixValidationErrors(field+".", err)
+	}
+	if !imported.CreatedAt.IsZero() {
+		comment.CreatedAt = imported.CreatedAt
+	}
+	if err := r.service.comments.Create(ctx, comment); err != nil {
+		return err
+	}
+	if err := r.saveMapping(ctx, common.ImportEntityComment, imported.ID, comment.ID); err != nil {
+		return err
+	}
+	r.report.CommentsCreated++
+	return nil
+}
+
+// user finds the account of a username of the other tool, recording the unknown ones
+func (r *externalImport) user(username string) (*entities.User, bool) {
+	username = strings.TrimSpace(username)
+	if username == "" {
+		return nil, false
+	}
+	user, ok := r.users[strings.ToLower(username)]
+	if !ok {
+		r.unknownUsers[username] = true
+	}
+	return user, ok
+}
+
+// resolveStatus picks the workflow status of a Trello list or Jira status name
+func (r *externalImport) resolveStatus(ctx context.Context, projectID int64, name string) (entities.WorkflowStatus, error) {
+	workflow, ok := r.workflows[projectID]
+	if !ok {
+		var err error
+		if workflow, err = r.service.workflows.workflowOf(ctx, projectID); err != nil {
+			return entities.WorkflowStatus{}, err
+		}
+		r.workflows[projectID] = workflow
+	}
+
+	normalized := strings.ToLower(strings.TrimSpace(name))
+	if key, ok := r.statusMap[normalized]; ok {
+		status, found := workflow.Status(key)
+		if !found {
+			return entities.WorkflowStatus{}, &common.FieldValidationError{
+				Field:   "status",
+				Message: fmt.Sprintf("%q is mapped to status %q which is not part of the workflow", name, key),
+			}
+		}
+		return status, nil
+	}
+	if status, ok := workflow.MatchStatus(name); ok {
+		return status, nil
+	}
+	if category := statusCategoryOf(normalized); category != "" {
+		if status, ok := workflow.StatusIn(category); ok {
+			return status, nil
+		}
+	}
+	status, _ := workflow.Status(workflow.InitialStatus)
+	return status, nil
+}
+
+func (r *externalImport) saveMapping(ctx context.Context, kind common.ImportEntityKind, externalID string, entityID int64) error {
+	mapping, err := entities.NewImportMapping(r.source(), kind, externalID, entityID)
+	if err != nil {
+		return err
+	}
+	// a record listed twice in the export is imported once
+	r.mappings[kind][externalID] = mapping
+	return r.service.mappings.Save(ctx, mapping)
+}
+
+// statusCategoryOf guesses the category of a status from the words of its name, returning
+// an empty category for the names that read as work still to do
+func statusCategoryOf(name string) common.StatusCategory {
+	switch {
+	case containsAny(name, "done", "closed", "resolved", "complete", "finished"):
+		return common.StatusCategoryDone
+	case containsAny(name, "cancel", "won't", "wont", "rejected"):
+		return common.StatusCategoryCancelled
+	case containsAny(name, "progress", "doing", "review", "testing"):
+		return common.StatusCategoryDoing
+	}
+	return ""
+}
+
+func containsAny(s string, words ...string) bool {
+	for _, word := range words {
+		if strings.Contains(s, word) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,160 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"task-engine/internal/domain/entities"
+	"task-engine/internal/domain/entities/common"
+	"task-engine/internal/domain/repositories"
+	"task-engine/pkg/logger"
+
+	"go.uber.org/zap"
+)
+
+// GitIntegrationService links commits to the tasks their messages reference and completes
+// the tasks they close once they land on one of the project's closing branches
+type GitIntegrationService struct {
+	projects    repositories.ProjectRepository
+	tasks       repositories.TaskRepository
+	commits     repositories.TaskCommitRepository
+	taskService *TaskService
+	comments    *CommentService
+}
+
+func NewGitIntegrationService(projects repositories.ProjectRepository, tasks repositories.TaskRepository, commits repositories.TaskCommitRepository, taskService *TaskService, comments *CommentService) *GitIntegrationService {
+	return &GitIntegrationService{
+		projects:    projects,
+		tasks:       tasks,
+		commits:     commits,
+		taskService: taskService,
+		comments:    comments,
+	}
+}
+
+// ProcessPush links every commit of the push to the tasks it references, commenting on each
+// task the first time, and completes the tasks closed on a closing branch. Comments and
+// transitions are made by actor, the caller who sent the push: commit authors are only
+// shown on the linked commits, as nothing proves who wrote a pushed commit.
+// Pushing the same commits again only completes what was not completed yet.
+func (s *GitIntegrationService) ProcessPush(ctx context.Context, push *entities.GitPush, actor entities.TransitionActor) (*entities.GitPushResult, error) {
+	result := &entities.GitPushResult{
+		Commits:   len(push.Commits),
+		Linked:    []*entities.TaskCommit{},
+		Completed: []int64{},
+		Skipped:   []*entities.SkippedGitReference{},
+	}
+
+	references := make(map[string][]entities.TaskReference, len(push.Commits))
+	var keys []string
+	for _, commit := range push.Commits {
+		references[commit.SHA] = entities.ParseTaskReferences(commit.Message)
+		for _, reference := range references[commit.SHA] {
+			if reference.Key != "" {
+				keys = append(keys, reference.Key)
+			}
+		}
+	}
+	byKey, err := s.projects.FindByKeys(ctx, keys)
+	if err != nil {
+		return nil, err
+	}
+
+	projects := make(map[int64]*entities.Project)
+	completed := make(map[int64]bool)
+	for _, commit := range push.Commits {
+		for _, reference := range references[commit.SHA] {
+			task, err := s.tasks.FindByID(ctx, reference.TaskID)
+			if errors.Is(err, repositories.ErrNotFound) {
+				result.Skip(commit, reference, "task not found")
+				continue
+			} else if err != nil {
+				return nil, err
+			}
+			if reference.Key != "" {
+				project, ok := byKey[reference.Key]
+				if !ok {
+					result.Skip(commit, reference, "no project has this key")
+					continue
+				}
+				if project.ID != task.ProjectID {
+					result.Skip(commit, reference, "task belongs to another project")
+					continue
+				}
+			}
+
+			link, err := s.link(ctx, push, commit, task, actor.UserID)
+			if err != nil {
+				return nil, err
+			}
+			if link != nil {
+				result.Linked = append(result.Linked, link)
+			}
+
+			if !reference.Closes || completed[task.ID] || task.IsClosed() {
+				continue
+			}
+			project, ok := projects[task.ProjectID]
+			if !ok {
+				if project, err = s.projects.FindByID(ctx, task.ProjectID); err != nil {
+					return nil, err
+				}
+				projects[project.ID] = project
+			}
+			if !project.Settings.ClosesOnBranch(push.Branch) {
+				continue
+			}
+
+			_, err = s.taskService.TransitionTask(ctx, task.ID, common.TaskStatusCompleted, actor)
+			var transitionErr *entities.TransitionNotAllowedError
+			var fieldErr *common.FieldValidationError
+			switch {
+			case errors.As(err, &transitionErr):
+				result.Skip(commit, reference, transitionErr.Error())
+			case errors.As(err, &fieldErr):
+				result.Skip(commit, reference, fieldErr.Message)
+			case err != nil:
+				return nil, err
+			default:
+				completed[task.ID] = true
+				result.Completed = append(result.Completed, task.ID)
+			}
+		}
+	}
+	return result, nil
+}
+
+// ListCommits returns the commits linked to the task, newest first
+func (s *GitIntegrationService) ListCommits(ctx context.Context, taskID int64) ([]*entities.TaskCommit, error) {
+	if _, err := s.tasks.FindByID(ctx, taskID); err != nil {
+		return nil, err
+	}
+	return s.commits.ListByTask(ctx, taskID)
+}
+
+// link links the commit to the task and comments on the task. It returns nil when the
+// commit was already linked.
+func (s *GitIntegrationService) link(ctx context.Context, push *entities.GitPush, commit *entities.GitCommit, task *entities.Task, userID int64) (*entities.TaskCommit, error) {
+	link := entities.NewTaskCommit(task.ID, push, commit)
+	created, err := s.commits.Create(ctx, link)
+	if err != nil || !created {
+		return nil, err
+	}
+
+	comment, err := s.comments.AddComment(ctx, AddCommentInput{
+		TaskID:  task.ID,
+		UserID:  userID,
+		Content: link.CommentContent(),
+	})
+	if err != nil {
+		// Unlink so the next push of the commit tries again
+		if deleteErr := s.commits.Delete(ctx, link.ID); deleteErr != nil {
+			logger.Error("failed to unlink commit", zap.Int64("task_commit_id", link.ID), zap.Error(deleteErr))
+		}
+		return nil, err
+	}
+	if err := s.commits.SetComment(ctx, link.ID, comment.ID); err != nil {
+		return nil, err
+	}
+	link.CommentID = comment.ID
+	return link, nil
+}
@@ -0,0 +1,453 @@
+package services
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"task-engine/internal/domain/entities"
+	"task-engine/internal/domain/entities/common"
+	"task-engine/internal/domain/repositories"
+	"time"
+)
+
+// importBatchSize is how many tasks a single COPY loads
+const importBatchSize = 1000
+
+// ImportColumns are the fields CSV columns are mapped to. Tags and assignees are comma
+// separated, assignees by username.
+var ImportColumns = []string{
+	"project", "project_key", "project_description", "title", "description", "status",
+	"priority", "due_date", "estimated_minutes", "tags", "assignees",
+}
+
+// errImportDryRun rolls back the projects a dry run created to validate their tasks
+var errImportDryRun = errors.New("import dry run")
+
+// ImportService loads projects and tasks migrated from other tools. Every project and task
+// is validated before anything is stored, then tasks are copied in batches. Imported tasks
+// do not publish events.
+type ImportService struct {
+	tx           repositories.Transactor
+	imports      repositories.ImportRepository
+	projects     repositories.ProjectRepository
+	users        repositories.UserRepository
+	customFields repositories.CustomFieldRepository
+	workflows    *WorkflowService
+}
+
+func NewImportService(tx repositories.Transactor, imports repositories.ImportRepository, projects repositories.ProjectRepository, users repositories.UserRepository, customFields repositories.CustomFieldRepository, workflows *WorkflowService) *ImportService {
+	return &ImportService{tx: tx, imports: imports, projects: projects, users: users, customFields: customFields, workflows: workflows}
+}
+
+// ImportProject is a project read from an import file. A project with the key of an existing
+// project adds its tasks to it, the others are created. Source names where the project was
+// read, such as "row 2" or "projects[0]", in errors.
+type ImportProject struct {
+	Source      string
+	Name        string
+	Key         string
+	Description string
+	Tasks       []*ImportTask
+}
+
+// ImportTask is a task read from an import file, its values are parsed when validated
+type ImportTask struct {
+	Source           string
+	Title            string
+	Description      string
+	Status           string
+	Priority         string
+	DueDate          string
+	EstimatedMinutes string
+	Tags             []string
+	Assignees        []string
+}
+
+type ImportInput struct {
+	OwnerID  int64
+	Projects []*ImportProject
+	DryRun   bool
+}
+
+// ImportReport counts what was imported. A dry run stores nothing and lists the errors of
+// every invalid row instead of failing.
+type ImportReport struct {
+	DryRun           bool
+	ProjectsCreated  int
+	ProjectsExisting int
+	TasksImported    int
+	Errors           common.ValidationErrors
+}
+
+// Import validates the projects and tasks and loads them in a single transaction. When any
+// of them is invalid nothing is stored and the ValidationErrors name the offending rows.
+// The tasks of an invalid project are only validated once the project is fixed.
+func (s *ImportService) Import(ctx context.Context, input ImportInput) (*ImportReport, error) {
+	report := &ImportReport{DryRun: input.DryRun}
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		tasks, rowErrors, err := s.prepare(ctx, input, report)
+		if err != nil {
+			return err
+		}
+		if len(rowErrors) > 0 {
+			if input.DryRun {
+				report.Errors = rowErrors
+				return errImportDryRun
+			}
+			return rowErrors
+		}
+
+		report.TasksImported = len(tasks)
+		if input.DryRun {
+			return errImportDryRun
+		}
+		return s.load(ctx, tasks)
+	})
+	if err != nil && !errors.Is(err, errImportDryRun) {
+		return nil, err
+	}
+	return report, nil
+}
+
+// prepare builds the tasks to load. New projects are created so their tasks are validated
+// against their workflow, a dry run rolls them back.
+func (s *ImportService) prepare(ctx context.Context, input ImportInput, report *ImportReport) ([]*entities.Task, common.ValidationErrors, error) {
+	var keys, usernames []string
+	for _, project := range input.Projects {
+		if key := strings.ToUpper(strings.TrimSpace(project.Key)); key != "" {
+			keys = append(keys, key)
+		}
+		for _, task := range project.Tasks {
+			usernames = append(usernames, task.Assignees...)
+		}
+	}
+	existing, err := s.projects.FindByKeys(ctx, keys)
+	if err != nil {
+		return nil, nil, err
+	}
+	users, err := s.users.FindByUsernames(ctx, usernames)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var (
+		tasks     []*entities.Task
+		rowErrors common.ValidationErrors
+		seenKeys  = make(map[string]bool, len(keys))
+	)
+	for _, imported := range input.Projects {
+		key := strings.ToUpper(strings.TrimSpace(imported.Key))
+		if key != "" && seenKeys[key] {
+			rowErrors = append(rowErrors, common.FieldValidationError{
+				Field:   imported.Source + ": key",
+				Message: fmt.Sprintf("project %s appears more than once", key),
+			})
+			continue
+		}
+		seenKeys[key] = key != ""
+
+		project, ok := existing[key]
+		if ok {
+			report.ProjectsExisting++
+		} else {
+			if project, err = newImportedProject(imported, input.OwnerID); err != nil {
+				rowErrors = append(rowErrors, prefixValidationErrors(imported.Source+": ", err)...)
+				continue
+			}
+			if err := s.projects.Create(ctx, project); err != nil {
+				return nil, nil, err
+			}
+			report.ProjectsCreated++
+		}
+
+		workflow, err := s.workflows.workflowOf(ctx, project.ID)
+		if err != nil {
+			return nil, nil, err
+		}
+		definitions, err := s.customFields.ListDefinitions(ctx, project.ID)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, importedTask := range imported.Tasks {
+			task, taskErrors := newImportedTask(project.ID, workflow, definitions, importedTask, users)
+			if len(taskErrors) > 0 {
+				rowErrors = append(rowErrors, taskErrors...)
+				continue
+			}
+			tasks = append(tasks, task)
+		}
+	}
+	return tasks, rowErrors, nil
+}
+
+func newImportedProject(imported *ImportProject, ownerID int64) (*entities.Project, error) {
+	project, err := entities.NewProject(strings.TrimSpace(imported.Name), strings.TrimSpace(imported.Description), ownerID)
+	if err != nil {
+		return nil, err
+	}
+	if err := project.SetKey(imported.Key); err != nil {
+		return nil, err
+	}
+	return project, project.Validate()
+}
+
+// newImportedTask runs the task validations on every value of the task, returning all of
+// their errors. Imported tasks have no custom field values, so a project with a required
+// custom field rejects them as CreateTask would.
+func newImportedTask(projectID int64, workflow *entities.Workflow, definitions []*entities.CustomFieldDefinition, imported *ImportTask, users map[string]*entities.User) (*entities.Task, common.ValidationErrors) {
+	var rowErrors common.ValidationErrors
+	check := func(err error) {
+		if err != nil {
+			rowErrors = append(rowErrors, prefixValidationErrors(imported.Source+": ", err)...)
+		}
+	}
+
+	task, err := entities.NewTask(projectID, strings.TrimSpace(imported.Title), imported.Description)
+	if err != nil {
+		check(err)
+		// the other values are still checked so that the row reports all of its errors
+		task = &entities.Task{ProjectID: projectID}
+	}
+
+	statusKey := workflow.InitialStatus
+	if value := strings.TrimSpace(imported.Status); value != "" {
+		statusKey = common.TaskStatus(value)
+	}
+	if status, ok := workflow.Status(statusKey); ok {
+		check(task.UpdateStatus(status))
+	} else {
+		check(&common.FieldValidationError{Field: "status", Message: fmt.Sprintf("status %q is not part of the workflow", statusKey)})
+	}
+	if value := strings.TrimSpace(imported.Priority); value != "" {
+		check(task.UpdatePriority(common.TaskPriority(strings.ToLower(value))))
+	}
+	if value := strings.TrimSpace(imported.DueDate); value != "" {
+		dueDate, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			check(&common.FieldValidationError{Field: "due_date", Message: "field must use the YYYY-MM-DD format"})
+		} else {
+			check(task.UpdateDueDate(dueDate))
+		}
+	}
+	if value := strings.TrimSpace(imported.EstimatedMinutes); value != "" {
+		minutes, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			check(&common.FieldValidationError{Field: "estimated_minutes", Message: "field must be a whole number of minutes"})
+		} else {
+			check(task.UpdateEstimate(minutes, nil))
+		}
+	}
+	check(task.UpdateTags(imported.Tags))
+	check(entities.ValidateCustomFieldValues(definitions, nil))
+
+	var assigneeIDs []int64
+	assigned := make(map[int64]bool, len(imported.Assignees))
+	for _, username := range imported.Assignees {
+		user, ok := users[strings.ToLower(username)]
+		if !ok {
+			check(&common.FieldValidationError{Field: "assignees", Message: fmt.Sprintf("unknown user %q", username)})
+			continue
+		}
+		if assigned[user.ID] {
+			check(&common.FieldValidationError{Field: "assignees", Message: fmt.Sprintf("user %q is listed more than once", username)})
+			continue
+		}
+		assigned[user.ID] = true
+		assigneeIDs = append(assigneeIDs, user.ID)
+	}
+	task.UpdateAssignees(assigneeIDs)
+
+	if len(rowErrors) > 0 {
+		return nil, rowErrors
+	}
+	return task, nil
+}
+
+// load copies the tasks with their tags and assignees in batches
+func (s *ImportService) load(ctx context.Context, tasks []*entities.Task) error {
+	for start := 0; start < len(tasks); start += importBatchSize {
+		batch := tasks[start:min(start+importBatchSize, len(tasks))]
+
+		ids, err := s.imports.ReserveTaskIDs(ctx, len(batch))
+		if err != nil {
+			return err
+		}
+		for i, task := range batch {
+			task.ID = ids[i]
+		}
+		if err := s.imports.CopyTasks(ctx, batch); err != nil {
+			return err
+		}
+		if err := s.imports.CopyTaskTags(ctx, batch); err != nil {
+			return err
+		}
+		if err := s.imports.CopyAssignments(ctx, batch); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ParseImportCSV reads a file with a task per row. mapping names the column holding each of
+// the ImportColumns when its header differs from the field name. Rows are grouped into
+// projects by project key, or by project name when they have no key.
+func ParseImportCSV(r io.Reader, mapping map[string]string) ([]*ImportProject, error) {
+	for field := range mapping {
+		if !isImportColumn(field) {
+			return nil, &common.FieldValidationError{Field: "mapping", Message: fmt.Sprintf("unknown field %q", field)}
+		}
+	}
+
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("reading csv header: %w", err)
+	}
+	positions := make(map[string]int, len(header))
+	for i, name := range header {
+		positions[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+
+	columns := make(map[string]int, len(ImportColumns))
+	for _, field := range ImportColumns {
+		name, mapped := mapping[field]
+		if !mapped {
+			name = field
+		}
+		if i, ok := positions[strings.ToLower(strings.TrimSpace(name))]; ok {
+			columns[field] = i
+		} else if mapped {
+			return nil, &common.FieldValidationError{Field: "mapping." + field, Message: fmt.Sprintf("the file has no %q column", name)}
+		}
+	}
+	_, hasProject := columns["project"]
+	_, hasKey := columns["project_key"]
+	if _, ok := columns["title"]; !ok || (!hasProject && !hasKey) {
+		return nil, errors.New("csv needs a title column and a project or project_key column")
+	}
+
+	var (
+		projects []*ImportProject
+		byGroup  = make(map[string]*ImportProject)
+	)
+	for row := 2; ; row++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading csv row %d: %w", row, err)
+		}
+		value := func(field string) string {
+			if i, ok := columns[field]; ok {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+
+		group := "name:" + value("project")
+		if key := strings.ToUpper(value("project_key")); key != "" {
+			group = "key:" + key
+		}
+		project, ok := byGroup[group]
+		if !ok {
+			project = &ImportProject{
+				Source:      fmt.Sprintf("row %d", row),
+				Name:        value("project"),
+				Key:         value("project_key"),
+				Description: value("project_description"),
+			}
+			byGroup[group] = project
+			projects = append(projects, project)
+		}
+		project.Tasks = append(project.Tasks, &ImportTask{
+			Source:           fmt.Sprintf("row %d", row),
+			Title:            value("title"),
+			Description:      value("description"),
+			Status:           value("status"),
+			Priority:         value("priority"),
+			DueDate:          value("due_date"),
+			EstimatedMinutes: value("estimated_minutes"),
+			Tags:             splitImportList(value("tags")),
+			Assignees:        splitImportList(value("assignees")),
+		})
+	}
+	return projects, nil
+}
+
+// importFile is the JSON import format: projects with their tasks
+type importFile struct {
+	Projects []struct {
+		Name        string `json:"name"`
+		Key         string `json:"key"`
+		Description string `json:"description"`
+		Tasks       []struct {
+			Title            string      `json:"title"`
+			Description      string      `json:"description"`
+			Status           string      `json:"status"`
+			Priority         string      `json:"priority"`
+			DueDate          string      `json:"due_date"`
+			EstimatedMinutes json.Number `json:"estimated_minutes"`
+			Tags             []string    `json:"tags"`
+			Assignees        []string    `json:"assignees"`
+		} `json:"tasks"`
+	} `json:"projects"`
+}
+
+// ParseImportJSON reads a {"projects": [{"name", "key", "description", "tasks": [...]}]} file
+func ParseImportJSON(r io.Reader) ([]*ImportProject, error) {
+	var file importFile
+	if err := json.NewDecoder(r).Decode(&file); err != nil {
+		return nil, fmt.Errorf("reading json: %w", err)
+	}
+
+	projects := make([]*ImportProject, len(file.Projects))
+	for i, p := range file.Projects {
+		project := &ImportProject{
+			Source:      fmt.Sprintf("projects[%d]", i),
+			Name:        p.Name,
+			Key:         p.Key,
+			Description: p.Description,
+		}
+		for j, t := range p.Tasks {
+			project.Tasks = append(project.Tasks, &ImportTask{
+				Source:           fmt.Sprintf("projects[%d].tasks[%d]", i, j),
+				Title:            t.Title,
+				Description:      t.Description,
+				Status:           t.Status,
+				Priority:         t.Priority,
+				DueDate:          t.DueDate,
+				EstimatedMinutes: t.EstimatedMinutes.String(),
+				Tags:             t.Tags,
+				Assignees:        t.Assignees,
+			})
+		}
+		projects[i] = project
+	}
+	return projects, nil
+}
+
+func isImportColumn(field string) bool {
+	for _, column := range ImportColumns {
+		if column == field {
+			return true
+		}
+	}
+	return false
+}
+
+func splitImportList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
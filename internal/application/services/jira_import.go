@@ -0,0 +1,287 @@
+package services
+
+import (
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"regexp"
+	"strings"
+	"task-engine/internal/domain/entities"
+	"task-engine/internal/domain/entities/common"
+	"time"
+)
+
+var (
+	htmlBreakPattern = regexp.MustCompile(`(?i)<br\s*/?>|</(p|div|li|tr|h[1-6])>`)
+	htmlTagPattern   = regexp.MustCompile(`(?s)<[^>]*>`)
+	blankRunPattern  = regexp.MustCompile(`\n{3,}`)
+)
+
+// jiraPriorities maps the default Jira priority schemes to task priorities
+var jiraPriorities = map[string]common.TaskPriority{
+	"highest":  common.TaskPriorityUrgent,
+	"blocker":  common.TaskPriorityUrgent,
+	"critical": common.TaskPriorityUrgent,
+	"high":     common.TaskPriorityHigh,
+	"major":    common.TaskPriorityHigh,
+	"medium":   common.TaskPriorityMedium,
+	"low":      common.TaskPriorityLow,
+	"lowest":   common.TaskPriorityLow,
+	"minor":    common.TaskPriorityLow,
+	"trivial":  common.TaskPriorityLow,
+}
+
+// jiraDateLayouts are the date formats of Jira exports, the RSS one and the CSV defaults
+var jiraDateLayouts = []string{
+	time.RFC1123Z,
+	"Mon, 2 Jan 2006 15:04:05 -0700",
+	"02/Jan/06 3:04 PM",
+	"2/Jan/06 3:04 PM",
+	"02/Jan/06",
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+// jiraRSS is the part of a Jira issue search exported as XML that is imported
+type jiraRSS struct {
+	Items []struct {
+		Key struct {
+			ID    string `xml:"id,attr"`
+			Value string `xml:",chardata"`
+		} `xml:"key"`
+		Summary     string `xml:"summary"`
+		Description string `xml:"description"`
+		Project     struct {
+			ID   string `xml:"id,attr"`
+			Key  string `xml:"key,attr"`
+			Name string `xml:",chardata"`
+		} `xml:"project"`
+		Status   string `xml:"status"`
+		Priority string `xml:"priority"`
+		Assignee struct {
+			Username string `xml:"username,attr"`
+		} `xml:"assignee"`
+		Created  string   `xml:"created"`
+		Due      string   `xml:"due"`
+		Labels   []string `xml:"labels>label"`
+		Comments []struct {
+			ID      string `xml:"id,attr"`
+			Author  string `xml:"author,attr"`
+			Created string `xml:"created,attr"`
+			Content string `xml:",chardata"`
+		} `xml:"comments>comment"`
+	} `xml:"channel>item"`
+}
+
+// ParseJiraXML reads issues exported from a Jira search as XML. Issues are grouped into a
+// project per Jira project, descriptions and comments lose their markup.
+func ParseJiraXML(r io.Reader) ([]*entities.ExternalProject, error) {
+	var rss jiraRSS
+	if err := xml.NewDecoder(r).Decode(&rss); err != nil {
+		return nil, fmt.Errorf("decoding jira xml: %w", err)
+	}
+
+	projects := newJiraProjects()
+	for _, item := range rss.Items {
+		task, err := newJiraTask(item.Key.ID, item.Key.Value, item.Summary, item.Status, item.Priority, item.Created, item.Due)
+		if err != nil {
+			return nil, err
+		}
+		task.Description = htmlToText(item.Description)
+		task.Labels = item.Labels
+		if item.Assignee.Username != "" {
+			task.Members = []string{item.Assignee.Username}
+		}
+		for _, comment := range item.Comments {
+			createdAt, err := parseJiraDate(comment.Created)
+			if err != nil {
+				return nil, fmt.Errorf("issue %s: comment %s: %w", item.Key.Value, comment.ID, err)
+			}
+			task.Comments = append(task.Comments, &entities.ExternalComment{
+				ID:        comment.ID,
+				Author:    comment.Author,
+				Content:   htmlToText(comment.Content),
+				CreatedAt: createdAt,
+			})
+		}
+		projects.add(item.Project.ID, item.Project.Key, item.Project.Name, item.Key.Value, task)
+	}
+	return projects.list, nil
+}
+
+// ParseJiraCSV reads issues exported from a Jira search as CSV. Labels and Comment columns
+// repeat once per value, comments are exported as "date;author;text". The export has no
+// comment ids, a comment is identified by a hash of its date, author and text so that it
+// keeps its id when comments are added or removed before it.
+func ParseJiraCSV(r io.Reader) ([]*entities.ExternalProject, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("reading csv header: %w", err)
+	}
+
+	columns := make(map[string][]int, len(header))
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(name))
+		columns[name] = append(columns[name], i)
+	}
+	for _, required := range []string{"summary", "issue key", "issue id"} {
+		if len(columns[required]) == 0 {
+			return nil, fmt.Errorf("csv has no %q column", required)
+		}
+	}
+
+	projects := newJiraProjects()
+	for row := 2; ; row++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading csv row %d: %w", row, err)
+		}
+		value := func(name string) string {
+			if positions := columns[name]; len(positions) > 0 && positions[0] < len(record) {
+				return strings.TrimSpace(record[positions[0]])
+			}
+			return ""
+		}
+		values := func(name string) []string {
+			var found []string
+			for _, i := range columns[name] {
+				if i < len(record) && strings.TrimSpace(record[i]) != "" {
+					found = append(found, strings.TrimSpace(record[i]))
+				}
+			}
+			return found
+		}
+
+		issueID := value("issue id")
+		task, err := newJiraTask(issueID, value("issue key"), value("summary"), value("status"), value("priority"), value("created"), value("due date"))
+		if err != nil {
+			return nil, fmt.Errorf("row %d: %w", row, err)
+		}
+		task.Description = value("description")
+		task.Labels = values("labels")
+		if assignee := value("assignee"); assignee != "" {
+			task.Members = []string{assignee}
+		}
+		seen := make(map[string]int)
+		for _, exported := range values("comment") {
+			parts := strings.SplitN(exported, ";", 3)
+			if len(parts) != 3 {
+				return nil, fmt.Errorf("row %d: comment %q is not date;author;text", row, exported)
+			}
+			createdAt, err := parseJiraDate(parts[0])
+			if err != nil {
+				return nil, fmt.Errorf("row %d: %w", row, err)
+			}
+			// identical comments are told apart by how many came before them
+			id := jiraCommentID(issueID, exported)
+			seen[id]++
+			if n := seen[id]; n > 1 {
+				id = fmt.Sprintf("%s-%d", id, n)
+			}
+			task.Comments = append(task.Comments, &entities.ExternalComment{
+				ID:        id,
+				Author:    parts[1],
+				Content:   parts[2],
+				CreatedAt: createdAt,
+			})
+		}
+		projects.add(value("project id"), value("project key"), value("project name"), value("issue key"), task)
+	}
+	return projects.list, nil
+}
+
+// jiraCommentID identifies an exported "date;author;text" comment of the issue
+func jiraCommentID(issueID, exported string) string {
+	sum := sha256.Sum256([]byte(exported))
+	return issueID + "#" + hex.EncodeToString(sum[:8])
+}
+
+// jiraProjects groups issues by project in the order the projects first appear
+type jiraProjects struct {
+	list  []*entities.ExternalProject
+	byKey map[string]*entities.ExternalProject
+}
+
+func newJiraProjects() *jiraProjects {
+	return &jiraProjects{byKey: make(map[string]*entities.ExternalProject)}
+}
+
+// add files the task under its project. Projects are identified by id, or by key in
+// exports without project ids. Without a project key, the one the issue key starts with is
+// used.
+func (p *jiraProjects) add(id, key, name, issueKey string, task *entities.ExternalTask) {
+	if key == "" {
+		key, _, _ = strings.Cut(issueKey, "-")
+	}
+	if id == "" {
+		id = key
+	}
+	project, ok := p.byKey[id]
+	if !ok {
+		project = &entities.ExternalProject{Source: common.ImportSourceJira, ID: id, Key: key, Name: name}
+		if project.Name == "" {
+			project.Name = key
+		}
+		p.byKey[id] = project
+		p.list = append(p.list, project)
+	}
+	project.Tasks = append(project.Tasks, task)
+}
+
+func newJiraTask(id, key, summary, status, priority, created, due string) (*entities.ExternalTask, error) {
+	if id == "" {
+		return nil, fmt.Errorf("issue %s has no id", key)
+	}
+	task := &entities.ExternalTask{
+		ID:       id,
+		Title:    strings.TrimSpace(summary),
+		Status:   strings.TrimSpace(status),
+		Priority: jiraPriorities[strings.ToLower(strings.TrimSpace(priority))],
+	}
+	if created != "" {
+		createdAt, err := parseJiraDate(created)
+		if err != nil {
+			return nil, fmt.Errorf("issue %s: %w", key, err)
+		}
+		task.CreatedAt = createdAt
+	}
+	if due != "" {
+		dueDate, err := parseJiraDate(due)
+		if err != nil {
+			return nil, fmt.Errorf("issue %s: %w", key, err)
+		}
+		task.DueDate = dueDate
+	}
+	return task, nil
+}
+
+func parseJiraDate(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	for _, layout := range jiraDateLayouts {
+		if parsed, err := time.Parse(layout, value); err == nil {
+			return parsed, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid date %q", value)
+}
+
+func htmlToText(markup string) string {
+	markup = htmlBreakPattern.ReplaceAllString(markup, "\n")
+	text := html.UnescapeString(htmlTagPattern.ReplaceAllString(markup, ""))
+
+	lines := strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace(line)
+	}
+	return strings.TrimSpace(blankRunPattern.ReplaceAllString(strings.Join(lines, "\n"), "\n\n"))
+}
@@ -0,0 +1,241 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"task-engine/internal/domain/entities"
+	"task-engine/internal/domain/entities/common"
+	"task-engine/internal/domain/repositories"
+	"task-engine/pkg/logger"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// mailIngestBatchSize is how many emails are read from the mailbox at a time
+const mailIngestBatchSize = 50
+
+// errEmailRejected marks the emails that will never be ingested, as opposed to failures
+// that may pass on the next run
+var errEmailRejected = errors.New("email rejected")
+
+// MailIngestionService turns inbound emails into tasks. An email becomes a task of the
+// project whose inbound address it was sent to, unless it replies to an ingested email:
+// replies become comments on the task of the thread. Emails are attributed to the user
+// with the sender's address, or to the fallback user when set.
+type MailIngestionService struct {
+	tx             repositories.Transactor
+	mailbox        repositories.Mailbox
+	projects       repositories.ProjectRepository
+	users          repositories.UserRepository
+	taskEmails     repositories.TaskEmailRepository
+	taskService    *TaskService
+	comments       *CommentService
+	attachments    *AttachmentService
+	fallbackUserID int64
+}
+
+func NewMailIngestionService(tx repositories.Transactor, mailbox repositories.Mailbox, projects repositories.ProjectRepository, users repositories.UserRepository, taskEmails repositories.TaskEmailRepository, taskService *TaskService, comments *CommentService, attachments *AttachmentService, fallbackUserID int64) *MailIngestionService {
+	return &MailIngestionService{
+		tx:             tx,
+		mailbox:        mailbox,
+		projects:       projects,
+		users:          users,
+		taskEmails:     taskEmails,
+		taskService:    taskService,
+		comments:       comments,
+		attachments:    attachments,
+		fallbackUserID: fallbackUserID,
+	}
+}
+
+// Ingest handles the new emails of the mailbox and returns how many became tasks or
+// comments. Rejected emails are marked failed, an email that failed for another reason
+// stops the run and stays in the mailbox for the next one.
+func (s *MailIngestionService) Ingest(ctx context.Context) (int, error) {
+	ingested := 0
+	for {
+		emails, err := s.mailbox.Fetch(ctx, mailIngestBatchSize)
+		if err != nil {
+			return ingested, err
+		}
+		for _, email := range emails {
+			err := s.handle(ctx, email)
+			switch {
+			case err == nil:
+				ingested++
+				err = s.mailbox.MarkDone(ctx, email)
+			case isRejectedEmail(err):
+				err = s.mailbox.MarkFailed(ctx, email, err)
+			}
+			if err != nil {
+				return ingested, err
+			}
+		}
+		if len(emails) < mailIngestBatchSize {
+			return ingested, nil
+		}
+	}
+}
+
+// Run ingests the mailbox every interval until ctx is cancelled
+func (s *MailIngestionService) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := s.Ingest(ctx); err != nil {
+				logger.Error("mail ingestion failed", zap.Error(err))
+			}
+		}
+	}
+}
+
+func (s *MailIngestionService) handle(ctx context.Context, email *entities.InboundEmail) error {
+	ids := email.ThreadIDs()
+	if email.MessageID != "" {
+		ids = append(ids, email.MessageID)
+	}
+	known, err := s.taskEmails.FindByMessageIDs(ctx, ids)
+	if err != nil {
+		return err
+	}
+	if _, ok := known[email.MessageID]; ok && email.MessageID != "" {
+		// delivered twice, the first copy was already ingested
+		return nil
+	}
+
+	userID, err := s.sender(ctx, email)
+	if err != nil {
+		return err
+	}
+
+	for _, id := range email.ThreadIDs() {
+		thread, ok := known[id]
+		if !ok {
+			continue
+		}
+		var comment *entities.Comment
+		err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+			var err error
+			comment, err = s.comments.AddComment(ctx, AddCommentInput{
+				TaskID:  thread.TaskID,
+				UserID:  userID,
+				Content: email.ReplyText(),
+			})
+			if err != nil {
+				return err
+			}
+			return s.record(ctx, email, thread.TaskID, comment.ID)
+		})
+		if errors.Is(err, repositories.ErrConflict) {
+			// another run ingested the email meanwhile, its comment was rolled back
+			return nil
+		} else if errors.Is(err, repositories.ErrNotFound) {
+			// the task of the thread was deleted, the reply starts a new task
+			break
+		} else if err != nil {
+			return err
+		}
+		s.upload(ctx, email, userID, func(input UploadInput) error {
+			_, err := s.attachments.UploadToComment(ctx, comment.ID, input)
+			return err
+		})
+		return nil
+	}
+
+	projects, err := s.projects.FindByInboundEmails(ctx, email.Recipients)
+	if err != nil {
+		return err
+	}
+	var project *entities.Project
+	for _, recipient := range email.Recipients {
+		if project = projects[recipient]; project != nil {
+			break
+		}
+	}
+	if project == nil {
+		return fmt.Errorf("%w: no project receives email at %v", errEmailRejected, email.Recipients)
+	}
+
+	var task *entities.Task
+	err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		task, err = s.taskService.CreateTask(ctx, project.ID, CreateTaskInput{
+			CreatedBy:   userID,
+			Title:       email.TaskTitle(),
+			Description: email.Text,
+		})
+		if err != nil {
+			return err
+		}
+		return s.record(ctx, email, task.ID, 0)
+	})
+	if errors.Is(err, repositories.ErrConflict) {
+		// another run ingested the email meanwhile, its task was rolled back
+		return nil
+	} else if err != nil {
+		return err
+	}
+	s.upload(ctx, email, userID, func(input UploadInput) error {
+		_, err := s.attachments.UploadToTask(ctx, task.ID, input)
+		return err
+	})
+	return nil
+}
+
+// sender returns the user the email is attributed to
+func (s *MailIngestionService) sender(ctx context.Context, email *entities.InboundEmail) (int64, error) {
+	users, err := s.users.FindByEmails(ctx, []string{email.From})
+	if err != nil {
+		return 0, err
+	}
+	if user, ok := users[email.From]; ok && user.IsActive() {
+		return user.ID, nil
+	}
+	if s.fallbackUserID == 0 {
+		return 0, fmt.Errorf("%w: no active user has the address %s", errEmailRejected, email.From)
+	}
+	return s.fallbackUserID, nil
+}
+
+// record remembers the email in the transaction that creates its task or comment, so it
+// is not ingested twice even if its attachments fail. It returns ErrConflict when the
+// email was already recorded, which rolls back the duplicate.
+func (s *MailIngestionService) record(ctx context.Context, email *entities.InboundEmail, taskID, commentID int64) error {
+	if email.MessageID == "" {
+		return nil
+	}
+	return s.taskEmails.Create(ctx, entities.NewTaskEmail(email, taskID, commentID))
+}
+
+// upload stores the attachments of the email. An attachment that cannot be stored is
+// skipped rather than failing the email, whose task or comment already exists.
+func (s *MailIngestionService) upload(ctx context.Context, email *entities.InboundEmail, userID int64, store func(UploadInput) error) {
+	for _, attachment := range email.Attachments {
+		err := store(UploadInput{
+			Filename:   attachment.Filename,
+			UploadedBy: userID,
+			Content:    bytes.NewReader(attachment.Content),
+		})
+		if err != nil {
+			logger.Warn("email attachment skipped",
+				zap.String("message_id", email.MessageID),
+				zap.String("filename", attachment.Filename),
+				zap.Error(err))
+		}
+	}
+}
+
+// isRejectedEmail reports whether the email can never be ingested as it is
+func isRejectedEmail(err error) bool {
+	var fieldErr *common.FieldValidationError
+	var validationErrs common.ValidationErrors
+	return errors.Is(err, errEmailRejected) || errors.As(err, &fieldErr) || errors.As(err, &validationErrs)
+}
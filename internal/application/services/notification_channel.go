@@ -0,0 +1,37 @@
+package services
+
+import (
+	"context"
+	"task-engine/internal/domain/entities"
+	"task-engine/internal/domain/entities/common"
+	"task-engine/internal/domain/repositories"
+)
+
+// NotificationChannel delivers notifications outside the in-app inbox. Deliveries are queued
+// when an event is handled and sent later by NotificationService.DispatchPending, so a slow
+// channel never holds up the request that caused the event.
+type NotificationChannel interface {
+	Name() common.NotificationChannel
+	Deliver(ctx context.Context, recipient *entities.User, notification *entities.Notification) error
+}
+
+// EmailChannel sends notifications as plain text emails
+type EmailChannel struct {
+	mailer repositories.Mailer
+}
+
+func NewEmailChannel(mailer repositories.Mailer) *EmailChannel {
+	return &EmailChannel{mailer: mailer}
+}
+
+func (c *EmailChannel) Name() common.NotificationChannel {
+	return common.NotificationChannelEmail
+}
+
+func (c *EmailChannel) Deliver(ctx context.Context, recipient *entities.User, notification *entities.Notification) error {
+	return c.mailer.Send(ctx, &entities.EmailMessage{
+		To:      recipient.Email,
+		Subject: notification.Title,
+		Text:    notification.Body,
+	})
+}
@@ -0,0 +1,459 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"task-engine/internal/domain/entities"
+	"task-engine/internal/domain/entities/common"
+	"task-engine/internal/domain/repositories"
+	"task-engine/pkg/logger"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	defaultInboxLimit     = 50
+	maxInboxLimit         = 200
+	deliveryBatchSize     = 100
+	maxNotificationTitle  = 255
+	maxCommentTextInTitle = 280
+)
+
+// NotificationService turns events into notifications for the users returned by
+// SubscriptionService.Recipients, keeps their inbox and sends queued deliveries
+type NotificationService struct {
+	tx            repositories.Transactor
+	notifications repositories.NotificationRepository
+	preferences   repositories.NotificationPreferenceRepository
+	users         repositories.UserRepository
+	projects      repositories.ProjectRepository
+	tasks         repositories.TaskRepository
+	comments      repositories.CommentRepository
+	subscriptions *SubscriptionService
+	digests       repositories.DigestScheduleRepository
+	channels      map[common.NotificationChannel]NotificationChannel
+	maxAttempts   int
+}
+
+func NewNotificationService(tx repositories.Transactor, notifications repositories.NotificationRepository, preferences repositories.NotificationPreferenceRepository, users repositories.UserRepository, projects repositories.ProjectRepository, tasks repositories.TaskRepository, comments repositories.CommentRepository, subscriptions *SubscriptionService, digests repositories.DigestScheduleRepository, maxAttempts int, channels ...NotificationChannel) *NotificationService {
+	registered := make(map[common.NotificationChannel]NotificationChannel, len(channels))
+	for _, channel := range channels {
+		registered[channel.Name()] = channel
+	}
+	return &NotificationService{
+		tx:            tx,
+		notifications: notifications,
+		preferences:   preferences,
+		users:         users,
+		projects:      projects,
+		tasks:         tasks,
+		comments:      comments,
+		subscriptions: subscriptions,
+		digests:       digests,
+		channels:      registered,
+		maxAttempts:   maxAttempts,
+	}
+}
+
+type InboxInput struct {
+	UserID     int64
+	UnreadOnly bool
+	Limit      int
+	Offset     int
+}
+
+// NotificationSettings are the notification options of a user. Preferences lists every
+// event type and channel, with the defaults filled in.
+type NotificationSettings struct {
+	Locale      string                             `json:"locale"`
+	Timezone    string                             `json:"timezone"`
+	Preferences []*entities.NotificationPreference `json:"preferences"`
+}
+
+type UpdateNotificationSettingsInput struct {
+	Locale      *string
+	Timezone    *string
+	Preferences []*entities.NotificationPreference
+}
+
+// HandleEvent notifies the recipients of the event on the channels they kept enabled
+func (s *NotificationService) HandleEvent(ctx context.Context, event *entities.Event) error {
+	recipients, err := s.subscriptions.Recipients(ctx, event)
+	if err != nil || len(recipients) == 0 {
+		return err
+	}
+
+	users, err := s.users.FindByIDs(ctx, recipients)
+	if err != nil {
+		return err
+	}
+	preferences, err := s.preferences.ListByUsers(ctx, recipients)
+	if err != nil {
+		return err
+	}
+	data, assigneeID, err := s.notificationData(ctx, event)
+	if err != nil {
+		return err
+	}
+
+	return s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		for _, userID := range recipients {
+			user, ok := users[userID]
+			if !ok || !user.IsActive() {
+				continue
+			}
+			data.AssignedToYou = assigneeID == userID
+			if err := s.notify(ctx, user, event, data, entities.EnabledChannels(preferences[userID], event.Type)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (s *NotificationService) ListInbox(ctx context.Context, input InboxInput) ([]*entities.Notification, int, error) {
+	filter := repositories.InboxFilter{
+		UserID:     input.UserID,
+		UnreadOnly: input.UnreadOnly,
+		Limit:      input.Limit,
+		Offset:     input.Offset,
+	}
+	if filter.Limit <= 0 {
+		filter.Limit = defaultInboxLimit
+	}
+	if filter.Limit > maxInboxLimit {
+		filter.Limit = maxInboxLimit
+	}
+
+	notifications, err := s.notifications.ListInbox(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	unread, err := s.notifications.CountUnread(ctx, input.UserID)
+	if err != nil {
+		return nil, 0, err
+	}
+	return notifications, unread, nil
+}
+
+func (s *NotificationService) MarkRead(ctx context.Context, userID, notificationID int64) (*entities.Notification, error) {
+	notification, err := s.findOwn(ctx, userID, notificationID)
+	if err != nil {
+		return nil, err
+	}
+
+	notification.MarkRead()
+	if err := s.notifications.Update(ctx, notification); err != nil {
+		return nil, err
+	}
+	return notification, nil
+}
+
+func (s *NotificationService) MarkUnread(ctx context.Context, userID, notificationID int64) (*entities.Notification, error) {
+	notification, err := s.findOwn(ctx, userID, notificationID)
+	if err != nil {
+		return nil, err
+	}
+
+	notification.MarkUnread()
+	if err := s.notifications.Update(ctx, notification); err != nil {
+		return nil, err
+	}
+	return notification, nil
+}
+
+func (s *NotificationService) MarkAllRead(ctx context.Context, userID int64) (int64, error) {
+	return s.notifications.MarkAllRead(ctx, userID, time.Now())
+}
+
+func (s *NotificationService) GetSettings(ctx context.Context, userID int64) (*NotificationSettings, error) {
+	user, err := s.users.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	preferences, err := s.preferences.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	settings := &NotificationSettings{Locale: user.Locale, Timezone: user.Timezone}
+	for _, eventType := range entities.NotificationEventTypes {
+		enabled := make(map[common.NotificationChannel]bool)
+		for _, channel := range entities.EnabledChannels(preferences, eventType) {
+			enabled[channel] = true
+		}
+		for _, channel := range entities.NotificationChannels {
+			settings.Preferences = append(settings.Preferences, &entities.NotificationPreference{
+				UserID:    userID,
+				EventType: eventType,
+				Channel:   channel,
+				Enabled:   enabled[channel],
+			})
+		}
+	}
+	return settings, nil
+}
+
+// UpdateSettings changes the locale and timezone when given and saves the listed preferences,
+// others keep their value. A digest schedule follows the new timezone.
+func (s *NotificationService) UpdateSettings(ctx context.Context, userID int64, input UpdateNotificationSettingsInput) (*NotificationSettings, error) {
+	user, err := s.users.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var validations []*common.FieldValidationError
+	for i, preference := range input.Preferences {
+		validations = append(validations, preference.Validate(i)...)
+	}
+	if err := common.ValidateFields(validations...); err != nil {
+		return nil, err
+	}
+
+	err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if input.Locale != nil {
+			if err := user.UpdateLocale(*input.Locale); err != nil {
+				return err
+			}
+		}
+		if input.Timezone != nil {
+			if err := user.UpdateTimezone(*input.Timezone); err != nil {
+				return err
+			}
+			if err := s.rescheduleDigest(ctx, user); err != nil {
+				return err
+			}
+		}
+		if input.Locale != nil || input.Timezone != nil {
+			if err := s.users.Update(ctx, user); err != nil {
+				return err
+			}
+		}
+		return s.preferences.Save(ctx, userID, input.Preferences)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.GetSettings(ctx, userID)
+}
+
+// DispatchPending sends the queued deliveries of every registered channel and returns how
+// many were sent. Failed deliveries are retried on later calls up to the attempt limit.
+func (s *NotificationService) DispatchPending(ctx context.Context) (int, error) {
+	sent := 0
+	for _, channel := range s.channels {
+		n, err := s.dispatch(ctx, channel)
+		sent += n
+		if err != nil {
+			return sent, err
+		}
+	}
+	return sent, nil
+}
+
+// Run dispatches pending deliveries every interval until ctx is cancelled
+func (s *NotificationService) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := s.DispatchPending(ctx); err != nil {
+				logger.Error("notification dispatch failed", zap.Error(err))
+			}
+		}
+	}
+}
+
+// dispatch sends up to deliveryBatchSize deliveries of the channel. Each one is claimed,
+// sent and recorded in its own transaction, so a failure only concerns the delivery at hand
+// and never returns deliveries that were already sent to the queue.
+func (s *NotificationService) dispatch(ctx context.Context, channel NotificationChannel) (int, error) {
+	sent := 0
+	var afterID int64
+	for i := 0; i < deliveryBatchSize; i++ {
+		var delivery *entities.NotificationDelivery
+		err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+			var err error
+			if delivery, err = s.notifications.ClaimPendingDelivery(ctx, channel.Name(), afterID); err != nil {
+				return err
+			}
+			return s.deliver(ctx, channel, delivery)
+		})
+		if errors.Is(err, repositories.ErrNotFound) && delivery == nil {
+			return sent, nil
+		}
+		if err != nil {
+			return sent, err
+		}
+		// failed deliveries stay pending and are retried on the next call, not in this one
+		afterID = delivery.ID
+		if delivery.Status == common.DeliveryStatusSent {
+			sent++
+		}
+	}
+	return sent, nil
+}
+
+// deliver sends a claimed delivery and records the outcome
+func (s *NotificationService) deliver(ctx context.Context, channel NotificationChannel, delivery *entities.NotificationDelivery) error {
+	notifications, err := s.notifications.FindByIDs(ctx, []int64{delivery.NotificationID})
+	if err != nil {
+		return err
+	}
+	notification := notifications[delivery.NotificationID]
+	users, err := s.users.FindByIDs(ctx, []int64{notification.UserID})
+	if err != nil {
+		return err
+	}
+
+	if err := channel.Deliver(ctx, users[notification.UserID], notification); err != nil {
+		delivery.MarkFailed(err, s.maxAttempts)
+		logger.Warn("notification delivery failed",
+			zap.Int64("delivery_id", delivery.ID),
+			zap.String("channel", string(delivery.Channel)),
+			zap.Int("attempts", delivery.Attempts),
+			zap.Error(err),
+		)
+	} else {
+		delivery.MarkSent()
+	}
+	return s.notifications.UpdateDelivery(ctx, delivery)
+}
+
+// notify stores the notification of one recipient and queues it on the external channels
+func (s *NotificationService) notify(ctx context.Context, user *entities.User, event *entities.Event, data notificationData, channels []common.NotificationChannel) error {
+	inApp := false
+	var external []common.NotificationChannel
+	for _, channel := range channels {
+		if channel == common.NotificationChannelInApp {
+			inApp = true
+		} else if _, ok := s.channels[channel]; ok {
+			external = append(external, channel)
+		}
+	}
+	if !inApp && len(external) == 0 {
+		return nil
+	}
+
+	title, body, err := renderNotification(user.Locale, data)
+	if err != nil {
+		return err
+	}
+	notification, err := entities.NewNotification(user.ID, event, truncate(title, maxNotificationTitle), body, inApp)
+	if err != nil {
+		return err
+	}
+	if err := s.notifications.Create(ctx, notification); err != nil {
+		return err
+	}
+
+	for _, channel := range external {
+		delivery, err := entities.NewNotificationDelivery(notification.ID, channel)
+		if err != nil {
+			return err
+		}
+		if err := s.notifications.CreateDelivery(ctx, delivery); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// notificationData loads what the templates show about the event, it also returns the
+// assigned user of task.assigned and task.unassigned events
+func (s *NotificationService) notificationData(ctx context.Context, event *entities.Event) (notificationData, int64, error) {
+	data := notificationData{EventType: event.Type}
+	if len(event.Payload) > 0 {
+		if err := json.Unmarshal(event.Payload, &data.Payload); err != nil {
+			return data, 0, err
+		}
+	}
+
+	if event.ProjectID != 0 {
+		project, err := s.projects.FindByID(ctx, event.ProjectID)
+		if err != nil {
+			return data, 0, err
+		}
+		data.ProjectName = project.Name
+	}
+	if event.TaskID != 0 {
+		task, err := s.tasks.FindByID(ctx, event.TaskID)
+		if err != nil {
+			return data, 0, err
+		}
+		data.TaskTitle = task.Title
+	}
+
+	var assignee entities.TaskAssignedPayload
+	var comment entities.CommentAddedPayload
+	switch event.Type {
+	case common.EventTypeTaskAssigned, common.EventTypeTaskUnassigned:
+		// both payloads carry the assignee id
+		if err := event.DecodePayload(&assignee); err != nil {
+			return data, 0, err
+		}
+	case common.EventTypeCommentAdded, common.EventTypeCommentMentioned:
+		// both payloads carry the comment id
+		if err := event.DecodePayload(&comment); err != nil {
+			return data, 0, err
+		}
+		found, err := s.comments.FindByID(ctx, comment.CommentID)
+		if err != nil && !errors.Is(err, repositories.ErrNotFound) {
+			return data, 0, err
+		}
+		if found != nil {
+			data.CommentText = truncate(found.Content, maxCommentTextInTitle)
+		}
+	}
+
+	users, err := s.users.FindByIDs(ctx, []int64{event.ActorID, assignee.AssigneeID})
+	if err != nil {
+		return data, 0, err
+	}
+	if actor, ok := users[event.ActorID]; ok {
+		data.ActorName = actor.Name
+	}
+	if user, ok := users[assignee.AssigneeID]; ok {
+		data.AssigneeName = user.Name
+	}
+	return data, assignee.AssigneeID, nil
+}
+
+func (s *NotificationService) rescheduleDigest(ctx context.Context, user *entities.User) error {
+	schedule, err := s.digests.Find(ctx, user.ID)
+	if errors.Is(err, repositories.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	schedule.Reschedule(time.Now(), user.Location())
+	return s.digests.Save(ctx, schedule)
+}
+
+func (s *NotificationService) findOwn(ctx context.Context, userID, notificationID int64) (*entities.Notification, error) {
+	notification, err := s.notifications.FindByID(ctx, notificationID)
+	if err != nil {
+		return nil, err
+	}
+	if notification.UserID != userID || !notification.InApp {
+		return nil, repositories.ErrNotFound
+	}
+	return notification, nil
+}
+
+// truncate shortens text to at most max runes, marking the cut with an ellipsis
+func truncate(text string, max int) string {
+	runes := []rune(text)
+	if len(runes) <= max {
+		return text
+	}
+	return string(runes[:max-1]) + "…"
+}
@@ -0,0 +1,131 @@
+package services
+
+import (
+	"strings"
+	"task-engine/internal/domain/entities/common"
+	"text/template"
+)
+
+// notificationData is what notification templates can use
+type notificationData struct {
+	EventType     common.EventType
+	ActorName     string
+	ProjectName   string
+	TaskTitle     string
+	AssigneeName  string
+	AssignedToYou bool
+	CommentText   string
+	Payload       map[string]interface{}
+}
+
+type notificationTemplate struct {
+	title *template.Template
+	body  *template.Template
+}
+
+// localeTemplates are the notification texts of one locale. Someone names an actor that is
+// unknown, e.g. for system events.
+type localeTemplates struct {
+	someone  string
+	fallback notificationTemplate
+	events   map[common.EventType]notificationTemplate
+}
+
+var notificationTemplates = map[string]localeTemplates{
+	"en": {
+		someone:  "Someone",
+		fallback: mustNotificationTemplate(`Update: {{.EventType}}`, ``),
+		events: map[common.EventType]notificationTemplate{
+			common.EventTypeTaskCreated: mustNotificationTemplate(
+				`New task: {{.TaskTitle}}`,
+				`{{.ActorName}} created "{{.TaskTitle}}" in {{.ProjectName}}.`),
+			common.EventTypeTaskAssigned: mustNotificationTemplate(
+				`{{if .AssignedToYou}}You were assigned to {{.TaskTitle}}{{else}}{{.AssigneeName}} was assigned to {{.TaskTitle}}{{end}}`,
+				`{{.ActorName}} assigned "{{.TaskTitle}}" to {{if .AssignedToYou}}you{{else}}{{.AssigneeName}}{{end}}.`),
+			common.EventTypeTaskUnassigned: mustNotificationTemplate(
+				`{{if .AssignedToYou}}You were unassigned from {{.TaskTitle}}{{else}}{{.AssigneeName}} was unassigned from {{.TaskTitle}}{{end}}`,
+				`{{.ActorName}} unassigned {{if .AssignedToYou}}you{{else}}{{.AssigneeName}}{{end}} from "{{.TaskTitle}}".`),
+			common.EventTypeTaskStatusChanged: mustNotificationTemplate(
+				`{{.TaskTitle}} moved to {{.Payload.to}}`,
+				`{{.ActorName}} moved "{{.TaskTitle}}" from {{.Payload.from}} to {{.Payload.to}}.`),
+			common.EventTypeCommentAdded: mustNotificationTemplate(
+				`New comment on {{.TaskTitle}}`,
+				`{{.ActorName}} commented: {{.CommentText}}`),
+			common.EventTypeCommentMentioned: mustNotificationTemplate(
+				`{{.ActorName}} mentioned you on {{.TaskTitle}}`,
+				`{{.ActorName}}: {{.CommentText}}`),
+			common.EventTypeBudgetBurnAlert: mustNotificationTemplate(
+				`Budget alert on {{.ProjectName}}`,
+				`{{.ProjectName}} has used {{printf "%.0f" .Payload.burn_percent}}% of its budget, crossing the {{.Payload.threshold}}% threshold.`),
+		},
+	},
+	"es": {
+		someone:  "Alguien",
+		fallback: mustNotificationTemplate(`Novedad: {{.EventType}}`, ``),
+		events: map[common.EventType]notificationTemplate{
+			common.EventTypeTaskCreated: mustNotificationTemplate(
+				`Nueva tarea: {{.TaskTitle}}`,
+				`{{.ActorName}} creó "{{.TaskTitle}}" en {{.ProjectName}}.`),
+			common.EventTypeTaskAssigned: mustNotificationTemplate(
+				`{{if .AssignedToYou}}Te asignaron {{.TaskTitle}}{{else}}{{.AssigneeName}} fue asignado a {{.TaskTitle}}{{end}}`,
+				`{{.ActorName}} asignó "{{.TaskTitle}}" a {{if .AssignedToYou}}ti{{else}}{{.AssigneeName}}{{end}}.`),
+			common.EventTypeTaskUnassigned: mustNotificationTemplate(
+				`{{if .AssignedToYou}}Ya no estás asignado a {{.TaskTitle}}{{else}}{{.AssigneeName}} ya no está asignado a {{.TaskTitle}}{{end}}`,
+				`{{.ActorName}} quitó a {{if .AssignedToYou}}ti{{else}}{{.AssigneeName}}{{end}} de "{{.TaskTitle}}".`),
+			common.EventTypeTaskStatusChanged: mustNotificationTemplate(
+				`{{.TaskTitle}} pasó a {{.Payload.to}}`,
+				`{{.ActorName}} movió "{{.TaskTitle}}" de {{.Payload.from}} a {{.Payload.to}}.`),
+			common.EventTypeCommentAdded: mustNotificationTemplate(
+				`Nuevo comentario en {{.TaskTitle}}`,
+				`{{.ActorName}} comentó: {{.CommentText}}`),
+			common.EventTypeCommentMentioned: mustNotificationTemplate(
+				`{{.ActorName}} te mencionó en {{.TaskTitle}}`,
+				`{{.ActorName}}: {{.CommentText}}`),
+			common.EventTypeBudgetBurnAlert: mustNotificationTemplate(
+				`Alerta de presupuesto en {{.ProjectName}}`,
+				`{{.ProjectName}} ha consumido el {{printf "%.0f" .Payload.burn_percent}}% de su presupuesto y superó el umbral del {{.Payload.threshold}}%.`),
+		},
+	},
+}
+
+func mustNotificationTemplate(title, body string) notificationTemplate {
+	return notificationTemplate{
+		title: template.Must(template.New("title").Parse(title)),
+		body:  template.Must(template.New("body").Parse(body)),
+	}
+}
+
+// templatesFor returns the templates of the locale, falling back to its language
+// ("pt" for "pt-BR") and then to the default locale
+func templatesFor(locale string) localeTemplates {
+	if templates, ok := notificationTemplates[locale]; ok {
+		return templates
+	}
+	if language, _, found := strings.Cut(locale, "-"); found {
+		if templates, ok := notificationTemplates[language]; ok {
+			return templates
+		}
+	}
+	return notificationTemplates[common.DefaultLocale]
+}
+
+// renderNotification returns the title and body of a notification in the given locale
+func renderNotification(locale string, data notificationData) (string, string, error) {
+	templates := templatesFor(locale)
+	if data.ActorName == "" {
+		data.ActorName = templates.someone
+	}
+	tmpl, ok := templates.events[data.EventType]
+	if !ok {
+		tmpl = templates.fallback
+	}
+
+	var title, body strings.Builder
+	if err := tmpl.title.Execute(&title, data); err != nil {
+		return "", "", err
+	}
+	if err := tmpl.body.Execute(&body, data); err != nil {
+		return "", "", err
+	}
+	return title.String(), body.String(), nil
+}
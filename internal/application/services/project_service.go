@@ -0,0 +1,84 @@
+package services
+
+import (
+	"context"
+	"task-engine/internal/domain/entities"
+	"task-engine/internal/domain/entities/common"
+	"task-engine/internal/domain/repositories"
+)
+
+type ProjectService struct {
+	projects repositories.ProjectRepository
+}
+
+func NewProjectService(projects repositories.ProjectRepository) *ProjectService {
+	return &ProjectService{projects: projects}
+}
+
+func (s *ProjectService) GetProject(ctx context.Context, projectID int64) (*entities.Project, error) {
+	return s.projects.FindByID(ctx, projectID)
+}
+
+func (s *ProjectService) UpdateSettings(ctx context.Context, projectID int64, settings entities.ProjectSettings) (*entities.Project, error) {
+	project, err := s.projects.FindByID(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := project.UpdateSettings(settings); err != nil {
+		return nil, err
+	}
+	if err := s.projects.Update(ctx, project); err != nil {
+		return nil, err
+	}
+	return project, nil
+}
+
+// SetKey changes the prefix used to reference the project's tasks, e.g. PROJ-45 in commit messages
+func (s *ProjectService) SetKey(ctx context.Context, projectID int64, key string) (*entities.Project, error) {
+	project, err := s.projects.FindByID(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := project.SetKey(key); err != nil {
+		return nil, err
+	}
+	if err := s.projects.Update(ctx, project); err != nil {
+		return nil, err
+	}
+	return project, nil
+}
+
+// SetInboundEmail changes the address whose emails become tasks of the project
+func (s *ProjectService) SetInboundEmail(ctx context.Context, projectID int64, address string) (*entities.Project, error) {
+	project, err := s.projects.FindByID(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := project.SetInboundEmail(address); err != nil {
+		return nil, err
+	}
+	if err := s.projects.Update(ctx, project); err != nil {
+		return nil, err
+	}
+	return project, nil
+}
+
+// SetSearchLanguage changes the language new and edited tasks and comments of the project are
+// indexed in, the existing ones are moved over by SearchService.Reindex
+func (s *ProjectService) SetSearchLanguage(ctx context.Context, projectID int64, language common.SearchLanguage) (*entities.Project, error) {
+	project, err := s.projects.FindByID(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := project.SetSearchLanguage(language); err != nil {
+		return nil, err
+	}
+	if err := s.projects.Update(ctx, project); err != nil {
+		return nil, err
+	}
+	return project, nil
+}
@@ -0,0 +1,313 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"task-engine/internal/domain/entities"
+	"task-engine/internal/domain/entities/common"
+	"task-engine/internal/domain/repositories"
+	"time"
+)
+
+// ProjectTemplateService saves projects as templates, instantiates templates and clones projects
+type ProjectTemplateService struct {
+	tx           repositories.Transactor
+	templates    repositories.ProjectTemplateRepository
+	projects     repositories.ProjectRepository
+	workflows    repositories.WorkflowRepository
+	customFields repositories.CustomFieldRepository
+	users        repositories.UserRepository
+	tasks        *TaskService
+}
+
+func NewProjectTemplateService(tx repositories.Transactor, templates repositories.ProjectTemplateRepository, projects repositories.ProjectRepository, workflows repositories.WorkflowRepository, customFields repositories.CustomFieldRepository, users repositories.UserRepository, tasks *TaskService) *ProjectTemplateService {
+	return &ProjectTemplateService{
+		tx:           tx,
+		templates:    templates,
+		projects:     projects,
+		workflows:    workflows,
+		customFields: customFields,
+		users:        users,
+		tasks:        tasks,
+	}
+}
+
+type SaveTemplateInput struct {
+	Name        string
+	Description string
+	CreatedBy   int64
+}
+
+type InstantiateTemplateInput struct {
+	Name      string
+	OwnerID   int64
+	StartDate time.Time
+	// RoleAssignees picks the user assigned to template tasks that expect a role
+	RoleAssignees map[common.UserRole]int64
+}
+
+type CloneProjectInput struct {
+	Name    string
+	OwnerID int64
+	// StartDate shifts every date of the copy, a zero value keeps the source dates
+	StartDate time.Time
+	Options   entities.CopyOptions
+}
+
+func (s *ProjectTemplateService) ListTemplates(ctx context.Context) ([]*entities.ProjectTemplate, error) {
+	return s.templates.List(ctx)
+}
+
+func (s *ProjectTemplateService) GetTemplate(ctx context.Context, templateID int64) (*entities.ProjectTemplate, error) {
+	return s.templates.FindByID(ctx, templateID)
+}
+
+func (s *ProjectTemplateService) DeleteTemplate(ctx context.Context, templateID int64) error {
+	return s.templates.Delete(ctx, templateID)
+}
+
+// SaveAsTemplate captures the project with its tasks, subtasks, tags, custom fields and workflow.
+// Assignees are recorded by role so the template can be reused by other teams.
+func (s *ProjectTemplateService) SaveAsTemplate(ctx context.Context, projectID int64, input SaveTemplateInput) (*entities.ProjectTemplate, error) {
+	project, err := s.projects.FindByID(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	content, err := s.capture(ctx, project, entities.TemplateCopyOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	template, err := entities.NewProjectTemplate(input.Name, input.Description, project.ID, input.CreatedBy, content)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.templates.Create(ctx, template); err != nil {
+		return nil, err
+	}
+	return template, nil
+}
+
+// InstantiateTemplate creates a new project from the template starting on input.StartDate
+func (s *ProjectTemplateService) InstantiateTemplate(ctx context.Context, templateID int64, input InstantiateTemplateInput) (*entities.Project, error) {
+	template, err := s.templates.FindByID(ctx, templateID)
+	if err != nil {
+		return nil, err
+	}
+
+	startDate := input.StartDate
+	if startDate.IsZero() {
+		startDate = time.Now()
+	}
+	project, err := entities.NewProjectBuilder().
+		FromTemplate(template.Content, startDate).
+		WithName(input.Name).
+		WithOwner(input.OwnerID).
+		Build()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.instantiate(ctx, project, template.Content, input.RoleAssignees); err != nil {
+		return nil, err
+	}
+	return project, nil
+}
+
+// CloneProject copies a project into a new one, input.Options selects what is copied
+func (s *ProjectTemplateService) CloneProject(ctx context.Context, projectID int64, input CloneProjectInput) (*entities.Project, error) {
+	source, err := s.projects.FindByID(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	options := input.Options
+	options.AssigneesByRole = false
+	content, err := s.capture(ctx, source, options)
+	if err != nil {
+		return nil, err
+	}
+
+	startDate := input.StartDate
+	if startDate.IsZero() {
+		startDate = entities.StartOf(source)
+	}
+	ownerID := input.OwnerID
+	if ownerID == 0 {
+		ownerID = source.OwnerID
+	}
+	project, err := entities.NewProjectBuilder().
+		FromTemplate(content, startDate).
+		WithName(input.Name).
+		WithOwner(ownerID).
+		WithTeam(source.TeamID).
+		Build()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.instantiate(ctx, project, content, nil); err != nil {
+		return nil, err
+	}
+	return project, nil
+}
+
+func (s *ProjectTemplateService) capture(ctx context.Context, project *entities.Project, options entities.CopyOptions) (entities.TemplateContent, error) {
+	var content entities.TemplateContent
+
+	workflow, err := s.workflows.FindByProject(ctx, project.ID)
+	if err != nil && !errors.Is(err, repositories.ErrNotFound) {
+		return content, err
+	}
+	definitions, err := s.customFields.ListDefinitions(ctx, project.ID)
+	if err != nil {
+		return content, err
+	}
+
+	var (
+		tasks []*entities.Task
+		users map[int64]*entities.User
+	)
+	if options.Tasks {
+		if tasks, err = s.tasks.listProjectTasks(ctx, project.ID); err != nil {
+			return content, err
+		}
+		if options.Assignees && options.AssigneesByRole {
+			if users, err = s.users.FindByIDs(ctx, assigneeIDsOf(tasks)); err != nil {
+				return content, err
+			}
+		}
+	}
+
+	return entities.CaptureProject(project, workflow, definitions, tasks, users, options), nil
+}
+
+// instantiate creates the project and everything in content in a single transaction
+func (s *ProjectTemplateService) instantiate(ctx context.Context, project *entities.Project, content entities.TemplateContent, roleAssignees map[common.UserRole]int64) error {
+	return s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.projects.Create(ctx, project); err != nil {
+			return err
+		}
+
+		workflow := entities.DefaultWorkflow(project.ID)
+		if content.Workflow != nil {
+			var err error
+			workflow, err = entities.NewWorkflow(project.ID, content.Workflow.Name, content.Workflow.InitialStatus, content.Workflow.Statuses, content.Workflow.Transitions)
+			if err != nil {
+				return err
+			}
+			if err := s.workflows.Save(ctx, workflow); err != nil {
+				return err
+			}
+		}
+		initialStatus, _ := workflow.Status(workflow.InitialStatus)
+
+		definitions := make(map[string]*entities.CustomFieldDefinition, len(content.CustomFields))
+		for _, field := range content.CustomFields {
+			definition, err := entities.NewCustomFieldDefinition(project.ID, field.Key, field.Name, field.Type, field.Options, field.Required)
+			if err != nil {
+				return err
+			}
+			if err := s.customFields.CreateDefinition(ctx, definition); err != nil {
+				return err
+			}
+			definitions[definition.Key] = definition
+		}
+
+		created := make(map[int]*entities.Task, len(content.Tasks))
+		for _, item := range content.Tasks {
+			task, err := s.instantiateTask(ctx, project, item, initialStatus, created[item.ParentRef], definitions, roleAssignees)
+			if err != nil {
+				return err
+			}
+			created[item.Ref] = task
+		}
+		return nil
+	})
+}
+
+func (s *ProjectTemplateService) instantiateTask(ctx context.Context, project *entities.Project, item entities.TemplateTask, status entities.WorkflowStatus, parent *entities.Task, definitions map[string]*entities.CustomFieldDefinition, roleAssignees map[common.UserRole]int64) (*entities.Task, error) {
+	task, err := entities.NewTask(project.ID, item.Title, item.Description)
+	if err != nil {
+		return nil, err
+	}
+	if item.Priority != "" {
+		if err := task.UpdatePriority(item.Priority); err != nil {
+			return nil, err
+		}
+	}
+	if err := task.UpdateStatus(status); err != nil {
+		return nil, err
+	}
+	if err := task.UpdateDueDate(item.DueDate(project.StartDate)); err != nil {
+		return nil, err
+	}
+	if err := task.UpdateEstimate(item.EstimatedMinutes, nil); err != nil {
+		return nil, err
+	}
+	if err := task.UpdateTags(item.Tags); err != nil {
+		return nil, err
+	}
+	if parent != nil {
+		if err := task.SetParent(parent); err != nil {
+			return nil, err
+		}
+	}
+
+	var values []*entities.CustomFieldValue
+	for key, raw := range item.CustomFields {
+		definition, ok := definitions[key]
+		if !ok {
+			continue
+		}
+		var decoded interface{}
+		if err := json.Unmarshal(raw, &decoded); err != nil {
+			return nil, err
+		}
+		value, fieldErr := definition.ParseValue(decoded)
+		if fieldErr != nil {
+			return nil, fieldErr
+		}
+		if value != nil {
+			values = append(values, value)
+		}
+	}
+
+	// the values must satisfy the project's custom fields as in CreateTask, required ones included
+	list := make([]*entities.CustomFieldDefinition, 0, len(definitions))
+	for _, definition := range definitions {
+		list = append(list, definition)
+	}
+	if err := entities.ValidateCustomFieldValues(list, values); err != nil {
+		return nil, err
+	}
+
+	task.CustomFields = values
+	assigneeIDs := append([]int64(nil), item.AssigneeIDs...)
+	for _, role := range item.AssigneeRoles {
+		if userID, ok := roleAssignees[role]; ok {
+			assigneeIDs = append(assigneeIDs, userID)
+		}
+	}
+	task.UpdateAssignees(assigneeIDs)
+
+	if err := s.tasks.persist(ctx, task); err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+func assigneeIDsOf(tasks []*entities.Task) []int64 {
+	seen := make(map[int64]bool)
+	var ids []int64
+	for _, task := range tasks {
+		for _, userID := range task.AssigneeIDs {
+			if !seen[userID] {
+				seen[userID] = true
+				ids = append(ids, userID)
+			}
+		}
+	}
+	return ids
+}
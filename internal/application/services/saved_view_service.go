@@ -0,0 +1,260 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"task-engine/internal/domain/entities"
+	"task-engine/internal/domain/entities/common"
+	"task-engine/internal/domain/repositories"
+)
+
+// SavedViewService keeps named task lists. The query of a view is resolved for the user
+// reading it, so assignee:me in a shared view lists the reader's tasks.
+type SavedViewService struct {
+	views    repositories.SavedViewRepository
+	projects repositories.ProjectRepository
+	teams    repositories.TeamRepository
+	tasks    *TaskService
+}
+
+func NewSavedViewService(views repositories.SavedViewRepository, projects repositories.ProjectRepository, teams repositories.TeamRepository, tasks *TaskService) *SavedViewService {
+	return &SavedViewService{views: views, projects: projects, teams: teams, tasks: tasks}
+}
+
+// CreateSavedViewInput holds a new view, a zero ProjectID makes it span every project
+type CreateSavedViewInput struct {
+	UserID     int64
+	Role       common.UserRole
+	ProjectID  int64
+	Name       string
+	Visibility common.SavedViewVisibility
+	TeamID     int64
+	Settings   entities.TaskListSettings
+}
+
+// UpdateSavedViewInput changes the fields that are set
+type UpdateSavedViewInput struct {
+	UserID     int64
+	Role       common.UserRole
+	Name       *string
+	Visibility *common.SavedViewVisibility
+	TeamID     *int64
+	Settings   *entities.TaskListSettings
+}
+
+// SavedViewTasks is a page of the tasks of a view and how many match in total
+type SavedViewTasks struct {
+	View       *entities.SavedView
+	Tasks      []*entities.Task
+	Total      int
+	NextCursor string
+}
+
+func (s *SavedViewService) CreateView(ctx context.Context, input CreateSavedViewInput) (*entities.SavedView, error) {
+	if input.ProjectID != 0 {
+		if _, err := s.projects.FindByID(ctx, input.ProjectID); err != nil {
+			return nil, err
+		}
+	}
+
+	view, err := entities.NewSavedView(input.UserID, input.ProjectID, input.Name, input.Visibility, input.TeamID, input.Settings)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.check(ctx, view, input.UserID, input.Role); err != nil {
+		return nil, err
+	}
+
+	if err := s.views.Create(ctx, view); err != nil {
+		return nil, err
+	}
+	return view, nil
+}
+
+// GetView returns the view if the user sees it
+func (s *SavedViewService) GetView(ctx context.Context, id, userID int64) (*entities.SavedView, error) {
+	view, err := s.visibleView(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+	if view.Pinned, err = s.views.IsPinned(ctx, view.ID, userID); err != nil {
+		return nil, err
+	}
+	return view, nil
+}
+
+// ListViews returns the views the user sees with how many tasks each lists, pinned views
+// first. The views are counted together in one query. The count of a view whose query no
+// longer applies, e.g. after a custom field was deleted, is left out.
+func (s *SavedViewService) ListViews(ctx context.Context, userID int64, role common.UserRole, projectID int64) ([]*entities.SavedView, error) {
+	views, err := s.views.List(ctx, repositories.SavedViewFilter{UserID: userID, ProjectID: projectID})
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		counted []*entities.SavedView
+		filters []repositories.TaskFilter
+	)
+	for _, view := range views {
+		filter, err := s.tasks.buildFilter(ctx, s.listInput(view, userID, role))
+		if isInvalidTaskQuery(err) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		counted = append(counted, view)
+		filters = append(filters, filter)
+	}
+	counts, err := s.tasks.tasks.CountEach(ctx, filters)
+	if err != nil {
+		return nil, err
+	}
+	for i, view := range counted {
+		view.TaskCount = &counts[i]
+	}
+	return views, nil
+}
+
+func (s *SavedViewService) UpdateView(ctx context.Context, id int64, input UpdateSavedViewInput) (*entities.SavedView, error) {
+	view, err := s.views.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !view.CanModify(input.UserID, input.Role) {
+		return nil, &entities.ForbiddenError{Reason: "only the owner or a manager can change this view"}
+	}
+
+	name, visibility, teamID, settings := view.Name, view.Visibility, view.TeamID, view.TaskListSettings
+	if input.Name != nil {
+		name = *input.Name
+	}
+	if input.Visibility != nil {
+		visibility = *input.Visibility
+		if visibility != common.SavedViewVisibilityTeam {
+			teamID = 0
+		}
+	}
+	if input.TeamID != nil {
+		teamID = *input.TeamID
+	}
+	if input.Settings != nil {
+		settings = *input.Settings
+	}
+	if err := view.Update(name, visibility, teamID, settings); err != nil {
+		return nil, err
+	}
+	if err := s.check(ctx, view, input.UserID, input.Role); err != nil {
+		return nil, err
+	}
+
+	if err := s.views.Update(ctx, view); err != nil {
+		return nil, err
+	}
+	return view, nil
+}
+
+func (s *SavedViewService) DeleteView(ctx context.Context, id, userID int64, role common.UserRole) error {
+	view, err := s.views.FindByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !view.CanModify(userID, role) {
+		return &entities.ForbiddenError{Reason: "only the owner or a manager can delete this view"}
+	}
+	return s.views.Delete(ctx, id)
+}
+
+// PinView pins the view for the user only
+func (s *SavedViewService) PinView(ctx context.Context, id, userID int64) error {
+	if _, err := s.visibleView(ctx, id, userID); err != nil {
+		return err
+	}
+	return s.views.Pin(ctx, id, userID)
+}
+
+func (s *SavedViewService) UnpinView(ctx context.Context, id, userID int64) error {
+	if _, err := s.views.FindByID(ctx, id); err != nil {
+		return err
+	}
+	return s.views.Unpin(ctx, id, userID)
+}
+
+// ViewTasks returns a page of the tasks the view lists for the user, read from the offset
+// or from the cursor of the previous page
+func (s *SavedViewService) ViewTasks(ctx context.Context, id, userID int64, role common.UserRole, limit, offset int, cursor string) (*SavedViewTasks, error) {
+	view, err := s.GetView(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	input := s.listInput(view, userID, role)
+	input.Limit, input.Offset, input.Cursor = limit, offset, cursor
+	page, err := s.tasks.ListTasksPage(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+	total, err := s.tasks.CountTasks(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+	return &SavedViewTasks{View: view, Tasks: page.Tasks, Total: total, NextCursor: page.NextCursor}, nil
+}
+
+func (s *SavedViewService) visibleView(ctx context.Context, id, userID int64) (*entities.SavedView, error) {
+	view, err := s.views.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	var team *entities.Team
+	if view.Visibility == common.SavedViewVisibilityTeam && view.OwnerID != userID {
+		if team, err = s.teams.FindByID(ctx, view.TeamID); err != nil {
+			return nil, err
+		}
+	}
+	if !view.IsVisibleTo(userID, team) {
+		return nil, &entities.ForbiddenError{Reason: "this view is not shared with you"}
+	}
+	return view, nil
+}
+
+// check makes sure the query and sort of the view apply to its project and that a view is
+// only shared with a team by one of its members or a manager
+func (s *SavedViewService) check(ctx context.Context, view *entities.SavedView, userID int64, role common.UserRole) error {
+	if _, err := s.tasks.ListFilter(ctx, s.listInput(view, userID, role)); err != nil {
+		return err
+	}
+	if view.Visibility != common.SavedViewVisibilityTeam {
+		return nil
+	}
+	team, err := s.teams.FindByID(ctx, view.TeamID)
+	if errors.Is(err, repositories.ErrNotFound) {
+		return &common.FieldValidationError{Field: "team_id", Message: "team not found"}
+	} else if err != nil {
+		return err
+	}
+	if !team.HasMember(userID) && role != common.UserRoleAdmin && role != common.UserRoleManager {
+		return &entities.ForbiddenError{Reason: "only members of the team or a manager can share a view with it"}
+	}
+	return nil
+}
+
+func (s *SavedViewService) listInput(view *entities.SavedView, userID int64, role common.UserRole) ListTasksInput {
+	return ListTasksInput{
+		ProjectID: view.ProjectID,
+		Query:     view.Query,
+		Sort:      view.Sort,
+		UserID:    userID,
+		Role:      role,
+	}
+}
+
+// isInvalidTaskQuery reports whether the error comes from a query or sort that does not
+// apply, as opposed to a failure to run it
+func isInvalidTaskQuery(err error) bool {
+	var (
+		queryErr *entities.TaskQueryError
+		fieldErr *common.FieldValidationError
+	)
+	return errors.As(err, &queryErr) || errors.As(err, &fieldErr)
+}
@@ -0,0 +1,161 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"task-engine/internal/domain/entities"
+	"task-engine/internal/domain/entities/common"
+	"task-engine/internal/domain/repositories"
+)
+
+const (
+	defaultSearchLimit = 20
+	maxSearchLimit     = 100
+	maxSearchQuery     = 500
+
+	defaultSuggestLimit = 5
+	maxSuggestLimit     = 20
+	maxSuggestPrefix    = 100
+)
+
+// SearchService runs full-text searches over tasks, projects and comments
+type SearchService struct {
+	projects repositories.ProjectRepository
+	search   repositories.SearchRepository
+}
+
+func NewSearchService(projects repositories.ProjectRepository, search repositories.SearchRepository) *SearchService {
+	return &SearchService{projects: projects, search: search}
+}
+
+// seesEveryProject reports whether users of the role see every project. The others see the
+// projects they own, belong to through its team or have an assigned task in.
+func seesEveryProject(role common.UserRole) bool {
+	return role == common.UserRoleAdmin || role == common.UserRoleManager
+}
+
+// checkProjectVisible fails with a ForbiddenError unless the user sees the project
+func checkProjectVisible(ctx context.Context, projects repositories.ProjectRepository, projectID, userID int64, role common.UserRole) error {
+	if seesEveryProject(role) {
+		return nil
+	}
+	visible, err := projects.IsVisibleTo(ctx, projectID, userID)
+	if err != nil {
+		return err
+	}
+	if !visible {
+		return &entities.ForbiddenError{Reason: "you do not have access to this project"}
+	}
+	return nil
+}
+
+// SearchInput holds a search in web search syntax: quoted phrases, "or" and -excluded
+// words. Admins and managers search every project, other users the projects they can see.
+type SearchInput struct {
+	Query     string
+	Types     []common.SearchResultType
+	ProjectID int64
+	Statuses  []common.TaskStatus
+	Tags      []string
+	UserID    int64
+	Role      common.UserRole
+	Limit     int
+	Offset    int
+}
+
+// Search returns the records matching the query, best match first
+func (s *SearchService) Search(ctx context.Context, input SearchInput) ([]*entities.SearchResult, error) {
+	filter := repositories.SearchFilter{
+		Query:     strings.TrimSpace(input.Query),
+		Types:     input.Types,
+		ProjectID: input.ProjectID,
+		Statuses:  input.Statuses,
+		Tags:      input.Tags,
+		Limit:     input.Limit,
+		Offset:    input.Offset,
+	}
+	if filter.Limit <= 0 {
+		filter.Limit = defaultSearchLimit
+	}
+	if filter.Limit > maxSearchLimit {
+		filter.Limit = maxSearchLimit
+	}
+	if filter.Offset < 0 {
+		filter.Offset = 0
+	}
+	if !seesEveryProject(input.Role) {
+		filter.VisibleTo = input.UserID
+	}
+
+	validations := []*common.FieldValidationError{
+		common.ValidateRequired("q", filter.Query),
+		common.ValidateStringLength("q", filter.Query, maxSearchQuery),
+	}
+	for _, resultType := range filter.Types {
+		validations = append(validations, common.ValidateSearchResultType("type", resultType))
+	}
+	if err := common.ValidateFields(validations...); err != nil {
+		return nil, err
+	}
+
+	if filter.ProjectID != 0 {
+		if _, err := s.projects.FindByID(ctx, filter.ProjectID); err != nil {
+			return nil, err
+		}
+	}
+	return s.search.Search(ctx, filter)
+}
+
+// SuggestInput holds what was typed so far. Limit applies to each type.
+type SuggestInput struct {
+	Prefix    string
+	Types     []common.SuggestionType
+	ProjectID int64
+	UserID    int64
+	Role      common.UserRole
+	Limit     int
+}
+
+// Suggest returns the tasks, projects, tags and users whose name starts with the prefix,
+// grouped by type and in alphabetical order within a type
+func (s *SearchService) Suggest(ctx context.Context, input SuggestInput) ([]*entities.Suggestion, error) {
+	filter := repositories.SuggestFilter{
+		Prefix:    strings.TrimSpace(input.Prefix),
+		Types:     input.Types,
+		ProjectID: input.ProjectID,
+		Limit:     input.Limit,
+	}
+	if filter.Limit <= 0 {
+		filter.Limit = defaultSuggestLimit
+	}
+	if filter.Limit > maxSuggestLimit {
+		filter.Limit = maxSuggestLimit
+	}
+	if !seesEveryProject(input.Role) {
+		filter.VisibleTo = input.UserID
+	}
+
+	validations := []*common.FieldValidationError{
+		common.ValidateRequired("q", filter.Prefix),
+		common.ValidateStringLength("q", filter.Prefix, maxSuggestPrefix),
+	}
+	for _, suggestionType := range filter.Types {
+		validations = append(validations, common.ValidateSuggestionType("type", suggestionType))
+	}
+	if err := common.ValidateFields(validations...); err != nil {
+		return nil, err
+	}
+
+	return s.search.Suggest(ctx, filter)
+}
+
+// Reindex indexes the tasks and comments of the project again in its search language, or
+// those of every project when projectID is zero. It returns how many were reindexed.
+func (s *SearchService) Reindex(ctx context.Context, projectID int64) (int64, error) {
+	if projectID != 0 {
+		if _, err := s.projects.FindByID(ctx, projectID); err != nil {
+			return 0, err
+		}
+	}
+	return s.search.Reindex(ctx, projectID)
+}
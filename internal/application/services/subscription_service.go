@@ -0,0 +1,190 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"task-engine/internal/domain/entities"
+	"task-engine/internal/domain/entities/common"
+	"task-engine/internal/domain/repositories"
+)
+
+// SubscriptionService manages who follows which project or task. Recipients is the one
+// place that decides who is told about an event.
+type SubscriptionService struct {
+	projects      repositories.ProjectRepository
+	tasks         repositories.TaskRepository
+	subscriptions repositories.SubscriptionRepository
+}
+
+func NewSubscriptionService(projects repositories.ProjectRepository, tasks repositories.TaskRepository, subscriptions repositories.SubscriptionRepository) *SubscriptionService {
+	return &SubscriptionService{projects: projects, tasks: tasks, subscriptions: subscriptions}
+}
+
+func (s *SubscriptionService) GetSubscription(ctx context.Context, userID int64, target entities.SubscriptionTarget) (*entities.Subscription, error) {
+	return s.subscriptions.Find(ctx, userID, target)
+}
+
+func (s *SubscriptionService) ListSubscriptions(ctx context.Context, userID int64) ([]*entities.Subscription, error) {
+	return s.subscriptions.ListByUser(ctx, userID)
+}
+
+// ListWatchers returns the active subscriptions to the target itself, not those inherited from the project
+func (s *SubscriptionService) ListWatchers(ctx context.Context, target entities.SubscriptionTarget) ([]*entities.Subscription, error) {
+	if err := s.checkTarget(ctx, target); err != nil {
+		return nil, err
+	}
+	return s.subscriptions.ListByTarget(ctx, target)
+}
+
+// Watch subscribes the user explicitly, which also lifts a previous unsubscribe.
+// A non-nil muted replaces the muted event types.
+func (s *SubscriptionService) Watch(ctx context.Context, userID int64, target entities.SubscriptionTarget, muted []common.EventType) (*entities.Subscription, error) {
+	if err := s.checkTarget(ctx, target); err != nil {
+		return nil, err
+	}
+
+	subscription, err := s.subscriptions.Find(ctx, userID, target)
+	if errors.Is(err, repositories.ErrNotFound) {
+		if subscription, err = entities.NewSubscription(userID, target, common.SubscriptionReasonWatch); err != nil {
+			return nil, err
+		}
+		if muted != nil {
+			if err := subscription.SetMuted(muted); err != nil {
+				return nil, err
+			}
+		}
+		if err := s.subscriptions.Create(ctx, subscription); err != nil {
+			return nil, err
+		}
+		return subscription, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	subscription.Watch()
+	if muted != nil {
+		if err := subscription.SetMuted(muted); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.subscriptions.Update(ctx, subscription); err != nil {
+		return nil, err
+	}
+	return subscription, nil
+}
+
+// Unwatch stops updates about the target. Unwatching a task of a watched project records an
+// unsubscribed task subscription, which silences the task without leaving the project.
+func (s *SubscriptionService) Unwatch(ctx context.Context, userID int64, target entities.SubscriptionTarget) error {
+	if err := s.checkTarget(ctx, target); err != nil {
+		return err
+	}
+
+	subscription, err := s.subscriptions.Find(ctx, userID, target)
+	if errors.Is(err, repositories.ErrNotFound) {
+		if subscription, err = entities.NewSubscription(userID, target, common.SubscriptionReasonWatch); err != nil {
+			return err
+		}
+		subscription.Unsubscribe()
+		err = s.subscriptions.Create(ctx, subscription)
+		if errors.Is(err, repositories.ErrConflict) {
+			return nil
+		}
+		return err
+	}
+	if err != nil {
+		return err
+	}
+
+	subscription.Unsubscribe()
+	return s.subscriptions.Update(ctx, subscription)
+}
+
+// Recipients returns the ids of the users who should be told about the event. A mention
+// reaches the mentioned users whatever their subscriptions, and they are left out of the
+// comment.added recipients so they hear about the comment once.
+func (s *SubscriptionService) Recipients(ctx context.Context, event *entities.Event) ([]int64, error) {
+	var excluded []int64
+	switch event.Type {
+	case common.EventTypeCommentMentioned:
+		var payload entities.CommentMentionedPayload
+		if err := event.DecodePayload(&payload); err != nil {
+			return nil, err
+		}
+		return payload.UserIDs, nil
+	case common.EventTypeCommentAdded:
+		var payload entities.CommentAddedPayload
+		if err := event.DecodePayload(&payload); err != nil {
+			return nil, err
+		}
+		excluded = payload.MentionedUserIDs
+	}
+
+	subscriptions, err := s.subscriptions.ListForEvent(ctx, event.ProjectID, event.TaskID)
+	if err != nil {
+		return nil, err
+	}
+	recipients := entities.Recipients(event, subscriptions)
+	if len(excluded) == 0 {
+		return recipients, nil
+	}
+
+	skip := make(map[int64]bool, len(excluded))
+	for _, userID := range excluded {
+		skip[userID] = true
+	}
+	kept := recipients[:0]
+	for _, userID := range recipients {
+		if !skip[userID] {
+			kept = append(kept, userID)
+		}
+	}
+	return kept, nil
+}
+
+// HandleEvent watches tasks on behalf of the users who create them, are assigned to them or
+// comment on them. It must be subscribed to the publisher before any handler that uses
+// Recipients, so that e.g. a new assignee hears about their own assignment.
+func (s *SubscriptionService) HandleEvent(ctx context.Context, event *entities.Event) error {
+	target := entities.TaskTarget(event.TaskID)
+
+	switch event.Type {
+	case common.EventTypeTaskCreated:
+		return s.autoWatch(ctx, event.ActorID, target, common.SubscriptionReasonCreated)
+	case common.EventTypeTaskAssigned:
+		var payload entities.TaskAssignedPayload
+		if err := event.DecodePayload(&payload); err != nil {
+			return err
+		}
+		return s.autoWatch(ctx, payload.AssigneeID, target, common.SubscriptionReasonAssigned)
+	case common.EventTypeCommentAdded:
+		return s.autoWatch(ctx, event.ActorID, target, common.SubscriptionReasonCommented)
+	}
+	return nil
+}
+
+// autoWatch subscribes the user unless they already have a subscription, an earlier
+// unsubscribe included
+func (s *SubscriptionService) autoWatch(ctx context.Context, userID int64, target entities.SubscriptionTarget, reason common.SubscriptionReason) error {
+	if userID == 0 || target.TaskID == 0 {
+		return nil
+	}
+	subscription, err := entities.NewSubscription(userID, target, reason)
+	if err != nil {
+		return err
+	}
+	if err := s.subscriptions.Create(ctx, subscription); err != nil && !errors.Is(err, repositories.ErrConflict) {
+		return err
+	}
+	return nil
+}
+
+func (s *SubscriptionService) checkTarget(ctx context.Context, target entities.SubscriptionTarget) error {
+	if target.IsTask() {
+		_, err := s.tasks.FindByID(ctx, target.TaskID)
+		return err
+	}
+	_, err := s.projects.FindByID(ctx, target.ProjectID)
+	return err
+}
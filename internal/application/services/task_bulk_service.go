@@ -0,0 +1,390 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"task-engine/internal/domain/entities"
+	"task-engine/internal/domain/entities/common"
+	"task-engine/internal/domain/repositories"
+	"time"
+)
+
+// maxBulkTasks bounds how many tasks a single bulk request changes
+const maxBulkTasks = 100
+
+// errBulkRolledBack undoes an atomic bulk request in which a task failed
+var errBulkRolledBack = errors.New("bulk request rolled back")
+
+// TaskBulkService applies one change to many tasks at once. Every task goes through the
+// same entity methods and workflow checks as when it is changed on its own.
+type TaskBulkService struct {
+	tx          repositories.Transactor
+	tasks       *TaskService
+	assignments *AssignmentService
+}
+
+func NewTaskBulkService(tx repositories.Transactor, tasks *TaskService, assignments *AssignmentService) *TaskBulkService {
+	return &TaskBulkService{tx: tx, tasks: tasks, assignments: assignments}
+}
+
+// BulkTaskInput is the change applied to every task of TaskIDs. The action reads Status for
+// set_status, Priority for set_priority, DueDate for set_due_date where a zero date clears
+// it, UserID for assign and unassign, Tags for add_tags and remove_tags and ProjectID for
+// move. Mode defaults to atomic.
+type BulkTaskInput struct {
+	Actor     entities.TransitionActor
+	TaskIDs   []int64
+	Action    common.BulkTaskAction
+	Mode      common.BulkMode
+	Status    common.TaskStatus
+	Priority  common.TaskPriority
+	DueDate   time.Time
+	UserID    int64
+	Tags      []string
+	ProjectID int64
+}
+
+// BulkTaskResult tells whether the change was applied to the task, and why not otherwise
+type BulkTaskResult struct {
+	TaskID  int64
+	Applied bool
+	Errors  common.ValidationErrors
+}
+
+// BulkTaskReport has a result per task in the order of the request. In atomic mode no task
+// is changed when any of them fails, so every result is left unapplied.
+type BulkTaskReport struct {
+	Mode    common.BulkMode
+	Results []BulkTaskResult
+	Applied int
+	Failed  int
+}
+
+// ApplyBulk changes the tasks one by one. An atomic request runs in a single transaction
+// rolled back when a task fails, a best effort request runs every task in its own
+// transaction and keeps the changes that succeeded. Errors other than the ones caused by a
+// task, such as a lost connection, fail the whole request. A subtask already deleted with
+// its parent earlier in the request counts as applied.
+func (s *TaskBulkService) ApplyBulk(ctx context.Context, input BulkTaskInput) (*BulkTaskReport, error) {
+	if input.Mode == "" {
+		input.Mode = common.BulkModeAtomic
+	}
+	taskIDs, err := s.validate(input)
+	if err != nil {
+		return nil, err
+	}
+
+	// the destination is read once for every moved task
+	var destination *bulkDestination
+	if input.Action == common.BulkTaskActionMove {
+		if _, err := s.tasks.projects.FindByID(ctx, input.ProjectID); errors.Is(err, repositories.ErrNotFound) {
+			return nil, &common.FieldValidationError{Field: "project_id", Message: "project does not exist"}
+		} else if err != nil {
+			return nil, err
+		}
+		destination = &bulkDestination{}
+		if destination.workflow, err = s.tasks.workflows.workflowOf(ctx, input.ProjectID); err != nil {
+			return nil, err
+		}
+		if destination.customFields, err = s.tasks.customFields.ListDefinitions(ctx, input.ProjectID); err != nil {
+			return nil, err
+		}
+	}
+
+	report := &BulkTaskReport{Mode: input.Mode, Results: make([]BulkTaskResult, len(taskIDs))}
+	var blobKeys []string
+	// cascaded holds the subtasks removed with a task deleted earlier in the request, they
+	// are deleted already when their own turn comes
+	cascaded := make(map[int64]bool)
+	if input.Mode == common.BulkModeAtomic {
+		var keys []string
+		err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+			failed := false
+			for i, taskID := range taskIDs {
+				report.Results[i].TaskID = taskID
+				if cascaded[taskID] {
+					continue
+				}
+				subtaskIDs, err := s.cascadedBy(ctx, input, taskID)
+				if err != nil {
+					return err
+				}
+				deleted, err := s.apply(ctx, input, destination, taskID)
+				if itemErrs, ok := bulkItemErrors(err); ok {
+					report.Results[i].Errors = itemErrs
+					failed = true
+					continue
+				} else if err != nil {
+					return err
+				}
+				keys = append(keys, deleted...)
+				for _, subtaskID := range subtaskIDs {
+					cascaded[subtaskID] = true
+				}
+			}
+			if failed {
+				return errBulkRolledBack
+			}
+			return nil
+		})
+		if err != nil && !errors.Is(err, errBulkRolledBack) {
+			return nil, err
+		}
+		if err == nil {
+			for i := range report.Results {
+				report.Results[i].Applied = true
+			}
+			blobKeys = keys
+		}
+	} else {
+		for i, taskID := range taskIDs {
+			report.Results[i].TaskID = taskID
+			if cascaded[taskID] {
+				report.Results[i].Applied = true
+				continue
+			}
+			var (
+				deleted    []string
+				subtaskIDs []int64
+			)
+			err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+				var err error
+				if subtaskIDs, err = s.cascadedBy(ctx, input, taskID); err != nil {
+					return err
+				}
+				deleted, err = s.apply(ctx, input, destination, taskID)
+				return err
+			})
+			if itemErrs, ok := bulkItemErrors(err); ok {
+				report.Results[i].Errors = itemErrs
+				continue
+			} else if err != nil {
+				return nil, err
+			}
+			report.Results[i].Applied = true
+			blobKeys = append(blobKeys, deleted...)
+			for _, subtaskID := range subtaskIDs {
+				cascaded[subtaskID] = true
+			}
+		}
+	}
+
+	for _, result := range report.Results {
+		if result.Applied {
+			report.Applied++
+		} else if len(result.Errors) > 0 {
+			report.Failed++
+		}
+	}
+
+	// attachment contents are only removed once the deletions are committed
+	if err := s.tasks.attachments.collectGarbage(ctx, blobKeys); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// validate checks the request as a whole and returns its task ids without duplicates
+func (s *TaskBulkService) validate(input BulkTaskInput) ([]int64, error) {
+	validations := []*common.FieldValidationError{
+		common.ValidateBulkTaskAction("action", input.Action),
+		common.ValidateBulkMode("mode", input.Mode),
+	}
+	if len(input.TaskIDs) == 0 {
+		validations = append(validations, &common.FieldValidationError{Field: "task_ids", Message: "field is required"})
+	}
+
+	seen := make(map[int64]bool, len(input.TaskIDs))
+	taskIDs := make([]int64, 0, len(input.TaskIDs))
+	for _, taskID := range input.TaskIDs {
+		if seen[taskID] {
+			continue
+		}
+		seen[taskID] = true
+		taskIDs = append(taskIDs, taskID)
+		validations = append(validations, common.ValidatePositiveInt("task_ids", taskID))
+	}
+	if len(taskIDs) > maxBulkTasks {
+		validations = append(validations, &common.FieldValidationError{
+			Field:   "task_ids",
+			Message: fmt.Sprintf("at most %d tasks can be changed at once", maxBulkTasks),
+		})
+	}
+
+	switch input.Action {
+	case common.BulkTaskActionSetStatus:
+		validations = append(validations, common.ValidateRequired("status", string(input.Status)))
+	case common.BulkTaskActionSetPriority:
+		validations = append(validations, common.ValidateTaskPriority("priority", input.Priority))
+	case common.BulkTaskActionAssign, common.BulkTaskActionUnassign:
+		validations = append(validations, common.ValidatePositiveInt("user_id", input.UserID))
+	case common.BulkTaskActionAddTags, common.BulkTaskActionRemoveTags:
+		if len(input.Tags) == 0 {
+			validations = append(validations, &common.FieldValidationError{Field: "tags", Message: "field is required"})
+		}
+	case common.BulkTaskActionMove:
+		validations = append(validations, common.ValidatePositiveInt("project_id", input.ProjectID))
+	}
+
+	if err := common.ValidateFields(validations...); err != nil {
+		return nil, err
+	}
+	return taskIDs, nil
+}
+
+// cascadedBy returns the subtasks that applying a delete to the task removes with it
+func (s *TaskBulkService) cascadedBy(ctx context.Context, input BulkTaskInput, taskID int64) ([]int64, error) {
+	if input.Action != common.BulkTaskActionDelete {
+		return nil, nil
+	}
+	subtasks, err := s.tasks.tasks.ListSubtasks(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]int64, len(subtasks))
+	for i, subtask := range subtasks {
+		ids[i] = subtask.ID
+	}
+	return ids, nil
+}
+
+// apply changes a single task, returning the attachment contents a deletion may have orphaned
+func (s *TaskBulkService) apply(ctx context.Context, input BulkTaskInput, destination *bulkDestination, taskID int64) ([]string, error) {
+	switch input.Action {
+	case common.BulkTaskActionSetStatus:
+		_, err := s.tasks.TransitionTask(ctx, taskID, input.Status, input.Actor)
+		return nil, err
+	case common.BulkTaskActionAssign:
+		return nil, s.assignments.Assign(ctx, taskID, input.UserID, input.Actor.UserID)
+	case common.BulkTaskActionUnassign:
+		return nil, s.assignments.Unassign(ctx, taskID, input.UserID, input.Actor.UserID)
+	case common.BulkTaskActionMove:
+		return nil, s.move(ctx, taskID, input.ProjectID, destination)
+	case common.BulkTaskActionDelete:
+		keys, err := s.tasks.attachments.blobKeysOf(ctx, taskID)
+		if err != nil {
+			return nil, err
+		}
+		return keys, s.tasks.tasks.Delete(ctx, taskID)
+	}
+
+	task, err := s.tasks.GetTask(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	switch input.Action {
+	case common.BulkTaskActionSetPriority:
+		err = task.UpdatePriority(input.Priority)
+	case common.BulkTaskActionSetDueDate:
+		err = task.UpdateDueDate(input.DueDate)
+	case common.BulkTaskActionAddTags:
+		err = task.UpdateTags(append(task.Tags, input.Tags...))
+	case common.BulkTaskActionRemoveTags:
+		removed := make(map[string]bool, len(input.Tags))
+		for _, tag := range input.Tags {
+			removed[tag] = true
+		}
+		kept := make([]string, 0, len(task.Tags))
+		for _, tag := range task.Tags {
+			if !removed[tag] {
+				kept = append(kept, tag)
+			}
+		}
+		err = task.UpdateTags(kept)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if input.Action == common.BulkTaskActionAddTags || input.Action == common.BulkTaskActionRemoveTags {
+		if err := s.tasks.tags.SetTaskTags(ctx, task.ID, task.Tags); err != nil {
+			return nil, err
+		}
+	}
+	return nil, s.tasks.tasks.Update(ctx, task)
+}
+
+// bulkDestination is the project tasks are moved to
+type bulkDestination struct {
+	workflow     *entities.Workflow
+	customFields []*entities.CustomFieldDefinition
+}
+
+// move moves a top level task and its subtasks to the project. Tasks keep their status when
+// the destination workflow has it and start over in its initial status otherwise. Custom
+// field values do not follow the task, so a destination with required custom fields
+// rejects it.
+func (s *TaskBulkService) move(ctx context.Context, taskID, projectID int64, destination *bulkDestination) error {
+	task, err := s.tasks.tasks.FindByID(ctx, taskID)
+	if err != nil {
+		return err
+	}
+	if task.IsSubtask() {
+		return &common.FieldValidationError{Field: "task_ids", Message: "subtasks are moved together with their parent task"}
+	}
+	if err := entities.ValidateCustomFieldValues(destination.customFields, nil); err != nil {
+		return err
+	}
+	subtasks, err := s.tasks.tasks.ListSubtasks(ctx, taskID)
+	if err != nil {
+		return err
+	}
+
+	moved := append([]*entities.Task{task}, subtasks...)
+	ids := make([]int64, len(moved))
+	for i, t := range moved {
+		ids[i] = t.ID
+	}
+	values, err := s.tasks.customFields.ListValues(ctx, ids)
+	if err != nil {
+		return err
+	}
+
+	for _, t := range moved {
+		status, ok := destination.workflow.Status(t.Status)
+		if !ok {
+			status, _ = destination.workflow.Status(destination.workflow.InitialStatus)
+		}
+		if err := t.MoveTo(projectID, status); err != nil {
+			return err
+		}
+		if err := s.tasks.tasks.Update(ctx, t); err != nil {
+			return err
+		}
+
+		var fieldIDs []int64
+		for _, value := range values[t.ID] {
+			fieldIDs = append(fieldIDs, value.FieldID)
+		}
+		if err := s.tasks.customFields.UnsetValues(ctx, t.ID, fieldIDs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// bulkItemErrors describes the errors caused by the task itself, which fail that task only
+func bulkItemErrors(err error) (common.ValidationErrors, bool) {
+	if err == nil {
+		return nil, false
+	}
+
+	var (
+		validationErrs common.ValidationErrors
+		fieldErr       *common.FieldValidationError
+		transitionErr  *entities.TransitionNotAllowedError
+		forbiddenErr   *entities.ForbiddenError
+	)
+	switch {
+	case errors.As(err, &validationErrs), errors.As(err, &fieldErr):
+		return prefixValidationErrors("", err), true
+	case errors.As(err, &transitionErr):
+		return common.ValidationErrors{{Field: "status", Message: transitionErr.Error()}}, true
+	case errors.As(err, &forbiddenErr):
+		return common.ValidationErrors{{Field: "task_ids", Message: forbiddenErr.Error()}}, true
+	case errors.Is(err, repositories.ErrNotFound):
+		return common.ValidationErrors{{Field: "task_ids", Message: "task not found"}}, true
+	}
+	return nil, false
+}
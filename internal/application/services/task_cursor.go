@@ -0,0 +1,167 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"task-engine/internal/domain/entities"
+	"task-engine/internal/domain/entities/common"
+	"task-engine/internal/domain/repositories"
+	"task-engine/pkg/cursor"
+	"time"
+)
+
+// TaskPage is a page of tasks, NextCursor reads the following page and is empty on the last
+// one or when the sort cannot be paged by cursor
+type TaskPage struct {
+	Tasks      []*entities.Task
+	NextCursor string
+}
+
+// taskCursor is the position after the last task of a page. It keeps the sort and a
+// fingerprint of the filter so that it is not used with another list.
+type taskCursor struct {
+	Sort   string  `json:"s"`
+	Filter string  `json:"f"`
+	Value  *string `json:"v"`
+	ID     int64   `json:"id"`
+}
+
+// ListTasksPage returns the tasks ListTasks would and a cursor to the next page. Pages read
+// with input.Cursor start right after the last task of the previous page, so tasks created
+// or moved meanwhile are neither skipped nor repeated as with offsets. Cursors need the
+// default order or a single sort by created_at, due_date or priority.
+func (s *TaskService) ListTasksPage(ctx context.Context, input ListTasksInput) (*TaskPage, error) {
+	filter, err := s.buildFilter(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+	sort, keyset := taskKeysetSort(filter.Sort)
+
+	if input.Cursor != "" {
+		if input.Offset != 0 {
+			return nil, &common.FieldValidationError{Field: "cursor", Message: "cursor and offset cannot be combined"}
+		}
+		if !keyset {
+			return nil, &common.FieldValidationError{Field: "cursor", Message: "cursors need a single sort by created_at, due_date or priority"}
+		}
+		if filter.After, err = s.decodeTaskCursor(input, sort); err != nil {
+			return nil, err
+		}
+	}
+
+	tasks, err := s.tasks.List(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.attachDetails(ctx, tasks); err != nil {
+		return nil, err
+	}
+
+	page := &TaskPage{Tasks: tasks}
+	if keyset && len(tasks) > 0 && len(tasks) == filter.Limit {
+		if page.NextCursor, err = s.encodeTaskCursor(input, sort, tasks[len(tasks)-1]); err != nil {
+			return nil, err
+		}
+	}
+	return page, nil
+}
+
+// taskKeysetSort returns the column pages are keyed on, "-" prefixed when descending
+func taskKeysetSort(sorts []repositories.TaskSort) (string, bool) {
+	if len(sorts) == 0 {
+		return "-" + repositories.TaskSortCreatedAt, true
+	}
+	sort := sorts[0]
+	if len(sorts) > 1 || sort.CustomField != nil || !repositories.IsTaskKeysetColumn(sort.Column) {
+		return "", false
+	}
+	if sort.Descending {
+		return "-" + sort.Column, true
+	}
+	return sort.Column, true
+}
+
+func (s *TaskService) encodeTaskCursor(input ListTasksInput, sort string, last *entities.Task) (string, error) {
+	position := taskCursor{Sort: sort, Filter: taskFilterFingerprint(input), ID: last.ID}
+	var value string
+	switch strings.TrimPrefix(sort, "-") {
+	case repositories.TaskSortCreatedAt:
+		value = last.CreatedAt.UTC().Format(time.RFC3339Nano)
+	case repositories.TaskSortDueDate:
+		if !last.DueDate.IsZero() {
+			value = last.DueDate.Format("2006-01-02")
+		}
+	case repositories.TaskSortPriority:
+		value = string(last.Priority)
+	}
+	if value != "" {
+		position.Value = &value
+	}
+	return cursor.Encode(s.cursorSecret, position)
+}
+
+func (s *TaskService) decodeTaskCursor(input ListTasksInput, sort string) (*repositories.TaskKeyset, error) {
+	var position taskCursor
+	if err := cursor.Decode(s.cursorSecret, input.Cursor, &position); errors.Is(err, cursor.ErrInvalidCursor) {
+		return nil, &common.FieldValidationError{Field: "cursor", Message: "invalid cursor"}
+	} else if err != nil {
+		return nil, err
+	}
+	if position.Sort != sort || position.Filter != taskFilterFingerprint(input) {
+		return nil, &common.FieldValidationError{Field: "cursor", Message: "the cursor belongs to a list with another filter or sort"}
+	}
+
+	keyset := &repositories.TaskKeyset{ID: position.ID}
+	if position.Value == nil {
+		return keyset, nil
+	}
+	invalid := &common.FieldValidationError{Field: "cursor", Message: "invalid cursor"}
+	switch strings.TrimPrefix(sort, "-") {
+	case repositories.TaskSortCreatedAt:
+		createdAt, err := time.Parse(time.RFC3339Nano, *position.Value)
+		if err != nil {
+			return nil, invalid
+		}
+		keyset.Value = createdAt
+	case repositories.TaskSortDueDate:
+		dueDate, err := time.Parse("2006-01-02", *position.Value)
+		if err != nil {
+			return nil, invalid
+		}
+		keyset.Value = dueDate
+	case repositories.TaskSortPriority:
+		priority := common.TaskPriority(*position.Value)
+		if common.ValidateTaskPriority("cursor", priority) != nil {
+			return nil, invalid
+		}
+		keyset.Value = priority
+	}
+	return keyset, nil
+}
+
+// taskFilterFingerprint identifies the tasks a list selects, leaving out the page. The
+// custom field filters are sorted first, their order does not change the tasks and the
+// handler reads them from the query parameters in no particular order.
+func taskFilterFingerprint(input ListTasksInput) string {
+	customFields := append([]CustomFieldFilter(nil), input.CustomFields...)
+	sort.Slice(customFields, func(i, j int) bool {
+		a, b := customFields[i], customFields[j]
+		if a.Key != b.Key {
+			return a.Key < b.Key
+		}
+		if a.Operator != b.Operator {
+			return a.Operator < b.Operator
+		}
+		return a.Value < b.Value
+	})
+
+	hash := sha256.New()
+	fmt.Fprintf(hash, "%d|%v|%v|%v|%q|%d|%q|%q|%q",
+		input.ProjectID, input.Statuses, input.Categories, input.Priorities, input.Query, input.UserID, input.Role, input.Sort, customFields)
+	return hex.EncodeToString(hash.Sum(nil)[:12])
+}
@@ -0,0 +1,379 @@
+package services
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"task-engine/internal/domain/entities"
+	"task-engine/internal/domain/entities/common"
+	"task-engine/internal/domain/repositories"
+	"time"
+)
+
+// taskPriorityOrder ranks the priorities for ordered comparisons such as priority>=high
+var taskPriorityOrder = []common.TaskPriority{
+	common.TaskPriorityLow,
+	common.TaskPriorityMedium,
+	common.TaskPriorityHigh,
+	common.TaskPriorityUrgent,
+}
+
+// taskQueryDateColumns are the date fields of task queries
+var taskQueryDateColumns = map[string]string{
+	"due":     repositories.TaskColumnDueDate,
+	"created": repositories.TaskColumnCreatedAt,
+	"updated": repositories.TaskColumnUpdatedAt,
+}
+
+// taskQueryResolver turns a parsed task query into a TaskExpression, checking every value
+// against the project: its workflow statuses, custom fields and the users named. The
+// workflow and custom fields are loaded the first time a term needs them.
+type taskQueryResolver struct {
+	service     *TaskService
+	query       string
+	projectID   int64
+	userID      int64
+	now         time.Time
+	workflow    *entities.Workflow
+	definitions map[string]*entities.CustomFieldDefinition
+}
+
+// resolveTaskQuery parses the query and resolves it, it returns nil for an empty query
+func (s *TaskService) resolveTaskQuery(ctx context.Context, query string, projectID, userID int64, now time.Time) (repositories.TaskExpression, error) {
+	node, err := entities.ParseTaskQuery(query)
+	if err != nil || node == nil {
+		return nil, err
+	}
+	r := &taskQueryResolver{service: s, query: query, projectID: projectID, userID: userID, now: now}
+	return r.resolve(ctx, node)
+}
+
+func (r *taskQueryResolver) resolve(ctx context.Context, node entities.TaskQueryNode) (repositories.TaskExpression, error) {
+	switch n := node.(type) {
+	case *entities.TaskQueryAnd:
+		operands, err := r.resolveAll(ctx, andOperands(n))
+		if err != nil {
+			return nil, err
+		}
+		return repositories.TaskAnd{Operands: operands}, nil
+	case *entities.TaskQueryOr:
+		operands, err := r.resolveAll(ctx, orOperands(n))
+		if err != nil {
+			return nil, err
+		}
+		return repositories.TaskOr{Operands: operands}, nil
+	case *entities.TaskQueryNot:
+		operand, err := r.resolve(ctx, n.Operand)
+		if err != nil {
+			return nil, err
+		}
+		return repositories.TaskNot{Operand: operand}, nil
+	case *entities.TaskQueryTerm:
+		if n.Operator == entities.TaskQueryNotEqual {
+			// field!=a,b matches the tasks field:a,b does not
+			equal := *n
+			equal.Operator = entities.TaskQueryEqual
+			operand, err := r.resolveTerm(ctx, &equal)
+			if err != nil {
+				return nil, err
+			}
+			return repositories.TaskNot{Operand: operand}, nil
+		}
+		return r.resolveTerm(ctx, n)
+	}
+	return nil, r.errorAt(node.Position(), "unsupported query node")
+}
+
+// andOperands lists the operands of a chain of ANDs, so a b c becomes one AND of three terms
+// rather than nested pairs
+func andOperands(node entities.TaskQueryNode) []entities.TaskQueryNode {
+	if n, ok := node.(*entities.TaskQueryAnd); ok {
+		return append(andOperands(n.Left), andOperands(n.Right)...)
+	}
+	return []entities.TaskQueryNode{node}
+}
+
+func orOperands(node entities.TaskQueryNode) []entities.TaskQueryNode {
+	if n, ok := node.(*entities.TaskQueryOr); ok {
+		return append(orOperands(n.Left), orOperands(n.Right)...)
+	}
+	return []entities.TaskQueryNode{node}
+}
+
+func (r *taskQueryResolver) resolveAll(ctx context.Context, nodes []entities.TaskQueryNode) ([]repositories.TaskExpression, error) {
+	operands := make([]repositories.TaskExpression, len(nodes))
+	for i, node := range nodes {
+		operand, err := r.resolve(ctx, node)
+		if err != nil {
+			return nil, err
+		}
+		operands[i] = operand
+	}
+	return operands, nil
+}
+
+// resolveTerm resolves a term whose operator is not !=
+func (r *taskQueryResolver) resolveTerm(ctx context.Context, term *entities.TaskQueryTerm) (repositories.TaskExpression, error) {
+	if term.Operator.IsOrdering() && len(term.Values) > 1 {
+		return nil, r.errorAt(term.Values[1].Pos, "%s%s takes a single value", term.Field, term.Operator)
+	}
+	if column, ok := taskQueryDateColumns[term.Field]; ok {
+		return r.resolveDate(column, term)
+	}
+	if key, ok := strings.CutPrefix(term.Field, "cf."); ok {
+		return r.resolveCustomField(ctx, key, term)
+	}
+
+	switch term.Field {
+	case "status":
+		return r.resolveStatus(ctx, term)
+	case "category":
+		if err := r.requireEquality(term); err != nil {
+			return nil, err
+		}
+		values := make([]string, len(term.Values))
+		for i, value := range term.Values {
+			category := common.StatusCategory(strings.ToLower(value.Text))
+			if fieldErr := common.ValidateStatusCategory("category", category); fieldErr != nil {
+				return nil, r.invalidValue(term.Field, value, fieldErr)
+			}
+			values[i] = string(category)
+		}
+		return repositories.TaskValueCondition{Column: repositories.TaskColumnCategory, Values: values}, nil
+	case "priority":
+		return r.resolvePriority(term)
+	case "tag":
+		if err := r.requireEquality(term); err != nil {
+			return nil, err
+		}
+		names := make([]string, len(term.Values))
+		for i, value := range term.Values {
+			names[i] = value.Text
+		}
+		return repositories.TaskTagCondition{Names: names}, nil
+	case "assignee":
+		return r.resolveAssignee(ctx, term)
+	}
+	return nil, r.errorAt(term.Pos, "unknown field %q, expected status, category, priority, due, created, updated, tag, assignee or cf.<key>", term.Field)
+}
+
+func (r *taskQueryResolver) resolveStatus(ctx context.Context, term *entities.TaskQueryTerm) (repositories.TaskExpression, error) {
+	if err := r.requireEquality(term); err != nil {
+		return nil, err
+	}
+	// statuses are checked against the workflow of the project, across projects only
+	// their shape can be
+	if r.workflow == nil && r.projectID != 0 {
+		workflow, err := r.service.workflows.workflowOf(ctx, r.projectID)
+		if err != nil {
+			return nil, err
+		}
+		r.workflow = workflow
+	}
+	values := make([]string, len(term.Values))
+	for i, value := range term.Values {
+		status := common.TaskStatus(strings.ToLower(value.Text))
+		fieldErr := common.ValidateTaskStatus("status", status)
+		if fieldErr == nil && r.workflow != nil {
+			fieldErr = r.workflow.ValidateStatus("status", status)
+		}
+		if fieldErr != nil {
+			return nil, r.invalidValue(term.Field, value, fieldErr)
+		}
+		values[i] = string(status)
+	}
+	return repositories.TaskValueCondition{Column: repositories.TaskColumnStatus, Values: values}, nil
+}
+
+func (r *taskQueryResolver) resolvePriority(term *entities.TaskQueryTerm) (repositories.TaskExpression, error) {
+	ranks := make([]int, len(term.Values))
+	for i, value := range term.Values {
+		ranks[i] = -1
+		for rank, priority := range taskPriorityOrder {
+			if string(priority) == strings.ToLower(value.Text) {
+				ranks[i] = rank
+			}
+		}
+		if ranks[i] < 0 {
+			return nil, r.errorAt(value.Pos, "invalid priority %q, expected one of %v", value.Text, taskPriorityOrder)
+		}
+	}
+
+	values := []string{}
+	for rank, priority := range taskPriorityOrder {
+		var matches bool
+		switch term.Operator {
+		case entities.TaskQueryLess:
+			matches = rank < ranks[0]
+		case entities.TaskQueryLessOrEqual:
+			matches = rank <= ranks[0]
+		case entities.TaskQueryGreater:
+			matches = rank > ranks[0]
+		case entities.TaskQueryGreaterOrEqual:
+			matches = rank >= ranks[0]
+		default:
+			for _, want := range ranks {
+				matches = matches || rank == want
+			}
+		}
+		if matches {
+			values = append(values, string(priority))
+		}
+	}
+	return repositories.TaskValueCondition{Column: repositories.TaskColumnPriority, Values: values}, nil
+}
+
+// resolveDate compares a date column. A day, such as today or 2024-05-01, spans the whole
+// day: due:today matches every task due today and due>today those due from tomorrow. Equality
+// with an instant, such as due:7d, matches the day of the instant.
+func (r *taskQueryResolver) resolveDate(column string, term *entities.TaskQueryTerm) (repositories.TaskExpression, error) {
+	var days []repositories.TaskExpression
+	for _, value := range term.Values {
+		if strings.EqualFold(value.Text, "none") {
+			if term.Operator.IsOrdering() {
+				return nil, r.errorAt(value.Pos, "%s%s none cannot be compared by order", term.Field, term.Operator)
+			}
+			days = append(days, repositories.TaskDateCondition{Column: column, IsNull: true})
+			continue
+		}
+
+		at, wholeDay, ok := entities.ParseTaskQueryDate(value.Text, r.now)
+		if !ok {
+			return nil, r.errorAt(value.Pos, "invalid date %q, expected YYYY-MM-DD, today, yesterday, tomorrow, now, none or an offset such as 7d or -2w", value.Text)
+		}
+		start, end := at, at
+		if wholeDay || !term.Operator.IsOrdering() {
+			start = time.Date(at.Year(), at.Month(), at.Day(), 0, 0, 0, 0, at.Location())
+			end = start.AddDate(0, 0, 1)
+		}
+
+		condition := repositories.TaskDateCondition{Column: column}
+		switch term.Operator {
+		case entities.TaskQueryLess:
+			condition.To = start
+		case entities.TaskQueryLessOrEqual:
+			condition.To = end
+		case entities.TaskQueryGreater:
+			condition.From = end
+		case entities.TaskQueryGreaterOrEqual:
+			condition.From = start
+		default:
+			condition.From, condition.To = start, end
+		}
+		days = append(days, condition)
+	}
+	if len(days) == 1 {
+		return days[0], nil
+	}
+	return repositories.TaskOr{Operands: days}, nil
+}
+
+// resolveAssignee reads me, none, user ids and usernames
+func (r *taskQueryResolver) resolveAssignee(ctx context.Context, term *entities.TaskQueryTerm) (repositories.TaskExpression, error) {
+	if err := r.requireEquality(term); err != nil {
+		return nil, err
+	}
+	var (
+		condition repositories.TaskAssigneeCondition
+		usernames []entities.TaskQueryValue
+	)
+	for _, value := range term.Values {
+		switch text := strings.ToLower(value.Text); {
+		case text == "me":
+			if r.userID == 0 {
+				return nil, r.errorAt(value.Pos, "assignee:me needs a signed in user")
+			}
+			condition.UserIDs = append(condition.UserIDs, r.userID)
+		case text == "none":
+			condition.Unassigned = true
+		default:
+			if id, err := strconv.ParseInt(text, 10, 64); err == nil && id > 0 {
+				condition.UserIDs = append(condition.UserIDs, id)
+				continue
+			}
+			usernames = append(usernames, value)
+		}
+	}
+
+	if len(usernames) > 0 {
+		names := make([]string, len(usernames))
+		for i, value := range usernames {
+			names[i] = value.Text
+		}
+		users, err := r.service.users.FindByUsernames(ctx, names)
+		if err != nil {
+			return nil, err
+		}
+		for _, value := range usernames {
+			user, ok := users[strings.ToLower(value.Text)]
+			if !ok {
+				return nil, r.errorAt(value.Pos, "unknown user %q", value.Text)
+			}
+			condition.UserIDs = append(condition.UserIDs, user.ID)
+		}
+	}
+	return condition, nil
+}
+
+// resolveCustomField compares a custom field of the project. ":" looks for a substring in
+// text fields and is an equality otherwise.
+func (r *taskQueryResolver) resolveCustomField(ctx context.Context, key string, term *entities.TaskQueryTerm) (repositories.TaskExpression, error) {
+	if r.projectID == 0 {
+		return nil, r.errorAt(term.Pos, "custom fields can only be queried within a project")
+	}
+	if r.definitions == nil {
+		list, err := r.service.customFields.ListDefinitions(ctx, r.projectID)
+		if err != nil {
+			return nil, err
+		}
+		r.definitions = make(map[string]*entities.CustomFieldDefinition, len(list))
+		for _, definition := range list {
+			r.definitions[definition.Key] = definition
+		}
+	}
+	definition, ok := r.definitions[key]
+	if !ok {
+		return nil, r.errorAt(term.Pos, "unknown custom field %q", key)
+	}
+
+	operator := map[entities.TaskQueryOperator]repositories.FilterOperator{
+		entities.TaskQueryMatch:          repositories.FilterOpEqual,
+		entities.TaskQueryEqual:          repositories.FilterOpEqual,
+		entities.TaskQueryLess:           repositories.FilterOpLess,
+		entities.TaskQueryLessOrEqual:    repositories.FilterOpLessOrEqual,
+		entities.TaskQueryGreater:        repositories.FilterOpGreater,
+		entities.TaskQueryGreaterOrEqual: repositories.FilterOpGreaterOrEqual,
+	}[term.Operator]
+	if term.Operator == entities.TaskQueryMatch && definition.Type == common.CustomFieldTypeText {
+		operator = repositories.FilterOpContains
+	}
+
+	conditions := make([]repositories.TaskExpression, len(term.Values))
+	for i, value := range term.Values {
+		condition, fieldErr := customFieldFilter(definition, operator, value.Text)
+		if fieldErr != nil {
+			return nil, r.invalidValue(term.Field, value, fieldErr)
+		}
+		conditions[i] = condition
+	}
+	if len(conditions) == 1 {
+		return conditions[0], nil
+	}
+	return repositories.TaskOr{Operands: conditions}, nil
+}
+
+// requireEquality rejects ordered comparisons on fields without an order
+func (r *taskQueryResolver) requireEquality(term *entities.TaskQueryTerm) error {
+	if term.Operator.IsOrdering() {
+		return r.errorAt(term.Pos, "%s cannot be compared with %s", term.Field, term.Operator)
+	}
+	return nil
+}
+
+func (r *taskQueryResolver) invalidValue(field string, value entities.TaskQueryValue, fieldErr *common.FieldValidationError) error {
+	return r.errorAt(value.Pos, "invalid %s %q: %s", field, value.Text, fieldErr.Message)
+}
+
+func (r *taskQueryResolver) errorAt(offset int, format string, args ...interface{}) error {
+	return entities.NewTaskQueryError(r.query, offset, format, args...)
+}
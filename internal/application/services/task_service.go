@@ -0,0 +1,710 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"task-engine/internal/domain/entities"
+	"task-engine/internal/domain/entities/common"
+	"task-engine/internal/domain/repositories"
+	"time"
+)
+
+const (
+	defaultTaskListLimit = 50
+	maxTaskListLimit     = 200
+)
+
+type TaskService struct {
+	tx           repositories.Transactor
+	projects     repositories.ProjectRepository
+	tasks        repositories.TaskRepository
+	customFields repositories.CustomFieldRepository
+	tags         repositories.TagRepository
+	assignments  repositories.AssignmentRepository
+	checklists   repositories.ChecklistRepository
+	users        repositories.UserRepository
+	workflows    *WorkflowService
+	attachments  *AttachmentService
+	publisher    *EventPublisher
+	// cursorSecret signs the cursors of task list pages
+	cursorSecret string
+}
+
+func NewTaskService(tx repositories.Transactor, projects repositories.ProjectRepository, tasks repositories.TaskRepository, customFields repositories.CustomFieldRepository, tags repositories.TagRepository, assignments repositories.AssignmentRepository, checklists repositories.ChecklistRepository, users repositories.UserRepository, workflows *WorkflowService, attachments *AttachmentService, publisher *EventPublisher, cursorSecret string) *TaskService {
+	return &TaskService{
+		tx:           tx,
+		projects:     projects,
+		tasks:        tasks,
+		customFields: customFields,
+		tags:         tags,
+		assignments:  assignments,
+		checklists:   checklists,
+		users:        users,
+		workflows:    workflows,
+		attachments:  attachments,
+		publisher:    publisher,
+		cursorSecret: cursorSecret,
+	}
+}
+
+type CreateTaskInput struct {
+	CreatedBy        int64
+	ParentID         int64
+	Title            string
+	Description      string
+	Priority         common.TaskPriority
+	DueDate          time.Time
+	EstimatedMinutes int64
+	Tags             []string
+	AssigneeIDs      []int64
+	CustomFields     map[string]interface{}
+}
+
+// CustomFieldFilter is a raw "cf.<key>[<operator>]=<value>" condition from the task list API
+type CustomFieldFilter struct {
+	Key      string
+	Operator repositories.FilterOperator
+	Value    string
+}
+
+type ListTasksInput struct {
+	ProjectID    int64
+	Statuses     []common.TaskStatus
+	Categories   []common.StatusCategory
+	Priorities   []common.TaskPriority
+	CustomFields []CustomFieldFilter
+	// Query is a task query such as "status:todo priority>=high due<7d", see
+	// entities.ParseTaskQuery. UserID is who "me" stands for, unless Role sees every project
+	// the tasks are also restricted to the projects UserID sees.
+	Query  string
+	UserID int64
+	Role   common.UserRole
+	// Sort lists columns or "cf.<key>" custom fields, prefixed with "-" for descending order
+	Sort   []string
+	Limit  int
+	Offset int
+	// Cursor is the NextCursor of the previous page, see ListTasksPage
+	Cursor string
+}
+
+// CreateTask creates the task in the initial status of the project workflow. The project is
+// locked for share so the workflow cannot change until the task is stored.
+func (s *TaskService) CreateTask(ctx context.Context, projectID int64, input CreateTaskInput) (*entities.Task, error) {
+	var task *entities.Task
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		task, err = s.createTask(ctx, projectID, input)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+func (s *TaskService) createTask(ctx context.Context, projectID int64, input CreateTaskInput) (*entities.Task, error) {
+	if _, err := s.projects.FindByIDForShare(ctx, projectID); err != nil {
+		return nil, err
+	}
+
+	task, err := entities.NewTask(projectID, input.Title, input.Description)
+	if err != nil {
+		return nil, err
+	}
+	workflow, err := s.workflows.workflowOf(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	initial, _ := workflow.Status(workflow.InitialStatus)
+	if err := task.UpdateStatus(initial); err != nil {
+		return nil, err
+	}
+	if input.Priority != "" {
+		if err := task.UpdatePriority(input.Priority); err != nil {
+			return nil, err
+		}
+	}
+	if err := task.UpdateDueDate(input.DueDate); err != nil {
+		return nil, err
+	}
+	if err := task.UpdateEstimate(input.EstimatedMinutes, nil); err != nil {
+		return nil, err
+	}
+	if err := task.UpdateTags(input.Tags); err != nil {
+		return nil, err
+	}
+	if input.ParentID != 0 {
+		parent, err := s.tasks.FindByID(ctx, input.ParentID)
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, &common.FieldValidationError{Field: "parent_id", Message: "parent task does not exist"}
+		}
+		if err != nil {
+			return nil, err
+		}
+		if err := task.SetParent(parent); err != nil {
+			return nil, err
+		}
+	}
+
+	definitions, err := s.customFields.ListDefinitions(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	values, _, err := parseCustomFieldValues(definitions, input.CustomFields)
+	if err != nil {
+		return nil, err
+	}
+	if err := entities.ValidateCustomFieldValues(definitions, values); err != nil {
+		return nil, err
+	}
+	if err := s.checkUsers(ctx, definitions, input.AssigneeIDs, values); err != nil {
+		return nil, err
+	}
+
+	task.CustomFields = values
+	task.UpdateAssignees(input.AssigneeIDs)
+	if err := s.persist(ctx, task); err != nil {
+		return nil, err
+	}
+
+	if err := s.publisher.PublishTaskEvent(ctx, common.EventTypeTaskCreated, task, input.CreatedBy, entities.TaskCreatedPayload{
+		Title: task.Title,
+	}); err != nil {
+		return nil, err
+	}
+	for _, userID := range task.AssigneeIDs {
+		if err := s.publisher.PublishTaskEvent(ctx, common.EventTypeTaskAssigned, task, input.CreatedBy, entities.TaskAssignedPayload{
+			AssigneeID: userID,
+		}); err != nil {
+			return nil, err
+		}
+	}
+	return task, nil
+}
+
+// persist stores a new task together with its custom field values, tags and assignees in a
+// single transaction, so the task is never left without its required custom fields
+func (s *TaskService) persist(ctx context.Context, task *entities.Task) error {
+	return s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.tasks.Create(ctx, task); err != nil {
+			return err
+		}
+		if err := s.customFields.SetValues(ctx, task.ID, task.CustomFields); err != nil {
+			return err
+		}
+		if len(task.Tags) > 0 {
+			if err := s.tags.SetTaskTags(ctx, task.ID, task.Tags); err != nil {
+				return err
+			}
+		}
+		for _, userID := range task.AssigneeIDs {
+			if _, err := s.assignments.Assign(ctx, task.ID, userID); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// listProjectTasks returns every task of the project with its details loaded
+func (s *TaskService) listProjectTasks(ctx context.Context, projectID int64) ([]*entities.Task, error) {
+	tasks, err := s.tasks.ListByProject(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.attachDetails(ctx, tasks); err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+func (s *TaskService) GetTask(ctx context.Context, taskID int64) (*entities.Task, error) {
+	task, err := s.tasks.FindByID(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.attachDetails(ctx, []*entities.Task{task}); err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+// GetVisibleTask returns the task when the user sees its project
+func (s *TaskService) GetVisibleTask(ctx context.Context, taskID, userID int64, role common.UserRole) (*entities.Task, error) {
+	task, err := s.GetTask(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkProjectVisible(ctx, s.projects, task.ProjectID, userID, role); err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+func (s *TaskService) ListTasks(ctx context.Context, input ListTasksInput) ([]*entities.Task, error) {
+	filter, err := s.buildFilter(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks, err := s.tasks.List(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.attachDetails(ctx, tasks); err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+// CountTasks returns how many tasks ListTasks would return without a limit
+func (s *TaskService) CountTasks(ctx context.Context, input ListTasksInput) (int, error) {
+	filter, err := s.buildFilter(ctx, input)
+	if err != nil {
+		return 0, err
+	}
+	return s.tasks.Count(ctx, filter)
+}
+
+// CountFacets counts the tasks ListTasks would return, without a limit, by each facet
+func (s *TaskService) CountFacets(ctx context.Context, input ListTasksInput, facets []common.TaskFacet) (entities.TaskFacets, error) {
+	var (
+		validations []*common.FieldValidationError
+		unique      []common.TaskFacet
+		seen        = make(map[common.TaskFacet]bool, len(facets))
+	)
+	for _, facet := range facets {
+		validations = append(validations, common.ValidateTaskFacet("facets", facet))
+		if !seen[facet] {
+			seen[facet] = true
+			unique = append(unique, facet)
+		}
+	}
+	if err := common.ValidateFields(validations...); err != nil {
+		return nil, err
+	}
+
+	filter, err := s.buildFilter(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+	return s.tasks.CountFacets(ctx, filter, unique)
+}
+
+// ListFilter returns the repository filter ListTasks runs for the input, so the SQL a task
+// query compiles to can be looked at
+func (s *TaskService) ListFilter(ctx context.Context, input ListTasksInput) (repositories.TaskFilter, error) {
+	return s.buildFilter(ctx, input)
+}
+
+// TransitionTask moves the task to another status of its project's workflow. The task is
+// locked while its guards are checked, and its project is locked for share so the workflow
+// cannot remove the status meanwhile.
+func (s *TaskService) TransitionTask(ctx context.Context, taskID int64, to common.TaskStatus, actor entities.TransitionActor) (*entities.Task, error) {
+	var task *entities.Task
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		task, err = s.transitionTask(ctx, taskID, to, actor)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+func (s *TaskService) transitionTask(ctx context.Context, taskID int64, to common.TaskStatus, actor entities.TransitionActor) (*entities.Task, error) {
+	project, task, err := s.lockTask(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.attachDetails(ctx, []*entities.Task{task}); err != nil {
+		return nil, err
+	}
+	workflow, err := s.workflows.workflowOf(ctx, task.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	status, err := workflow.CanTransition(task, to, actor)
+	if err != nil {
+		return nil, err
+	}
+	if status.Category == common.StatusCategoryDone && project.Settings.RequireChecklistCompletion &&
+		task.Checklist != nil && !task.Checklist.IsComplete() {
+		return nil, &entities.TransitionNotAllowedError{
+			From:   task.Status,
+			To:     to,
+			Reason: fmt.Sprintf("%d checklist items are still open", task.Checklist.Open()),
+		}
+	}
+	from := task.Status
+	if err := task.UpdateStatus(status); err != nil {
+		return nil, err
+	}
+	if err := s.tasks.Update(ctx, task); err != nil {
+		return nil, err
+	}
+
+	if err := s.publisher.PublishTaskEvent(ctx, common.EventTypeTaskStatusChanged, task, actor.UserID, entities.TaskStatusChangedPayload{
+		From:     from,
+		To:       task.Status,
+		Category: task.StatusCategory,
+		UserID:   actor.UserID,
+	}); err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+// lockTask locks the project of the task for share, as CreateTask does, and then the task
+// for update, in the order UpdateWorkflow takes its locks
+func (s *TaskService) lockTask(ctx context.Context, taskID int64) (*entities.Project, *entities.Task, error) {
+	task, err := s.tasks.FindByID(ctx, taskID)
+	if err != nil {
+		return nil, nil, err
+	}
+	for {
+		project, err := s.projects.FindByIDForShare(ctx, task.ProjectID)
+		if err != nil {
+			return nil, nil, err
+		}
+		if task, err = s.tasks.FindByIDForUpdate(ctx, taskID); err != nil {
+			return nil, nil, err
+		}
+		// the task was moved before it was locked, it cannot move again while locked
+		if task.ProjectID == project.ID {
+			return project, task, nil
+		}
+	}
+}
+
+// DeleteTask hard-deletes the task with its subtasks, comments and attachments, then removes
+// the attachment contents no other task uses
+func (s *TaskService) DeleteTask(ctx context.Context, taskID int64) error {
+	keys, err := s.attachments.blobKeysOf(ctx, taskID)
+	if err != nil {
+		return err
+	}
+	if err := s.tasks.Delete(ctx, taskID); err != nil {
+		return err
+	}
+	return s.attachments.collectGarbage(ctx, keys)
+}
+
+// GetMetrics summarises the progress of the project's tasks by status category
+func (s *TaskService) GetMetrics(ctx context.Context, projectID int64) (*entities.TaskMetrics, error) {
+	if _, err := s.projects.FindByID(ctx, projectID); err != nil {
+		return nil, err
+	}
+	tasks, err := s.listProjectTasks(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	return entities.NewTaskMetrics(projectID, tasks), nil
+}
+
+// SetCustomFields updates the given custom fields of a task, a nil value unsets the field
+func (s *TaskService) SetCustomFields(ctx context.Context, taskID int64, raw map[string]interface{}) (*entities.Task, error) {
+	task, err := s.tasks.FindByID(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	definitions, err := s.customFields.ListDefinitions(ctx, task.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	values, unset, err := parseCustomFieldValues(definitions, raw)
+	if err != nil {
+		return nil, err
+	}
+
+	// validate the resulting set of values, not only the changed ones
+	current, err := s.customFields.ListValues(ctx, []int64{taskID})
+	if err != nil {
+		return nil, err
+	}
+	if err := entities.ValidateCustomFieldValues(definitions, mergeCustomFieldValues(current[taskID], values, unset)); err != nil {
+		return nil, err
+	}
+	if err := s.checkUsers(ctx, definitions, nil, values); err != nil {
+		return nil, err
+	}
+
+	err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.customFields.SetValues(ctx, taskID, values); err != nil {
+			return err
+		}
+		return s.customFields.UnsetValues(ctx, taskID, unset)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.attachDetails(ctx, []*entities.Task{task}); err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+// attachDetails loads the custom fields, tags, assignees and checklist summaries of the tasks
+func (s *TaskService) attachDetails(ctx context.Context, tasks []*entities.Task) error {
+	ids := make([]int64, len(tasks))
+	for i, task := range tasks {
+		ids[i] = task.ID
+	}
+
+	values, err := s.customFields.ListValues(ctx, ids)
+	if err != nil {
+		return err
+	}
+	tags, err := s.tags.ListByTasks(ctx, ids)
+	if err != nil {
+		return err
+	}
+	assignees, err := s.assignments.ListByTasks(ctx, ids)
+	if err != nil {
+		return err
+	}
+	checklists, err := s.checklists.SummarizeByTasks(ctx, ids)
+	if err != nil {
+		return err
+	}
+	for _, task := range tasks {
+		task.CustomFields = values[task.ID]
+		task.Tags = tags[task.ID]
+		task.AssigneeIDs = assignees[task.ID]
+		if summary, ok := checklists[task.ID]; ok {
+			task.Checklist = &summary
+		}
+	}
+	return nil
+}
+
+func (s *TaskService) buildFilter(ctx context.Context, input ListTasksInput) (repositories.TaskFilter, error) {
+	filter := repositories.TaskFilter{
+		ProjectID:  input.ProjectID,
+		Statuses:   input.Statuses,
+		Categories: input.Categories,
+		Priorities: input.Priorities,
+		Limit:      input.Limit,
+		Offset:     input.Offset,
+	}
+	if input.UserID != 0 && !seesEveryProject(input.Role) {
+		filter.VisibleTo = input.UserID
+	}
+	if filter.Limit <= 0 {
+		filter.Limit = defaultTaskListLimit
+	}
+	if filter.Limit > maxTaskListLimit {
+		filter.Limit = maxTaskListLimit
+	}
+
+	var validations []*common.FieldValidationError
+	if len(input.Statuses) > 0 && input.ProjectID != 0 {
+		workflow, err := s.workflows.workflowOf(ctx, input.ProjectID)
+		if err != nil {
+			return filter, err
+		}
+		for _, status := range input.Statuses {
+			validations = append(validations, workflow.ValidateStatus("status", status))
+		}
+	}
+	for _, category := range input.Categories {
+		validations = append(validations, common.ValidateStatusCategory("category", category))
+	}
+	for _, priority := range input.Priorities {
+		validations = append(validations, common.ValidateTaskPriority("priority", priority))
+	}
+	if err := common.ValidateFields(validations...); err != nil {
+		return filter, err
+	}
+
+	expression, err := s.resolveTaskQuery(ctx, input.Query, input.ProjectID, input.UserID, time.Now())
+	if err != nil {
+		return filter, err
+	}
+	filter.Expression = expression
+
+	var definitions map[string]*entities.CustomFieldDefinition
+	if len(input.CustomFields) > 0 || hasCustomFieldSort(input.Sort) {
+		if input.ProjectID == 0 {
+			return filter, &common.FieldValidationError{Field: "custom_fields", Message: "custom field filters require a project"}
+		}
+		list, err := s.customFields.ListDefinitions(ctx, input.ProjectID)
+		if err != nil {
+			return filter, err
+		}
+		definitions = make(map[string]*entities.CustomFieldDefinition, len(list))
+		for _, definition := range list {
+			definitions[definition.Key] = definition
+		}
+	}
+
+	for _, condition := range input.CustomFields {
+		definition, ok := definitions[condition.Key]
+		if !ok {
+			return filter, unknownCustomField(condition.Key)
+		}
+		operator := condition.Operator
+		if operator == "" {
+			operator = repositories.FilterOpEqual
+		}
+		if !operator.IsValid() {
+			return filter, &common.FieldValidationError{
+				Field:   "custom_fields." + condition.Key,
+				Message: fmt.Sprintf("unknown operator %q", operator),
+			}
+		}
+
+		customField, fieldErr := customFieldFilter(definition, operator, condition.Value)
+		if fieldErr != nil {
+			return filter, fieldErr
+		}
+		filter.CustomFields = append(filter.CustomFields, customField)
+	}
+
+	for _, raw := range input.Sort {
+		sort := repositories.TaskSort{Column: strings.TrimPrefix(raw, "-"), Descending: strings.HasPrefix(raw, "-")}
+		if key, ok := strings.CutPrefix(sort.Column, "cf."); ok {
+			definition, found := definitions[key]
+			if !found {
+				return filter, unknownCustomField(key)
+			}
+			sort.CustomField = definition
+		} else if !repositories.IsTaskSortColumn(sort.Column) {
+			return filter, &common.FieldValidationError{Field: "sort", Message: fmt.Sprintf("cannot sort by %q", sort.Column)}
+		}
+		filter.Sort = append(filter.Sort, sort)
+	}
+
+	return filter, nil
+}
+
+// customFieldFilter compares the custom field with a textual value
+func customFieldFilter(definition *entities.CustomFieldDefinition, operator repositories.FilterOperator, text string) (repositories.CustomFieldCondition, *common.FieldValidationError) {
+	// "contains" on text fields is a substring search, the value is not an exact field value
+	var value *entities.CustomFieldValue
+	if operator == repositories.FilterOpContains && definition.Type == common.CustomFieldTypeText {
+		value = &entities.CustomFieldValue{FieldID: definition.ID, Key: definition.Key, Type: definition.Type, Text: text}
+	} else {
+		var fieldErr *common.FieldValidationError
+		if value, fieldErr = definition.ParseText(text); fieldErr != nil {
+			return repositories.CustomFieldCondition{}, fieldErr
+		}
+	}
+	return repositories.CustomFieldCondition{Field: definition, Operator: operator, Value: value}, nil
+}
+
+func hasCustomFieldSort(sort []string) bool {
+	for _, raw := range sort {
+		if strings.HasPrefix(strings.TrimPrefix(raw, "-"), "cf.") {
+			return true
+		}
+	}
+	return false
+}
+
+func unknownCustomField(key string) *common.FieldValidationError {
+	return &common.FieldValidationError{
+		Field:   "custom_fields." + key,
+		Message: "unknown custom field",
+	}
+}
+
+// parseCustomFieldValues converts raw JSON values keyed by field key into typed values.
+// Fields set to null are returned in unset.
+func parseCustomFieldValues(definitions []*entities.CustomFieldDefinition, raw map[string]interface{}) ([]*entities.CustomFieldValue, []int64, error) {
+	byKey := make(map[string]*entities.CustomFieldDefinition, len(definitions))
+	for _, definition := range definitions {
+		byKey[definition.Key] = definition
+	}
+
+	var (
+		values      []*entities.CustomFieldValue
+		unset       []int64
+		validations []*common.FieldValidationError
+	)
+	for key, rawValue := range raw {
+		definition, ok := byKey[key]
+		if !ok {
+			validations = append(validations, unknownCustomField(key))
+			continue
+		}
+		value, fieldErr := definition.ParseValue(rawValue)
+		if fieldErr != nil {
+			validations = append(validations, fieldErr)
+			continue
+		}
+		if value == nil {
+			unset = append(unset, definition.ID)
+			continue
+		}
+		values = append(values, value)
+	}
+
+	if err := common.ValidateFields(validations...); err != nil {
+		return nil, nil, err
+	}
+	return values, unset, nil
+}
+
+// checkUsers makes sure the assignees and the users set in user custom fields exist, the
+// database does not check them
+func (s *TaskService) checkUsers(ctx context.Context, definitions []*entities.CustomFieldDefinition, assigneeIDs []int64, values []*entities.CustomFieldValue) error {
+	keys := make(map[int64]string, len(definitions))
+	for _, definition := range definitions {
+		if definition.Type == common.CustomFieldTypeUser {
+			keys[definition.ID] = definition.Key
+		}
+	}
+	userIDs := append([]int64(nil), assigneeIDs...)
+	for _, value := range values {
+		if _, ok := keys[value.FieldID]; ok {
+			userIDs = append(userIDs, value.UserID)
+		}
+	}
+	if len(userIDs) == 0 {
+		return nil
+	}
+
+	users, err := s.users.FindByIDs(ctx, userIDs)
+	if err != nil {
+		return err
+	}
+	var validations []*common.FieldValidationError
+	for _, userID := range assigneeIDs {
+		if _, ok := users[userID]; !ok {
+			validations = append(validations, &common.FieldValidationError{Field: "assignee_ids", Message: fmt.Sprintf("user %d does not exist", userID)})
+		}
+	}
+	for _, value := range values {
+		key, ok := keys[value.FieldID]
+		if _, found := users[value.UserID]; ok && !found {
+			validations = append(validations, &common.FieldValidationError{Field: "custom_fields." + key, Message: "user does not exist"})
+		}
+	}
+	return common.ValidateFields(validations...)
+}
+
+func mergeCustomFieldValues(current, changed []*entities.CustomFieldValue, unset []int64) []*entities.CustomFieldValue {
+	byField := make(map[int64]*entities.CustomFieldValue, len(current)+len(changed))
+	for _, value := range current {
+		byField[value.FieldID] = value
+	}
+	for _, value := range changed {
+		byField[value.FieldID] = value
+	}
+	for _, fieldID := range unset {
+		delete(byField, fieldID)
+	}
+
+	merged := make([]*entities.CustomFieldValue, 0, len(byField))
+	for _, value := range byField {
+		merged = append(merged, value)
+	}
+	return merged
+}
@@ -0,0 +1,61 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"task-engine/internal/domain/entities"
+	"task-engine/internal/domain/entities/common"
+	"task-engine/internal/domain/repositories"
+)
+
+type TeamService struct {
+	teams repositories.TeamRepository
+	users repositories.UserRepository
+}
+
+func NewTeamService(teams repositories.TeamRepository, users repositories.UserRepository) *TeamService {
+	return &TeamService{teams: teams, users: users}
+}
+
+func (s *TeamService) CreateTeam(ctx context.Context, name, handle string) (*entities.Team, error) {
+	team, err := entities.NewTeam(name, handle)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.teams.Create(ctx, team); err != nil {
+		return nil, err
+	}
+	return team, nil
+}
+
+func (s *TeamService) GetTeam(ctx context.Context, id int64) (*entities.Team, error) {
+	return s.teams.FindByID(ctx, id)
+}
+
+func (s *TeamService) ListTeams(ctx context.Context) ([]*entities.Team, error) {
+	return s.teams.List(ctx)
+}
+
+// AddMember adds the user to the team, adding a member twice is a no-op
+func (s *TeamService) AddMember(ctx context.Context, teamID, userID int64) (*entities.Team, error) {
+	if _, err := s.teams.FindByID(ctx, teamID); err != nil {
+		return nil, err
+	}
+	if _, err := s.users.FindByID(ctx, userID); errors.Is(err, repositories.ErrNotFound) {
+		return nil, &common.FieldValidationError{Field: "user_id", Message: "user does not exist"}
+	} else if err != nil {
+		return nil, err
+	}
+
+	if err := s.teams.AddMember(ctx, teamID, userID); err != nil {
+		return nil, err
+	}
+	return s.teams.FindByID(ctx, teamID)
+}
+
+func (s *TeamService) RemoveMember(ctx context.Context, teamID, userID int64) error {
+	if _, err := s.teams.FindByID(ctx, teamID); err != nil {
+		return err
+	}
+	return s.teams.RemoveMember(ctx, teamID, userID)
+}
@@ -0,0 +1,162 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"task-engine/internal/domain/entities"
+	"task-engine/internal/domain/entities/common"
+	"time"
+)
+
+// trelloBoard is the part of a Trello board JSON export that is imported
+type trelloBoard struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Desc  string `json:"desc"`
+	Lists []struct {
+		ID     string `json:"id"`
+		Name   string `json:"name"`
+		Closed bool   `json:"closed"`
+	} `json:"lists"`
+	Cards []struct {
+		ID        string   `json:"id"`
+		Name      string   `json:"name"`
+		Desc      string   `json:"desc"`
+		Closed    bool     `json:"closed"`
+		IDList    string   `json:"idList"`
+		IDLabels  []string `json:"idLabels"`
+		IDMembers []string `json:"idMembers"`
+		Due       string   `json:"due"`
+	} `json:"cards"`
+	Labels []struct {
+		ID    string `json:"id"`
+		Name  string `json:"name"`
+		Color string `json:"color"`
+	} `json:"labels"`
+	Members []struct {
+		ID       string `json:"id"`
+		Username string `json:"username"`
+	} `json:"members"`
+	Actions []struct {
+		ID   string `json:"id"`
+		Type string `json:"type"`
+		Date string `json:"date"`
+		Data struct {
+			Text string `json:"text"`
+			Card struct {
+				ID string `json:"id"`
+			} `json:"card"`
+		} `json:"data"`
+		MemberCreator struct {
+			Username string `json:"username"`
+		} `json:"memberCreator"`
+	} `json:"actions"`
+}
+
+// ParseTrelloBoard reads a board exported as JSON from Trello. Every open card of an open
+// list becomes a task whose status is the name of its list, unnamed labels are named after
+// their color.
+func ParseTrelloBoard(r io.Reader) (*entities.ExternalProject, error) {
+	var board trelloBoard
+	if err := json.NewDecoder(r).Decode(&board); err != nil {
+		return nil, fmt.Errorf("decoding trello board: %w", err)
+	}
+	if board.ID == "" {
+		return nil, fmt.Errorf("trello board has no id")
+	}
+
+	lists := make(map[string]string, len(board.Lists))
+	for _, list := range board.Lists {
+		if !list.Closed {
+			lists[list.ID] = list.Name
+		}
+	}
+	labels := make(map[string]string, len(board.Labels))
+	for _, label := range board.Labels {
+		name := strings.TrimSpace(label.Name)
+		if name == "" {
+			name = label.Color
+		}
+		labels[label.ID] = name
+	}
+	members := make(map[string]string, len(board.Members))
+	for _, member := range board.Members {
+		members[member.ID] = member.Username
+	}
+
+	project := &entities.ExternalProject{
+		Source:      common.ImportSourceTrello,
+		ID:          board.ID,
+		Name:        board.Name,
+		Description: board.Desc,
+	}
+	cards := make(map[string]*entities.ExternalTask, len(board.Cards))
+	for _, card := range board.Cards {
+		status, ok := lists[card.IDList]
+		if card.Closed || !ok {
+			continue
+		}
+
+		task := &entities.ExternalTask{
+			ID:          card.ID,
+			Title:       card.Name,
+			Description: card.Desc,
+			Status:      status,
+			CreatedAt:   trelloCreatedAt(card.ID),
+		}
+		if card.Due != "" {
+			due, err := time.Parse(time.RFC3339, card.Due)
+			if err != nil {
+				return nil, fmt.Errorf("card %s: invalid due date %q", card.ID, card.Due)
+			}
+			task.DueDate = due
+		}
+		for _, labelID := range card.IDLabels {
+			if name := labels[labelID]; name != "" {
+				task.Labels = append(task.Labels, name)
+			}
+		}
+		for _, memberID := range card.IDMembers {
+			if username := members[memberID]; username != "" {
+				task.Members = append(task.Members, username)
+			}
+		}
+		cards[card.ID] = task
+		project.Tasks = append(project.Tasks, task)
+	}
+
+	// actions are exported newest first
+	for i := len(board.Actions) - 1; i >= 0; i-- {
+		action := board.Actions[i]
+		task, ok := cards[action.Data.Card.ID]
+		if action.Type != "commentCard" || !ok {
+			continue
+		}
+		createdAt, err := time.Parse(time.RFC3339, action.Date)
+		if err != nil {
+			return nil, fmt.Errorf("action %s: invalid date %q", action.ID, action.Date)
+		}
+		task.Comments = append(task.Comments, &entities.ExternalComment{
+			ID:        action.ID,
+			Author:    action.MemberCreator.Username,
+			Content:   action.Data.Text,
+			CreatedAt: createdAt,
+		})
+	}
+	return project, nil
+}
+
+// trelloCreatedAt reads the creation time Trello ids start with, as hex seconds
+func trelloCreatedAt(id string) time.Time {
+	if len(id) < 8 {
+		return time.Time{}
+	}
+	seconds, err := strconv.ParseInt(id[:8], 16, 64)
+	if err != nil {
+		return time.Time{}
+	}
+	return time.Unix(seconds, 0).UTC()
+}
@@ -0,0 +1,307 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"task-engine/internal/domain/entities"
+	"task-engine/internal/domain/entities/common"
+	"task-engine/internal/domain/repositories"
+	"task-engine/pkg/logger"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	defaultWebhookDeliveryLimit = 50
+	maxWebhookDeliveryLimit     = 200
+	webhookBatchSize            = 20
+	// webhookClaimLease is how long claimed deliveries are kept from other dispatchers while
+	// their requests are sent one after the other
+	webhookClaimLease = 10 * time.Minute
+)
+
+// WebhookService manages webhooks, queues a delivery for every event a webhook subscribes
+// to and sends the due deliveries
+type WebhookService struct {
+	tx          repositories.Transactor
+	webhooks    repositories.WebhookRepository
+	projects    repositories.ProjectRepository
+	sender      repositories.WebhookSender
+	maxAttempts int
+	backoff     time.Duration
+}
+
+func NewWebhookService(tx repositories.Transactor, webhooks repositories.WebhookRepository, projects repositories.ProjectRepository, sender repositories.WebhookSender, maxAttempts int, backoff time.Duration) *WebhookService {
+	return &WebhookService{
+		tx:          tx,
+		webhooks:    webhooks,
+		projects:    projects,
+		sender:      sender,
+		maxAttempts: maxAttempts,
+		backoff:     backoff,
+	}
+}
+
+// CreateWebhookInput holds a new webhook, a zero ProjectID makes it organisation-wide
+type CreateWebhookInput struct {
+	ProjectID  int64
+	URL        string
+	EventTypes []common.EventType
+	CreatedBy  int64
+}
+
+// UpdateWebhookInput changes the fields that are set
+type UpdateWebhookInput struct {
+	URL        *string
+	EventTypes *[]common.EventType
+	Active     *bool
+}
+
+type WebhookDeliveriesInput struct {
+	WebhookID int64
+	Status    common.WebhookDeliveryStatus
+	Limit     int
+	Offset    int
+}
+
+// CreateWebhook stores the webhook with a new random secret. The secret is only returned here.
+func (s *WebhookService) CreateWebhook(ctx context.Context, input CreateWebhookInput) (*entities.Webhook, error) {
+	if input.ProjectID != 0 {
+		if _, err := s.projects.FindByID(ctx, input.ProjectID); err != nil {
+			return nil, err
+		}
+	}
+
+	secret, err := newWebhookSecret()
+	if err != nil {
+		return nil, err
+	}
+	webhook, err := entities.NewWebhook(input.ProjectID, input.URL, input.EventTypes, secret, input.CreatedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.webhooks.Create(ctx, webhook); err != nil {
+		return nil, err
+	}
+	return webhook, nil
+}
+
+func (s *WebhookService) GetWebhook(ctx context.Context, id int64) (*entities.Webhook, error) {
+	return s.webhooks.FindByID(ctx, id)
+}
+
+// ListWebhooks returns the webhooks of the project, or every webhook when projectID is zero
+func (s *WebhookService) ListWebhooks(ctx context.Context, projectID int64) ([]*entities.Webhook, error) {
+	return s.webhooks.List(ctx, repositories.WebhookFilter{ProjectID: projectID})
+}
+
+func (s *WebhookService) UpdateWebhook(ctx context.Context, id int64, input UpdateWebhookInput) (*entities.Webhook, error) {
+	webhook, err := s.webhooks.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	url, eventTypes, active := webhook.URL, webhook.EventTypes, webhook.Active
+	if input.URL != nil {
+		url = *input.URL
+	}
+	if input.EventTypes != nil {
+		eventTypes = *input.EventTypes
+	}
+	if input.Active != nil {
+		active = *input.Active
+	}
+	if err := webhook.Update(url, eventTypes, active); err != nil {
+		return nil, err
+	}
+
+	if err := s.webhooks.Update(ctx, webhook); err != nil {
+		return nil, err
+	}
+	return webhook, nil
+}
+
+// DeleteWebhook removes the webhook with its delivery log
+func (s *WebhookService) DeleteWebhook(ctx context.Context, id int64) error {
+	return s.webhooks.Delete(ctx, id)
+}
+
+// HandleEvent queues a delivery of the event for every webhook subscribed to it
+func (s *WebhookService) HandleEvent(ctx context.Context, event *entities.Event) error {
+	if event.ProjectID == 0 {
+		return nil
+	}
+	webhooks, err := s.webhooks.ListForProject(ctx, event.ProjectID)
+	if err != nil {
+		return err
+	}
+
+	return s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		for _, webhook := range webhooks {
+			if !webhook.Subscribes(event) {
+				continue
+			}
+			delivery, err := entities.NewWebhookDelivery(webhook, event)
+			if err != nil {
+				return err
+			}
+			if err := s.webhooks.CreateDelivery(ctx, delivery); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// ListDeliveries returns the delivery log of the webhook, newest first, without attempts
+func (s *WebhookService) ListDeliveries(ctx context.Context, input WebhookDeliveriesInput) ([]*entities.WebhookDelivery, error) {
+	if _, err := s.webhooks.FindByID(ctx, input.WebhookID); err != nil {
+		return nil, err
+	}
+	if input.Status != "" {
+		if err := common.ValidateFields(common.ValidateWebhookDeliveryStatus("status", input.Status)); err != nil {
+			return nil, err
+		}
+	}
+
+	limit := input.Limit
+	if limit <= 0 {
+		limit = defaultWebhookDeliveryLimit
+	} else if limit > maxWebhookDeliveryLimit {
+		limit = maxWebhookDeliveryLimit
+	}
+	offset := input.Offset
+	if offset < 0 {
+		offset = 0
+	}
+
+	return s.webhooks.ListDeliveries(ctx, repositories.WebhookDeliveryFilter{
+		WebhookID: input.WebhookID,
+		Status:    input.Status,
+		Limit:     limit,
+		Offset:    offset,
+	})
+}
+
+// GetDelivery returns the delivery with every attempt, request headers and responses included
+func (s *WebhookService) GetDelivery(ctx context.Context, id int64) (*entities.WebhookDelivery, error) {
+	delivery, err := s.webhooks.FindDelivery(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if delivery.History, err = s.webhooks.ListAttempts(ctx, delivery.ID); err != nil {
+		return nil, err
+	}
+	return delivery, nil
+}
+
+// ReplayDelivery queues the body of a delivery again, whatever its status. The original
+// delivery and its log are kept.
+func (s *WebhookService) ReplayDelivery(ctx context.Context, id int64) (*entities.WebhookDelivery, error) {
+	delivery, err := s.webhooks.FindDelivery(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	replay := delivery.Replay()
+	if err := s.webhooks.CreateDelivery(ctx, replay); err != nil {
+		return nil, err
+	}
+	return replay, nil
+}
+
+// DispatchPending sends the deliveries that are due and returns how many were delivered.
+// Failed deliveries are retried with backoff until they are dead. Requests are sent outside
+// of any transaction and each attempt is recorded on its own, so a failure never sends the
+// deliveries that already succeeded again.
+func (s *WebhookService) DispatchPending(ctx context.Context) (int, error) {
+	now := time.Now()
+	deliveries, err := s.webhooks.ClaimDueDeliveries(ctx, now, now.Add(webhookClaimLease), webhookBatchSize)
+	if err != nil || len(deliveries) == 0 {
+		return 0, err
+	}
+
+	ids := make([]int64, len(deliveries))
+	for i, delivery := range deliveries {
+		ids[i] = delivery.WebhookID
+	}
+	webhooks, err := s.webhooks.FindByIDs(ctx, ids)
+	if err != nil {
+		return 0, err
+	}
+
+	delivered := 0
+	for _, delivery := range deliveries {
+		webhook, found := webhooks[delivery.WebhookID]
+		if !found {
+			// the webhook was deleted since the claim, its deliveries went with it
+			continue
+		}
+		ok, err := s.send(ctx, webhook, delivery)
+		if err != nil {
+			return delivered, err
+		}
+		if ok {
+			delivered++
+		}
+	}
+	return delivered, nil
+}
+
+// Run dispatches due deliveries every interval until ctx is cancelled
+func (s *WebhookService) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := s.DispatchPending(ctx); err != nil {
+				logger.Error("webhook dispatch failed", zap.Error(err))
+			}
+		}
+	}
+}
+
+// send makes one attempt of the delivery and logs it, it reports whether the receiver accepted it
+func (s *WebhookService) send(ctx context.Context, webhook *entities.Webhook, delivery *entities.WebhookDelivery) (bool, error) {
+	now := time.Now()
+	request := delivery.Request(webhook, now)
+	response, err := s.sender.Send(ctx, request)
+	attempt := entities.NewWebhookAttempt(delivery.ID, request, response, err, now, time.Since(now))
+
+	delivery.Record(attempt, s.maxAttempts, s.backoff)
+	if !attempt.Succeeded() {
+		logger.Warn("webhook delivery failed",
+			zap.Int64("delivery_id", delivery.ID),
+			zap.Int64("webhook_id", webhook.ID),
+			zap.Int("attempts", delivery.Attempts),
+			zap.String("status", string(delivery.Status)),
+			zap.String("error", attempt.Failure()),
+		)
+	}
+
+	err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.webhooks.CreateAttempt(ctx, attempt); err != nil {
+			return err
+		}
+		return s.webhooks.UpdateDelivery(ctx, delivery)
+	})
+	if err != nil {
+		return false, err
+	}
+	return attempt.Succeeded(), nil
+}
+
+func newWebhookSecret() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(secret), nil
+}
@@ -0,0 +1,103 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"task-engine/internal/domain/entities"
+	"task-engine/internal/domain/entities/common"
+	"task-engine/internal/domain/repositories"
+)
+
+type WorkflowService struct {
+	tx        repositories.Transactor
+	projects  repositories.ProjectRepository
+	workflows repositories.WorkflowRepository
+	tasks     repositories.TaskRepository
+}
+
+func NewWorkflowService(tx repositories.Transactor, projects repositories.ProjectRepository, workflows repositories.WorkflowRepository, tasks repositories.TaskRepository) *WorkflowService {
+	return &WorkflowService{
+		tx:        tx,
+		projects:  projects,
+		workflows: workflows,
+		tasks:     tasks,
+	}
+}
+
+type UpdateWorkflowInput struct {
+	Name          string
+	InitialStatus common.TaskStatus
+	Statuses      []entities.WorkflowStatus
+	Transitions   []entities.WorkflowTransition
+}
+
+// GetWorkflow returns the project's workflow, or the default workflow when none was configured
+func (s *WorkflowService) GetWorkflow(ctx context.Context, projectID int64) (*entities.Workflow, error) {
+	if _, err := s.projects.FindByID(ctx, projectID); err != nil {
+		return nil, err
+	}
+	return s.workflowOf(ctx, projectID)
+}
+
+// UpdateWorkflow replaces the project's workflow. Statuses still used by tasks cannot be removed,
+// and tasks follow the new category of their status. The check, the save and the
+// re-categorisation run in one transaction holding the project row lock.
+func (s *WorkflowService) UpdateWorkflow(ctx context.Context, projectID int64, input UpdateWorkflowInput) (*entities.Workflow, error) {
+	var workflow *entities.Workflow
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if _, err := s.projects.FindByIDForUpdate(ctx, projectID); err != nil {
+			return err
+		}
+		var err error
+		if workflow, err = s.workflowOf(ctx, projectID); err != nil {
+			return err
+		}
+		return s.replaceWorkflow(ctx, workflow, input)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return workflow, nil
+}
+
+func (s *WorkflowService) replaceWorkflow(ctx context.Context, workflow *entities.Workflow, input UpdateWorkflowInput) error {
+	if err := workflow.Update(input.Name, input.InitialStatus, input.Statuses, input.Transitions); err != nil {
+		return err
+	}
+
+	counts, err := s.tasks.CountByStatus(ctx, workflow.ProjectID)
+	if err != nil {
+		return err
+	}
+	var validations []*common.FieldValidationError
+	for status, count := range counts {
+		if _, ok := workflow.Status(status); !ok {
+			validations = append(validations, &common.FieldValidationError{
+				Field:   "statuses",
+				Message: fmt.Sprintf("status %q is still used by %d tasks", status, count),
+			})
+		}
+	}
+	if err := common.ValidateFields(validations...); err != nil {
+		return err
+	}
+
+	if err := s.workflows.Save(ctx, workflow); err != nil {
+		return err
+	}
+
+	categories := make(map[common.TaskStatus]common.StatusCategory, len(workflow.Statuses))
+	for _, status := range workflow.Statuses {
+		categories[status.Key] = status.Category
+	}
+	return s.tasks.UpdateStatusCategories(ctx, workflow.ProjectID, categories)
+}
+
+func (s *WorkflowService) workflowOf(ctx context.Context, projectID int64) (*entities.Workflow, error) {
+	workflow, err := s.workflows.FindByProject(ctx, projectID)
+	if errors.Is(err, repositories.ErrNotFound) {
+		return entities.DefaultWorkflow(projectID), nil
+	}
+	return workflow, err
+}
@@ -0,0 +1,93 @@
+package entities
+
+import (
+	"fmt"
+	"path"
+	"strings"
+	"task-engine/internal/domain/entities/common"
+	"time"
+	"unicode"
+)
+
+// Attachment is a file uploaded to a task or to one of its comments. The content lives in
+// the blob store under BlobKey, identical files share the same blob.
+type Attachment struct {
+	ID          int64     `json:"id" db:"id"`
+	TaskID      int64     `json:"task_id" db:"task_id"`
+	CommentID   int64     `json:"comment_id,omitempty" db:"comment_id"`
+	BlobKey     string    `json:"-" db:"blob_key"`
+	Filename    string    `json:"filename" db:"filename"`
+	ContentType string    `json:"content_type" db:"content_type"`
+	SizeBytes   int64     `json:"size_bytes" db:"size_bytes"`
+	UploadedBy  int64     `json:"uploaded_by,omitempty" db:"uploaded_by"`
+	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+}
+
+func NewAttachment(taskID, commentID int64, filename, contentType, blobKey string, size, uploadedBy int64) (*Attachment, error) {
+	attachment := &Attachment{
+		TaskID:      taskID,
+		CommentID:   commentID,
+		BlobKey:     blobKey,
+		Filename:    CleanFilename(filename),
+		ContentType: contentType,
+		SizeBytes:   size,
+		UploadedBy:  uploadedBy,
+		CreatedAt:   time.Now(),
+	}
+
+	if err := attachment.Validate(); err != nil {
+		return nil, err
+	}
+
+	return attachment, nil
+}
+
+// Validations methods
+
+func (a *Attachment) Validate() error {
+	return common.ValidateFields(
+		common.ValidatePositiveInt("task_id", a.TaskID),
+		common.ValidateRequired("filename", a.Filename),
+		common.ValidateStringLength("filename", a.Filename, 255),
+		common.ValidateRequired("content_type", a.ContentType),
+		common.ValidateStringLength("content_type", a.ContentType, 255),
+		common.ValidateRequired("blob_key", a.BlobKey),
+	)
+}
+
+// Business methods
+
+func (a *Attachment) IsOnComment() bool {
+	return a.CommentID != 0
+}
+
+// CanDelete reports whether the user may remove the attachment: its uploader, a manager or an admin
+func (a *Attachment) CanDelete(userID int64, role common.UserRole) bool {
+	return a.UploadedBy == userID || role == common.UserRoleManager || role == common.UserRoleAdmin
+}
+
+// CleanFilename keeps the last element of a client supplied file name and drops control
+// characters, so the name is safe to echo back in a Content-Disposition header
+func CleanFilename(name string) string {
+	name = path.Base(strings.ReplaceAll(name, "\\", "/"))
+	name = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) || r == '"' {
+			return -1
+		}
+		return r
+	}, name)
+	name = strings.TrimSpace(name)
+	if name == "." || name == "/" || name == ".." {
+		return ""
+	}
+	return name
+}
+
+// AttachmentTooLargeError is returned when an upload exceeds the configured size limit
+type AttachmentTooLargeError struct {
+	Limit int64
+}
+
+func (e *AttachmentTooLargeError) Error() string {
+	return fmt.Sprintf("attachment exceeds the %d bytes limit", e.Limit)
+}
@@ -0,0 +1,146 @@
+package entities
+
+import (
+	"sort"
+	"task-engine/internal/domain/entities/common"
+	"time"
+)
+
+// DefaultBurnAlertThresholds are the spend percentages that raise a burn alert when none are configured
+var DefaultBurnAlertThresholds = []int{80, 100}
+
+// MoneyConverter converts an amount into the ledger currency
+type MoneyConverter func(common.Money) (common.Money, error)
+
+// AppliedRate describes the exchange rate used to convert amounts from one currency into the ledger currency
+type AppliedRate struct {
+	From     common.Currency `json:"from"`
+	To       common.Currency `json:"to"`
+	Rate     string          `json:"rate"`
+	RateDate time.Time       `json:"rate_date"`
+	Inverted bool            `json:"inverted,omitempty"`
+}
+
+type CategoryTotals struct {
+	Category common.ExpenseCategory `json:"category"`
+	Planned  common.Money           `json:"planned"`
+	Actual   common.Money           `json:"actual"`
+}
+
+// BudgetLedger aggregates a project's expenses against its budget.
+// All amounts are expressed in Currency, Rates lists the conversions applied to get there.
+type BudgetLedger struct {
+	ProjectID   int64            `json:"project_id"`
+	Currency    common.Currency  `json:"currency"`
+	Budget      common.Money     `json:"budget"`
+	Planned     common.Money     `json:"planned"`
+	Actual      common.Money     `json:"actual"`
+	Remaining   common.Money     `json:"remaining"`
+	Unplanned   common.Money     `json:"unplanned"`
+	BurnPercent float64          `json:"burn_percent"`
+	Categories  []CategoryTotals `json:"categories"`
+	Rates       []AppliedRate    `json:"rates,omitempty"`
+}
+
+// NewBudgetLedger builds the ledger of project in the currency produced by convert.
+// A nil converter keeps the project budget currency and requires every expense to share it.
+func NewBudgetLedger(project *Project, expenses []*Expense, convert MoneyConverter) (*BudgetLedger, error) {
+	if convert == nil {
+		convert = func(m common.Money) (common.Money, error) { return m, nil }
+	}
+
+	budget, err := convert(project.Budget)
+	if err != nil {
+		return nil, err
+	}
+
+	currency := budget.Currency
+	ledger := &BudgetLedger{
+		ProjectID: project.ID,
+		Currency:  currency,
+		Budget:    budget,
+		Planned:   common.ZeroMoney(currency),
+		Actual:    common.ZeroMoney(currency),
+	}
+
+	byCategory := make(map[common.ExpenseCategory]*CategoryTotals)
+	for _, expense := range expenses {
+		totals, ok := byCategory[expense.Category]
+		if !ok {
+			totals = &CategoryTotals{
+				Category: expense.Category,
+				Planned:  common.ZeroMoney(currency),
+				Actual:   common.ZeroMoney(currency),
+			}
+			byCategory[expense.Category] = totals
+		}
+
+		amount, err := convert(expense.Amount)
+		if err != nil {
+			return nil, err
+		}
+
+		if expense.IsPlanned() {
+			if ledger.Planned, err = ledger.Planned.Add(amount); err != nil {
+				return nil, err
+			}
+			totals.Planned, _ = totals.Planned.Add(amount)
+		} else {
+			if ledger.Actual, err = ledger.Actual.Add(amount); err != nil {
+				return nil, err
+			}
+			totals.Actual, _ = totals.Actual.Add(amount)
+		}
+	}
+
+	ledger.Remaining, _ = ledger.Budget.Sub(ledger.Actual)
+	ledger.Unplanned, _ = ledger.Budget.Sub(ledger.Planned)
+	ledger.BurnPercent, _ = ledger.Actual.PercentOf(ledger.Budget)
+
+	ledger.Categories = make([]CategoryTotals, 0, len(byCategory))
+	for _, totals := range byCategory {
+		ledger.Categories = append(ledger.Categories, *totals)
+	}
+	sort.Slice(ledger.Categories, func(i, j int) bool {
+		return ledger.Categories[i].Category < ledger.Categories[j].Category
+	})
+
+	return ledger, nil
+}
+
+// Business methods
+
+func (l *BudgetLedger) IsOverBudget() bool {
+	return l.Remaining.IsNegative()
+}
+
+// ReachedThresholds returns the thresholds (percent of budget) already reached by the actual spend
+func (l *BudgetLedger) ReachedThresholds(thresholds []int) []int {
+	if !l.Budget.IsPositive() {
+		return nil
+	}
+
+	var reached []int
+	for _, threshold := range thresholds {
+		if ok, err := l.Actual.ReachesPercent(l.Budget, threshold); err == nil && ok {
+			reached = append(reached, threshold)
+		}
+	}
+	return reached
+}
+
+// CrossedThresholds returns the thresholds reached by this ledger that were not reached by previous
+func (l *BudgetLedger) CrossedThresholds(previous *BudgetLedger, thresholds []int) []int {
+	before := make(map[int]bool)
+	for _, threshold := range previous.ReachedThresholds(thresholds) {
+		before[threshold] = true
+	}
+
+	var crossed []int
+	for _, threshold := range l.ReachedThresholds(thresholds) {
+		if !before[threshold] {
+			crossed = append(crossed, threshold)
+		}
+	}
+	return crossed
+}
@@ -0,0 +1,94 @@
+package entities
+
+import (
+	"task-engine/internal/domain/entities/common"
+	"time"
+)
+
+// ChecklistItem is a lightweight step of a task, cheaper than a subtask
+type ChecklistItem struct {
+	ID        int64     `json:"id" db:"id"`
+	TaskID    int64     `json:"task_id" db:"task_id"`
+	Text      string    `json:"text" db:"text"`
+	Position  int       `json:"position" db:"position"`
+	CheckedBy int64     `json:"checked_by,omitempty" db:"checked_by"`
+	CheckedAt time.Time `json:"checked_at,omitempty" db:"checked_at"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+}
+
+func NewChecklistItem(taskID int64, text string) (*ChecklistItem, error) {
+	item := &ChecklistItem{
+		TaskID:    taskID,
+		Text:      text,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	if err := item.Validate(); err != nil {
+		return nil, err
+	}
+
+	return item, nil
+}
+
+// Validations methods
+
+func (i *ChecklistItem) Validate() error {
+	return common.ValidateFields(
+		common.ValidatePositiveInt("task_id", i.TaskID),
+		common.ValidateRequired("text", i.Text),
+		common.ValidateStringLength("text", i.Text, 500),
+	)
+}
+
+// Business methods
+
+func (i *ChecklistItem) IsChecked() bool {
+	return !i.CheckedAt.IsZero()
+}
+
+// Modification methods
+
+func (i *ChecklistItem) Update(text string, position int) error {
+	if err := common.ValidateFields(
+		common.ValidateRequired("text", text),
+		common.ValidateStringLength("text", text, 500),
+	); err != nil {
+		return err
+	}
+
+	i.Text = text
+	i.Position = position
+	i.UpdatedAt = time.Now()
+	return nil
+}
+
+func (i *ChecklistItem) Check(userID int64) {
+	if i.IsChecked() {
+		return
+	}
+	i.CheckedBy = userID
+	i.CheckedAt = time.Now()
+	i.UpdatedAt = i.CheckedAt
+}
+
+func (i *ChecklistItem) Uncheck() {
+	i.CheckedBy = 0
+	i.CheckedAt = time.Time{}
+	i.UpdatedAt = time.Now()
+}
+
+// ChecklistSummary counts the checklist items of a task
+type ChecklistSummary struct {
+	Total   int `json:"total"`
+	Checked int `json:"checked"`
+}
+
+func (s ChecklistSummary) Open() int {
+	return s.Total - s.Checked
+}
+
+func (s ChecklistSummary) IsComplete() bool {
+	return s.Checked >= s.Total
+}
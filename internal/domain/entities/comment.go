@@ -0,0 +1,224 @@
+package entities
+
+import (
+	"task-engine/internal/domain/entities/common"
+	"time"
+)
+
+// Comment is a message on a task. A reply has the top comment of its thread as ParentID,
+// threads are one level deep. Deleted comments stay as tombstones so their replies keep
+// their place, Redact hides what they said.
+type Comment struct {
+	ID        int64     `json:"id" db:"id"`
+	TaskID    int64     `json:"task_id" db:"task_id"`
+	ParentID  int64     `json:"parent_id,omitempty" db:"parent_id"`
+	UserID    int64     `json:"user_id" db:"user_id"`
+	Content   string    `json:"content" db:"content"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	EditedAt  time.Time `json:"edited_at,omitempty" db:"edited_at"`
+	DeletedAt time.Time `json:"deleted_at,omitempty" db:"deleted_at"`
+	DeletedBy int64     `json:"deleted_by,omitempty" db:"deleted_by"`
+
+	Attachments []*Attachment      `json:"attachments,omitempty" db:"-"`
+	Mentions    []*CommentMention  `json:"mentions,omitempty" db:"-"`
+	Reactions   []*ReactionSummary `json:"reactions,omitempty" db:"-"`
+	Replies     []*Comment         `json:"replies,omitempty" db:"-"`
+}
+
+// CommentRevision keeps the content a comment had before an edit. EditedBy made the edit
+// at CreatedAt.
+type CommentRevision struct {
+	ID        int64     `json:"id" db:"id"`
+	CommentID int64     `json:"comment_id" db:"comment_id"`
+	Content   string    `json:"content" db:"content"`
+	EditedBy  int64     `json:"edited_by" db:"edited_by"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+// CommentReaction is one user's emoji on a comment, a user reacts with each emoji once
+type CommentReaction struct {
+	CommentID int64     `json:"comment_id" db:"comment_id"`
+	UserID    int64     `json:"user_id" db:"user_id"`
+	Emoji     string    `json:"emoji" db:"emoji"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+// ReactionSummary counts the users who reacted to a comment with an emoji
+type ReactionSummary struct {
+	Emoji   string  `json:"emoji"`
+	Count   int     `json:"count"`
+	UserIDs []int64 `json:"user_ids"`
+}
+
+func NewComment(taskID, userID int64, content string) (*Comment, error) {
+	comment := &Comment{
+		TaskID:    taskID,
+		UserID:    userID,
+		Content:   content,
+		CreatedAt: time.Now(),
+	}
+
+	if err := comment.Validate(); err != nil {
+		return nil, err
+	}
+
+	return comment, nil
+}
+
+func NewCommentReaction(commentID, userID int64, emoji string) (*CommentReaction, error) {
+	reaction := &CommentReaction{
+		CommentID: commentID,
+		UserID:    userID,
+		Emoji:     emoji,
+		CreatedAt: time.Now(),
+	}
+
+	if err := common.ValidateFields(
+		common.ValidatePositiveInt("comment_id", reaction.CommentID),
+		common.ValidatePositiveInt("user_id", reaction.UserID),
+		common.ValidateEmoji("emoji", reaction.Emoji),
+	); err != nil {
+		return nil, err
+	}
+
+	return reaction, nil
+}
+
+// Validations methods
+
+func (c *Comment) Validate() error {
+	return common.ValidateFields(
+		common.ValidatePositiveInt("task_id", c.TaskID),
+		common.ValidatePositiveInt("user_id", c.UserID),
+		common.ValidateRequired("content", c.Content),
+		common.ValidateStringLength("content", c.Content, 10000),
+	)
+}
+
+// Business methods
+
+func (c *Comment) IsReply() bool {
+	return c.ParentID != 0
+}
+
+func (c *Comment) IsEdited() bool {
+	return !c.EditedAt.IsZero()
+}
+
+func (c *Comment) IsDeleted() bool {
+	return !c.DeletedAt.IsZero()
+}
+
+// CanModify reports whether the user may edit or delete the comment: its author, a manager or an admin
+func (c *Comment) CanModify(userID int64, role common.UserRole) bool {
+	return c.UserID == userID || role == common.UserRoleManager || role == common.UserRoleAdmin
+}
+
+// Modification methods
+
+// ReplyTo places the comment in the thread of parent. Replying to a reply joins the thread
+// of its top comment.
+func (c *Comment) ReplyTo(parent *Comment) error {
+	if parent.TaskID != c.TaskID {
+		return &common.FieldValidationError{Field: "parent_id", Message: "parent comment belongs to another task"}
+	}
+	if parent.IsDeleted() {
+		return &common.FieldValidationError{Field: "parent_id", Message: "cannot reply to a deleted comment"}
+	}
+
+	c.ParentID = parent.ID
+	if parent.IsReply() {
+		c.ParentID = parent.ParentID
+	}
+	return nil
+}
+
+// Edit replaces the content and returns the revision keeping the previous one
+func (c *Comment) Edit(content string, userID int64, role common.UserRole) (*CommentRevision, error) {
+	if !c.CanModify(userID, role) {
+		return nil, &ForbiddenError{Reason: "only the author or a manager can edit this comment"}
+	}
+	if c.IsDeleted() {
+		return nil, &common.FieldValidationError{Field: "content", Message: "deleted comments cannot be edited"}
+	}
+	if err := common.ValidateFields(
+		common.ValidateRequired("content", content),
+		common.ValidateStringLength("content", content, 10000),
+	); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	revision := &CommentRevision{
+		CommentID: c.ID,
+		Content:   c.Content,
+		EditedBy:  userID,
+		CreatedAt: now,
+	}
+	c.Content = content
+	c.EditedAt = now
+	return revision, nil
+}
+
+// Delete turns the comment into a tombstone, deleting twice is a no-op
+func (c *Comment) Delete(userID int64, role common.UserRole) error {
+	if !c.CanModify(userID, role) {
+		return &ForbiddenError{Reason: "only the author or a manager can delete this comment"}
+	}
+	if c.IsDeleted() {
+		return nil
+	}
+
+	c.DeletedAt = time.Now()
+	c.DeletedBy = userID
+	return nil
+}
+
+// Redact clears what a deleted comment said before it is shown. The stored row keeps it.
+func (c *Comment) Redact() {
+	if !c.IsDeleted() {
+		return
+	}
+	c.Content = ""
+	c.Attachments = nil
+	c.Mentions = nil
+	c.Reactions = nil
+}
+
+// SummarizeReactions groups reactions by emoji, in the order each emoji was first used
+func SummarizeReactions(reactions []*CommentReaction) []*ReactionSummary {
+	var summaries []*ReactionSummary
+	byEmoji := make(map[string]*ReactionSummary)
+	for _, reaction := range reactions {
+		summary, ok := byEmoji[reaction.Emoji]
+		if !ok {
+			summary = &ReactionSummary{Emoji: reaction.Emoji}
+			byEmoji[reaction.Emoji] = summary
+			summaries = append(summaries, summary)
+		}
+		summary.Count++
+		summary.UserIDs = append(summary.UserIDs, reaction.UserID)
+	}
+	return summaries
+}
+
+// BuildThreads nests replies under their top comment. Comments are expected oldest first,
+// replies whose top comment is missing are listed at the top level.
+func BuildThreads(comments []*Comment) []*Comment {
+	roots := make(map[int64]*Comment)
+	for _, comment := range comments {
+		if !comment.IsReply() {
+			roots[comment.ID] = comment
+		}
+	}
+
+	var threads []*Comment
+	for _, comment := range comments {
+		if parent, ok := roots[comment.ParentID]; ok && comment.IsReply() {
+			parent.Replies = append(parent.Replies, comment)
+			continue
+		}
+		threads = append(threads, comment)
+	}
+	return threads
+}
@@ -0,0 +1,251 @@
+package common
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrCurrencyMismatch = errors.New("money values have different currencies")
+	ErrInvalidCurrency  = errors.New("currency must be a 3-letter ISO 4217 code")
+	ErrInvalidAmount    = errors.New("invalid money amount")
+)
+
+// Currency is an ISO 4217 alphabetic currency code (e.g. "BRL").
+type Currency string
+
+const (
+	CurrencyBRL Currency = "BRL"
+	CurrencyUSD Currency = "USD"
+	CurrencyEUR Currency = "EUR"
+)
+
+// DefaultCurrency is used when a budget is created without an explicit currency
+const DefaultCurrency = CurrencyBRL
+
+// currencies whose minor unit is not the usual 1/100
+var currencyExponents = map[Currency]int{
+	"JPY": 0,
+	"KRW": 0,
+	"CLP": 0,
+	"BHD": 3,
+	"KWD": 3,
+	"TND": 3,
+}
+
+func (c Currency) IsValid() bool {
+	if len(c) != 3 {
+		return false
+	}
+	for _, r := range c {
+		if r < 'A' || r > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+// Exponent returns the number of decimal digits of the currency minor unit
+func (c Currency) Exponent() int {
+	if exp, ok := currencyExponents[c]; ok {
+		return exp
+	}
+	return 2
+}
+
+// Money is an exact monetary value stored in the currency minor unit (cents for BRL/USD/EUR).
+type Money struct {
+	Amount   int64    `json:"amount"`
+	Currency Currency `json:"currency"`
+}
+
+func NewMoney(amount int64, currency Currency) Money {
+	return Money{Amount: amount, Currency: currency}
+}
+
+func ZeroMoney(currency Currency) Money {
+	return Money{Currency: currency}
+}
+
+// ParseMoney parses a decimal string such as "1234.56" or "-10" into minor units.
+// Values with more decimal digits than the currency allows are rejected instead of rounded.
+func ParseMoney(value string, currency Currency) (Money, error) {
+	if !currency.IsValid() {
+		return Money{}, ErrInvalidCurrency
+	}
+
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return Money{}, ErrInvalidAmount
+	}
+
+	negative := false
+	switch value[0] {
+	case '-':
+		negative = true
+		value = value[1:]
+	case '+':
+		value = value[1:]
+	}
+
+	whole, fraction, _ := strings.Cut(value, ".")
+	exp := currency.Exponent()
+	if whole == "" || len(fraction) > exp || !isDigits(whole) || !isDigits(fraction) {
+		return Money{}, fmt.Errorf("%w: %q", ErrInvalidAmount, value)
+	}
+	fraction += strings.Repeat("0", exp-len(fraction))
+
+	amount, err := strconv.ParseInt(whole+fraction, 10, 64)
+	if err != nil {
+		return Money{}, fmt.Errorf("%w: %q", ErrInvalidAmount, value)
+	}
+	if negative {
+		amount = -amount
+	}
+	return Money{Amount: amount, Currency: currency}, nil
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// Business methods
+
+func (m Money) IsZero() bool {
+	return m.Amount == 0
+}
+
+func (m Money) IsNegative() bool {
+	return m.Amount < 0
+}
+
+func (m Money) IsPositive() bool {
+	return m.Amount > 0
+}
+
+func (m Money) SameCurrency(other Money) bool {
+	return m.Currency == other.Currency
+}
+
+func (m Money) Add(other Money) (Money, error) {
+	if !m.SameCurrency(other) {
+		return Money{}, ErrCurrencyMismatch
+	}
+	return Money{Amount: m.Amount + other.Amount, Currency: m.Currency}, nil
+}
+
+func (m Money) Sub(other Money) (Money, error) {
+	if !m.SameCurrency(other) {
+		return Money{}, ErrCurrencyMismatch
+	}
+	return Money{Amount: m.Amount - other.Amount, Currency: m.Currency}, nil
+}
+
+func (m Money) Negate() Money {
+	return Money{Amount: -m.Amount, Currency: m.Currency}
+}
+
+// Compare returns -1, 0 or 1 depending on whether m is less than, equal to or greater than other
+func (m Money) Compare(other Money) (int, error) {
+	if !m.SameCurrency(other) {
+		return 0, ErrCurrencyMismatch
+	}
+	switch {
+	case m.Amount < other.Amount:
+		return -1, nil
+	case m.Amount > other.Amount:
+		return 1, nil
+	}
+	return 0, nil
+}
+
+// ReachesPercent reports whether m is at least percent% of total, using exact integer arithmetic
+func (m Money) ReachesPercent(total Money, percent int) (bool, error) {
+	if !m.SameCurrency(total) {
+		return false, ErrCurrencyMismatch
+	}
+	lhs := new(big.Int).Mul(big.NewInt(m.Amount), big.NewInt(100))
+	rhs := new(big.Int).Mul(big.NewInt(total.Amount), big.NewInt(int64(percent)))
+	return lhs.Cmp(rhs) >= 0, nil
+}
+
+// PercentOf returns m as a percentage of total, for display purposes only
+func (m Money) PercentOf(total Money) (float64, error) {
+	if !m.SameCurrency(total) {
+		return 0, ErrCurrencyMismatch
+	}
+	if total.Amount == 0 {
+		return 0, nil
+	}
+	ratio := new(big.Rat).SetFrac(big.NewInt(m.Amount), big.NewInt(total.Amount))
+	percent, _ := ratio.Mul(ratio, big.NewRat(100, 1)).Float64()
+	return percent, nil
+}
+
+// MulFrac returns m * num / den rounded half away from zero to the minor unit
+func (m Money) MulFrac(num, den int64) Money {
+	value := new(big.Rat).SetFrac(new(big.Int).Mul(big.NewInt(m.Amount), big.NewInt(num)), big.NewInt(den))
+	return Money{Amount: roundRat(value), Currency: m.Currency}
+}
+
+// Convert converts m into the target currency using rate (units of target per unit of m.Currency).
+// The result is rounded half away from zero to the target minor unit.
+func (m Money) Convert(rate *big.Rat, to Currency) Money {
+	if m.Currency == to {
+		return m
+	}
+
+	value := new(big.Rat).SetFrac(big.NewInt(m.Amount), pow10(m.Currency.Exponent()))
+	value.Mul(value, rate)
+	value.Mul(value, new(big.Rat).SetInt(pow10(to.Exponent())))
+
+	return Money{Amount: roundRat(value), Currency: to}
+}
+
+func pow10(exp int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(exp)), nil)
+}
+
+// roundRat rounds r half away from zero
+func roundRat(r *big.Rat) int64 {
+	num := new(big.Int).Abs(r.Num())
+	quo, rem := new(big.Int).QuoRem(num, r.Denom(), new(big.Int))
+	if new(big.Int).Mul(rem, big.NewInt(2)).Cmp(r.Denom()) >= 0 {
+		quo.Add(quo, big.NewInt(1))
+	}
+	if r.Sign() < 0 {
+		quo.Neg(quo)
+	}
+	return quo.Int64()
+}
+
+// Decimal formats the amount as a plain decimal string (e.g. "1234.56")
+func (m Money) Decimal() string {
+	exp := m.Currency.Exponent()
+	amount := m.Amount
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+	digits := strconv.FormatInt(amount, 10)
+	if exp == 0 {
+		return sign + digits
+	}
+	if len(digits) <= exp {
+		digits = strings.Repeat("0", exp-len(digits)+1) + digits
+	}
+	return sign + digits[:len(digits)-exp] + "." + digits[len(digits)-exp:]
+}
+
+func (m Money) String() string {
+	return m.Decimal() + " " + string(m.Currency)
+}
@@ -22,6 +22,8 @@ const (
 
 // Task types
 
+// TaskStatus is the key of a status in the project's workflow.
+// The constants below are the statuses of the default workflow.
 type TaskStatus string
 
 const (
@@ -31,6 +33,26 @@ const (
 	TaskStatusCancelled  TaskStatus = "cancelled"
 )
 
+// StatusCategory groups workflow statuses so built-in behaviour does not depend on status names
+type StatusCategory string
+
+const (
+	StatusCategoryTodo      StatusCategory = "todo"
+	StatusCategoryDoing     StatusCategory = "doing"
+	StatusCategoryDone      StatusCategory = "done"
+	StatusCategoryCancelled StatusCategory = "cancelled"
+)
+
+// TransitionGuardType is a condition a task must meet before moving to another status
+type TransitionGuardType string
+
+const (
+	TransitionGuardManagerOnly    TransitionGuardType = "manager_only"
+	TransitionGuardEstimateSet    TransitionGuardType = "estimate_set"
+	TransitionGuardDueDateSet     TransitionGuardType = "due_date_set"
+	TransitionGuardCustomFieldSet TransitionGuardType = "custom_field_set"
+)
+
 type TaskPriority string
 
 const (
@@ -40,6 +62,19 @@ const (
 	TaskPriorityUrgent TaskPriority = "urgent"
 )
 
+// Custom field types
+
+type CustomFieldType string
+
+const (
+	CustomFieldTypeText         CustomFieldType = "text"
+	CustomFieldTypeNumber       CustomFieldType = "number"
+	CustomFieldTypeDate         CustomFieldType = "date"
+	CustomFieldTypeSingleSelect CustomFieldType = "single_select"
+	CustomFieldTypeMultiSelect  CustomFieldType = "multi_select"
+	CustomFieldTypeUser         CustomFieldType = "user"
+)
+
 // User types
 
 type UserRole string
@@ -58,3 +93,230 @@ const (
 	UserStatusInactive UserStatus = "inactive"
 	UserStatusSuspended UserStatus = "suspended"
 )
+
+// Budget types
+
+type ExpenseKind string
+
+const (
+	ExpenseKindPlanned ExpenseKind = "planned"
+	ExpenseKindActual  ExpenseKind = "actual"
+)
+
+type ExpenseCategory string
+
+const (
+	ExpenseCategoryLabor    ExpenseCategory = "labor"
+	ExpenseCategorySoftware ExpenseCategory = "software"
+	ExpenseCategoryHardware ExpenseCategory = "hardware"
+	ExpenseCategoryServices ExpenseCategory = "services"
+	ExpenseCategoryTravel   ExpenseCategory = "travel"
+	ExpenseCategoryOther    ExpenseCategory = "other"
+)
+
+// Event types
+
+type EventType string
+
+const (
+	EventTypeBudgetBurnAlert   EventType = "project.budget_burn_alert"
+	EventTypeTaskCreated       EventType = "task.created"
+	EventTypeTaskAssigned      EventType = "task.assigned"
+	EventTypeTaskUnassigned    EventType = "task.unassigned"
+	EventTypeTaskStatusChanged EventType = "task.status_changed"
+	EventTypeCommentAdded      EventType = "comment.added"
+	EventTypeCommentMentioned  EventType = "comment.mentioned"
+)
+
+// Notification types
+
+type NotificationChannel string
+
+const (
+	NotificationChannelInApp NotificationChannel = "in_app"
+	NotificationChannelEmail NotificationChannel = "email"
+)
+
+type DeliveryStatus string
+
+const (
+	DeliveryStatusPending DeliveryStatus = "pending"
+	DeliveryStatusSent    DeliveryStatus = "sent"
+	DeliveryStatusFailed  DeliveryStatus = "failed"
+)
+
+// WebhookDeliveryStatus is the state of a webhook delivery. Dead deliveries used up their
+// attempts and are only sent again when replayed.
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryStatusPending   WebhookDeliveryStatus = "pending"
+	WebhookDeliveryStatusDelivered WebhookDeliveryStatus = "delivered"
+	WebhookDeliveryStatusDead      WebhookDeliveryStatus = "dead"
+)
+
+// SearchResultType is the kind of record a search result points to
+type SearchResultType string
+
+const (
+	SearchResultTypeTask    SearchResultType = "task"
+	SearchResultTypeProject SearchResultType = "project"
+	SearchResultTypeComment SearchResultType = "comment"
+)
+
+// SuggestionType is the kind of record a typeahead suggestion points to
+type SuggestionType string
+
+const (
+	SuggestionTypeTask    SuggestionType = "task"
+	SuggestionTypeProject SuggestionType = "project"
+	SuggestionTypeTag     SuggestionType = "tag"
+	SuggestionTypeUser    SuggestionType = "user"
+)
+
+// SearchLanguage is the text search configuration a project's text is indexed with. Simple
+// only lowercases words, the others also stem them and drop stop words of their language.
+type SearchLanguage string
+
+const (
+	SearchLanguageSimple     SearchLanguage = "simple"
+	SearchLanguageArabic     SearchLanguage = "arabic"
+	SearchLanguageDanish     SearchLanguage = "danish"
+	SearchLanguageDutch      SearchLanguage = "dutch"
+	SearchLanguageEnglish    SearchLanguage = "english"
+	SearchLanguageFinnish    SearchLanguage = "finnish"
+	SearchLanguageFrench     SearchLanguage = "french"
+	SearchLanguageGerman     SearchLanguage = "german"
+	SearchLanguageGreek      SearchLanguage = "greek"
+	SearchLanguageHungarian  SearchLanguage = "hungarian"
+	SearchLanguageIndonesian SearchLanguage = "indonesian"
+	SearchLanguageIrish      SearchLanguage = "irish"
+	SearchLanguageItalian    SearchLanguage = "italian"
+	SearchLanguageLithuanian SearchLanguage = "lithuanian"
+	SearchLanguageNepali     SearchLanguage = "nepali"
+	SearchLanguageNorwegian  SearchLanguage = "norwegian"
+	SearchLanguagePortuguese SearchLanguage = "portuguese"
+	SearchLanguageRomanian   SearchLanguage = "romanian"
+	SearchLanguageRussian    SearchLanguage = "russian"
+	SearchLanguageSpanish    SearchLanguage = "spanish"
+	SearchLanguageSwedish    SearchLanguage = "swedish"
+	SearchLanguageTamil      SearchLanguage = "tamil"
+	SearchLanguageTurkish    SearchLanguage = "turkish"
+)
+
+// DefaultSearchLanguage is the language of new projects, the one search was first built for
+const DefaultSearchLanguage = SearchLanguagePortuguese
+
+// DefaultLocale is used for users without a locale and for locales without templates
+const DefaultLocale = "en"
+
+// DefaultTimezone is used for users who have not set a timezone
+const DefaultTimezone = "UTC"
+
+type DigestFrequency string
+
+const (
+	DigestFrequencyDaily  DigestFrequency = "daily"
+	DigestFrequencyWeekly DigestFrequency = "weekly"
+)
+
+// Subscription types
+
+// SubscriptionReason records why a user watches a project or task
+type SubscriptionReason string
+
+const (
+	SubscriptionReasonWatch     SubscriptionReason = "watch"
+	SubscriptionReasonCreated   SubscriptionReason = "created"
+	SubscriptionReasonAssigned  SubscriptionReason = "assigned"
+	SubscriptionReasonCommented SubscriptionReason = "commented"
+)
+
+// Mention types
+
+// MentionKind tells whether a comment mention names a user or a team
+type MentionKind string
+
+const (
+	MentionKindUser MentionKind = "user"
+	MentionKindTeam MentionKind = "team"
+)
+
+// Saved view types
+
+// SavedViewVisibility tells who sees a saved view: its owner, the members of its team or
+// everyone working on its project
+type SavedViewVisibility string
+
+const (
+	SavedViewVisibilityPrivate SavedViewVisibility = "private"
+	SavedViewVisibilityTeam    SavedViewVisibility = "team"
+	SavedViewVisibilityProject SavedViewVisibility = "project"
+)
+
+// TaskGrouping is the field a task list is grouped by
+type TaskGrouping string
+
+const (
+	TaskGroupingStatus   TaskGrouping = "status"
+	TaskGroupingCategory TaskGrouping = "category"
+	TaskGroupingPriority TaskGrouping = "priority"
+	TaskGroupingAssignee TaskGrouping = "assignee"
+	TaskGroupingTag      TaskGrouping = "tag"
+	TaskGroupingDueDate  TaskGrouping = "due_date"
+)
+
+// TaskFacet is a field task lists count their results by
+type TaskFacet string
+
+const (
+	TaskFacetStatus   TaskFacet = "status"
+	TaskFacetPriority TaskFacet = "priority"
+	TaskFacetTag      TaskFacet = "tag"
+	TaskFacetAssignee TaskFacet = "assignee"
+)
+
+// Bulk task types
+
+// BulkTaskAction is the change a bulk request applies to each of its tasks
+type BulkTaskAction string
+
+const (
+	BulkTaskActionSetStatus   BulkTaskAction = "set_status"
+	BulkTaskActionSetPriority BulkTaskAction = "set_priority"
+	BulkTaskActionSetDueDate  BulkTaskAction = "set_due_date"
+	BulkTaskActionAssign      BulkTaskAction = "assign"
+	BulkTaskActionUnassign    BulkTaskAction = "unassign"
+	BulkTaskActionAddTags     BulkTaskAction = "add_tags"
+	BulkTaskActionRemoveTags  BulkTaskAction = "remove_tags"
+	BulkTaskActionMove        BulkTaskAction = "move"
+	BulkTaskActionDelete      BulkTaskAction = "delete"
+)
+
+// BulkMode tells whether a bulk request is applied to all of its tasks or none, or to every
+// task it can be applied to
+type BulkMode string
+
+const (
+	BulkModeAtomic     BulkMode = "atomic"
+	BulkModeBestEffort BulkMode = "best_effort"
+)
+
+// Import types
+
+// ImportSource is the tool an export file comes from
+type ImportSource string
+
+const (
+	ImportSourceTrello ImportSource = "trello"
+	ImportSourceJira   ImportSource = "jira"
+)
+
+// ImportEntityKind is the kind of entity a record of another tool was imported as
+type ImportEntityKind string
+
+const (
+	ImportEntityProject ImportEntityKind = "project"
+	ImportEntityTask    ImportEntityKind = "task"
+	ImportEntityComment ImportEntityKind = "comment"
+)
@@ -2,7 +2,16 @@ package common
 
 import (
 	"fmt"
+	"regexp"
 	"time"
+	"unicode"
+)
+
+var (
+	statusKeyPattern  = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+	localePattern     = regexp.MustCompile(`^[a-z]{2,3}(-[A-Z]{2})?$`)
+	handlePattern     = regexp.MustCompile(`^[a-z0-9][a-z0-9._-]*$`)
+	projectKeyPattern = regexp.MustCompile(`^[A-Z][A-Z0-9]{1,9}$`)
 )
 
 type FieldValidationError struct {
@@ -98,6 +107,44 @@ func ValidateEnum(fieldName string, value interface{}, allowedValues ...interfac
 	}
 }
 
+// Money validations
+
+func ValidateCurrency(fieldName string, currency Currency) *FieldValidationError {
+	if !currency.IsValid() {
+		return &FieldValidationError{
+			Field:   fieldName,
+			Message: "field must be a 3-letter ISO 4217 currency code",
+		}
+	}
+	return nil
+}
+
+func ValidateNonNegativeMoney(fieldName string, value Money) *FieldValidationError {
+	if err := ValidateCurrency(fieldName+".currency", value.Currency); err != nil {
+		return err
+	}
+	if value.IsNegative() {
+		return &FieldValidationError{
+			Field:   fieldName,
+			Message: "field cannot be negative",
+		}
+	}
+	return nil
+}
+
+func ValidatePositiveMoney(fieldName string, value Money) *FieldValidationError {
+	if err := ValidateCurrency(fieldName+".currency", value.Currency); err != nil {
+		return err
+	}
+	if !value.IsPositive() {
+		return &FieldValidationError{
+			Field:   fieldName,
+			Message: "field must be greater than zero",
+		}
+	}
+	return nil
+}
+
 // Date validations
 
 func ValidateDateRange(fieldName string, date time.Time, minDate, maxDate time.Time) *FieldValidationError {
@@ -212,12 +259,36 @@ func ValidateProjectPriority(fieldName string, priority ProjectPriority) *FieldV
 	)
 }
 
+// ValidateTaskStatus checks the shape of a status key, whether the status exists
+// depends on the project's workflow
 func ValidateTaskStatus(fieldName string, status TaskStatus) *FieldValidationError {
-	return ValidateEnum(fieldName, status,
-		TaskStatusPending,
-		TaskStatusInProgress,
-		TaskStatusCompleted,
-		TaskStatusCancelled,
+	if err := ValidateRequired(fieldName, string(status)); err != nil {
+		return err
+	}
+	if !statusKeyPattern.MatchString(string(status)) {
+		return &FieldValidationError{
+			Field:   fieldName,
+			Message: "field must start with a lowercase letter and contain only lowercase letters, digits and underscores",
+		}
+	}
+	return ValidateStringLength(fieldName, string(status), 50)
+}
+
+func ValidateStatusCategory(fieldName string, category StatusCategory) *FieldValidationError {
+	return ValidateEnum(fieldName, category,
+		StatusCategoryTodo,
+		StatusCategoryDoing,
+		StatusCategoryDone,
+		StatusCategoryCancelled,
+	)
+}
+
+func ValidateTransitionGuardType(fieldName string, guard TransitionGuardType) *FieldValidationError {
+	return ValidateEnum(fieldName, guard,
+		TransitionGuardManagerOnly,
+		TransitionGuardEstimateSet,
+		TransitionGuardDueDateSet,
+		TransitionGuardCustomFieldSet,
 	)
 }
 
@@ -230,6 +301,17 @@ func ValidateTaskPriority(fieldName string, priority TaskPriority) *FieldValidat
 	)
 }
 
+func ValidateCustomFieldType(fieldName string, fieldType CustomFieldType) *FieldValidationError {
+	return ValidateEnum(fieldName, fieldType,
+		CustomFieldTypeText,
+		CustomFieldTypeNumber,
+		CustomFieldTypeDate,
+		CustomFieldTypeSingleSelect,
+		CustomFieldTypeMultiSelect,
+		CustomFieldTypeUser,
+	)
+}
+
 func ValidateUserRole(fieldName string, role UserRole) *FieldValidationError {
 	return ValidateEnum(fieldName, role,
 		UserRoleAdmin,
@@ -247,6 +329,247 @@ func ValidateUserStatus(fieldName string, status UserStatus) *FieldValidationErr
 	)
 }
 
+// Domain specific validations (Budget)
+
+func ValidateExpenseKind(fieldName string, kind ExpenseKind) *FieldValidationError {
+	return ValidateEnum(fieldName, kind,
+		ExpenseKindPlanned,
+		ExpenseKindActual,
+	)
+}
+
+func ValidateExpenseCategory(fieldName string, category ExpenseCategory) *FieldValidationError {
+	return ValidateEnum(fieldName, category,
+		ExpenseCategoryLabor,
+		ExpenseCategorySoftware,
+		ExpenseCategoryHardware,
+		ExpenseCategoryServices,
+		ExpenseCategoryTravel,
+		ExpenseCategoryOther,
+	)
+}
+
+// Domain specific validations (Events and subscriptions)
+
+func ValidateEventType(fieldName string, eventType EventType) *FieldValidationError {
+	return ValidateEnum(fieldName, eventType,
+		EventTypeBudgetBurnAlert,
+		EventTypeTaskCreated,
+		EventTypeTaskAssigned,
+		EventTypeTaskUnassigned,
+		EventTypeTaskStatusChanged,
+		EventTypeCommentAdded,
+		EventTypeCommentMentioned,
+	)
+}
+
+func ValidateSubscriptionReason(fieldName string, reason SubscriptionReason) *FieldValidationError {
+	return ValidateEnum(fieldName, reason,
+		SubscriptionReasonWatch,
+		SubscriptionReasonCreated,
+		SubscriptionReasonAssigned,
+		SubscriptionReasonCommented,
+	)
+}
+
+func ValidateNotificationChannel(fieldName string, channel NotificationChannel) *FieldValidationError {
+	return ValidateEnum(fieldName, channel,
+		NotificationChannelInApp,
+		NotificationChannelEmail,
+	)
+}
+
+func ValidateWebhookDeliveryStatus(fieldName string, status WebhookDeliveryStatus) *FieldValidationError {
+	return ValidateEnum(fieldName, status,
+		WebhookDeliveryStatusPending,
+		WebhookDeliveryStatusDelivered,
+		WebhookDeliveryStatusDead,
+	)
+}
+
+func ValidateSearchResultType(fieldName string, resultType SearchResultType) *FieldValidationError {
+	return ValidateEnum(fieldName, resultType,
+		SearchResultTypeTask,
+		SearchResultTypeProject,
+		SearchResultTypeComment,
+	)
+}
+
+func ValidateSuggestionType(fieldName string, suggestionType SuggestionType) *FieldValidationError {
+	return ValidateEnum(fieldName, suggestionType,
+		SuggestionTypeTask,
+		SuggestionTypeProject,
+		SuggestionTypeTag,
+		SuggestionTypeUser,
+	)
+}
+
+func ValidateSearchLanguage(fieldName string, language SearchLanguage) *FieldValidationError {
+	return ValidateEnum(fieldName, language,
+		SearchLanguageSimple,
+		SearchLanguageArabic,
+		SearchLanguageDanish,
+		SearchLanguageDutch,
+		SearchLanguageEnglish,
+		SearchLanguageFinnish,
+		SearchLanguageFrench,
+		SearchLanguageGerman,
+		SearchLanguageGreek,
+		SearchLanguageHungarian,
+		SearchLanguageIndonesian,
+		SearchLanguageIrish,
+		SearchLanguageItalian,
+		SearchLanguageLithuanian,
+		SearchLanguageNepali,
+		SearchLanguageNorwegian,
+		SearchLanguagePortuguese,
+		SearchLanguageRomanian,
+		SearchLanguageRussian,
+		SearchLanguageSpanish,
+		SearchLanguageSwedish,
+		SearchLanguageTamil,
+		SearchLanguageTurkish,
+	)
+}
+
+// ValidateLocale checks a language tag such as "en" or "pt-BR"
+func ValidateLocale(fieldName, locale string) *FieldValidationError {
+	if !localePattern.MatchString(locale) {
+		return &FieldValidationError{
+			Field:   fieldName,
+			Message: "field must be a language tag such as en or pt-BR",
+		}
+	}
+	return nil
+}
+
+// ValidateTimezone checks an IANA timezone name such as "Europe/Madrid"
+func ValidateTimezone(fieldName, timezone string) *FieldValidationError {
+	// LoadLocation accepts "" and "Local" as the server's zone, which is not a user setting
+	if _, err := time.LoadLocation(timezone); err != nil || timezone == "" || timezone == "Local" {
+		return &FieldValidationError{
+			Field:   fieldName,
+			Message: "field must be a timezone such as UTC or Europe/Madrid",
+		}
+	}
+	return nil
+}
+
+func ValidateDigestFrequency(fieldName string, frequency DigestFrequency) *FieldValidationError {
+	return ValidateEnum(fieldName, frequency,
+		DigestFrequencyDaily,
+		DigestFrequencyWeekly,
+	)
+}
+
+// ValidateHandle checks a team handle, written after @ to mention the team
+func ValidateHandle(fieldName, handle string) *FieldValidationError {
+	if err := ValidateStringLengthRange(fieldName, handle, 2, 50); err != nil {
+		return err
+	}
+	if !handlePattern.MatchString(handle) {
+		return &FieldValidationError{
+			Field:   fieldName,
+			Message: "field must contain lowercase letters, digits, dots, dashes or underscores",
+		}
+	}
+	return nil
+}
+
+// ValidateProjectKey checks a project key such as PROJ, empty keys are allowed
+func ValidateProjectKey(fieldName, key string) *FieldValidationError {
+	if key == "" || projectKeyPattern.MatchString(key) {
+		return nil
+	}
+	return &FieldValidationError{
+		Field:   fieldName,
+		Message: "field must be 2 to 10 uppercase letters or digits, starting with a letter",
+	}
+}
+
+// ValidateEmoji checks a single emoji, including skin tones and sequences joined with a
+// zero width joiner such as family emoji
+func ValidateEmoji(fieldName, emoji string) *FieldValidationError {
+	invalid := &FieldValidationError{Field: fieldName, Message: "field must be an emoji"}
+	if emoji == "" || len(emoji) > 32 {
+		return invalid
+	}
+	for i, r := range emoji {
+		switch {
+		case i == 0 && !unicode.Is(unicode.So, r):
+			return invalid
+		case unicode.In(r, unicode.So, unicode.Sk, unicode.Mn, unicode.Me), r == '\u200d':
+		default:
+			return invalid
+		}
+	}
+	return nil
+}
+
+func ValidateSavedViewVisibility(fieldName string, visibility SavedViewVisibility) *FieldValidationError {
+	return ValidateEnum(fieldName, visibility,
+		SavedViewVisibilityPrivate,
+		SavedViewVisibilityTeam,
+		SavedViewVisibilityProject,
+	)
+}
+
+func ValidateTaskGrouping(fieldName string, grouping TaskGrouping) *FieldValidationError {
+	return ValidateEnum(fieldName, grouping,
+		TaskGroupingStatus,
+		TaskGroupingCategory,
+		TaskGroupingPriority,
+		TaskGroupingAssignee,
+		TaskGroupingTag,
+		TaskGroupingDueDate,
+	)
+}
+
+func ValidateTaskFacet(fieldName string, facet TaskFacet) *FieldValidationError {
+	return ValidateEnum(fieldName, facet,
+		TaskFacetStatus,
+		TaskFacetPriority,
+		TaskFacetTag,
+		TaskFacetAssignee,
+	)
+}
+
+func ValidateBulkTaskAction(fieldName string, action BulkTaskAction) *FieldValidationError {
+	return ValidateEnum(fieldName, action,
+		BulkTaskActionSetStatus,
+		BulkTaskActionSetPriority,
+		BulkTaskActionSetDueDate,
+		BulkTaskActionAssign,
+		BulkTaskActionUnassign,
+		BulkTaskActionAddTags,
+		BulkTaskActionRemoveTags,
+		BulkTaskActionMove,
+		BulkTaskActionDelete,
+	)
+}
+
+func ValidateBulkMode(fieldName string, mode BulkMode) *FieldValidationError {
+	return ValidateEnum(fieldName, mode,
+		BulkModeAtomic,
+		BulkModeBestEffort,
+	)
+}
+
+func ValidateImportSource(fieldName string, source ImportSource) *FieldValidationError {
+	return ValidateEnum(fieldName, source,
+		ImportSourceTrello,
+		ImportSourceJira,
+	)
+}
+
+func ValidateImportEntityKind(fieldName string, kind ImportEntityKind) *FieldValidationError {
+	return ValidateEnum(fieldName, kind,
+		ImportEntityProject,
+		ImportEntityTask,
+		ImportEntityComment,
+	)
+}
+
 // Auxiliary functions
 
 func contains(s, substr string) bool {
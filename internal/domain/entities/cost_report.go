@@ -0,0 +1,164 @@
+package entities
+
+import (
+	"sort"
+	"task-engine/internal/domain/entities/common"
+)
+
+type TaskCost struct {
+	TaskID           int64             `json:"task_id"`
+	Title            string            `json:"title"`
+	Status           common.TaskStatus `json:"status"`
+	LoggedMinutes    int64             `json:"logged_minutes"`
+	Cost             common.Money      `json:"cost"`
+	RemainingMinutes int64             `json:"remaining_minutes"`
+	RemainingCost    common.Money      `json:"remaining_cost"`
+	CostAtCompletion common.Money      `json:"cost_at_completion"`
+
+	pricedMinutes int64
+}
+
+type UserCost struct {
+	UserID        int64        `json:"user_id"`
+	Name          string       `json:"name,omitempty"`
+	LoggedMinutes int64        `json:"logged_minutes"`
+	Cost          common.Money `json:"cost"`
+}
+
+// CostReport prices the time logged on a project's tasks and forecasts the cost at completion.
+// Remaining work is priced at the task's average cost per minute so far, falling back to the
+// project average for tasks without priced time.
+type CostReport struct {
+	ProjectID        int64           `json:"project_id"`
+	Currency         common.Currency `json:"currency"`
+	Budget           common.Money    `json:"budget"`
+	LoggedMinutes    int64           `json:"logged_minutes"`
+	UnpricedMinutes  int64           `json:"unpriced_minutes"`
+	ActualCost       common.Money    `json:"actual_cost"`
+	RemainingMinutes int64           `json:"remaining_minutes"`
+	RemainingCost    common.Money    `json:"remaining_cost"`
+	CostAtCompletion common.Money    `json:"cost_at_completion"`
+	BudgetVariance   common.Money    `json:"budget_variance"`
+	Tasks            []TaskCost      `json:"tasks"`
+	Users            []UserCost      `json:"users"`
+	UnpricedUserIDs  []int64         `json:"unpriced_user_ids,omitempty"`
+	Rates            []AppliedRate   `json:"rates,omitempty"`
+}
+
+func NewCostReport(project *Project, tasks []*Task, entries []*TimeEntry, users map[int64]*User, card *RateCard, convert MoneyConverter) (*CostReport, error) {
+	if convert == nil {
+		convert = func(m common.Money) (common.Money, error) { return m, nil }
+	}
+
+	budget, err := convert(project.Budget)
+	if err != nil {
+		return nil, err
+	}
+
+	currency := budget.Currency
+	report := &CostReport{
+		ProjectID:  project.ID,
+		Currency:   currency,
+		Budget:     budget,
+		ActualCost: common.ZeroMoney(currency),
+	}
+
+	taskCosts := make(map[int64]*TaskCost, len(tasks))
+	for _, task := range tasks {
+		taskCosts[task.ID] = &TaskCost{
+			TaskID: task.ID,
+			Title:  task.Title,
+			Status: task.Status,
+			Cost:   common.ZeroMoney(currency),
+		}
+	}
+
+	userCosts := make(map[int64]*UserCost)
+	unpricedUsers := make(map[int64]bool)
+	var pricedMinutes int64
+
+	for _, entry := range entries {
+		taskCost, ok := taskCosts[entry.TaskID]
+		if !ok {
+			continue
+		}
+		userCost, ok := userCosts[entry.UserID]
+		if !ok {
+			userCost = &UserCost{UserID: entry.UserID, Cost: common.ZeroMoney(currency)}
+			if user := users[entry.UserID]; user != nil {
+				userCost.Name = user.Name
+			}
+			userCosts[entry.UserID] = userCost
+		}
+
+		report.LoggedMinutes += entry.Minutes
+		taskCost.LoggedMinutes += entry.Minutes
+		userCost.LoggedMinutes += entry.Minutes
+
+		user := users[entry.UserID]
+		if user == nil {
+			report.UnpricedMinutes += entry.Minutes
+			unpricedUsers[entry.UserID] = true
+			continue
+		}
+		rate, ok := card.RateFor(user, entry.SpentOn)
+		if !ok {
+			report.UnpricedMinutes += entry.Minutes
+			unpricedUsers[entry.UserID] = true
+			continue
+		}
+
+		cost, err := convert(rate.CostOf(entry.Minutes))
+		if err != nil {
+			return nil, err
+		}
+		report.ActualCost, _ = report.ActualCost.Add(cost)
+		taskCost.Cost, _ = taskCost.Cost.Add(cost)
+		userCost.Cost, _ = userCost.Cost.Add(cost)
+		taskCost.pricedMinutes += entry.Minutes
+		pricedMinutes += entry.Minutes
+	}
+
+	report.RemainingCost = common.ZeroMoney(currency)
+	for _, task := range tasks {
+		taskCost := taskCosts[task.ID]
+		taskCost.RemainingMinutes = task.GetRemainingMinutes(taskCost.LoggedMinutes)
+		taskCost.RemainingCost = common.ZeroMoney(currency)
+
+		switch {
+		case taskCost.pricedMinutes > 0:
+			taskCost.RemainingCost = taskCost.Cost.MulFrac(taskCost.RemainingMinutes, taskCost.pricedMinutes)
+		case pricedMinutes > 0:
+			taskCost.RemainingCost = report.ActualCost.MulFrac(taskCost.RemainingMinutes, pricedMinutes)
+		}
+		taskCost.CostAtCompletion, _ = taskCost.Cost.Add(taskCost.RemainingCost)
+
+		report.RemainingMinutes += taskCost.RemainingMinutes
+		report.RemainingCost, _ = report.RemainingCost.Add(taskCost.RemainingCost)
+		report.Tasks = append(report.Tasks, *taskCost)
+	}
+
+	report.CostAtCompletion, _ = report.ActualCost.Add(report.RemainingCost)
+	report.BudgetVariance, _ = report.Budget.Sub(report.CostAtCompletion)
+
+	for _, userCost := range userCosts {
+		report.Users = append(report.Users, *userCost)
+	}
+	sort.Slice(report.Users, func(i, j int) bool {
+		return report.Users[i].UserID < report.Users[j].UserID
+	})
+	for userID := range unpricedUsers {
+		report.UnpricedUserIDs = append(report.UnpricedUserIDs, userID)
+	}
+	sort.Slice(report.UnpricedUserIDs, func(i, j int) bool {
+		return report.UnpricedUserIDs[i] < report.UnpricedUserIDs[j]
+	})
+
+	return report, nil
+}
+
+// Business methods
+
+func (r *CostReport) IsForecastOverBudget() bool {
+	return r.BudgetVariance.IsNegative()
+}
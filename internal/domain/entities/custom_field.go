@@ -0,0 +1,313 @@
+package entities
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"task-engine/internal/domain/entities/common"
+	"time"
+)
+
+var customFieldKeyPattern = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// CustomFieldDefinition declares a structured field that tasks of a project can carry
+type CustomFieldDefinition struct {
+	ID        int64                  `json:"id" db:"id"`
+	ProjectID int64                  `json:"project_id" db:"project_id"`
+	Key       string                 `json:"key" db:"key"`
+	Name      string                 `json:"name" db:"name"`
+	Type      common.CustomFieldType `json:"type" db:"field_type"`
+	Options   []string               `json:"options,omitempty" db:"options"`
+	Required  bool                   `json:"required" db:"required"`
+	Position  int                    `json:"position" db:"position"`
+	CreatedAt time.Time              `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time              `json:"updated_at" db:"updated_at"`
+}
+
+func NewCustomFieldDefinition(projectID int64, key, name string, fieldType common.CustomFieldType, options []string, required bool) (*CustomFieldDefinition, error) {
+	definition := &CustomFieldDefinition{
+		ProjectID: projectID,
+		Key:       key,
+		Name:      name,
+		Type:      fieldType,
+		Options:   options,
+		Required:  required,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	if err := definition.Validate(); err != nil {
+		return nil, err
+	}
+
+	return definition, nil
+}
+
+// Validations methods
+
+func (d *CustomFieldDefinition) Validate() error {
+	var keyErr, optionsErr *common.FieldValidationError
+
+	if d.Key != "" && !customFieldKeyPattern.MatchString(d.Key) {
+		keyErr = &common.FieldValidationError{
+			Field:   "key",
+			Message: "field must start with a lowercase letter and contain only lowercase letters, digits and underscores",
+		}
+	}
+
+	if d.IsSelect() {
+		optionsErr = validateOptions(d.Options)
+	} else if len(d.Options) > 0 {
+		optionsErr = &common.FieldValidationError{
+			Field:   "options",
+			Message: "options are only allowed for select fields",
+		}
+	}
+
+	return common.ValidateFields(
+		common.ValidatePositiveInt("project_id", d.ProjectID),
+		common.ValidateRequired("key", d.Key),
+		common.ValidateStringLength("key", d.Key, 50),
+		keyErr,
+		common.ValidateRequired("name", d.Name),
+		common.ValidateStringLength("name", d.Name, 100),
+		common.ValidateCustomFieldType("type", d.Type),
+		optionsErr,
+	)
+}
+
+func validateOptions(options []string) *common.FieldValidationError {
+	if len(options) == 0 {
+		return &common.FieldValidationError{
+			Field:   "options",
+			Message: "select fields need at least one option",
+		}
+	}
+
+	seen := make(map[string]bool, len(options))
+	for _, option := range options {
+		if err := common.ValidateStringLengthRange("options", option, 1, 100); err != nil {
+			return err
+		}
+		if seen[option] {
+			return &common.FieldValidationError{
+				Field:   "options",
+				Message: fmt.Sprintf("option %q is duplicated", option),
+			}
+		}
+		seen[option] = true
+	}
+	return nil
+}
+
+// ValidateValue checks a value against the field type and options
+func (d *CustomFieldDefinition) ValidateValue(value *CustomFieldValue) *common.FieldValidationError {
+	fieldName := "custom_fields." + d.Key
+
+	switch d.Type {
+	case common.CustomFieldTypeText:
+		return common.ValidateStringLength(fieldName, value.Text, 1000)
+	case common.CustomFieldTypeSingleSelect:
+		return d.validateOption(fieldName, value.Text)
+	case common.CustomFieldTypeMultiSelect:
+		for _, option := range value.Options {
+			if err := d.validateOption(fieldName, option); err != nil {
+				return err
+			}
+		}
+	case common.CustomFieldTypeUser:
+		return common.ValidatePositiveInt(fieldName, value.UserID)
+	}
+	return nil
+}
+
+func (d *CustomFieldDefinition) validateOption(fieldName, option string) *common.FieldValidationError {
+	allowed := make([]interface{}, len(d.Options))
+	for i, o := range d.Options {
+		allowed[i] = o
+	}
+	return common.ValidateEnum(fieldName, option, allowed...)
+}
+
+// Modification methods
+
+// Update changes the mutable attributes of the definition. Key and type are fixed once created
+// because stored values and saved filters refer to them.
+func (d *CustomFieldDefinition) Update(name string, options []string, required bool, position int) error {
+	updated := *d
+	updated.Name = name
+	updated.Options = options
+	updated.Required = required
+	updated.Position = position
+
+	if err := updated.Validate(); err != nil {
+		return err
+	}
+
+	*d = updated
+	d.UpdatedAt = time.Now()
+	return nil
+}
+
+// Business methods
+
+func (d *CustomFieldDefinition) IsSelect() bool {
+	return d.Type == common.CustomFieldTypeSingleSelect || d.Type == common.CustomFieldTypeMultiSelect
+}
+
+// ParseValue converts a decoded JSON value into a typed value for this field.
+// A nil raw value yields a nil value, meaning "unset".
+func (d *CustomFieldDefinition) ParseValue(raw interface{}) (*CustomFieldValue, *common.FieldValidationError) {
+	if raw == nil {
+		return nil, nil
+	}
+
+	value := &CustomFieldValue{FieldID: d.ID, Key: d.Key, Type: d.Type}
+	invalid := &common.FieldValidationError{
+		Field:   "custom_fields." + d.Key,
+		Message: fmt.Sprintf("field must be a valid %s value", d.Type),
+	}
+
+	switch d.Type {
+	case common.CustomFieldTypeNumber:
+		number, ok := raw.(float64)
+		if !ok {
+			return nil, invalid
+		}
+		value.Number = number
+	case common.CustomFieldTypeUser:
+		number, ok := raw.(float64)
+		if !ok || number != float64(int64(number)) {
+			return nil, invalid
+		}
+		value.UserID = int64(number)
+	case common.CustomFieldTypeMultiSelect:
+		items, ok := raw.([]interface{})
+		if !ok {
+			return nil, invalid
+		}
+		for _, item := range items {
+			option, ok := item.(string)
+			if !ok {
+				return nil, invalid
+			}
+			value.Options = append(value.Options, option)
+		}
+	default:
+		text, ok := raw.(string)
+		if !ok {
+			return nil, invalid
+		}
+		return d.ParseText(text)
+	}
+
+	if err := d.ValidateValue(value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// ParseText converts a textual representation (e.g. a query string parameter) into a typed value
+func (d *CustomFieldDefinition) ParseText(text string) (*CustomFieldValue, *common.FieldValidationError) {
+	value := &CustomFieldValue{FieldID: d.ID, Key: d.Key, Type: d.Type}
+	invalid := &common.FieldValidationError{
+		Field:   "custom_fields." + d.Key,
+		Message: fmt.Sprintf("field must be a valid %s value", d.Type),
+	}
+
+	switch d.Type {
+	case common.CustomFieldTypeNumber:
+		number, err := strconv.ParseFloat(text, 64)
+		if err != nil {
+			return nil, invalid
+		}
+		value.Number = number
+	case common.CustomFieldTypeDate:
+		date, err := time.Parse("2006-01-02", text)
+		if err != nil {
+			return nil, invalid
+		}
+		value.Date = date
+	case common.CustomFieldTypeUser:
+		userID, err := strconv.ParseInt(text, 10, 64)
+		if err != nil {
+			return nil, invalid
+		}
+		value.UserID = userID
+	case common.CustomFieldTypeMultiSelect:
+		for _, option := range strings.Split(text, ",") {
+			value.Options = append(value.Options, strings.TrimSpace(option))
+		}
+	default:
+		value.Text = text
+	}
+
+	if err := d.ValidateValue(value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// CustomFieldValue is the value a task holds for one custom field.
+// Only the member matching Type is meaningful.
+type CustomFieldValue struct {
+	TaskID  int64
+	FieldID int64
+	Key     string
+	Type    common.CustomFieldType
+	Text    string
+	Number  float64
+	Date    time.Time
+	Options []string
+	UserID  int64
+}
+
+// Value returns the value as a plain Go value suitable for JSON encoding
+func (v *CustomFieldValue) Value() interface{} {
+	switch v.Type {
+	case common.CustomFieldTypeNumber:
+		return v.Number
+	case common.CustomFieldTypeDate:
+		return v.Date.Format("2006-01-02")
+	case common.CustomFieldTypeMultiSelect:
+		return v.Options
+	case common.CustomFieldTypeUser:
+		return v.UserID
+	}
+	return v.Text
+}
+
+func (v *CustomFieldValue) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		FieldID int64                  `json:"field_id"`
+		Key     string                 `json:"key"`
+		Type    common.CustomFieldType `json:"type"`
+		Value   interface{}            `json:"value"`
+	}{v.FieldID, v.Key, v.Type, v.Value()})
+}
+
+// ValidateCustomFieldValues checks that every required field is set and every value is valid
+func ValidateCustomFieldValues(definitions []*CustomFieldDefinition, values []*CustomFieldValue) error {
+	byField := make(map[int64]*CustomFieldValue, len(values))
+	for _, value := range values {
+		byField[value.FieldID] = value
+	}
+
+	var validations []*common.FieldValidationError
+	for _, definition := range definitions {
+		value, ok := byField[definition.ID]
+		if !ok {
+			if definition.Required {
+				validations = append(validations, &common.FieldValidationError{
+					Field:   "custom_fields." + definition.Key,
+					Message: "field is required",
+				})
+			}
+			continue
+		}
+		validations = append(validations, definition.ValidateValue(value))
+	}
+	return common.ValidateFields(validations...)
+}
@@ -0,0 +1,165 @@
+package entities
+
+import (
+	"task-engine/internal/domain/entities/common"
+	"time"
+)
+
+// DigestSchedule replaces a user's per-event emails with a daily or weekly summary sent at
+// Hour in the user's timezone. Weekday (0 is Sunday) only applies to weekly digests.
+// NextRunAt is kept in UTC so due schedules can be found without knowing their timezone.
+type DigestSchedule struct {
+	UserID     int64                  `json:"-" db:"user_id"`
+	Frequency  common.DigestFrequency `json:"frequency" db:"frequency"`
+	Hour       int                    `json:"hour" db:"hour"`
+	Weekday    time.Weekday           `json:"weekday" db:"weekday"`
+	NextRunAt  time.Time              `json:"next_run_at" db:"next_run_at"`
+	LastSentAt time.Time              `json:"last_sent_at,omitempty" db:"last_sent_at"`
+	CreatedAt  time.Time              `json:"created_at" db:"created_at"`
+	UpdatedAt  time.Time              `json:"updated_at" db:"updated_at"`
+}
+
+func NewDigestSchedule(userID int64, frequency common.DigestFrequency, hour int, weekday time.Weekday) (*DigestSchedule, error) {
+	schedule := &DigestSchedule{
+		UserID:    userID,
+		Frequency: frequency,
+		Hour:      hour,
+		Weekday:   weekday,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	if err := schedule.Validate(); err != nil {
+		return nil, err
+	}
+
+	return schedule, nil
+}
+
+// Validations methods
+
+func (s *DigestSchedule) Validate() error {
+	return common.ValidateFields(
+		common.ValidatePositiveInt("user_id", s.UserID),
+		common.ValidateDigestFrequency("frequency", s.Frequency),
+		common.ValidateIntRange("hour", int64(s.Hour), 0, 23),
+		common.ValidateIntRange("weekday", int64(s.Weekday), int64(time.Sunday), int64(time.Saturday)),
+	)
+}
+
+// Business methods
+
+// Period is how far back a digest looks when it has never been sent
+func (s *DigestSchedule) Period() time.Duration {
+	if s.Frequency == common.DigestFrequencyWeekly {
+		return 7 * 24 * time.Hour
+	}
+	return 24 * time.Hour
+}
+
+// NextRun returns the first scheduled time strictly after the given time. Days are added
+// on the local calendar, so the digest keeps its local hour across DST changes.
+func (s *DigestSchedule) NextRun(after time.Time, location *time.Location) time.Time {
+	local := after.In(location)
+	next := time.Date(local.Year(), local.Month(), local.Day(), s.Hour, 0, 0, 0, location)
+
+	if s.Frequency == common.DigestFrequencyWeekly {
+		next = next.AddDate(0, 0, (int(s.Weekday)-int(next.Weekday())+7)%7)
+		if !next.After(after) {
+			next = next.AddDate(0, 0, 7)
+		}
+		return next
+	}
+
+	if !next.After(after) {
+		next = next.AddDate(0, 0, 1)
+	}
+	return next
+}
+
+func (s *DigestSchedule) IsDue(now time.Time) bool {
+	return !s.NextRunAt.After(now)
+}
+
+// Modification methods
+
+func (s *DigestSchedule) Update(frequency common.DigestFrequency, hour int, weekday time.Weekday) error {
+	s.Frequency = frequency
+	s.Hour = hour
+	s.Weekday = weekday
+	if err := s.Validate(); err != nil {
+		return err
+	}
+
+	s.UpdatedAt = time.Now()
+	return nil
+}
+
+// Reschedule sets the next run after now, used when the schedule or the timezone changes
+func (s *DigestSchedule) Reschedule(now time.Time, location *time.Location) {
+	s.NextRunAt = s.NextRun(now, location).UTC()
+	s.UpdatedAt = time.Now()
+}
+
+// MarkSent records a digest sent at now and schedules the next one
+func (s *DigestSchedule) MarkSent(now time.Time, location *time.Location) {
+	s.LastSentAt = now.UTC()
+	s.Reschedule(now, location)
+}
+
+// Digest is the content of one digest email. Notifications are the pending ones it
+// replaces, split by what they are about; tasks are the recipient's open assigned work.
+type Digest struct {
+	User        *User
+	Frequency   common.DigestFrequency
+	PeriodStart time.Time
+	PeriodEnd   time.Time
+
+	DueSoon  []*Task
+	Overdue  []*Task
+	Assigned []*Notification
+	Comments []*Notification
+	Other    []*Notification
+}
+
+// NewDigest sorts the pending notifications and assigned tasks of a user into digest
+// sections. Tasks due before dueSoonBefore are due soon unless already overdue, and
+// task.assigned notifications count as assigned work only for tasks in assignedTaskIDs.
+func NewDigest(user *User, schedule *DigestSchedule, now time.Time, notifications []*Notification, tasks []*Task, assignedTaskIDs map[int64]bool, dueSoonBefore time.Time) *Digest {
+	digest := &Digest{
+		User:        user,
+		Frequency:   schedule.Frequency,
+		PeriodStart: schedule.LastSentAt,
+		PeriodEnd:   now,
+	}
+	if digest.PeriodStart.IsZero() {
+		digest.PeriodStart = now.Add(-schedule.Period())
+	}
+
+	for _, task := range tasks {
+		switch {
+		case task.IsOverdue():
+			digest.Overdue = append(digest.Overdue, task)
+		case !task.IsClosed() && !task.DueDate.IsZero() && task.DueDate.Before(dueSoonBefore):
+			digest.DueSoon = append(digest.DueSoon, task)
+		}
+	}
+
+	for _, notification := range notifications {
+		switch {
+		case notification.EventType == common.EventTypeTaskAssigned && assignedTaskIDs[notification.TaskID]:
+			digest.Assigned = append(digest.Assigned, notification)
+		case notification.EventType == common.EventTypeCommentAdded || notification.EventType == common.EventTypeCommentMentioned:
+			digest.Comments = append(digest.Comments, notification)
+		default:
+			digest.Other = append(digest.Other, notification)
+		}
+	}
+	return digest
+}
+
+// IsEmpty reports whether there is nothing worth sending
+func (d *Digest) IsEmpty() bool {
+	return len(d.DueSoon) == 0 && len(d.Overdue) == 0 &&
+		len(d.Assigned) == 0 && len(d.Comments) == 0 && len(d.Other) == 0
+}
@@ -0,0 +1,10 @@
+package entities
+
+// ForbiddenError is returned when the acting user is not allowed to perform an operation
+type ForbiddenError struct {
+	Reason string
+}
+
+func (e *ForbiddenError) Error() string {
+	return e.Reason
+}
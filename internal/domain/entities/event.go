@@ -0,0 +1,110 @@
+package entities
+
+import (
+	"encoding/json"
+	"task-engine/internal/domain/entities/common"
+	"time"
+)
+
+// Event records something that happened to a project or task.
+// Events are persisted in the events table and fanned out to subscribers.
+// ActorID is the user who caused the event, zero for system events.
+type Event struct {
+	ID        int64            `json:"id" db:"id"`
+	ProjectID int64            `json:"project_id,omitempty" db:"project_id"`
+	TaskID    int64            `json:"task_id,omitempty" db:"task_id"`
+	ActorID   int64            `json:"actor_id,omitempty" db:"actor_id"`
+	Type      common.EventType `json:"event_type" db:"event_type"`
+	Payload   json.RawMessage  `json:"payload,omitempty" db:"payload"`
+	CreatedAt time.Time        `json:"created_at" db:"created_at"`
+}
+
+func NewEvent(eventType common.EventType, projectID, taskID int64, payload interface{}) (*Event, error) {
+	event := &Event{
+		ProjectID: projectID,
+		TaskID:    taskID,
+		Type:      eventType,
+		CreatedAt: time.Now(),
+	}
+
+	if payload != nil {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return nil, err
+		}
+		event.Payload = data
+	}
+
+	if err := event.Validate(); err != nil {
+		return nil, err
+	}
+
+	return event, nil
+}
+
+// Validations methods
+
+func (e *Event) Validate() error {
+	var referenceErr *common.FieldValidationError
+	if e.ProjectID <= 0 && e.TaskID <= 0 {
+		referenceErr = &common.FieldValidationError{
+			Field:   "project_id",
+			Message: "event must reference a project or a task",
+		}
+	}
+
+	return common.ValidateFields(
+		common.ValidateRequired("event_type", string(e.Type)),
+		common.ValidateStringLength("event_type", string(e.Type), 100),
+		referenceErr,
+	)
+}
+
+// DecodePayload unmarshals the event payload into v
+func (e *Event) DecodePayload(v interface{}) error {
+	if len(e.Payload) == 0 {
+		return nil
+	}
+	return json.Unmarshal(e.Payload, v)
+}
+
+// Payloads
+
+type BudgetBurnAlertPayload struct {
+	Threshold   int          `json:"threshold"`
+	Budget      common.Money `json:"budget"`
+	Actual      common.Money `json:"actual"`
+	Remaining   common.Money `json:"remaining"`
+	BurnPercent float64      `json:"burn_percent"`
+	ExpenseID   int64        `json:"expense_id,omitempty"`
+}
+
+type TaskCreatedPayload struct {
+	Title string `json:"title"`
+}
+
+type TaskAssignedPayload struct {
+	AssigneeID int64 `json:"assignee_id"`
+}
+
+type TaskUnassignedPayload struct {
+	AssigneeID int64 `json:"assignee_id"`
+}
+
+// CommentAddedPayload lists the mentioned users, who are told through comment.mentioned instead
+type CommentAddedPayload struct {
+	CommentID        int64   `json:"comment_id"`
+	MentionedUserIDs []int64 `json:"mentioned_user_ids,omitempty"`
+}
+
+type CommentMentionedPayload struct {
+	CommentID int64   `json:"comment_id"`
+	UserIDs   []int64 `json:"user_ids"`
+}
+
+type TaskStatusChangedPayload struct {
+	From     common.TaskStatus     `json:"from"`
+	To       common.TaskStatus     `json:"to"`
+	Category common.StatusCategory `json:"category"`
+	UserID   int64                 `json:"user_id,omitempty"`
+}
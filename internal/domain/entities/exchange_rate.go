@@ -0,0 +1,91 @@
+package entities
+
+import (
+	"fmt"
+	"math/big"
+	"task-engine/internal/domain/entities/common"
+	"time"
+)
+
+// ExchangeRate states how many units of QuoteCurrency one unit of BaseCurrency was worth on RateDate.
+type ExchangeRate struct {
+	ID            int64           `json:"id" db:"id"`
+	BaseCurrency  common.Currency `json:"base_currency" db:"base_currency"`
+	QuoteCurrency common.Currency `json:"quote_currency" db:"quote_currency"`
+	Rate          string          `json:"rate" db:"rate"`
+	RateDate      time.Time       `json:"rate_date" db:"rate_date"`
+	CreatedAt     time.Time       `json:"created_at" db:"created_at"`
+}
+
+func NewExchangeRate(base, quote common.Currency, rate string, rateDate time.Time) (*ExchangeRate, error) {
+	exchangeRate := &ExchangeRate{
+		BaseCurrency:  base,
+		QuoteCurrency: quote,
+		Rate:          rate,
+		RateDate:      rateDate,
+		CreatedAt:     time.Now(),
+	}
+
+	if err := exchangeRate.Validate(); err != nil {
+		return nil, err
+	}
+
+	return exchangeRate, nil
+}
+
+// Validations methods
+
+func (r *ExchangeRate) Validate() error {
+	var rateErr, pairErr, dateErr *common.FieldValidationError
+
+	if ratio, ok := new(big.Rat).SetString(r.Rate); !ok || ratio.Sign() <= 0 {
+		rateErr = &common.FieldValidationError{
+			Field:   "rate",
+			Message: "field must be a positive decimal number",
+		}
+	}
+	if r.BaseCurrency == r.QuoteCurrency {
+		pairErr = &common.FieldValidationError{
+			Field:   "quote_currency",
+			Message: "quote currency must differ from base currency",
+		}
+	}
+	if r.RateDate.IsZero() {
+		dateErr = &common.FieldValidationError{
+			Field:   "rate_date",
+			Message: "field is required",
+		}
+	}
+
+	return common.ValidateFields(
+		common.ValidateCurrency("base_currency", r.BaseCurrency),
+		common.ValidateCurrency("quote_currency", r.QuoteCurrency),
+		rateErr,
+		pairErr,
+		dateErr,
+	)
+}
+
+// Business methods
+
+// Ratio returns the rate as an exact rational number
+func (r *ExchangeRate) Ratio() *big.Rat {
+	ratio, ok := new(big.Rat).SetString(r.Rate)
+	if !ok {
+		return new(big.Rat)
+	}
+	return ratio
+}
+
+// Errors
+
+// MissingExchangeRateError is returned when no rate is known for a currency pair on or before a date
+type MissingExchangeRateError struct {
+	From common.Currency
+	To   common.Currency
+	AsOf time.Time
+}
+
+func (e *MissingExchangeRateError) Error() string {
+	return fmt.Sprintf("no exchange rate from %s to %s on or before %s", e.From, e.To, e.AsOf.Format("2006-01-02"))
+}
@@ -0,0 +1,66 @@
+package entities
+
+import (
+	"task-engine/internal/domain/entities/common"
+	"time"
+)
+
+// Expense is a single entry of a project's budget ledger, either planned or actually spent.
+type Expense struct {
+	ID          int64                  `json:"id" db:"id"`
+	ProjectID   int64                  `json:"project_id" db:"project_id"`
+	Kind        common.ExpenseKind     `json:"kind" db:"kind"`
+	Category    common.ExpenseCategory `json:"category" db:"category"`
+	Description string                 `json:"description" db:"description"`
+	Amount      common.Money           `json:"amount" db:"amount"`
+	IncurredOn  time.Time              `json:"incurred_on" db:"incurred_on"`
+	CreatedBy   int64                  `json:"created_by,omitempty" db:"created_by"`
+	CreatedAt   time.Time              `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time              `json:"updated_at" db:"updated_at"`
+}
+
+func NewExpense(projectID int64, kind common.ExpenseKind, category common.ExpenseCategory, description string, amount common.Money, incurredOn time.Time, createdBy int64) (*Expense, error) {
+	if incurredOn.IsZero() {
+		incurredOn = time.Now()
+	}
+
+	expense := &Expense{
+		ProjectID:   projectID,
+		Kind:        kind,
+		Category:    category,
+		Description: description,
+		Amount:      amount,
+		IncurredOn:  incurredOn,
+		CreatedBy:   createdBy,
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}
+
+	if err := expense.Validate(); err != nil {
+		return nil, err
+	}
+
+	return expense, nil
+}
+
+// Validations methods
+
+func (e *Expense) Validate() error {
+	return common.ValidateFields(
+		common.ValidatePositiveInt("project_id", e.ProjectID),
+		common.ValidateExpenseKind("kind", e.Kind),
+		common.ValidateExpenseCategory("category", e.Category),
+		common.ValidateStringLength("description", e.Description, 500),
+		common.ValidatePositiveMoney("amount", e.Amount),
+	)
+}
+
+// Business methods
+
+func (e *Expense) IsPlanned() bool {
+	return e.Kind == common.ExpenseKindPlanned
+}
+
+func (e *Expense) IsActual() bool {
+	return e.Kind == common.ExpenseKindActual
+}
@@ -0,0 +1,82 @@
+package entities
+
+import (
+	"strings"
+	"task-engine/internal/domain/entities/common"
+	"time"
+)
+
+// ExternalProject is a Trello board or a Jira project read from an export file, ids are the
+// ones of the tool it comes from
+type ExternalProject struct {
+	Source      common.ImportSource
+	ID          string
+	Name        string
+	Key         string
+	Description string
+	Tasks       []*ExternalTask
+}
+
+// ExternalTask is a Trello card or a Jira issue. Status is the name of its Trello list or
+// Jira status, Priority is empty when the tool has none. Labels become tags and Members
+// holds the usernames of the people working on it.
+type ExternalTask struct {
+	ID          string
+	Title       string
+	Description string
+	Status      string
+	Priority    common.TaskPriority
+	DueDate     time.Time
+	Labels      []string
+	Members     []string
+	Comments    []*ExternalComment
+	CreatedAt   time.Time
+}
+
+// ExternalComment is a comment on a card or an issue, Author is the username of who wrote it
+type ExternalComment struct {
+	ID        string
+	Author    string
+	Content   string
+	CreatedAt time.Time
+}
+
+// ImportMapping links a record of another tool to the entity it was imported as, so that
+// importing the same export again updates that entity instead of creating a copy
+type ImportMapping struct {
+	Source     common.ImportSource     `json:"source" db:"source"`
+	Kind       common.ImportEntityKind `json:"kind" db:"kind"`
+	ExternalID string                  `json:"external_id" db:"external_id"`
+	EntityID   int64                   `json:"entity_id" db:"entity_id"`
+	CreatedAt  time.Time               `json:"created_at" db:"created_at"`
+	UpdatedAt  time.Time               `json:"updated_at" db:"updated_at"`
+}
+
+func NewImportMapping(source common.ImportSource, kind common.ImportEntityKind, externalID string, entityID int64) (*ImportMapping, error) {
+	mapping := &ImportMapping{
+		Source:     source,
+		Kind:       kind,
+		ExternalID: strings.TrimSpace(externalID),
+		EntityID:   entityID,
+		CreatedAt:  time.Now(),
+		UpdatedAt:  time.Now(),
+	}
+
+	if err := mapping.Validate(); err != nil {
+		return nil, err
+	}
+
+	return mapping, nil
+}
+
+// Validations methods
+
+func (m *ImportMapping) Validate() error {
+	return common.ValidateFields(
+		common.ValidateImportSource("source", m.Source),
+		common.ValidateImportEntityKind("kind", m.Kind),
+		common.ValidateRequired("external_id", m.ExternalID),
+		common.ValidateStringLength("external_id", m.ExternalID, 255),
+		common.ValidatePositiveInt("entity_id", m.EntityID),
+	)
+}
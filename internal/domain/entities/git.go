@@ -0,0 +1,214 @@
+package entities
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"task-engine/internal/domain/entities/common"
+	"time"
+)
+
+// taskReferencePattern finds a keyword followed by one or more task references, e.g.
+// "fixes #123", "refs PROJ-45" or "closes #1, #2 and OPS-7". Keywords are matched in any
+// case, project keys only in upper case so "see utf-8" is not a reference.
+var (
+	taskReferencePattern = regexp.MustCompile(`(?i:\b(close[sd]?|fix(?:e[sd])?|resolve[sd]?|refs?|references|see))\b:?\s+` +
+		`((?:#\d+|[A-Z][A-Z0-9]{1,9}-\d+)(?:\s*(?:,|(?i:and))\s*(?:#\d+|[A-Z][A-Z0-9]{1,9}-\d+))*)`)
+	taskReferenceItemPattern = regexp.MustCompile(`#(\d+)|([A-Z][A-Z0-9]{1,9})-(\d+)`)
+)
+
+// TaskReference is a task named in a commit message. Key is empty for "#123" references,
+// which name a task by id in any project; "PROJ-45" names task 45 of the project with key PROJ.
+// Closes is set when a closing keyword (fixes, closes, resolves) introduced it.
+type TaskReference struct {
+	Key    string `json:"key,omitempty"`
+	TaskID int64  `json:"task_id"`
+	Closes bool   `json:"closes"`
+}
+
+func (r TaskReference) String() string {
+	if r.Key == "" {
+		return fmt.Sprintf("#%d", r.TaskID)
+	}
+	return fmt.Sprintf("%s-%d", r.Key, r.TaskID)
+}
+
+// ParseTaskReferences returns the tasks a commit message references, in order of first
+// appearance. A task referenced several times closes if any of its references does.
+func ParseTaskReferences(message string) []TaskReference {
+	var references []TaskReference
+	seen := make(map[string]int)
+
+	for _, match := range taskReferencePattern.FindAllStringSubmatch(message, -1) {
+		keyword := strings.ToLower(match[1])
+		closes := !strings.HasPrefix(keyword, "ref") && keyword != "see"
+
+		for _, item := range taskReferenceItemPattern.FindAllStringSubmatch(match[2], -1) {
+			reference := TaskReference{Key: item[2], Closes: closes}
+			number := item[1]
+			if reference.Key != "" {
+				number = item[3]
+			}
+			id, err := strconv.ParseInt(number, 10, 64)
+			if err != nil || id <= 0 {
+				continue
+			}
+			reference.TaskID = id
+
+			if i, ok := seen[reference.String()]; ok {
+				references[i].Closes = references[i].Closes || closes
+				continue
+			}
+			seen[reference.String()] = len(references)
+			references = append(references, reference)
+		}
+	}
+	return references
+}
+
+// GitCommit is a commit reported by a push or read from a repository's history
+type GitCommit struct {
+	SHA         string    `json:"sha"`
+	Message     string    `json:"message"`
+	AuthorName  string    `json:"author_name,omitempty"`
+	AuthorEmail string    `json:"author_email,omitempty"`
+	URL         string    `json:"url,omitempty"`
+	CommittedAt time.Time `json:"committed_at,omitempty"`
+}
+
+func (c *GitCommit) ShortSHA() string {
+	if len(c.SHA) > 7 {
+		return c.SHA[:7]
+	}
+	return c.SHA
+}
+
+// Subject returns the first line of the message
+func (c *GitCommit) Subject() string {
+	subject, _, _ := strings.Cut(strings.TrimSpace(c.Message), "\n")
+	return strings.TrimSpace(subject)
+}
+
+// GitPush is a set of commits that landed on a branch. Branch is empty when the commits
+// are not on a branch, e.g. for tag pushes, such commits never close tasks.
+type GitPush struct {
+	Repository string       `json:"repository,omitempty"`
+	Branch     string       `json:"branch,omitempty"`
+	Commits    []*GitCommit `json:"commits"`
+}
+
+func NewGitPush(repository, ref string, commits []*GitCommit) (*GitPush, error) {
+	push := &GitPush{
+		Repository: repository,
+		Branch:     BranchFromRef(ref),
+		Commits:    commits,
+	}
+
+	if err := push.Validate(); err != nil {
+		return nil, err
+	}
+
+	return push, nil
+}
+
+// Validations methods
+
+func (p *GitPush) Validate() error {
+	errs := []*common.FieldValidationError{
+		common.ValidateStringLength("repository", p.Repository, 255),
+		common.ValidateStringLength("branch", p.Branch, 255),
+	}
+	for i, commit := range p.Commits {
+		errs = append(errs,
+			common.ValidateRequired(fmt.Sprintf("commits[%d].id", i), commit.SHA),
+			common.ValidateStringLength(fmt.Sprintf("commits[%d].id", i), commit.SHA, 64),
+		)
+	}
+	return common.ValidateFields(errs...)
+}
+
+// BranchFromRef returns the branch name of a git ref such as refs/heads/main. Other refs,
+// e.g. tags, have no branch. A bare name is taken as a branch.
+func BranchFromRef(ref string) string {
+	if branch, ok := strings.CutPrefix(ref, "refs/heads/"); ok {
+		return branch
+	}
+	if strings.HasPrefix(ref, "refs/") {
+		return ""
+	}
+	return ref
+}
+
+// TaskCommit links a commit to a task it references. A task is linked to a commit once,
+// whichever branches the commit is later pushed to; Branch is where it was first seen.
+type TaskCommit struct {
+	ID          int64     `json:"id" db:"id"`
+	TaskID      int64     `json:"task_id" db:"task_id"`
+	SHA         string    `json:"sha" db:"sha"`
+	Repository  string    `json:"repository,omitempty" db:"repository"`
+	Branch      string    `json:"branch,omitempty" db:"branch"`
+	Message     string    `json:"message" db:"message"`
+	AuthorName  string    `json:"author_name,omitempty" db:"author_name"`
+	AuthorEmail string    `json:"author_email,omitempty" db:"author_email"`
+	URL         string    `json:"url,omitempty" db:"url"`
+	CommittedAt time.Time `json:"committed_at,omitempty" db:"committed_at"`
+	CommentID   int64     `json:"comment_id,omitempty" db:"comment_id"`
+	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+}
+
+func NewTaskCommit(taskID int64, push *GitPush, commit *GitCommit) *TaskCommit {
+	return &TaskCommit{
+		TaskID:      taskID,
+		SHA:         commit.SHA,
+		Repository:  push.Repository,
+		Branch:      push.Branch,
+		Message:     commit.Message,
+		AuthorName:  commit.AuthorName,
+		AuthorEmail: commit.AuthorEmail,
+		URL:         commit.URL,
+		CommittedAt: commit.CommittedAt,
+		CreatedAt:   time.Now(),
+	}
+}
+
+// CommentContent is the comment added to the task when the commit is linked
+func (c *TaskCommit) CommentContent() string {
+	commit := GitCommit{SHA: c.SHA, Message: c.Message}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Commit %s", commit.ShortSHA())
+	if c.AuthorName != "" {
+		fmt.Fprintf(&b, " by %s", c.AuthorName)
+	}
+	if c.Repository != "" {
+		fmt.Fprintf(&b, " in %s", c.Repository)
+	}
+	if c.Branch != "" {
+		fmt.Fprintf(&b, " on %s", c.Branch)
+	}
+	fmt.Fprintf(&b, ": %s", commit.Subject())
+	if c.URL != "" {
+		fmt.Fprintf(&b, "\n\n%s", c.URL)
+	}
+	return b.String()
+}
+
+// GitPushResult tells what processing a push did
+type GitPushResult struct {
+	Commits   int                    `json:"commits"`
+	Linked    []*TaskCommit          `json:"linked"`
+	Completed []int64                `json:"completed_task_ids"`
+	Skipped   []*SkippedGitReference `json:"skipped"`
+}
+
+// SkippedGitReference is a task reference that could not be linked or closed
+type SkippedGitReference struct {
+	SHA       string `json:"sha"`
+	Reference string `json:"reference"`
+	Reason    string `json:"reason"`
+}
+
+func (r *GitPushResult) Skip(commit *GitCommit, reference TaskReference, reason string) {
+	r.Skipped = append(r.Skipped, &SkippedGitReference{SHA: commit.SHA, Reference: reference.String(), Reason: reason})
+}
@@ -0,0 +1,104 @@
+package entities
+
+import (
+	"sort"
+	"task-engine/internal/domain/entities/common"
+	"time"
+)
+
+// HourlyRate is the cost of one hour of work, set either for a specific user or for every user of a role.
+// A rate applies from EffectiveFrom until a newer rate for the same user or role takes effect.
+type HourlyRate struct {
+	ID            int64           `json:"id" db:"id"`
+	UserID        int64           `json:"user_id,omitempty" db:"user_id"`
+	Role          common.UserRole `json:"role,omitempty" db:"role"`
+	Rate          common.Money    `json:"rate" db:"rate"`
+	EffectiveFrom time.Time       `json:"effective_from" db:"effective_from"`
+	CreatedAt     time.Time       `json:"created_at" db:"created_at"`
+}
+
+func NewUserHourlyRate(userID int64, rate common.Money, effectiveFrom time.Time) (*HourlyRate, error) {
+	return newHourlyRate(&HourlyRate{UserID: userID, Rate: rate, EffectiveFrom: effectiveFrom})
+}
+
+func NewRoleHourlyRate(role common.UserRole, rate common.Money, effectiveFrom time.Time) (*HourlyRate, error) {
+	return newHourlyRate(&HourlyRate{Role: role, Rate: rate, EffectiveFrom: effectiveFrom})
+}
+
+func newHourlyRate(rate *HourlyRate) (*HourlyRate, error) {
+	rate.CreatedAt = time.Now()
+	if err := rate.Validate(); err != nil {
+		return nil, err
+	}
+	return rate, nil
+}
+
+// Validations methods
+
+func (r *HourlyRate) Validate() error {
+	var subjectErr, roleErr, dateErr *common.FieldValidationError
+
+	if (r.UserID == 0) == (r.Role == "") {
+		subjectErr = &common.FieldValidationError{
+			Field:   "user_id",
+			Message: "exactly one of user_id or role must be set",
+		}
+	}
+	if r.Role != "" {
+		roleErr = common.ValidateUserRole("role", r.Role)
+	}
+	if r.EffectiveFrom.IsZero() {
+		dateErr = &common.FieldValidationError{
+			Field:   "effective_from",
+			Message: "field is required",
+		}
+	}
+
+	return common.ValidateFields(
+		subjectErr,
+		roleErr,
+		common.ValidatePositiveMoney("rate", r.Rate),
+		dateErr,
+	)
+}
+
+// Business methods
+
+func (r *HourlyRate) IsUserRate() bool {
+	return r.UserID != 0
+}
+
+// CostOf prices the given number of minutes at this rate
+func (r *HourlyRate) CostOf(minutes int64) common.Money {
+	return r.Rate.MulFrac(minutes, 60)
+}
+
+// RateCard resolves the hourly rate applicable to a user on a given day
+type RateCard struct {
+	rates []*HourlyRate
+}
+
+func NewRateCard(rates []*HourlyRate) *RateCard {
+	sorted := append([]*HourlyRate(nil), rates...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].EffectiveFrom.After(sorted[j].EffectiveFrom)
+	})
+	return &RateCard{rates: sorted}
+}
+
+// RateFor returns the newest rate effective on day, preferring the user's own rate over the role rate
+func (c *RateCard) RateFor(user *User, day time.Time) (*HourlyRate, bool) {
+	var roleRate *HourlyRate
+	for _, rate := range c.rates {
+		if rate.EffectiveFrom.After(day) {
+			continue
+		}
+		if rate.UserID == user.ID {
+			return rate, true
+		}
+		if roleRate == nil && rate.Role != "" && rate.Role == user.Role {
+			roleRate = rate
+		}
+	}
+	return roleRate, roleRate != nil
+}
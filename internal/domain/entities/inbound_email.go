@@ -0,0 +1,142 @@
+package entities
+
+import (
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	maxEmailTaskTitle   = 255
+	maxEmailCommentText = 10000
+)
+
+var (
+	// replySubjectPrefix matches the reply and forward markers mail clients add to subjects
+	replySubjectPrefix = regexp.MustCompile(`(?i)^\s*(re|fwd?|aw|wg|sv|vs)\s*(\[\d+\])?\s*:\s*`)
+	// quoteHeaderPattern matches the line mail clients put above the quoted message of a reply
+	quoteHeaderPattern = regexp.MustCompile(`(?i)^(on\s.+wrote:|-+\s*original message\s*-+|_{20,})\s*$`)
+)
+
+// InboundEmail is a parsed email received for ingestion. MessageID, InReplyTo and
+// References hold message ids without angle brackets. Recipients lists every address the
+// message was sent to, lowercased. Source identifies the message in its mailbox.
+type InboundEmail struct {
+	Source      string
+	MessageID   string
+	InReplyTo   string
+	References  []string
+	From        string
+	FromName    string
+	Recipients  []string
+	Subject     string
+	Text        string
+	Attachments []*InboundAttachment
+	Date        time.Time
+}
+
+// InboundAttachment is a file attached to an inbound email
+type InboundAttachment struct {
+	Filename    string
+	ContentType string
+	Content     []byte
+}
+
+// Business methods
+
+// ThreadIDs returns the ids of the messages the email replies to, closest first
+func (e *InboundEmail) ThreadIDs() []string {
+	var ids []string
+	if e.InReplyTo != "" {
+		ids = append(ids, e.InReplyTo)
+	}
+	for i := len(e.References) - 1; i >= 0; i-- {
+		if e.References[i] != e.InReplyTo {
+			ids = append(ids, e.References[i])
+		}
+	}
+	return ids
+}
+
+// TaskTitle returns the subject without reply and forward markers, fitting a task title
+func (e *InboundEmail) TaskTitle() string {
+	subject := strings.Join(strings.Fields(e.Subject), " ")
+	for {
+		stripped := replySubjectPrefix.ReplaceAllString(subject, "")
+		if stripped == subject {
+			break
+		}
+		subject = stripped
+	}
+	if subject == "" {
+		subject = "(no subject)"
+	}
+	return truncateBytes(subject, maxEmailTaskTitle)
+}
+
+// ReplyText returns the text of a reply without the message it quotes. Falls back to the
+// sender and attachment names when nothing is left, as comments cannot be empty.
+func (e *InboundEmail) ReplyText() string {
+	var kept []string
+	for _, line := range strings.Split(e.Text, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if quoteHeaderPattern.MatchString(trimmed) {
+			break
+		}
+		if strings.HasPrefix(trimmed, ">") {
+			continue
+		}
+		kept = append(kept, strings.TrimRight(line, " \t\r"))
+	}
+	text := strings.TrimSpace(strings.Join(kept, "\n"))
+
+	if text == "" {
+		names := make([]string, len(e.Attachments))
+		for i, attachment := range e.Attachments {
+			names[i] = attachment.Filename
+		}
+		text = "Reply from " + e.From
+		if len(names) > 0 {
+			text += " with " + strings.Join(names, ", ")
+		}
+	}
+	return truncateBytes(text, maxEmailCommentText)
+}
+
+// TaskEmail records an ingested email and the task or comment it became, so replies can
+// be threaded through their In-Reply-To and References headers
+type TaskEmail struct {
+	ID         int64     `json:"id" db:"id"`
+	MessageID  string    `json:"message_id" db:"message_id"`
+	TaskID     int64     `json:"task_id" db:"task_id"`
+	CommentID  int64     `json:"comment_id,omitempty" db:"comment_id"`
+	From       string    `json:"from" db:"from_address"`
+	Subject    string    `json:"subject" db:"subject"`
+	ReceivedAt time.Time `json:"received_at,omitempty" db:"received_at"`
+	CreatedAt  time.Time `json:"created_at" db:"created_at"`
+}
+
+func NewTaskEmail(email *InboundEmail, taskID, commentID int64) *TaskEmail {
+	return &TaskEmail{
+		MessageID:  email.MessageID,
+		TaskID:     taskID,
+		CommentID:  commentID,
+		From:       email.From,
+		Subject:    truncateBytes(email.Subject, 998),
+		ReceivedAt: email.Date,
+		CreatedAt:  time.Now(),
+	}
+}
+
+// truncateBytes cuts text to at most max bytes without splitting a character
+func truncateBytes(text string, max int) string {
+	if len(text) <= max {
+		return text
+	}
+	cut := max
+	for cut > 0 && !utf8.RuneStart(text[cut]) {
+		cut--
+	}
+	return text[:cut]
+}
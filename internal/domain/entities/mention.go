@@ -0,0 +1,103 @@
+package entities
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"task-engine/internal/domain/entities/common"
+	"unicode"
+	"unicode/utf8"
+)
+
+var mentionPattern = regexp.MustCompile(`@([\p{L}\p{N}][\p{L}\p{N}._-]*)`)
+
+// MentionToken is an @handle found in a comment. Start and End are offsets in Unicode code
+// points, End excluded, and cover the @ as well as the handle.
+type MentionToken struct {
+	Handle string
+	Start  int
+	End    int
+}
+
+// CommentMention is a mention token that named a known user or team. Handle keeps the
+// spelling used in the comment.
+type CommentMention struct {
+	ID        int64              `json:"-" db:"id"`
+	CommentID int64              `json:"-" db:"comment_id"`
+	Kind      common.MentionKind `json:"kind" db:"kind"`
+	UserID    int64              `json:"user_id,omitempty" db:"user_id"`
+	TeamID    int64              `json:"team_id,omitempty" db:"team_id"`
+	Handle    string             `json:"handle" db:"handle"`
+	Start     int                `json:"start" db:"start_offset"`
+	End       int                `json:"end" db:"end_offset"`
+}
+
+// ParseMentions finds the @handles of a comment. An @ preceded by a letter or digit, as in
+// an email address, does not start a mention, and trailing dots are left out so a mention
+// can end a sentence.
+func ParseMentions(content string) []MentionToken {
+	var tokens []MentionToken
+	for _, match := range mentionPattern.FindAllStringSubmatchIndex(content, -1) {
+		start, handleStart, handleEnd := match[0], match[2], match[3]
+		if start > 0 {
+			previous, _ := utf8.DecodeLastRuneInString(content[:start])
+			if previous == '@' || previous == '_' || unicode.IsLetter(previous) || unicode.IsDigit(previous) {
+				continue
+			}
+		}
+
+		handle := strings.TrimRight(content[handleStart:handleEnd], ".")
+		if len(handle) < 2 {
+			continue
+		}
+		tokens = append(tokens, MentionToken{
+			Handle: handle,
+			Start:  utf8.RuneCountInString(content[:start]),
+			End:    utf8.RuneCountInString(content[:handleStart+len(handle)]),
+		})
+	}
+	return tokens
+}
+
+// ResolveMentions matches tokens against users and teams keyed by their lowercased username
+// or handle. A username wins over a team handle, unknown handles are dropped.
+func ResolveMentions(tokens []MentionToken, users map[string]*User, teams map[string]*Team) []*CommentMention {
+	var mentions []*CommentMention
+	for _, token := range tokens {
+		key := strings.ToLower(token.Handle)
+		mention := &CommentMention{Handle: token.Handle, Start: token.Start, End: token.End}
+		if user, ok := users[key]; ok {
+			mention.Kind = common.MentionKindUser
+			mention.UserID = user.ID
+		} else if team, ok := teams[key]; ok {
+			mention.Kind = common.MentionKindTeam
+			mention.TeamID = team.ID
+		} else {
+			continue
+		}
+		mentions = append(mentions, mention)
+	}
+	return mentions
+}
+
+// MentionedUserIDs returns the users named by the mentions, team members included, sorted
+// and without duplicates
+func MentionedUserIDs(mentions []*CommentMention, teamMembers map[int64][]int64) []int64 {
+	seen := make(map[int64]bool)
+	for _, mention := range mentions {
+		if mention.Kind == common.MentionKindTeam {
+			for _, userID := range teamMembers[mention.TeamID] {
+				seen[userID] = true
+			}
+		} else {
+			seen[mention.UserID] = true
+		}
+	}
+
+	userIDs := make([]int64, 0, len(seen))
+	for userID := range seen {
+		userIDs = append(userIDs, userID)
+	}
+	sort.Slice(userIDs, func(i, j int) bool { return userIDs[i] < userIDs[j] })
+	return userIDs
+}
@@ -0,0 +1,183 @@
+package entities
+
+import (
+	"fmt"
+	"task-engine/internal/domain/entities/common"
+	"time"
+)
+
+// Notification tells a user about an event. Title and Body are rendered in the recipient's
+// locale when the notification is created. InApp is false when the user only wants the
+// event on other channels, such notifications stay out of the inbox.
+type Notification struct {
+	ID        int64            `json:"id" db:"id"`
+	UserID    int64            `json:"user_id" db:"user_id"`
+	EventID   int64            `json:"event_id,omitempty" db:"event_id"`
+	EventType common.EventType `json:"event_type" db:"event_type"`
+	ProjectID int64            `json:"project_id,omitempty" db:"project_id"`
+	TaskID    int64            `json:"task_id,omitempty" db:"task_id"`
+	ActorID   int64            `json:"actor_id,omitempty" db:"actor_id"`
+	Title     string           `json:"title" db:"title"`
+	Body      string           `json:"body" db:"body"`
+	InApp     bool             `json:"-" db:"in_app"`
+	ReadAt    time.Time        `json:"read_at,omitempty" db:"read_at"`
+	CreatedAt time.Time        `json:"created_at" db:"created_at"`
+}
+
+func NewNotification(userID int64, event *Event, title, body string, inApp bool) (*Notification, error) {
+	notification := &Notification{
+		UserID:    userID,
+		EventID:   event.ID,
+		EventType: event.Type,
+		ProjectID: event.ProjectID,
+		TaskID:    event.TaskID,
+		ActorID:   event.ActorID,
+		Title:     title,
+		Body:      body,
+		InApp:     inApp,
+		CreatedAt: time.Now(),
+	}
+
+	if err := notification.Validate(); err != nil {
+		return nil, err
+	}
+
+	return notification, nil
+}
+
+// Validations methods
+
+func (n *Notification) Validate() error {
+	return common.ValidateFields(
+		common.ValidatePositiveInt("user_id", n.UserID),
+		common.ValidateRequired("event_type", string(n.EventType)),
+		common.ValidateRequired("title", n.Title),
+		common.ValidateStringLength("title", n.Title, 255),
+	)
+}
+
+// Business methods
+
+func (n *Notification) IsRead() bool {
+	return !n.ReadAt.IsZero()
+}
+
+// Modification methods
+
+func (n *Notification) MarkRead() {
+	if !n.IsRead() {
+		n.ReadAt = time.Now()
+	}
+}
+
+func (n *Notification) MarkUnread() {
+	n.ReadAt = time.Time{}
+}
+
+// NotificationDelivery tracks sending a notification through a channel other than the inbox
+type NotificationDelivery struct {
+	ID             int64                      `json:"id" db:"id"`
+	NotificationID int64                      `json:"notification_id" db:"notification_id"`
+	Channel        common.NotificationChannel `json:"channel" db:"channel"`
+	Status         common.DeliveryStatus      `json:"status" db:"status"`
+	Attempts       int                        `json:"attempts" db:"attempts"`
+	LastError      string                     `json:"last_error,omitempty" db:"last_error"`
+	SentAt         time.Time                  `json:"sent_at,omitempty" db:"sent_at"`
+	CreatedAt      time.Time                  `json:"created_at" db:"created_at"`
+}
+
+func NewNotificationDelivery(notificationID int64, channel common.NotificationChannel) (*NotificationDelivery, error) {
+	delivery := &NotificationDelivery{
+		NotificationID: notificationID,
+		Channel:        channel,
+		Status:         common.DeliveryStatusPending,
+		CreatedAt:      time.Now(),
+	}
+
+	if err := common.ValidateFields(
+		common.ValidatePositiveInt("notification_id", delivery.NotificationID),
+		common.ValidateNotificationChannel("channel", delivery.Channel),
+	); err != nil {
+		return nil, err
+	}
+
+	return delivery, nil
+}
+
+// Modification methods
+
+func (d *NotificationDelivery) MarkSent() {
+	d.Attempts++
+	d.Status = common.DeliveryStatusSent
+	d.LastError = ""
+	d.SentAt = time.Now()
+}
+
+// MarkFailed records a failed attempt, the delivery is given up after maxAttempts
+func (d *NotificationDelivery) MarkFailed(err error, maxAttempts int) {
+	d.Attempts++
+	d.LastError = err.Error()
+	if d.Attempts >= maxAttempts {
+		d.Status = common.DeliveryStatusFailed
+	}
+}
+
+// NotificationPreference turns a channel on or off for one event type. Without a
+// preference every channel is on.
+type NotificationPreference struct {
+	UserID    int64                      `json:"-" db:"user_id"`
+	EventType common.EventType           `json:"event_type" db:"event_type"`
+	Channel   common.NotificationChannel `json:"channel" db:"channel"`
+	Enabled   bool                       `json:"enabled" db:"enabled"`
+}
+
+func (p *NotificationPreference) Validate(index int) []*common.FieldValidationError {
+	prefix := fmt.Sprintf("preferences[%d].", index)
+	return []*common.FieldValidationError{
+		common.ValidateEventType(prefix+"event_type", p.EventType),
+		common.ValidateNotificationChannel(prefix+"channel", p.Channel),
+	}
+}
+
+// NotificationEventTypes lists the events users can be notified about
+var NotificationEventTypes = []common.EventType{
+	common.EventTypeTaskCreated,
+	common.EventTypeTaskAssigned,
+	common.EventTypeTaskUnassigned,
+	common.EventTypeTaskStatusChanged,
+	common.EventTypeCommentAdded,
+	common.EventTypeCommentMentioned,
+	common.EventTypeBudgetBurnAlert,
+}
+
+// NotificationChannels lists every channel a notification can reach
+var NotificationChannels = []common.NotificationChannel{
+	common.NotificationChannelInApp,
+	common.NotificationChannelEmail,
+}
+
+// EnabledChannels returns the channels the preferences leave on for the event type
+func EnabledChannels(preferences []*NotificationPreference, eventType common.EventType) []common.NotificationChannel {
+	disabled := make(map[common.NotificationChannel]bool)
+	for _, preference := range preferences {
+		if preference.EventType == eventType && !preference.Enabled {
+			disabled[preference.Channel] = true
+		}
+	}
+
+	var channels []common.NotificationChannel
+	for _, channel := range NotificationChannels {
+		if !disabled[channel] {
+			channels = append(channels, channel)
+		}
+	}
+	return channels
+}
+
+// EmailMessage is an email ready to be sent. HTML is optional.
+type EmailMessage struct {
+	To      string
+	Subject string
+	Text    string
+	HTML    string
+}
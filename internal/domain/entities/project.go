@@ -2,35 +2,61 @@ package entities
 
 import (
 	"errors"
+	"fmt"
+	"strings"
 	"task-engine/internal/domain/entities/common"
 	"time"
 )
 
 type Project struct {
-	ID          int64                    `json:"id" db:"id"`
-	Name        string                   `json:"name" db:"name"`
-	Description string                   `json:"description" db:"description"`
-	Status      common.ProjectStatus     `json:"status" db:"status"`
-	Priority    common.ProjectPriority   `json:"priority" db:"priority"`
-	OwnerID     int64                    `json:"owner_id" db:"owner_id"`
-	TeamID      int64                    `json:"team_id,omitempty" db:"team_id"`
-	StartDate   time.Time                `json:"start_date,omitempty" db:"start_date"`
-	EndDate     time.Time                `json:"end_date,omitempty" db:"end_date"`
-	Budget      float64                  `json:"budget,omitempty" db:"budget"`
-	CreatedAt   time.Time                `json:"created_at" db:"created_at"`
-	UpdatedAt   time.Time                `json:"updated_at" db:"updated_at"`
-	DeletedAt   time.Time                `json:"deleted_at,omitempty" db:"deleted_at"`
+	ID             int64                  `json:"id" db:"id"`
+	Name           string                 `json:"name" db:"name"`
+	Key            string                 `json:"key,omitempty" db:"key"`
+	InboundEmail   string                 `json:"inbound_email,omitempty" db:"inbound_email"`
+	SearchLanguage common.SearchLanguage  `json:"search_language" db:"search_language"`
+	Description    string                 `json:"description" db:"description"`
+	Status         common.ProjectStatus   `json:"status" db:"status"`
+	Priority       common.ProjectPriority `json:"priority" db:"priority"`
+	OwnerID        int64                  `json:"owner_id" db:"owner_id"`
+	TeamID         int64                  `json:"team_id,omitempty" db:"team_id"`
+	StartDate      time.Time              `json:"start_date,omitempty" db:"start_date"`
+	EndDate        time.Time              `json:"end_date,omitempty" db:"end_date"`
+	Budget         common.Money           `json:"budget" db:"budget"`
+	Settings       ProjectSettings        `json:"settings" db:"settings"`
+	CreatedAt      time.Time              `json:"created_at" db:"created_at"`
+	UpdatedAt      time.Time              `json:"updated_at" db:"updated_at"`
+	DeletedAt      time.Time              `json:"deleted_at,omitempty" db:"deleted_at"`
+}
+
+// ProjectSettings holds per-project options
+type ProjectSettings struct {
+	// RequireChecklistCompletion keeps tasks out of done statuses while checklist items are open
+	RequireChecklistCompletion bool `json:"require_checklist_completion"`
+	// GitCloseBranches lists the branches on which a commit saying "fixes #123" completes the task
+	GitCloseBranches []string `json:"git_close_branches,omitempty"`
+}
+
+// ClosesOnBranch reports whether closing commits complete tasks when they land on the branch
+func (s ProjectSettings) ClosesOnBranch(branch string) bool {
+	for _, closing := range s.GitCloseBranches {
+		if branch != "" && closing == branch {
+			return true
+		}
+	}
+	return false
 }
 
 func NewProject(name string, description string, ownerID int64) (*Project, error) {
 	project := &Project{
-		Name:        name,
-		Description: description,
-		OwnerID:     ownerID,
-		Status:      common.ProjectStatusActive,
-		Priority:    common.ProjectPriorityMedium,
-		CreatedAt:   time.Now(),
-		UpdatedAt:   time.Now(),
+		Name:           name,
+		Description:    description,
+		OwnerID:        ownerID,
+		Status:         common.ProjectStatusActive,
+		Priority:       common.ProjectPriorityMedium,
+		Budget:         common.ZeroMoney(common.DefaultCurrency),
+		SearchLanguage: common.DefaultSearchLanguage,
+		CreatedAt:      time.Now(),
+		UpdatedAt:      time.Now(),
 	}
 
 	if err := project.Validate(); err != nil {
@@ -40,19 +66,20 @@ func NewProject(name string, description string, ownerID int64) (*Project, error
 	return project, nil
 }
 
-func NewProjectWithDetails(name, description string, ownerID int64, status common.ProjectStatus, priority common.ProjectPriority, teamID int64, startDate, endDate time.Time, budget float64) (*Project, error) {
+func NewProjectWithDetails(name, description string, ownerID int64, status common.ProjectStatus, priority common.ProjectPriority, teamID int64, startDate, endDate time.Time, budget common.Money) (*Project, error) {
 	project := &Project{
-		Name:        name,
-		Description: description,
-		OwnerID:     ownerID,
-		Status:      status,
-		Priority:    priority,
-		TeamID:      teamID,
-		StartDate:   startDate,
-		EndDate:     endDate,
-		Budget:      budget,
-		CreatedAt:   time.Now(),
-		UpdatedAt:   time.Now(),
+		Name:           name,
+		Description:    description,
+		OwnerID:        ownerID,
+		Status:         status,
+		Priority:       priority,
+		TeamID:         teamID,
+		StartDate:      startDate,
+		EndDate:        endDate,
+		Budget:         budget,
+		SearchLanguage: common.DefaultSearchLanguage,
+		CreatedAt:      time.Now(),
+		UpdatedAt:      time.Now(),
 	}
 
 	if err := project.Validate(); err != nil {
@@ -69,18 +96,22 @@ func (p *Project) Validate() error {
 		// basic validations
 		common.ValidateRequired("name", p.Name),
 		common.ValidateStringLength("name", p.Name, 255),
+		common.ValidateProjectKey("key", p.Key),
+		common.ValidateEmail("inbound_email", p.InboundEmail),
+		common.ValidateStringLength("inbound_email", p.InboundEmail, 255),
 		common.ValidateStringLength("description", p.Description, 1000),
 		common.ValidatePositiveInt("owner_id", p.OwnerID),
 
 		// enum validations
 		common.ValidateProjectStatus("status", p.Status),
 		common.ValidateProjectPriority("priority", p.Priority),
+		common.ValidateSearchLanguage("search_language", p.SearchLanguage),
 
 		// date validations
 		common.ValidateDateOrder("start_date", "end_date", p.StartDate, p.EndDate),
 
 		// budget validation
-		common.ValidatePositiveFloat("budget", p.Budget),
+		common.ValidateNonNegativeMoney("budget", p.Budget),
 
 		// domain specific validations
 		p.validateProjectSpecificRules(),
@@ -88,7 +119,7 @@ func (p *Project) Validate() error {
 }
 
 func (p *Project) validateProjectSpecificRules() *common.FieldValidationError {
-	if p.Status == common.ProjectStatusActive && p.Budget < 0 {
+	if p.Status == common.ProjectStatusActive && p.Budget.IsNegative() {
 		return &common.FieldValidationError{
 			Field:   "budget",
 			Message: "active projects cannot have negative budget",
@@ -229,14 +260,14 @@ func (p *Project) UpdateDates(startDate, endDate time.Time) error {
 	return nil
 }
 
-func (p *Project) UpdateBudget(budget float64) error {
+func (p *Project) UpdateBudget(budget common.Money) error {
 	if err := common.ValidateFields(
-		common.ValidatePositiveFloat("budget", budget),
+		common.ValidateNonNegativeMoney("budget", budget),
 	); err != nil {
 		return err
 	}
 
-	if p.Status == common.ProjectStatusActive && budget < 0 {
+	if p.Status == common.ProjectStatusActive && budget.IsNegative() {
 		return &common.FieldValidationError{
 			Field:   "budget",
 			Message: "active projects cannot have negative budget",
@@ -248,6 +279,61 @@ func (p *Project) UpdateBudget(budget float64) error {
 	return nil
 }
 
+func (p *Project) UpdateSettings(settings ProjectSettings) error {
+	for i, branch := range settings.GitCloseBranches {
+		if err := common.ValidateFields(
+			common.ValidateRequired(fmt.Sprintf("git_close_branches[%d]", i), branch),
+			common.ValidateStringLength(fmt.Sprintf("git_close_branches[%d]", i), branch, 255),
+		); err != nil {
+			return err
+		}
+	}
+
+	p.Settings = settings
+	p.UpdatedAt = time.Now()
+	return nil
+}
+
+// SetKey sets the prefix of the project's task references, an empty key removes it
+func (p *Project) SetKey(key string) error {
+	key = strings.ToUpper(strings.TrimSpace(key))
+	if err := common.ValidateFields(common.ValidateProjectKey("key", key)); err != nil {
+		return err
+	}
+
+	p.Key = key
+	p.UpdatedAt = time.Now()
+	return nil
+}
+
+// SetInboundEmail sets the address whose emails become tasks of the project, an empty
+// address stops the ingestion
+func (p *Project) SetInboundEmail(address string) error {
+	address = strings.ToLower(strings.TrimSpace(address))
+	if err := common.ValidateFields(
+		common.ValidateEmail("inbound_email", address),
+		common.ValidateStringLength("inbound_email", address, 255),
+	); err != nil {
+		return err
+	}
+
+	p.InboundEmail = address
+	p.UpdatedAt = time.Now()
+	return nil
+}
+
+// SetSearchLanguage changes the language the project's text is searched in. Tasks and
+// comments keep their search vectors until the project is reindexed.
+func (p *Project) SetSearchLanguage(language common.SearchLanguage) error {
+	if err := common.ValidateSearchLanguage("search_language", language); err != nil {
+		return err
+	}
+
+	p.SearchLanguage = language
+	p.UpdatedAt = time.Now()
+	return nil
+}
+
 // Business actions methods
 
 func (p *Project) Archive() error {
@@ -292,10 +378,12 @@ type ProjectBuilder struct {
 func NewProjectBuilder() *ProjectBuilder {
 	return &ProjectBuilder{
 		project: &Project{
-			Status:    common.ProjectStatusActive,
-			Priority:  common.ProjectPriorityMedium,
-			CreatedAt: time.Now(),
-			UpdatedAt: time.Now(),
+			Status:         common.ProjectStatusActive,
+			Priority:       common.ProjectPriorityMedium,
+			Budget:         common.ZeroMoney(common.DefaultCurrency),
+			SearchLanguage: common.DefaultSearchLanguage,
+			CreatedAt:      time.Now(),
+			UpdatedAt:      time.Now(),
 		},
 	}
 }
@@ -340,11 +428,31 @@ func (b *ProjectBuilder) WithEndDate(endDate time.Time) *ProjectBuilder {
 	return b
 }
 
-func (b *ProjectBuilder) WithBudget(budget float64) *ProjectBuilder {
+func (b *ProjectBuilder) WithBudget(budget common.Money) *ProjectBuilder {
 	b.project.Budget = budget
 	return b
 }
 
+// FromTemplate copies the template defaults into the project and places it on startDate,
+// the end date keeps the template's duration. Later With* calls override these values.
+func (b *ProjectBuilder) FromTemplate(content TemplateContent, startDate time.Time) *ProjectBuilder {
+	start := truncateToDay(startDate)
+
+	b.project.Description = content.Description
+	if content.Priority != "" {
+		b.project.Priority = content.Priority
+	}
+	if content.Budget != nil {
+		b.project.Budget = *content.Budget
+	}
+	b.project.StartDate = start
+	b.project.EndDate = time.Time{}
+	if content.DurationDays != nil {
+		b.project.EndDate = start.AddDate(0, 0, *content.DurationDays)
+	}
+	return b
+}
+
 func (b *ProjectBuilder) Build() (*Project, error) {
 	if err := b.project.Validate(); err != nil {
 		return nil, err
@@ -0,0 +1,294 @@
+package entities
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"task-engine/internal/domain/entities/common"
+	"time"
+)
+
+// ProjectTemplate is a reusable project skeleton. Dates are stored relative to the
+// project start so instantiating the template on a new start date shifts all of them.
+type ProjectTemplate struct {
+	ID              int64           `json:"id" db:"id"`
+	Name            string          `json:"name" db:"name"`
+	Description     string          `json:"description" db:"description"`
+	SourceProjectID int64           `json:"source_project_id,omitempty" db:"source_project_id"`
+	Content         TemplateContent `json:"content" db:"content"`
+	CreatedBy       int64           `json:"created_by,omitempty" db:"created_by"`
+	CreatedAt       time.Time       `json:"created_at" db:"created_at"`
+	UpdatedAt       time.Time       `json:"updated_at" db:"updated_at"`
+}
+
+// TemplateContent is everything copied from a project, used both by templates and clones
+type TemplateContent struct {
+	Description  string                 `json:"description,omitempty"`
+	Priority     common.ProjectPriority `json:"priority,omitempty"`
+	DurationDays *int                   `json:"duration_days,omitempty"`
+	Budget       *common.Money          `json:"budget,omitempty"`
+	Workflow     *TemplateWorkflow      `json:"workflow,omitempty"`
+	CustomFields []TemplateCustomField  `json:"custom_fields,omitempty"`
+	Tasks        []TemplateTask         `json:"tasks,omitempty"`
+}
+
+type TemplateWorkflow struct {
+	Name          string               `json:"name"`
+	InitialStatus common.TaskStatus    `json:"initial_status"`
+	Statuses      []WorkflowStatus     `json:"statuses"`
+	Transitions   []WorkflowTransition `json:"transitions,omitempty"`
+}
+
+type TemplateCustomField struct {
+	Key      string                 `json:"key"`
+	Name     string                 `json:"name"`
+	Type     common.CustomFieldType `json:"type"`
+	Options  []string               `json:"options,omitempty"`
+	Required bool                   `json:"required,omitempty"`
+}
+
+// TemplateTask is a task of a template. Ref identifies the task inside the template so
+// subtasks can point at their parent, parents always come before their subtasks.
+type TemplateTask struct {
+	Ref              int                        `json:"ref"`
+	ParentRef        int                        `json:"parent_ref,omitempty"`
+	Title            string                     `json:"title"`
+	Description      string                     `json:"description,omitempty"`
+	Priority         common.TaskPriority        `json:"priority,omitempty"`
+	DueOffsetDays    *int                       `json:"due_offset_days,omitempty"`
+	EstimatedMinutes int64                      `json:"estimated_minutes,omitempty"`
+	Tags             []string                   `json:"tags,omitempty"`
+	AssigneeRoles    []common.UserRole          `json:"assignee_roles,omitempty"`
+	AssigneeIDs      []int64                    `json:"assignee_ids,omitempty"`
+	CustomFields     map[string]json.RawMessage `json:"custom_fields,omitempty"`
+}
+
+// CopyOptions selects what is copied from a project. Assignees are copied as the roles of
+// the assigned users when AssigneesByRole is set, which is how templates stay reusable
+// across teams, and as the users themselves otherwise.
+type CopyOptions struct {
+	Tasks           bool `json:"tasks"`
+	Subtasks        bool `json:"subtasks"`
+	Tags            bool `json:"tags"`
+	CustomFields    bool `json:"custom_fields"`
+	Workflow        bool `json:"workflow"`
+	Assignees       bool `json:"assignees"`
+	AssigneesByRole bool `json:"-"`
+	Budget          bool `json:"budget"`
+}
+
+// TemplateCopyOptions copies everything a template can hold
+var TemplateCopyOptions = CopyOptions{
+	Tasks:           true,
+	Subtasks:        true,
+	Tags:            true,
+	CustomFields:    true,
+	Workflow:        true,
+	Assignees:       true,
+	AssigneesByRole: true,
+	Budget:          true,
+}
+
+func NewProjectTemplate(name, description string, sourceProjectID, createdBy int64, content TemplateContent) (*ProjectTemplate, error) {
+	template := &ProjectTemplate{
+		Name:            name,
+		Description:     description,
+		SourceProjectID: sourceProjectID,
+		Content:         content,
+		CreatedBy:       createdBy,
+		CreatedAt:       time.Now(),
+		UpdatedAt:       time.Now(),
+	}
+
+	if err := template.Validate(); err != nil {
+		return nil, err
+	}
+
+	return template, nil
+}
+
+// Validations methods
+
+func (t *ProjectTemplate) Validate() error {
+	validations := []*common.FieldValidationError{
+		common.ValidateRequired("name", t.Name),
+		common.ValidateStringLength("name", t.Name, 255),
+		common.ValidateStringLength("description", t.Description, 1000),
+	}
+	validations = append(validations, t.Content.validate()...)
+	return common.ValidateFields(validations...)
+}
+
+func (c *TemplateContent) validate() []*common.FieldValidationError {
+	var validations []*common.FieldValidationError
+
+	refs := make(map[int]bool, len(c.Tasks))
+	for i, task := range c.Tasks {
+		prefix := fmt.Sprintf("content.tasks[%d].", i)
+		validations = append(validations,
+			common.ValidateRequired(prefix+"title", task.Title),
+			common.ValidateStringLength(prefix+"title", task.Title, 255),
+		)
+		if task.Ref <= 0 || refs[task.Ref] {
+			validations = append(validations, &common.FieldValidationError{
+				Field:   prefix + "ref",
+				Message: "field must be a positive number unique within the template",
+			})
+		}
+		if task.ParentRef != 0 && !refs[task.ParentRef] {
+			validations = append(validations, &common.FieldValidationError{
+				Field:   prefix + "parent_ref",
+				Message: "parent must be listed before its subtasks",
+			})
+		}
+		refs[task.Ref] = true
+	}
+
+	for i, field := range c.CustomFields {
+		prefix := fmt.Sprintf("content.custom_fields[%d].", i)
+		validations = append(validations,
+			common.ValidateRequired(prefix+"key", field.Key),
+			common.ValidateCustomFieldType(prefix+"type", field.Type),
+		)
+	}
+	return validations
+}
+
+// Business methods
+
+// StartOf returns the date project dates are relative to: the start date, or the creation day when unset
+func StartOf(project *Project) time.Time {
+	if !project.StartDate.IsZero() {
+		return truncateToDay(project.StartDate)
+	}
+	return truncateToDay(project.CreatedAt)
+}
+
+// DueDate returns the task due date for a project starting on start, or the zero time when it has none
+func (t TemplateTask) DueDate(start time.Time) time.Time {
+	if t.DueOffsetDays == nil {
+		return time.Time{}
+	}
+	return truncateToDay(start).AddDate(0, 0, *t.DueOffsetDays)
+}
+
+// CaptureProject copies a project into template content. Tasks must have their custom fields,
+// tags and assignees loaded; users maps assignee ids to users so their roles can be recorded.
+// A nil workflow means the project uses the default workflow.
+func CaptureProject(project *Project, workflow *Workflow, definitions []*CustomFieldDefinition, tasks []*Task, users map[int64]*User, options CopyOptions) TemplateContent {
+	start := StartOf(project)
+	content := TemplateContent{
+		Description: project.Description,
+		Priority:    project.Priority,
+	}
+	if !project.EndDate.IsZero() {
+		days := daysBetween(start, project.EndDate)
+		content.DurationDays = &days
+	}
+	if options.Budget {
+		budget := project.Budget
+		content.Budget = &budget
+	}
+	if options.Workflow && workflow != nil {
+		content.Workflow = &TemplateWorkflow{
+			Name:          workflow.Name,
+			InitialStatus: workflow.InitialStatus,
+			Statuses:      workflow.Statuses,
+			Transitions:   workflow.Transitions,
+		}
+	}
+	if options.CustomFields {
+		for _, definition := range definitions {
+			content.CustomFields = append(content.CustomFields, TemplateCustomField{
+				Key:      definition.Key,
+				Name:     definition.Name,
+				Type:     definition.Type,
+				Options:  definition.Options,
+				Required: definition.Required,
+			})
+		}
+	}
+	if !options.Tasks {
+		return content
+	}
+
+	// parents first, then by creation so the copy keeps the original order
+	ordered := append([]*Task(nil), tasks...)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		if ordered[i].IsSubtask() != ordered[j].IsSubtask() {
+			return !ordered[i].IsSubtask()
+		}
+		return ordered[i].ID < ordered[j].ID
+	})
+
+	refs := make(map[int64]int, len(ordered))
+	for _, task := range ordered {
+		parentRef := 0
+		if task.IsSubtask() {
+			var ok bool
+			if parentRef, ok = refs[task.ParentID]; !ok || !options.Subtasks {
+				continue
+			}
+		}
+
+		item := TemplateTask{
+			Ref:              len(refs) + 1,
+			ParentRef:        parentRef,
+			Title:            task.Title,
+			Description:      task.Description,
+			Priority:         task.Priority,
+			EstimatedMinutes: task.EstimatedMinutes,
+		}
+		refs[task.ID] = item.Ref
+
+		if !task.DueDate.IsZero() {
+			offset := daysBetween(start, task.DueDate)
+			item.DueOffsetDays = &offset
+		}
+		if options.Tags {
+			item.Tags = task.Tags
+		}
+		if options.Assignees {
+			captureAssignees(&item, task.AssigneeIDs, users, options.AssigneesByRole)
+		}
+		if options.CustomFields && len(task.CustomFields) > 0 {
+			item.CustomFields = make(map[string]json.RawMessage, len(task.CustomFields))
+			for _, value := range task.CustomFields {
+				if raw, err := json.Marshal(value.Value()); err == nil {
+					item.CustomFields[value.Key] = raw
+				}
+			}
+		}
+		content.Tasks = append(content.Tasks, item)
+	}
+	return content
+}
+
+func captureAssignees(item *TemplateTask, assigneeIDs []int64, users map[int64]*User, byRole bool) {
+	if !byRole {
+		item.AssigneeIDs = assigneeIDs
+		return
+	}
+
+	seen := make(map[common.UserRole]bool)
+	for _, userID := range assigneeIDs {
+		user := users[userID]
+		if user == nil || seen[user.Role] {
+			continue
+		}
+		seen[user.Role] = true
+		item.AssigneeRoles = append(item.AssigneeRoles, user.Role)
+	}
+}
+
+func truncateToDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+func daysBetween(from, to time.Time) int {
+	from = truncateToDay(from)
+	to = time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, from.Location())
+	// rounding absorbs daylight saving shifts
+	return int(math.Round(to.Sub(from).Hours() / 24))
+}
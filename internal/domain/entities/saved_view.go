@@ -0,0 +1,153 @@
+package entities
+
+import (
+	"fmt"
+	"strings"
+	"task-engine/internal/domain/entities/common"
+	"time"
+)
+
+// SavedViewColumns are the task list columns a view can show, besides "cf.<key>" custom fields
+var SavedViewColumns = []string{
+	"id", "title", "status", "category", "priority", "due_date", "assignees", "tags",
+	"estimate", "remaining", "checklist", "created_at", "updated_at",
+}
+
+// TaskListSettings describe a task list: the tasks matching a task query, their order as
+// columns or "cf.<key>" prefixed with "-" for descending, how they are grouped and which
+// columns are shown
+type TaskListSettings struct {
+	Query   string              `json:"query" db:"query"`
+	Sort    []string            `json:"sort" db:"sort"`
+	GroupBy common.TaskGrouping `json:"group_by,omitempty" db:"group_by"`
+	Columns []string            `json:"columns" db:"columns"`
+}
+
+// SavedView is a named task list. A view with a ProjectID lists the tasks of that project,
+// one without those of every project. Private views are seen by their owner only, team
+// views by the members of the team and project views by everyone. Pinned is set for the
+// user reading the view and TaskCount when views are listed.
+type SavedView struct {
+	ID         int64                      `json:"id" db:"id"`
+	Name       string                     `json:"name" db:"name"`
+	OwnerID    int64                      `json:"owner_id" db:"owner_id"`
+	ProjectID  int64                      `json:"project_id,omitempty" db:"project_id"`
+	Visibility common.SavedViewVisibility `json:"visibility" db:"visibility"`
+	TeamID     int64                      `json:"team_id,omitempty" db:"team_id"`
+	TaskListSettings
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+
+	Pinned    bool `json:"pinned" db:"-"`
+	TaskCount *int `json:"task_count,omitempty" db:"-"`
+}
+
+func NewSavedView(ownerID, projectID int64, name string, visibility common.SavedViewVisibility, teamID int64, settings TaskListSettings) (*SavedView, error) {
+	view := &SavedView{
+		OwnerID:   ownerID,
+		ProjectID: projectID,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	view.set(name, visibility, teamID, settings)
+
+	if err := view.Validate(); err != nil {
+		return nil, err
+	}
+
+	return view, nil
+}
+
+// Validations methods
+
+func (v *SavedView) Validate() error {
+	errs := []*common.FieldValidationError{
+		common.ValidatePositiveInt("owner_id", v.OwnerID),
+		common.ValidateRequired("name", v.Name),
+		common.ValidateStringLength("name", v.Name, 100),
+		common.ValidateSavedViewVisibility("visibility", v.Visibility),
+		common.ValidateStringLength("query", v.Query, maxTaskQueryLength),
+	}
+	switch {
+	case v.Visibility == common.SavedViewVisibilityTeam && v.TeamID == 0:
+		errs = append(errs, &common.FieldValidationError{Field: "team_id", Message: "team views need a team"})
+	case v.Visibility != common.SavedViewVisibilityTeam && v.TeamID != 0:
+		errs = append(errs, &common.FieldValidationError{Field: "team_id", Message: "only team views have a team"})
+	case v.Visibility == common.SavedViewVisibilityProject && v.ProjectID == 0:
+		errs = append(errs, &common.FieldValidationError{Field: "project_id", Message: "project views need a project"})
+	}
+	if v.GroupBy != "" {
+		errs = append(errs, common.ValidateTaskGrouping("group_by", v.GroupBy))
+	}
+	for i, sort := range v.Sort {
+		errs = append(errs, common.ValidateRequired(fmt.Sprintf("sort[%d]", i), strings.TrimPrefix(sort, "-")))
+	}
+	for i, column := range v.Columns {
+		errs = append(errs, validateSavedViewColumn(fmt.Sprintf("columns[%d]", i), column))
+	}
+	return common.ValidateFields(errs...)
+}
+
+func validateSavedViewColumn(fieldName, column string) *common.FieldValidationError {
+	if key, ok := strings.CutPrefix(column, "cf."); ok {
+		if !customFieldKeyPattern.MatchString(key) {
+			return &common.FieldValidationError{Field: fieldName, Message: fmt.Sprintf("invalid custom field column %q", column)}
+		}
+		return nil
+	}
+	for _, known := range SavedViewColumns {
+		if column == known {
+			return nil
+		}
+	}
+	return &common.FieldValidationError{Field: fieldName, Message: fmt.Sprintf("unknown column %q", column)}
+}
+
+// Business methods
+
+// IsVisibleTo reports whether the user sees the view, team is the team of a team view
+func (v *SavedView) IsVisibleTo(userID int64, team *Team) bool {
+	switch v.Visibility {
+	case common.SavedViewVisibilityProject:
+		return true
+	case common.SavedViewVisibilityTeam:
+		if team != nil && team.ID == v.TeamID && team.HasMember(userID) {
+			return true
+		}
+	}
+	return v.OwnerID == userID
+}
+
+// CanModify reports whether the user may change or delete the view: its owner or a manager
+func (v *SavedView) CanModify(userID int64, role common.UserRole) bool {
+	return v.OwnerID == userID || role == common.UserRoleAdmin || role == common.UserRoleManager
+}
+
+// Modification methods
+
+func (v *SavedView) Update(name string, visibility common.SavedViewVisibility, teamID int64, settings TaskListSettings) error {
+	v.set(name, visibility, teamID, settings)
+	if err := v.Validate(); err != nil {
+		return err
+	}
+
+	v.UpdatedAt = time.Now()
+	return nil
+}
+
+func (v *SavedView) set(name string, visibility common.SavedViewVisibility, teamID int64, settings TaskListSettings) {
+	v.Name = strings.TrimSpace(name)
+	v.Visibility = visibility
+	if v.Visibility == "" {
+		v.Visibility = common.SavedViewVisibilityPrivate
+	}
+	v.TeamID = teamID
+	settings.Query = strings.TrimSpace(settings.Query)
+	if settings.Sort == nil {
+		settings.Sort = []string{}
+	}
+	if settings.Columns == nil {
+		settings.Columns = []string{}
+	}
+	v.TaskListSettings = settings
+}
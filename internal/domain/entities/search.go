@@ -0,0 +1,27 @@
+package entities
+
+import "task-engine/internal/domain/entities/common"
+
+// SearchResult is a task, project or comment matching a search. Title is the task title,
+// the project name, or the title of the task a comment belongs to. Snippet is an HTML-escaped
+// excerpt of the matched text with the matched words wrapped in <mark> tags.
+type SearchResult struct {
+	Type      common.SearchResultType `json:"type"`
+	ID        int64                   `json:"id"`
+	ProjectID int64                   `json:"project_id"`
+	TaskID    int64                   `json:"task_id,omitempty"`
+	Title     string                  `json:"title"`
+	Snippet   string                  `json:"snippet"`
+	Status    string                  `json:"status,omitempty"`
+	Rank      float64                 `json:"rank"`
+}
+
+// Suggestion is a task, project, tag or user whose name starts with what was typed. Label is
+// the task title, project name, tag name or user name, Detail the username of a user.
+type Suggestion struct {
+	Type      common.SuggestionType `json:"type"`
+	ID        int64                 `json:"id"`
+	ProjectID int64                 `json:"project_id,omitempty"`
+	Label     string                `json:"label"`
+	Detail    string                `json:"detail,omitempty"`
+}
@@ -0,0 +1,172 @@
+package entities
+
+import (
+	"fmt"
+	"sort"
+	"task-engine/internal/domain/entities/common"
+	"time"
+)
+
+// SubscriptionTarget is what a subscription follows: a whole project or a single task
+type SubscriptionTarget struct {
+	ProjectID int64 `json:"project_id,omitempty"`
+	TaskID    int64 `json:"task_id,omitempty"`
+}
+
+func ProjectTarget(projectID int64) SubscriptionTarget {
+	return SubscriptionTarget{ProjectID: projectID}
+}
+
+func TaskTarget(taskID int64) SubscriptionTarget {
+	return SubscriptionTarget{TaskID: taskID}
+}
+
+func (t SubscriptionTarget) IsTask() bool {
+	return t.TaskID != 0
+}
+
+// Subscription makes a user follow a project or task. An unsubscribed subscription is
+// kept so that automatic watching (on create, assign or comment) does not bring it back,
+// only an explicit watch does.
+type Subscription struct {
+	ID              int64                     `json:"id" db:"id"`
+	UserID          int64                     `json:"user_id" db:"user_id"`
+	ProjectID       int64                     `json:"project_id,omitempty" db:"project_id"`
+	TaskID          int64                     `json:"task_id,omitempty" db:"task_id"`
+	Reason          common.SubscriptionReason `json:"reason" db:"reason"`
+	MutedEventTypes []common.EventType        `json:"muted_event_types" db:"muted_event_types"`
+	UnsubscribedAt  time.Time                 `json:"unsubscribed_at,omitempty" db:"unsubscribed_at"`
+	CreatedAt       time.Time                 `json:"created_at" db:"created_at"`
+	UpdatedAt       time.Time                 `json:"updated_at" db:"updated_at"`
+}
+
+func NewSubscription(userID int64, target SubscriptionTarget, reason common.SubscriptionReason) (*Subscription, error) {
+	subscription := &Subscription{
+		UserID:          userID,
+		ProjectID:       target.ProjectID,
+		TaskID:          target.TaskID,
+		Reason:          reason,
+		MutedEventTypes: []common.EventType{},
+		CreatedAt:       time.Now(),
+		UpdatedAt:       time.Now(),
+	}
+
+	if err := subscription.Validate(); err != nil {
+		return nil, err
+	}
+
+	return subscription, nil
+}
+
+// Validations methods
+
+func (s *Subscription) Validate() error {
+	var targetErr *common.FieldValidationError
+	if (s.ProjectID == 0) == (s.TaskID == 0) {
+		targetErr = &common.FieldValidationError{
+			Field:   "target",
+			Message: "subscription must follow either a project or a task",
+		}
+	}
+
+	validations := []*common.FieldValidationError{
+		common.ValidatePositiveInt("user_id", s.UserID),
+		common.ValidateSubscriptionReason("reason", s.Reason),
+		targetErr,
+	}
+	for i, eventType := range s.MutedEventTypes {
+		validations = append(validations, common.ValidateEventType(fmt.Sprintf("muted_event_types[%d]", i), eventType))
+	}
+	return common.ValidateFields(validations...)
+}
+
+// Business methods
+
+func (s *Subscription) Target() SubscriptionTarget {
+	return SubscriptionTarget{ProjectID: s.ProjectID, TaskID: s.TaskID}
+}
+
+func (s *Subscription) IsActive() bool {
+	return s.UnsubscribedAt.IsZero()
+}
+
+func (s *Subscription) IsMuted(eventType common.EventType) bool {
+	for _, muted := range s.MutedEventTypes {
+		if muted == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// Wants reports whether the subscriber should be told about events of the given type
+func (s *Subscription) Wants(eventType common.EventType) bool {
+	return s.IsActive() && !s.IsMuted(eventType)
+}
+
+// Modification methods
+
+// Watch re-activates the subscription after an explicit request from the user
+func (s *Subscription) Watch() {
+	s.Reason = common.SubscriptionReasonWatch
+	s.UnsubscribedAt = time.Time{}
+	s.UpdatedAt = time.Now()
+}
+
+func (s *Subscription) Unsubscribe() {
+	if !s.IsActive() {
+		return
+	}
+	s.UnsubscribedAt = time.Now()
+	s.UpdatedAt = s.UnsubscribedAt
+}
+
+// SetMuted replaces the event types the subscriber does not want to hear about
+func (s *Subscription) SetMuted(eventTypes []common.EventType) error {
+	seen := make(map[common.EventType]bool, len(eventTypes))
+	muted := make([]common.EventType, 0, len(eventTypes))
+	var validations []*common.FieldValidationError
+	for i, eventType := range eventTypes {
+		validations = append(validations, common.ValidateEventType(fmt.Sprintf("muted_event_types[%d]", i), eventType))
+		if !seen[eventType] {
+			seen[eventType] = true
+			muted = append(muted, eventType)
+		}
+	}
+	if err := common.ValidateFields(validations...); err != nil {
+		return err
+	}
+
+	sort.Slice(muted, func(i, j int) bool { return muted[i] < muted[j] })
+	s.MutedEventTypes = muted
+	s.UpdatedAt = time.Now()
+	return nil
+}
+
+// Recipients returns the users to tell about the event among the subscriptions to its task
+// and project. A user's task subscription, even an unsubscribed one, takes precedence over
+// their project subscription. The actor is never told about their own change.
+func Recipients(event *Event, subscriptions []*Subscription) []int64 {
+	byUser := make(map[int64]*Subscription, len(subscriptions))
+	for _, subscription := range subscriptions {
+		if subscription.TaskID != 0 && subscription.TaskID != event.TaskID {
+			continue
+		}
+		if subscription.ProjectID != 0 && subscription.ProjectID != event.ProjectID {
+			continue
+		}
+		if current, ok := byUser[subscription.UserID]; ok && current.Target().IsTask() {
+			continue
+		}
+		byUser[subscription.UserID] = subscription
+	}
+
+	recipients := make([]int64, 0, len(byUser))
+	for userID, subscription := range byUser {
+		if userID != event.ActorID && subscription.Wants(event.Type) {
+			recipients = append(recipients, userID)
+		}
+	}
+	sort.Slice(recipients, func(i, j int) bool { return recipients[i] < recipients[j] })
+	return recipients
+}
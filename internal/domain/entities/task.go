@@ -0,0 +1,291 @@
+package entities
+
+import (
+	"strings"
+	"task-engine/internal/domain/entities/common"
+	"time"
+)
+
+type Task struct {
+	ID               int64                 `json:"id" db:"id"`
+	ProjectID        int64                 `json:"project_id" db:"project_id"`
+	ParentID         int64                 `json:"parent_id,omitempty" db:"parent_id"`
+	Title            string                `json:"title" db:"title"`
+	Description      string                `json:"description" db:"description"`
+	Status           common.TaskStatus     `json:"status" db:"status"`
+	StatusCategory   common.StatusCategory `json:"status_category" db:"status_category"`
+	Priority         common.TaskPriority   `json:"priority" db:"priority"`
+	DueDate          time.Time             `json:"due_date,omitempty" db:"due_date"`
+	EstimatedMinutes int64                 `json:"estimated_minutes,omitempty" db:"estimated_minutes"`
+	RemainingMinutes *int64                `json:"remaining_minutes,omitempty" db:"remaining_minutes"`
+	CreatedAt        time.Time             `json:"created_at" db:"created_at"`
+	UpdatedAt        time.Time             `json:"updated_at" db:"updated_at"`
+
+	CustomFields []*CustomFieldValue `json:"custom_fields,omitempty" db:"-"`
+	Tags         []string            `json:"tags,omitempty" db:"-"`
+	AssigneeIDs  []int64             `json:"assignee_ids,omitempty" db:"-"`
+	Checklist    *ChecklistSummary   `json:"checklist,omitempty" db:"-"`
+}
+
+func NewTask(projectID int64, title, description string) (*Task, error) {
+	task := &Task{
+		ProjectID:      projectID,
+		Title:          title,
+		Description:    description,
+		Status:         common.TaskStatusPending,
+		StatusCategory: common.StatusCategoryTodo,
+		Priority:       common.TaskPriorityMedium,
+		CreatedAt:      time.Now(),
+		UpdatedAt:      time.Now(),
+	}
+
+	if err := task.Validate(); err != nil {
+		return nil, err
+	}
+
+	return task, nil
+}
+
+// Validations methods
+
+func (t *Task) Validate() error {
+	var remainingErr *common.FieldValidationError
+	if t.RemainingMinutes != nil && *t.RemainingMinutes < 0 {
+		remainingErr = &common.FieldValidationError{
+			Field:   "remaining_minutes",
+			Message: "field cannot be negative",
+		}
+	}
+
+	return common.ValidateFields(
+		// basic validations
+		common.ValidatePositiveInt("project_id", t.ProjectID),
+		common.ValidateRequired("title", t.Title),
+		common.ValidateStringLength("title", t.Title, 255),
+
+		// enum validations
+		common.ValidateTaskStatus("status", t.Status),
+		common.ValidateTaskPriority("priority", t.Priority),
+
+		// estimate validations
+		common.ValidateIntRange("estimated_minutes", t.EstimatedMinutes, 0, maxEstimateMinutes),
+		remainingErr,
+	)
+}
+
+// a year of continuous work is more than any single task should be estimated at
+const maxEstimateMinutes = 365 * 24 * 60
+
+// Business methods
+
+func (t *Task) IsCompleted() bool {
+	return t.StatusCategory == common.StatusCategoryDone
+}
+
+func (t *Task) IsCancelled() bool {
+	return t.StatusCategory == common.StatusCategoryCancelled
+}
+
+// IsClosed reports whether no more work is expected on the task
+func (t *Task) IsClosed() bool {
+	return t.IsCompleted() || t.IsCancelled()
+}
+
+func (t *Task) IsOverdue() bool {
+	return !t.DueDate.IsZero() && t.DueDate.Before(time.Now()) && !t.IsClosed()
+}
+
+// GetRemainingMinutes returns the explicit remaining estimate or, when unset,
+// the original estimate minus the time already logged
+func (t *Task) GetRemainingMinutes(loggedMinutes int64) int64 {
+	if t.IsClosed() {
+		return 0
+	}
+	if t.RemainingMinutes != nil {
+		return *t.RemainingMinutes
+	}
+	remaining := t.EstimatedMinutes - loggedMinutes
+	if remaining < 0 {
+		return 0
+	}
+	return remaining
+}
+
+// Progress estimates how much of the task is done, in percent. Completed tasks are done,
+// open tasks progress with their checked checklist items.
+func (t *Task) Progress() float64 {
+	if t.IsCompleted() {
+		return 100
+	}
+	if t.Checklist == nil || t.Checklist.Total == 0 {
+		return 0
+	}
+	return float64(t.Checklist.Checked) / float64(t.Checklist.Total) * 100
+}
+
+func (t *Task) IsSubtask() bool {
+	return t.ParentID != 0
+}
+
+// HasCustomField reports whether the task holds a value for the custom field with the given key.
+// Custom fields must have been loaded.
+func (t *Task) HasCustomField(key string) bool {
+	for _, value := range t.CustomFields {
+		if value.Key == key {
+			return true
+		}
+	}
+	return false
+}
+
+// Modification methods
+
+func (t *Task) UpdateTitle(title string) error {
+	if err := common.ValidateFields(
+		common.ValidateRequired("title", title),
+		common.ValidateStringLength("title", title, 255),
+	); err != nil {
+		return err
+	}
+
+	t.Title = title
+	t.UpdatedAt = time.Now()
+	return nil
+}
+
+func (t *Task) UpdateDescription(description string) error {
+	t.Description = description
+	t.UpdatedAt = time.Now()
+	return nil
+}
+
+// UpdateStatus moves the task to a workflow status. Whether the move is allowed
+// is decided by Workflow.CanTransition.
+func (t *Task) UpdateStatus(status WorkflowStatus) error {
+	if err := common.ValidateFields(
+		common.ValidateTaskStatus("status", status.Key),
+		common.ValidateStatusCategory("status_category", status.Category),
+	); err != nil {
+		return err
+	}
+
+	t.Status = status.Key
+	t.StatusCategory = status.Category
+	t.UpdatedAt = time.Now()
+	return nil
+}
+
+func (t *Task) UpdatePriority(priority common.TaskPriority) error {
+	if err := common.ValidateTaskPriority("priority", priority); err != nil {
+		return err
+	}
+
+	t.Priority = priority
+	t.UpdatedAt = time.Now()
+	return nil
+}
+
+func (t *Task) UpdateDueDate(dueDate time.Time) error {
+	t.DueDate = dueDate
+	t.UpdatedAt = time.Now()
+	return nil
+}
+
+func (t *Task) UpdateEstimate(estimatedMinutes int64, remainingMinutes *int64) error {
+	var remainingErr *common.FieldValidationError
+	if remainingMinutes != nil && *remainingMinutes < 0 {
+		remainingErr = &common.FieldValidationError{
+			Field:   "remaining_minutes",
+			Message: "field cannot be negative",
+		}
+	}
+
+	if err := common.ValidateFields(
+		common.ValidateIntRange("estimated_minutes", estimatedMinutes, 0, maxEstimateMinutes),
+		remainingErr,
+	); err != nil {
+		return err
+	}
+
+	t.EstimatedMinutes = estimatedMinutes
+	t.RemainingMinutes = remainingMinutes
+	t.UpdatedAt = time.Now()
+	return nil
+}
+
+// SetParent makes the task a subtask of parent. Subtasks cannot have subtasks of their own.
+func (t *Task) SetParent(parent *Task) error {
+	if parent.ProjectID != t.ProjectID {
+		return &common.FieldValidationError{
+			Field:   "parent_id",
+			Message: "parent task must belong to the same project",
+		}
+	}
+	if parent.IsSubtask() || (t.ID != 0 && parent.ID == t.ID) {
+		return &common.FieldValidationError{
+			Field:   "parent_id",
+			Message: "parent task must be a top level task",
+		}
+	}
+
+	t.ParentID = parent.ID
+	t.UpdatedAt = time.Now()
+	return nil
+}
+
+// MoveTo moves the task to another project in a status of that project's workflow. Custom
+// field values are dropped as the fields belong to the former project, subtasks are moved
+// along with their parent.
+func (t *Task) MoveTo(projectID int64, status WorkflowStatus) error {
+	if err := common.ValidateFields(common.ValidatePositiveInt("project_id", projectID)); err != nil {
+		return err
+	}
+	if projectID == t.ProjectID {
+		return &common.FieldValidationError{
+			Field:   "project_id",
+			Message: "task already belongs to this project",
+		}
+	}
+	if err := t.UpdateStatus(status); err != nil {
+		return err
+	}
+
+	t.ProjectID = projectID
+	t.CustomFields = nil
+	return nil
+}
+
+// UpdateTags replaces the task tags, dropping blanks and duplicates
+func (t *Task) UpdateTags(tags []string) error {
+	seen := make(map[string]bool, len(tags))
+	normalized := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		tag = strings.TrimSpace(tag)
+		if tag == "" || seen[tag] {
+			continue
+		}
+		if err := common.ValidateStringLength("tags", tag, 100); err != nil {
+			return err
+		}
+		seen[tag] = true
+		normalized = append(normalized, tag)
+	}
+
+	t.Tags = normalized
+	t.UpdatedAt = time.Now()
+	return nil
+}
+
+// UpdateAssignees replaces the users the task is assigned to, dropping duplicates
+func (t *Task) UpdateAssignees(userIDs []int64) {
+	seen := make(map[int64]bool, len(userIDs))
+	assignees := make([]int64, 0, len(userIDs))
+	for _, userID := range userIDs {
+		if seen[userID] {
+			continue
+		}
+		seen[userID] = true
+		assignees = append(assignees, userID)
+	}
+	t.AssigneeIDs = assignees
+}
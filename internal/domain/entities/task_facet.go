@@ -0,0 +1,13 @@
+package entities
+
+import "task-engine/internal/domain/entities/common"
+
+// FacetCount is how many tasks hold a value of a facet. Tasks without a tag or assignee are
+// counted under the value "none", and a task with several tags or assignees under each.
+type FacetCount struct {
+	Value string `json:"value"`
+	Count int    `json:"count"`
+}
+
+// TaskFacets holds the counts of each facet, most frequent value first
+type TaskFacets map[common.TaskFacet][]FacetCount
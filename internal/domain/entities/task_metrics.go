@@ -0,0 +1,56 @@
+package entities
+
+import "task-engine/internal/domain/entities/common"
+
+// TaskMetrics summarises the progress of a project's tasks. Counts rely on status
+// categories so they mean the same thing whatever workflow the project uses, and
+// ProgressPercent also credits checked checklist items of open tasks.
+type TaskMetrics struct {
+	ProjectID         int64                         `json:"project_id"`
+	TotalTasks        int                           `json:"total_tasks"`
+	CompletedTasks    int                           `json:"completed_tasks"`
+	PendingTasks      int                           `json:"pending_tasks"`
+	CancelledTasks    int                           `json:"cancelled_tasks"`
+	OverdueTasks      int                           `json:"overdue_tasks"`
+	CompletionPercent float64                       `json:"completion_percent"`
+	ProgressPercent   float64                       `json:"progress_percent"`
+	ByCategory        map[common.StatusCategory]int `json:"by_category"`
+	ByStatus          map[common.TaskStatus]int     `json:"by_status"`
+}
+
+func NewTaskMetrics(projectID int64, tasks []*Task) *TaskMetrics {
+	metrics := &TaskMetrics{
+		ProjectID:  projectID,
+		TotalTasks: len(tasks),
+		ByCategory: make(map[common.StatusCategory]int),
+		ByStatus:   make(map[common.TaskStatus]int),
+	}
+
+	var progress float64
+	for _, task := range tasks {
+		metrics.ByCategory[task.StatusCategory]++
+		metrics.ByStatus[task.Status]++
+
+		switch {
+		case task.IsCompleted():
+			metrics.CompletedTasks++
+		case task.IsCancelled():
+			metrics.CancelledTasks++
+		default:
+			metrics.PendingTasks++
+		}
+		if !task.IsCancelled() {
+			progress += task.Progress()
+		}
+		if task.IsOverdue() {
+			metrics.OverdueTasks++
+		}
+	}
+
+	// cancelled tasks are out of scope and do not count against completion
+	if scope := metrics.TotalTasks - metrics.CancelledTasks; scope > 0 {
+		metrics.CompletionPercent = float64(metrics.CompletedTasks) / float64(scope) * 100
+		metrics.ProgressPercent = progress / float64(scope)
+	}
+	return metrics
+}
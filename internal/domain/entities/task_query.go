@@ -0,0 +1,419 @@
+package entities
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	maxTaskQueryLength = 2000
+	// maxTaskQueryDepth bounds the nesting of parentheses and NOT
+	maxTaskQueryDepth = 32
+)
+
+// TaskQueryOperator compares a task field with the values of a query term
+type TaskQueryOperator string
+
+const (
+	TaskQueryMatch          TaskQueryOperator = ":"
+	TaskQueryEqual          TaskQueryOperator = "="
+	TaskQueryNotEqual       TaskQueryOperator = "!="
+	TaskQueryLess           TaskQueryOperator = "<"
+	TaskQueryLessOrEqual    TaskQueryOperator = "<="
+	TaskQueryGreater        TaskQueryOperator = ">"
+	TaskQueryGreaterOrEqual TaskQueryOperator = ">="
+)
+
+// IsOrdering reports whether the operator compares by order rather than equality
+func (o TaskQueryOperator) IsOrdering() bool {
+	switch o {
+	case TaskQueryLess, TaskQueryLessOrEqual, TaskQueryGreater, TaskQueryGreaterOrEqual:
+		return true
+	}
+	return false
+}
+
+// TaskQueryNode is a node of a parsed task query
+type TaskQueryNode interface {
+	// Position is the byte offset of the node in the query
+	Position() int
+}
+
+type TaskQueryAnd struct {
+	Left, Right TaskQueryNode
+}
+
+type TaskQueryOr struct {
+	Left, Right TaskQueryNode
+}
+
+type TaskQueryNot struct {
+	Operand TaskQueryNode
+	Pos     int
+}
+
+// TaskQueryTerm compares a field with one or more values, e.g. status:todo,doing. Several
+// values match when any of them does.
+type TaskQueryTerm struct {
+	Field    string
+	Operator TaskQueryOperator
+	Values   []TaskQueryValue
+	Pos      int
+}
+
+type TaskQueryValue struct {
+	Text string
+	Pos  int
+}
+
+func (n *TaskQueryAnd) Position() int  { return n.Left.Position() }
+func (n *TaskQueryOr) Position() int   { return n.Left.Position() }
+func (n *TaskQueryNot) Position() int  { return n.Pos }
+func (n *TaskQueryTerm) Position() int { return n.Pos }
+
+// TaskQueryError reports a mistake in a task query and where it is. Column counts
+// characters from 1.
+type TaskQueryError struct {
+	Query   string
+	Column  int
+	Message string
+}
+
+func (e *TaskQueryError) Error() string {
+	return fmt.Sprintf("query error at column %d: %s", e.Column, e.Message)
+}
+
+// NewTaskQueryError reports a mistake at the byte offset of the query
+func NewTaskQueryError(query string, offset int, format string, args ...interface{}) *TaskQueryError {
+	if offset > len(query) {
+		offset = len(query)
+	}
+	return &TaskQueryError{
+		Query:   query,
+		Column:  utf8.RuneCountInString(query[:offset]) + 1,
+		Message: fmt.Sprintf(format, args...),
+	}
+}
+
+// ParseTaskQuery parses a task query such as
+//
+//	status:in_progress priority>=high due<7d (tag:backend OR tag:api) NOT assignee:me
+//
+// Terms next to each other must all match, OR has a lower precedence than AND, and NOT or a
+// leading "-" negates a term or a parenthesised group. Values holding spaces or operators
+// are quoted. An empty query returns nil.
+func ParseTaskQuery(query string) (TaskQueryNode, error) {
+	if len(query) > maxTaskQueryLength {
+		return nil, NewTaskQueryError(query, maxTaskQueryLength, "query is longer than %d bytes", maxTaskQueryLength)
+	}
+	tokens, err := lexTaskQuery(query)
+	if err != nil {
+		return nil, err
+	}
+	p := &taskQueryParser{query: query, tokens: tokens}
+	if p.peek().kind == tokenEOF {
+		return nil, nil
+	}
+
+	node, err := p.parseOr(0)
+	if err != nil {
+		return nil, err
+	}
+	if token := p.peek(); token.kind != tokenEOF {
+		if token.kind == tokenRightParen {
+			return nil, p.errorAt(token.pos, "unexpected ) without a matching (")
+		}
+		return nil, p.errorAt(token.pos, "unexpected %q", token.text)
+	}
+	return node, nil
+}
+
+// Lexer
+
+type taskQueryTokenKind int
+
+const (
+	tokenEOF taskQueryTokenKind = iota
+	tokenWord
+	tokenString
+	tokenOperator
+	tokenComma
+	tokenLeftParen
+	tokenRightParen
+)
+
+type taskQueryToken struct {
+	kind taskQueryTokenKind
+	text string
+	pos  int
+}
+
+// isTaskQueryDelimiter reports whether the character ends a word
+func isTaskQueryDelimiter(r rune) bool {
+	return unicode.IsSpace(r) || strings.ContainsRune(`()",:=!<>`, r)
+}
+
+func lexTaskQuery(query string) ([]taskQueryToken, error) {
+	var tokens []taskQueryToken
+	for i := 0; i < len(query); {
+		r, size := utf8.DecodeRuneInString(query[i:])
+		switch {
+		case unicode.IsSpace(r):
+			i += size
+		case r == '(':
+			tokens = append(tokens, taskQueryToken{kind: tokenLeftParen, text: "(", pos: i})
+			i++
+		case r == ')':
+			tokens = append(tokens, taskQueryToken{kind: tokenRightParen, text: ")", pos: i})
+			i++
+		case r == ',':
+			tokens = append(tokens, taskQueryToken{kind: tokenComma, text: ",", pos: i})
+			i++
+		case r == ':' || r == '=':
+			tokens = append(tokens, taskQueryToken{kind: tokenOperator, text: string(r), pos: i})
+			i++
+		case r == '!' || r == '<' || r == '>':
+			operator := string(r)
+			if strings.HasPrefix(query[i+1:], "=") {
+				operator += "="
+			} else if r == '!' {
+				return nil, NewTaskQueryError(query, i, "expected != but found a lone !")
+			}
+			tokens = append(tokens, taskQueryToken{kind: tokenOperator, text: operator, pos: i})
+			i += len(operator)
+		case r == '"':
+			var text strings.Builder
+			end := i + 1
+			closed := false
+			for end < len(query) {
+				c := query[end]
+				if c == '\\' && end+1 < len(query) {
+					text.WriteByte(query[end+1])
+					end += 2
+					continue
+				}
+				end++
+				if c == '"' {
+					closed = true
+					break
+				}
+				text.WriteByte(c)
+			}
+			if !closed {
+				return nil, NewTaskQueryError(query, i, "missing closing quote")
+			}
+			tokens = append(tokens, taskQueryToken{kind: tokenString, text: text.String(), pos: i})
+			i = end
+		default:
+			end := i
+			for end < len(query) {
+				r, size := utf8.DecodeRuneInString(query[end:])
+				if isTaskQueryDelimiter(r) {
+					break
+				}
+				end += size
+			}
+			tokens = append(tokens, taskQueryToken{kind: tokenWord, text: query[i:end], pos: i})
+			i = end
+		}
+	}
+	return append(tokens, taskQueryToken{kind: tokenEOF, pos: len(query)}), nil
+}
+
+// Parser
+
+type taskQueryParser struct {
+	query  string
+	tokens []taskQueryToken
+	next   int
+}
+
+func (p *taskQueryParser) peek() taskQueryToken {
+	return p.tokens[p.next]
+}
+
+func (p *taskQueryParser) take() taskQueryToken {
+	token := p.tokens[p.next]
+	if token.kind != tokenEOF {
+		p.next++
+	}
+	return token
+}
+
+func (p *taskQueryParser) errorAt(offset int, format string, args ...interface{}) error {
+	return NewTaskQueryError(p.query, offset, format, args...)
+}
+
+func (p *taskQueryParser) isKeyword(token taskQueryToken, keyword string) bool {
+	return token.kind == tokenWord && strings.EqualFold(token.text, keyword)
+}
+
+func (p *taskQueryParser) parseOr(depth int) (TaskQueryNode, error) {
+	left, err := p.parseAnd(depth)
+	if err != nil {
+		return nil, err
+	}
+	for p.isKeyword(p.peek(), "OR") {
+		p.take()
+		right, err := p.parseAnd(depth)
+		if err != nil {
+			return nil, err
+		}
+		left = &TaskQueryOr{Left: left, Right: right}
+	}
+	return left, nil
+}
+
+func (p *taskQueryParser) parseAnd(depth int) (TaskQueryNode, error) {
+	left, err := p.parseUnary(depth)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		token := p.peek()
+		if token.kind == tokenEOF || token.kind == tokenRightParen || p.isKeyword(token, "OR") {
+			return left, nil
+		}
+		if p.isKeyword(token, "AND") {
+			p.take()
+		}
+		right, err := p.parseUnary(depth)
+		if err != nil {
+			return nil, err
+		}
+		left = &TaskQueryAnd{Left: left, Right: right}
+	}
+}
+
+func (p *taskQueryParser) parseUnary(depth int) (TaskQueryNode, error) {
+	token := p.peek()
+	if depth >= maxTaskQueryDepth {
+		return nil, p.errorAt(token.pos, "query is nested more than %d levels deep", maxTaskQueryDepth)
+	}
+
+	switch {
+	case p.isKeyword(token, "NOT"):
+		p.take()
+		operand, err := p.parseUnary(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		return &TaskQueryNot{Operand: operand, Pos: token.pos}, nil
+	case token.kind == tokenWord && strings.HasPrefix(token.text, "-") && len(token.text) > 1:
+		// -tag:backend, the rest of the word is the field
+		p.tokens[p.next] = taskQueryToken{kind: tokenWord, text: token.text[1:], pos: token.pos + 1}
+		operand, err := p.parseUnary(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		return &TaskQueryNot{Operand: operand, Pos: token.pos}, nil
+	case token.kind == tokenWord && token.text == "-" && p.tokens[p.next+1].kind == tokenLeftParen:
+		p.take()
+		operand, err := p.parseUnary(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		return &TaskQueryNot{Operand: operand, Pos: token.pos}, nil
+	case token.kind == tokenLeftParen:
+		p.take()
+		if p.peek().kind == tokenRightParen {
+			return nil, p.errorAt(p.peek().pos, "empty parentheses")
+		}
+		node, err := p.parseOr(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		if closing := p.peek(); closing.kind != tokenRightParen {
+			return nil, p.errorAt(closing.pos, "missing ) to close the ( at column %d", utf8.RuneCountInString(p.query[:token.pos])+1)
+		}
+		p.take()
+		return node, nil
+	}
+	return p.parseTerm()
+}
+
+func (p *taskQueryParser) parseTerm() (TaskQueryNode, error) {
+	field := p.take()
+	switch {
+	case field.kind == tokenEOF:
+		return nil, p.errorAt(field.pos, "expected a condition such as status:todo at the end of the query")
+	case field.kind != tokenWord:
+		return nil, p.errorAt(field.pos, "expected a condition such as status:todo, found %q", field.text)
+	case p.isKeyword(field, "AND") || p.isKeyword(field, "OR"):
+		return nil, p.errorAt(field.pos, "%s needs a condition on both sides", strings.ToUpper(field.text))
+	}
+
+	operator := p.peek()
+	if operator.kind != tokenOperator {
+		return nil, p.errorAt(operator.pos, "expected an operator such as : or >= after %q", field.text)
+	}
+	p.take()
+
+	term := &TaskQueryTerm{
+		Field:    strings.ToLower(field.text),
+		Operator: TaskQueryOperator(operator.text),
+		Pos:      field.pos,
+	}
+	for {
+		value := p.take()
+		if value.kind != tokenWord && value.kind != tokenString {
+			return nil, p.errorAt(value.pos, "expected a value after %s%s", field.text, operator.text)
+		}
+		term.Values = append(term.Values, TaskQueryValue{Text: value.text, Pos: value.pos})
+		if p.peek().kind != tokenComma {
+			return term, nil
+		}
+		p.take()
+	}
+}
+
+// Relative dates
+
+// relativeDatePattern matches offsets from now such as 7d, -2w or +3m: hours, days, weeks,
+// months and years
+var relativeDatePattern = regexp.MustCompile(`^([+-]?)(\d{1,4})([hdwmy])$`)
+
+// ParseTaskQueryDate reads a date of a task query: a YYYY-MM-DD date, today, yesterday,
+// tomorrow, now, or an offset from now such as 7d (in seven days) or -2w (two weeks ago).
+// It returns the time and whether it denotes a whole day rather than an instant.
+func ParseTaskQueryDate(text string, now time.Time) (time.Time, bool, bool) {
+	midnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	switch strings.ToLower(text) {
+	case "now":
+		return now, false, true
+	case "today":
+		return midnight, true, true
+	case "yesterday":
+		return midnight.AddDate(0, 0, -1), true, true
+	case "tomorrow":
+		return midnight.AddDate(0, 0, 1), true, true
+	}
+	if date, err := time.ParseInLocation("2006-01-02", text, now.Location()); err == nil {
+		return date, true, true
+	}
+
+	match := relativeDatePattern.FindStringSubmatch(strings.ToLower(text))
+	if match == nil {
+		return time.Time{}, false, false
+	}
+	amount, _ := strconv.Atoi(match[2])
+	if match[1] == "-" {
+		amount = -amount
+	}
+	switch match[3] {
+	case "h":
+		return now.Add(time.Duration(amount) * time.Hour), false, true
+	case "d":
+		return now.AddDate(0, 0, amount), false, true
+	case "w":
+		return now.AddDate(0, 0, 7*amount), false, true
+	case "m":
+		return now.AddDate(0, amount, 0), false, true
+	}
+	return now.AddDate(amount, 0, 0), false, true
+}
@@ -0,0 +1,55 @@
+package entities
+
+import (
+	"strings"
+	"task-engine/internal/domain/entities/common"
+	"time"
+)
+
+// Team is a named group of users. Its Handle is written as @handle in comments to
+// mention every member at once.
+type Team struct {
+	ID        int64     `json:"id" db:"id"`
+	Name      string    `json:"name" db:"name"`
+	Handle    string    `json:"handle" db:"handle"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+
+	MemberIDs []int64 `json:"member_ids" db:"-"`
+}
+
+func NewTeam(name, handle string) (*Team, error) {
+	team := &Team{
+		Name:      strings.TrimSpace(name),
+		Handle:    strings.ToLower(strings.TrimSpace(handle)),
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	if err := team.Validate(); err != nil {
+		return nil, err
+	}
+
+	return team, nil
+}
+
+// Validations methods
+
+func (t *Team) Validate() error {
+	return common.ValidateFields(
+		common.ValidateRequired("name", t.Name),
+		common.ValidateStringLength("name", t.Name, 255),
+		common.ValidateHandle("handle", t.Handle),
+	)
+}
+
+// Business methods
+
+func (t *Team) HasMember(userID int64) bool {
+	for _, memberID := range t.MemberIDs {
+		if memberID == userID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,49 @@
+package entities
+
+import (
+	"task-engine/internal/domain/entities/common"
+	"time"
+)
+
+// TimeEntry is effort logged by a user on a task
+type TimeEntry struct {
+	ID          int64     `json:"id" db:"id"`
+	TaskID      int64     `json:"task_id" db:"task_id"`
+	UserID      int64     `json:"user_id" db:"user_id"`
+	Minutes     int64     `json:"minutes" db:"minutes"`
+	SpentOn     time.Time `json:"spent_on" db:"spent_on"`
+	Description string    `json:"description,omitempty" db:"description"`
+	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+}
+
+func NewTimeEntry(taskID, userID, minutes int64, spentOn time.Time, description string) (*TimeEntry, error) {
+	if spentOn.IsZero() {
+		spentOn = time.Now()
+	}
+
+	entry := &TimeEntry{
+		TaskID:      taskID,
+		UserID:      userID,
+		Minutes:     minutes,
+		SpentOn:     spentOn,
+		Description: description,
+		CreatedAt:   time.Now(),
+	}
+
+	if err := entry.Validate(); err != nil {
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+// Validations methods
+
+func (e *TimeEntry) Validate() error {
+	return common.ValidateFields(
+		common.ValidatePositiveInt("task_id", e.TaskID),
+		common.ValidatePositiveInt("user_id", e.UserID),
+		common.ValidateIntRange("minutes", e.Minutes, 1, 24*60),
+		common.ValidateStringLength("description", e.Description, 500),
+	)
+}
@@ -0,0 +1,98 @@
+package entities
+
+import (
+	"task-engine/internal/domain/entities/common"
+	"time"
+)
+
+type User struct {
+	ID        int64             `json:"id" db:"id"`
+	Name      string            `json:"name" db:"name"`
+	Username  string            `json:"username" db:"username"`
+	Email     string            `json:"email" db:"email"`
+	Role      common.UserRole   `json:"role" db:"role"`
+	Status    common.UserStatus `json:"status" db:"status"`
+	Locale    string            `json:"locale" db:"locale"`
+	Timezone  string            `json:"timezone" db:"timezone"`
+	CreatedAt time.Time         `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time         `json:"updated_at" db:"updated_at"`
+}
+
+func NewUser(name, username, email string, role common.UserRole) (*User, error) {
+	user := &User{
+		Name:      name,
+		Username:  username,
+		Email:     email,
+		Role:      role,
+		Status:    common.UserStatusActive,
+		Locale:    common.DefaultLocale,
+		Timezone:  common.DefaultTimezone,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	if err := user.Validate(); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// Validations methods
+
+func (u *User) Validate() error {
+	return common.ValidateFields(
+		common.ValidateRequired("name", u.Name),
+		common.ValidateStringLength("name", u.Name, 255),
+		common.ValidateRequired("username", u.Username),
+		common.ValidateStringLengthRange("username", u.Username, 2, 50),
+		common.ValidateRequired("email", u.Email),
+		common.ValidateEmail("email", u.Email),
+		common.ValidateUserRole("role", u.Role),
+		common.ValidateUserStatus("status", u.Status),
+		common.ValidateLocale("locale", u.Locale),
+		common.ValidateTimezone("timezone", u.Timezone),
+	)
+}
+
+// Business methods
+
+func (u *User) IsActive() bool {
+	return u.Status == common.UserStatusActive
+}
+
+// IsManager reports whether the user can manage other users' work
+func (u *User) IsManager() bool {
+	return u.Role == common.UserRoleAdmin || u.Role == common.UserRoleManager
+}
+
+// Location returns the user's timezone, falling back to UTC when it cannot be loaded
+func (u *User) Location() *time.Location {
+	location, err := time.LoadLocation(u.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return location
+}
+
+// Modification methods
+
+func (u *User) UpdateLocale(locale string) error {
+	if err := common.ValidateLocale("locale", locale); err != nil {
+		return err
+	}
+
+	u.Locale = locale
+	u.UpdatedAt = time.Now()
+	return nil
+}
+
+func (u *User) UpdateTimezone(timezone string) error {
+	if err := common.ValidateTimezone("timezone", timezone); err != nil {
+		return err
+	}
+
+	u.Timezone = timezone
+	u.UpdatedAt = time.Now()
+	return nil
+}
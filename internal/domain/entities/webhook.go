@@ -0,0 +1,307 @@
+package entities
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"task-engine/internal/domain/entities/common"
+	"time"
+)
+
+// Headers sent with every webhook request. The signature is an HMAC-SHA256 of the
+// timestamp, a dot and the body, keyed with the webhook secret.
+const (
+	WebhookEventHeader     = "X-Task-Engine-Event"
+	WebhookDeliveryHeader  = "X-Task-Engine-Delivery"
+	WebhookTimestampHeader = "X-Task-Engine-Timestamp"
+	WebhookSignatureHeader = "X-Task-Engine-Signature"
+)
+
+// maxWebhookBackoff caps the wait between two attempts of a delivery
+const maxWebhookBackoff = 6 * time.Hour
+
+// Webhook pushes events to an external URL. A webhook with a ProjectID receives the events
+// of that project, one without receives the events of every project. EventTypes filters
+// what is sent, empty means every event.
+type Webhook struct {
+	ID         int64              `json:"id" db:"id"`
+	ProjectID  int64              `json:"project_id,omitempty" db:"project_id"`
+	URL        string             `json:"url" db:"url"`
+	Secret     string             `json:"-" db:"secret"`
+	EventTypes []common.EventType `json:"event_types" db:"event_types"`
+	Active     bool               `json:"active" db:"active"`
+	CreatedBy  int64              `json:"created_by,omitempty" db:"created_by"`
+	CreatedAt  time.Time          `json:"created_at" db:"created_at"`
+	UpdatedAt  time.Time          `json:"updated_at" db:"updated_at"`
+}
+
+func NewWebhook(projectID int64, url string, eventTypes []common.EventType, secret string, createdBy int64) (*Webhook, error) {
+	webhook := &Webhook{
+		ProjectID:  projectID,
+		URL:        url,
+		Secret:     secret,
+		EventTypes: eventTypes,
+		Active:     true,
+		CreatedBy:  createdBy,
+		CreatedAt:  time.Now(),
+		UpdatedAt:  time.Now(),
+	}
+
+	if err := webhook.Validate(); err != nil {
+		return nil, err
+	}
+
+	return webhook, nil
+}
+
+// Validations methods
+
+func (w *Webhook) Validate() error {
+	errs := []*common.FieldValidationError{
+		common.ValidateRequired("url", w.URL),
+		common.ValidateURL("url", w.URL),
+		common.ValidateStringLength("url", w.URL, 2048),
+		common.ValidateRequired("secret", w.Secret),
+	}
+	for i, eventType := range w.EventTypes {
+		errs = append(errs, common.ValidateEventType(fmt.Sprintf("event_types[%d]", i), eventType))
+	}
+	return common.ValidateFields(errs...)
+}
+
+// Business methods
+
+func (w *Webhook) IsOrganisationWide() bool {
+	return w.ProjectID == 0
+}
+
+// Subscribes reports whether the webhook wants the event
+func (w *Webhook) Subscribes(event *Event) bool {
+	if !w.Active || (!w.IsOrganisationWide() && w.ProjectID != event.ProjectID) {
+		return false
+	}
+	if len(w.EventTypes) == 0 {
+		return true
+	}
+	for _, eventType := range w.EventTypes {
+		if eventType == event.Type {
+			return true
+		}
+	}
+	return false
+}
+
+// Sign returns the signature header value of a body sent at timestamp
+func (w *Webhook) Sign(timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(w.Secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Modification methods
+
+func (w *Webhook) Update(url string, eventTypes []common.EventType, active bool) error {
+	w.URL = url
+	w.EventTypes = eventTypes
+	w.Active = active
+	if err := w.Validate(); err != nil {
+		return err
+	}
+
+	w.UpdatedAt = time.Now()
+	return nil
+}
+
+// WebhookPayload is the JSON body of a webhook request, Data is the payload of the event
+type WebhookPayload struct {
+	EventID    int64            `json:"event_id"`
+	EventType  common.EventType `json:"event_type"`
+	ProjectID  int64            `json:"project_id,omitempty"`
+	TaskID     int64            `json:"task_id,omitempty"`
+	ActorID    int64            `json:"actor_id,omitempty"`
+	OccurredAt time.Time        `json:"occurred_at"`
+	Data       json.RawMessage  `json:"data,omitempty"`
+}
+
+// WebhookDelivery sends one event to one webhook. The body is built when the delivery is
+// queued so every attempt, and every replay, sends the same bytes. Failed attempts are
+// retried with exponential backoff until the attempt limit, then the delivery is dead.
+type WebhookDelivery struct {
+	ID             int64                        `json:"id" db:"id"`
+	WebhookID      int64                        `json:"webhook_id" db:"webhook_id"`
+	EventID        int64                        `json:"event_id,omitempty" db:"event_id"`
+	EventType      common.EventType             `json:"event_type" db:"event_type"`
+	RequestBody    json.RawMessage              `json:"request_body" db:"request_body"`
+	Status         common.WebhookDeliveryStatus `json:"status" db:"status"`
+	Attempts       int                          `json:"attempts" db:"attempts"`
+	NextAttemptAt  time.Time                    `json:"next_attempt_at,omitempty" db:"next_attempt_at"`
+	ResponseStatus int                          `json:"response_status,omitempty" db:"response_status"`
+	LastError      string                       `json:"last_error,omitempty" db:"last_error"`
+	DeliveredAt    time.Time                    `json:"delivered_at,omitempty" db:"delivered_at"`
+	ReplayOf       int64                        `json:"replay_of,omitempty" db:"replay_of"`
+	CreatedAt      time.Time                    `json:"created_at" db:"created_at"`
+
+	History []*WebhookAttempt `json:"history,omitempty" db:"-"`
+}
+
+func NewWebhookDelivery(webhook *Webhook, event *Event) (*WebhookDelivery, error) {
+	body, err := json.Marshal(WebhookPayload{
+		EventID:    event.ID,
+		EventType:  event.Type,
+		ProjectID:  event.ProjectID,
+		TaskID:     event.TaskID,
+		ActorID:    event.ActorID,
+		OccurredAt: event.CreatedAt,
+		Data:       event.Payload,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	return &WebhookDelivery{
+		WebhookID:     webhook.ID,
+		EventID:       event.ID,
+		EventType:     event.Type,
+		RequestBody:   body,
+		Status:        common.WebhookDeliveryStatusPending,
+		NextAttemptAt: now,
+		CreatedAt:     now,
+	}, nil
+}
+
+// Business methods
+
+func (d *WebhookDelivery) IsPending() bool {
+	return d.Status == common.WebhookDeliveryStatusPending
+}
+
+// Request builds the signed HTTP request of the next attempt
+func (d *WebhookDelivery) Request(webhook *Webhook, now time.Time) *WebhookRequest {
+	timestamp := now.Unix()
+	return &WebhookRequest{
+		URL: webhook.URL,
+		Headers: map[string]string{
+			"Content-Type":         "application/json",
+			"User-Agent":           "task-engine-webhooks",
+			WebhookEventHeader:     string(d.EventType),
+			WebhookDeliveryHeader:  strconv.FormatInt(d.ID, 10),
+			WebhookTimestampHeader: strconv.FormatInt(timestamp, 10),
+			WebhookSignatureHeader: webhook.Sign(timestamp, d.RequestBody),
+		},
+		Body: d.RequestBody,
+	}
+}
+
+// Replay returns a new pending delivery sending the same body again
+func (d *WebhookDelivery) Replay() *WebhookDelivery {
+	now := time.Now()
+	return &WebhookDelivery{
+		WebhookID:     d.WebhookID,
+		EventID:       d.EventID,
+		EventType:     d.EventType,
+		RequestBody:   d.RequestBody,
+		Status:        common.WebhookDeliveryStatusPending,
+		NextAttemptAt: now,
+		ReplayOf:      d.ID,
+		CreatedAt:     now,
+	}
+}
+
+// Modification methods
+
+// Record applies the outcome of an attempt. A failed delivery is retried after the backoff
+// of its attempt count and is dead once maxAttempts have failed.
+func (d *WebhookDelivery) Record(attempt *WebhookAttempt, maxAttempts int, backoff time.Duration) {
+	d.Attempts++
+	d.ResponseStatus = attempt.ResponseStatus
+	if attempt.Succeeded() {
+		d.Status = common.WebhookDeliveryStatusDelivered
+		d.LastError = ""
+		d.DeliveredAt = attempt.AttemptedAt
+		d.NextAttemptAt = time.Time{}
+		return
+	}
+
+	d.LastError = attempt.Failure()
+	if d.Attempts >= maxAttempts {
+		d.Status = common.WebhookDeliveryStatusDead
+		d.NextAttemptAt = time.Time{}
+		return
+	}
+	d.NextAttemptAt = attempt.AttemptedAt.Add(WebhookBackoff(d.Attempts, backoff))
+}
+
+// WebhookBackoff returns how long to wait after the given number of failed attempts: base,
+// then doubling, capped at six hours
+func WebhookBackoff(attempts int, base time.Duration) time.Duration {
+	delay := base
+	for i := 1; i < attempts && delay < maxWebhookBackoff; i++ {
+		delay *= 2
+	}
+	if delay > maxWebhookBackoff {
+		delay = maxWebhookBackoff
+	}
+	return delay
+}
+
+// WebhookRequest is a signed HTTP POST to a webhook URL
+type WebhookRequest struct {
+	URL     string
+	Headers map[string]string
+	Body    []byte
+}
+
+// WebhookResponse is what a receiver answered, Body may be truncated
+type WebhookResponse struct {
+	StatusCode int
+	Body       string
+}
+
+// WebhookAttempt logs one HTTP request of a delivery and what came back. Error is set when
+// no response was received.
+type WebhookAttempt struct {
+	ID             int64             `json:"id" db:"id"`
+	DeliveryID     int64             `json:"delivery_id" db:"delivery_id"`
+	RequestHeaders map[string]string `json:"request_headers" db:"request_headers"`
+	ResponseStatus int               `json:"response_status,omitempty" db:"response_status"`
+	ResponseBody   string            `json:"response_body,omitempty" db:"response_body"`
+	Error          string            `json:"error,omitempty" db:"error"`
+	DurationMs     int64             `json:"duration_ms" db:"duration_ms"`
+	AttemptedAt    time.Time         `json:"attempted_at" db:"attempted_at"`
+}
+
+func NewWebhookAttempt(deliveryID int64, request *WebhookRequest, response *WebhookResponse, err error, attemptedAt time.Time, duration time.Duration) *WebhookAttempt {
+	attempt := &WebhookAttempt{
+		DeliveryID:     deliveryID,
+		RequestHeaders: request.Headers,
+		DurationMs:     duration.Milliseconds(),
+		AttemptedAt:    attemptedAt,
+	}
+	if response != nil {
+		attempt.ResponseStatus = response.StatusCode
+		attempt.ResponseBody = response.Body
+	}
+	if err != nil {
+		attempt.Error = err.Error()
+	}
+	return attempt
+}
+
+// Succeeded reports whether the receiver answered with a 2xx status
+func (a *WebhookAttempt) Succeeded() bool {
+	return a.Error == "" && a.ResponseStatus >= 200 && a.ResponseStatus < 300
+}
+
+// Failure describes why the attempt failed
+func (a *WebhookAttempt) Failure() string {
+	if a.Error != "" {
+		return a.Error
+	}
+	return fmt.Sprintf("receiver answered with status %d", a.ResponseStatus)
+}
@@ -0,0 +1,284 @@
+package entities
+
+import (
+	"fmt"
+	"strings"
+	"task-engine/internal/domain/entities/common"
+	"time"
+)
+
+// WorkflowStatus is a named column of a project's workflow
+type WorkflowStatus struct {
+	Key      common.TaskStatus     `json:"key"`
+	Name     string                `json:"name"`
+	Category common.StatusCategory `json:"category"`
+}
+
+// TransitionGuard must hold for a transition to be allowed.
+// Field names the custom field checked by custom_field_set guards.
+type TransitionGuard struct {
+	Type  common.TransitionGuardType `json:"type"`
+	Field string                     `json:"field,omitempty"`
+}
+
+// WorkflowTransition allows moving a task from one status to another.
+// An empty From allows the move from any status.
+type WorkflowTransition struct {
+	From   common.TaskStatus `json:"from,omitempty"`
+	To     common.TaskStatus `json:"to"`
+	Guards []TransitionGuard `json:"guards,omitempty"`
+}
+
+// Workflow defines the statuses a project's tasks move through.
+// A workflow without transitions lets tasks move freely between its statuses.
+type Workflow struct {
+	ID            int64                `json:"id,omitempty" db:"id"`
+	ProjectID     int64                `json:"project_id" db:"project_id"`
+	Name          string               `json:"name" db:"name"`
+	InitialStatus common.TaskStatus    `json:"initial_status" db:"initial_status"`
+	Statuses      []WorkflowStatus     `json:"statuses" db:"statuses"`
+	Transitions   []WorkflowTransition `json:"transitions,omitempty" db:"transitions"`
+	CreatedAt     time.Time            `json:"created_at" db:"created_at"`
+	UpdatedAt     time.Time            `json:"updated_at" db:"updated_at"`
+}
+
+// DefaultWorkflow is used by projects that never configured one. It matches the
+// statuses tasks had before workflows were configurable.
+func DefaultWorkflow(projectID int64) *Workflow {
+	return &Workflow{
+		ProjectID:     projectID,
+		Name:          "Default",
+		InitialStatus: common.TaskStatusPending,
+		Statuses: []WorkflowStatus{
+			{Key: common.TaskStatusPending, Name: "Pending", Category: common.StatusCategoryTodo},
+			{Key: common.TaskStatusInProgress, Name: "In progress", Category: common.StatusCategoryDoing},
+			{Key: common.TaskStatusCompleted, Name: "Completed", Category: common.StatusCategoryDone},
+			{Key: common.TaskStatusCancelled, Name: "Cancelled", Category: common.StatusCategoryCancelled},
+		},
+	}
+}
+
+func NewWorkflow(projectID int64, name string, initialStatus common.TaskStatus, statuses []WorkflowStatus, transitions []WorkflowTransition) (*Workflow, error) {
+	workflow := &Workflow{
+		ProjectID:     projectID,
+		Name:          name,
+		InitialStatus: initialStatus,
+		Statuses:      statuses,
+		Transitions:   transitions,
+		CreatedAt:     time.Now(),
+		UpdatedAt:     time.Now(),
+	}
+
+	if err := workflow.Validate(); err != nil {
+		return nil, err
+	}
+
+	return workflow, nil
+}
+
+// Validations methods
+
+func (w *Workflow) Validate() error {
+	validations := []*common.FieldValidationError{
+		common.ValidatePositiveInt("project_id", w.ProjectID),
+		common.ValidateRequired("name", w.Name),
+		common.ValidateStringLength("name", w.Name, 100),
+	}
+
+	if len(w.Statuses) == 0 {
+		validations = append(validations, &common.FieldValidationError{
+			Field:   "statuses",
+			Message: "workflow needs at least one status",
+		})
+	}
+
+	seen := make(map[common.TaskStatus]bool, len(w.Statuses))
+	for i, status := range w.Statuses {
+		prefix := fmt.Sprintf("statuses[%d].", i)
+		validations = append(validations,
+			common.ValidateTaskStatus(prefix+"key", status.Key),
+			common.ValidateRequired(prefix+"name", status.Name),
+			common.ValidateStringLength(prefix+"name", status.Name, 100),
+			common.ValidateStatusCategory(prefix+"category", status.Category),
+		)
+		if seen[status.Key] {
+			validations = append(validations, &common.FieldValidationError{
+				Field:   prefix + "key",
+				Message: fmt.Sprintf("status %q is duplicated", status.Key),
+			})
+		}
+		seen[status.Key] = true
+	}
+
+	if !seen[w.InitialStatus] {
+		validations = append(validations, &common.FieldValidationError{
+			Field:   "initial_status",
+			Message: "field must be one of the workflow statuses",
+		})
+	}
+
+	for i, transition := range w.Transitions {
+		prefix := fmt.Sprintf("transitions[%d].", i)
+		if transition.From != "" && !seen[transition.From] {
+			validations = append(validations, unknownStatusError(prefix+"from", transition.From))
+		}
+		if !seen[transition.To] {
+			validations = append(validations, unknownStatusError(prefix+"to", transition.To))
+		}
+		for j, guard := range transition.Guards {
+			field := fmt.Sprintf("%sguards[%d]", prefix, j)
+			validations = append(validations, common.ValidateTransitionGuardType(field+".type", guard.Type))
+			if guard.Type == common.TransitionGuardCustomFieldSet {
+				validations = append(validations, common.ValidateRequired(field+".field", guard.Field))
+			}
+		}
+	}
+
+	return common.ValidateFields(validations...)
+}
+
+func unknownStatusError(field string, status common.TaskStatus) *common.FieldValidationError {
+	return &common.FieldValidationError{
+		Field:   field,
+		Message: fmt.Sprintf("status %q is not part of the workflow", status),
+	}
+}
+
+// Business methods
+
+// Status returns the workflow status with the given key
+func (w *Workflow) Status(key common.TaskStatus) (WorkflowStatus, bool) {
+	for _, status := range w.Statuses {
+		if status.Key == key {
+			return status, true
+		}
+	}
+	return WorkflowStatus{}, false
+}
+
+// MatchStatus finds the status whose key or name is the given name, ignoring case and
+// treating spaces and dashes as underscores, so "In Progress" matches in_progress
+func (w *Workflow) MatchStatus(name string) (WorkflowStatus, bool) {
+	normalize := strings.NewReplacer(" ", "_", "-", "_")
+	wanted := normalize.Replace(strings.ToLower(strings.TrimSpace(name)))
+	for _, status := range w.Statuses {
+		if string(status.Key) == wanted || normalize.Replace(strings.ToLower(status.Name)) == wanted {
+			return status, true
+		}
+	}
+	return WorkflowStatus{}, false
+}
+
+// StatusIn returns the first status of the category
+func (w *Workflow) StatusIn(category common.StatusCategory) (WorkflowStatus, bool) {
+	for _, status := range w.Statuses {
+		if status.Category == category {
+			return status, true
+		}
+	}
+	return WorkflowStatus{}, false
+}
+
+// ValidateStatus reports a validation error when the status is not part of the workflow
+func (w *Workflow) ValidateStatus(fieldName string, key common.TaskStatus) *common.FieldValidationError {
+	if _, ok := w.Status(key); !ok {
+		return unknownStatusError(fieldName, key)
+	}
+	return nil
+}
+
+// TransitionActor is the user moving a task, used to evaluate guards
+type TransitionActor struct {
+	UserID int64
+	Role   common.UserRole
+}
+
+func (a TransitionActor) IsManager() bool {
+	return a.Role == common.UserRoleAdmin || a.Role == common.UserRoleManager
+}
+
+// TransitionNotAllowedError is returned when the workflow forbids a status change
+type TransitionNotAllowedError struct {
+	From   common.TaskStatus
+	To     common.TaskStatus
+	Reason string
+}
+
+func (e *TransitionNotAllowedError) Error() string {
+	return fmt.Sprintf("cannot move task from %q to %q: %s", e.From, e.To, e.Reason)
+}
+
+// CanTransition checks that the workflow allows moving the task to the given status
+// and that the guards of at least one matching transition hold
+func (w *Workflow) CanTransition(task *Task, to common.TaskStatus, actor TransitionActor) (WorkflowStatus, error) {
+	target, ok := w.Status(to)
+	if !ok {
+		return WorkflowStatus{}, unknownStatusError("status", to)
+	}
+	if task.Status == to {
+		return WorkflowStatus{}, &TransitionNotAllowedError{From: task.Status, To: to, Reason: "task is already in this status"}
+	}
+	if len(w.Transitions) == 0 {
+		return target, nil
+	}
+
+	reason := "no transition between these statuses"
+	for _, transition := range w.Transitions {
+		if transition.To != to || (transition.From != "" && transition.From != task.Status) {
+			continue
+		}
+		failed := transition.failedGuard(task, actor)
+		if failed == "" {
+			return target, nil
+		}
+		reason = failed
+	}
+	return WorkflowStatus{}, &TransitionNotAllowedError{From: task.Status, To: to, Reason: reason}
+}
+
+// failedGuard returns why the first unmet guard fails, or an empty string when all hold
+func (t WorkflowTransition) failedGuard(task *Task, actor TransitionActor) string {
+	for _, guard := range t.Guards {
+		switch guard.Type {
+		case common.TransitionGuardManagerOnly:
+			if !actor.IsManager() {
+				return "only managers can make this transition"
+			}
+		case common.TransitionGuardEstimateSet:
+			if task.EstimatedMinutes <= 0 {
+				return "task needs an estimate"
+			}
+		case common.TransitionGuardDueDateSet:
+			if task.DueDate.IsZero() {
+				return "task needs a due date"
+			}
+		case common.TransitionGuardCustomFieldSet:
+			if !task.HasCustomField(guard.Field) {
+				return fmt.Sprintf("custom field %q must be set", guard.Field)
+			}
+		}
+	}
+	return ""
+}
+
+// Modification methods
+
+func (w *Workflow) Update(name string, initialStatus common.TaskStatus, statuses []WorkflowStatus, transitions []WorkflowTransition) error {
+	updated := *w
+	updated.Name = name
+	updated.InitialStatus = initialStatus
+	updated.Statuses = statuses
+	updated.Transitions = transitions
+
+	if err := updated.Validate(); err != nil {
+		return err
+	}
+
+	*w = updated
+	w.UpdatedAt = time.Now()
+	// the default workflow is only stored once a project changes it
+	if w.CreatedAt.IsZero() {
+		w.CreatedAt = w.UpdatedAt
+	}
+	return nil
+}
@@ -0,0 +1,11 @@
+package repositories
+
+import "context"
+
+type AssignmentRepository interface {
+	// ListByTasks returns the assigned user ids of the given tasks keyed by task id
+	ListByTasks(ctx context.Context, taskIDs []int64) (map[int64][]int64, error)
+	// Assign reports whether the user was newly assigned, assigning twice is a no-op
+	Assign(ctx context.Context, taskID, userID int64) (bool, error)
+	Unassign(ctx context.Context, taskID, userID int64) error
+}
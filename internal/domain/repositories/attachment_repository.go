@@ -0,0 +1,19 @@
+package repositories
+
+import (
+	"context"
+	"task-engine/internal/domain/entities"
+)
+
+type AttachmentRepository interface {
+	Create(ctx context.Context, attachment *entities.Attachment) error
+	FindByID(ctx context.Context, id int64) (*entities.Attachment, error)
+	ListByTask(ctx context.Context, taskID int64) ([]*entities.Attachment, error)
+	ListByComment(ctx context.Context, commentID int64) ([]*entities.Attachment, error)
+	Delete(ctx context.Context, id int64) error
+
+	// BlobKeysByTask returns the blob keys used by the task and its subtasks
+	BlobKeysByTask(ctx context.Context, taskID int64) ([]string, error)
+	// ReferencedKeys returns which of the given blob keys are still used by an attachment
+	ReferencedKeys(ctx context.Context, keys []string) (map[string]bool, error)
+}
@@ -0,0 +1,23 @@
+package repositories
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+// BlobStore keeps file contents addressed by the hex SHA-256 of their bytes, so storing
+// the same content twice yields the same key and a single copy.
+type BlobStore interface {
+	// Put stores the content read from r and returns its key and size. A failing reader
+	// leaves nothing behind.
+	Put(ctx context.Context, r io.Reader) (key string, size int64, err error)
+	// Open returns the content of a blob, ErrNotFound when there is none
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete removes a blob, deleting a missing blob is not an error
+	Delete(ctx context.Context, key string) error
+	// Modified returns the last time the blob was written, ErrNotFound when there is none
+	Modified(ctx context.Context, key string) (time.Time, error)
+	// Walk calls fn for every stored blob with the last time it was written
+	Walk(ctx context.Context, fn func(key string, modified time.Time) error) error
+}
@@ -0,0 +1,18 @@
+package repositories
+
+import (
+	"context"
+	"task-engine/internal/domain/entities"
+)
+
+type ChecklistRepository interface {
+	Create(ctx context.Context, item *entities.ChecklistItem) error
+	FindByID(ctx context.Context, id int64) (*entities.ChecklistItem, error)
+	ListByTask(ctx context.Context, taskID int64) ([]*entities.ChecklistItem, error)
+	Update(ctx context.Context, item *entities.ChecklistItem) error
+	Delete(ctx context.Context, id int64) error
+
+	// SummarizeByTasks counts the checklist items of the given tasks keyed by task id,
+	// tasks without items are left out
+	SummarizeByTasks(ctx context.Context, taskIDs []int64) (map[int64]entities.ChecklistSummary, error)
+}
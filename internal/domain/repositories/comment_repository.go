@@ -0,0 +1,29 @@
+package repositories
+
+import (
+	"context"
+	"task-engine/internal/domain/entities"
+)
+
+type CommentRepository interface {
+	Create(ctx context.Context, comment *entities.Comment) error
+	FindByID(ctx context.Context, id int64) (*entities.Comment, error)
+	// ListByTask returns the task's comments oldest first, tombstones and replies included
+	ListByTask(ctx context.Context, taskID int64) ([]*entities.Comment, error)
+	Update(ctx context.Context, comment *entities.Comment) error
+
+	CreateMentions(ctx context.Context, mentions []*entities.CommentMention) error
+	// ListMentions returns the mentions of the given comments keyed by comment id, in content order
+	ListMentions(ctx context.Context, commentIDs []int64) (map[int64][]*entities.CommentMention, error)
+	DeleteMentions(ctx context.Context, commentID int64) error
+
+	CreateRevision(ctx context.Context, revision *entities.CommentRevision) error
+	// ListRevisions returns the earlier contents of the comment, oldest first
+	ListRevisions(ctx context.Context, commentID int64) ([]*entities.CommentRevision, error)
+
+	// AddReaction reports whether the reaction is new, reacting twice with an emoji is a no-op
+	AddReaction(ctx context.Context, reaction *entities.CommentReaction) (bool, error)
+	RemoveReaction(ctx context.Context, commentID, userID int64, emoji string) error
+	// ListReactions returns the reactions of the given comments keyed by comment id, oldest first
+	ListReactions(ctx context.Context, commentIDs []int64) (map[int64][]*entities.CommentReaction, error)
+}
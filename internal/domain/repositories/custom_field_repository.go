@@ -0,0 +1,19 @@
+package repositories
+
+import (
+	"context"
+	"task-engine/internal/domain/entities"
+)
+
+type CustomFieldRepository interface {
+	CreateDefinition(ctx context.Context, definition *entities.CustomFieldDefinition) error
+	UpdateDefinition(ctx context.Context, definition *entities.CustomFieldDefinition) error
+	DeleteDefinition(ctx context.Context, id int64) error
+	FindDefinition(ctx context.Context, id int64) (*entities.CustomFieldDefinition, error)
+	ListDefinitions(ctx context.Context, projectID int64) ([]*entities.CustomFieldDefinition, error)
+
+	// ListValues returns the values of the given tasks keyed by task id
+	ListValues(ctx context.Context, taskIDs []int64) (map[int64][]*entities.CustomFieldValue, error)
+	SetValues(ctx context.Context, taskID int64, values []*entities.CustomFieldValue) error
+	UnsetValues(ctx context.Context, taskID int64, fieldIDs []int64) error
+}
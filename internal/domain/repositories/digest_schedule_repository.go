@@ -0,0 +1,18 @@
+package repositories
+
+import (
+	"context"
+	"task-engine/internal/domain/entities"
+	"time"
+)
+
+type DigestScheduleRepository interface {
+	Find(ctx context.Context, userID int64) (*entities.DigestSchedule, error)
+	// Save creates the user's schedule or replaces the existing one
+	Save(ctx context.Context, schedule *entities.DigestSchedule) error
+	Delete(ctx context.Context, userID int64) error
+	// ClaimDue locks the schedule whose next run is the earliest one not after now, skipping
+	// rows locked by another sender. It returns ErrNotFound when none is due and must run
+	// inside a transaction.
+	ClaimDue(ctx context.Context, now time.Time) (*entities.DigestSchedule, error)
+}
@@ -0,0 +1,8 @@
+package repositories
+
+import "errors"
+
+var (
+	ErrNotFound = errors.New("record not found")
+	ErrConflict = errors.New("record already exists")
+)
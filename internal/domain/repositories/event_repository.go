@@ -0,0 +1,11 @@
+package repositories
+
+import (
+	"context"
+	"task-engine/internal/domain/entities"
+)
+
+type EventRepository interface {
+	Create(ctx context.Context, event *entities.Event) error
+	ListByProject(ctx context.Context, projectID int64, limit int) ([]*entities.Event, error)
+}
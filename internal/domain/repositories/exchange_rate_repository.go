@@ -0,0 +1,17 @@
+package repositories
+
+import (
+	"context"
+	"task-engine/internal/domain/entities"
+	"task-engine/internal/domain/entities/common"
+	"time"
+)
+
+type ExchangeRateRepository interface {
+	// Upsert inserts the rate or replaces the one stored for the same pair and date
+	Upsert(ctx context.Context, rate *entities.ExchangeRate) error
+	// FindLatest returns the most recent rate for the pair dated on or before asOf
+	FindLatest(ctx context.Context, base, quote common.Currency, asOf time.Time) (*entities.ExchangeRate, error)
+	// List returns stored rates, optionally filtered by base and quote currency
+	List(ctx context.Context, base, quote common.Currency) ([]*entities.ExchangeRate, error)
+}
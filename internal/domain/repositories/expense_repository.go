@@ -0,0 +1,13 @@
+package repositories
+
+import (
+	"context"
+	"task-engine/internal/domain/entities"
+)
+
+type ExpenseRepository interface {
+	Create(ctx context.Context, expense *entities.Expense) error
+	FindByID(ctx context.Context, id int64) (*entities.Expense, error)
+	ListByProject(ctx context.Context, projectID int64) ([]*entities.Expense, error)
+	Delete(ctx context.Context, id int64) error
+}
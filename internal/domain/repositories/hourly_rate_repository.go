@@ -0,0 +1,11 @@
+package repositories
+
+import (
+	"context"
+	"task-engine/internal/domain/entities"
+)
+
+type HourlyRateRepository interface {
+	Create(ctx context.Context, rate *entities.HourlyRate) error
+	List(ctx context.Context) ([]*entities.HourlyRate, error)
+}
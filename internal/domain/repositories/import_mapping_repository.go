@@ -0,0 +1,14 @@
+package repositories
+
+import (
+	"context"
+	"task-engine/internal/domain/entities"
+	"task-engine/internal/domain/entities/common"
+)
+
+type ImportMappingRepository interface {
+	// FindByExternalIDs returns the mappings found for the records of the source, keyed by external id
+	FindByExternalIDs(ctx context.Context, source common.ImportSource, kind common.ImportEntityKind, externalIDs []string) (map[string]*entities.ImportMapping, error)
+	// Save creates the mapping or points the existing one at its new entity
+	Save(ctx context.Context, mapping *entities.ImportMapping) error
+}
@@ -0,0 +1,17 @@
+package repositories
+
+import (
+	"context"
+	"task-engine/internal/domain/entities"
+)
+
+// ImportRepository bulk loads imported tasks. Its methods must run within a transaction.
+type ImportRepository interface {
+	// ReserveTaskIDs takes n ids from the task id sequence, so tasks can be copied with their ids
+	ReserveTaskIDs(ctx context.Context, n int) ([]int64, error)
+	// CopyTasks loads tasks that already have ids, indexing them in their project's language
+	CopyTasks(ctx context.Context, tasks []*entities.Task) error
+	// CopyTaskTags loads the tags of the tasks, creating the tags that do not exist yet
+	CopyTaskTags(ctx context.Context, tasks []*entities.Task) error
+	CopyAssignments(ctx context.Context, tasks []*entities.Task) error
+}
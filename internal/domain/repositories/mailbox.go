@@ -0,0 +1,17 @@
+package repositories
+
+import (
+	"context"
+	"task-engine/internal/domain/entities"
+)
+
+// Mailbox is a source of inbound emails, e.g. a Maildir. Fetched emails stay in the mailbox
+// until they are marked done or failed, so an interrupted ingestion picks them up again.
+type Mailbox interface {
+	// Fetch returns up to limit new emails, oldest first. Messages that cannot be parsed are
+	// marked failed and skipped.
+	Fetch(ctx context.Context, limit int) ([]*entities.InboundEmail, error)
+	MarkDone(ctx context.Context, email *entities.InboundEmail) error
+	// MarkFailed sets the email aside for an operator to look at
+	MarkFailed(ctx context.Context, email *entities.InboundEmail, reason error) error
+}
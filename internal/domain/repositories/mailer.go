@@ -0,0 +1,11 @@
+package repositories
+
+import (
+	"context"
+	"task-engine/internal/domain/entities"
+)
+
+// Mailer sends emails, e.g. over SMTP
+type Mailer interface {
+	Send(ctx context.Context, message *entities.EmailMessage) error
+}
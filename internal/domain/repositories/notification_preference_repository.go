@@ -0,0 +1,14 @@
+package repositories
+
+import (
+	"context"
+	"task-engine/internal/domain/entities"
+)
+
+type NotificationPreferenceRepository interface {
+	ListByUser(ctx context.Context, userID int64) ([]*entities.NotificationPreference, error)
+	// ListByUsers returns the preferences of the given users keyed by user id
+	ListByUsers(ctx context.Context, userIDs []int64) (map[int64][]*entities.NotificationPreference, error)
+	// Save inserts or replaces the given preferences of the user, others are left unchanged
+	Save(ctx context.Context, userID int64, preferences []*entities.NotificationPreference) error
+}
@@ -0,0 +1,38 @@
+package repositories
+
+import (
+	"context"
+	"task-engine/internal/domain/entities"
+	"task-engine/internal/domain/entities/common"
+	"time"
+)
+
+// InboxFilter selects the in-app notifications of a user, newest first
+type InboxFilter struct {
+	UserID     int64
+	UnreadOnly bool
+	Limit      int
+	Offset     int
+}
+
+type NotificationRepository interface {
+	Create(ctx context.Context, notification *entities.Notification) error
+	FindByID(ctx context.Context, id int64) (*entities.Notification, error)
+	// FindByIDs returns the notifications found, keyed by id
+	FindByIDs(ctx context.Context, ids []int64) (map[int64]*entities.Notification, error)
+	ListInbox(ctx context.Context, filter InboxFilter) ([]*entities.Notification, error)
+	CountUnread(ctx context.Context, userID int64) (int, error)
+	Update(ctx context.Context, notification *entities.Notification) error
+	// MarkAllRead marks every unread inbox notification of the user as read and returns how many changed
+	MarkAllRead(ctx context.Context, userID int64, at time.Time) (int64, error)
+
+	CreateDelivery(ctx context.Context, delivery *entities.NotificationDelivery) error
+	// ClaimPendingDelivery locks the oldest pending delivery of the channel with an id above
+	// afterID, skipping rows locked by another dispatcher and emails of users who get a digest
+	// instead. It returns ErrNotFound when there is none and must run inside a transaction.
+	ClaimPendingDelivery(ctx context.Context, channel common.NotificationChannel, afterID int64) (*entities.NotificationDelivery, error)
+	// ListPendingDeliveriesByUser locks every pending delivery of the user on the channel, oldest
+	// first. It must run inside a transaction.
+	ListPendingDeliveriesByUser(ctx context.Context, userID int64, channel common.NotificationChannel) ([]*entities.NotificationDelivery, error)
+	UpdateDelivery(ctx context.Context, delivery *entities.NotificationDelivery) error
+}
@@ -0,0 +1,25 @@
+package repositories
+
+import (
+	"context"
+	"task-engine/internal/domain/entities"
+)
+
+type ProjectRepository interface {
+	// Create and Update return ErrConflict when another project has the same key or inbound email
+	Create(ctx context.Context, project *entities.Project) error
+	FindByID(ctx context.Context, id int64) (*entities.Project, error)
+	// FindByIDForUpdate locks the project row until the transaction of the context ends
+	FindByIDForUpdate(ctx context.Context, id int64) (*entities.Project, error)
+	// FindByIDForShare keeps the project row from being locked for update until the
+	// transaction of the context ends
+	FindByIDForShare(ctx context.Context, id int64) (*entities.Project, error)
+	// IsVisibleTo reports whether the user owns the project, belongs to it through its team
+	// or has an assigned task in it, as SearchFilter.VisibleTo
+	IsVisibleTo(ctx context.Context, projectID, userID int64) (bool, error)
+	// FindByKeys returns the projects found, keyed by project key
+	FindByKeys(ctx context.Context, keys []string) (map[string]*entities.Project, error)
+	// FindByInboundEmails returns the projects found, keyed by lowercased inbound email
+	FindByInboundEmails(ctx context.Context, addresses []string) (map[string]*entities.Project, error)
+	Update(ctx context.Context, project *entities.Project) error
+}
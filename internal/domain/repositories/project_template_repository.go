@@ -0,0 +1,13 @@
+package repositories
+
+import (
+	"context"
+	"task-engine/internal/domain/entities"
+)
+
+type ProjectTemplateRepository interface {
+	Create(ctx context.Context, template *entities.ProjectTemplate) error
+	FindByID(ctx context.Context, id int64) (*entities.ProjectTemplate, error)
+	List(ctx context.Context) ([]*entities.ProjectTemplate, error)
+	Delete(ctx context.Context, id int64) error
+}
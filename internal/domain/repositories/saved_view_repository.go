@@ -0,0 +1,27 @@
+package repositories
+
+import (
+	"context"
+	"task-engine/internal/domain/entities"
+)
+
+// SavedViewFilter selects the views the user sees, pinned ones first. A zero ProjectID
+// lists the views of every project and those spanning projects.
+type SavedViewFilter struct {
+	UserID    int64
+	ProjectID int64
+}
+
+type SavedViewRepository interface {
+	Create(ctx context.Context, view *entities.SavedView) error
+	FindByID(ctx context.Context, id int64) (*entities.SavedView, error)
+	// List sets Pinned for the user of the filter
+	List(ctx context.Context, filter SavedViewFilter) ([]*entities.SavedView, error)
+	Update(ctx context.Context, view *entities.SavedView) error
+	Delete(ctx context.Context, id int64) error
+
+	// Pin and Unpin are idempotent
+	Pin(ctx context.Context, viewID, userID int64) error
+	Unpin(ctx context.Context, viewID, userID int64) error
+	IsPinned(ctx context.Context, viewID, userID int64) (bool, error)
+}
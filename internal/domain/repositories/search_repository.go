@@ -0,0 +1,42 @@
+package repositories
+
+import (
+	"context"
+	"task-engine/internal/domain/entities"
+	"task-engine/internal/domain/entities/common"
+)
+
+// SearchFilter selects the records matching Query, best match first. Empty Types searches
+// every type. Statuses and Tags apply to tasks and to the comments of matching tasks, so
+// projects are left out when either is set. A non-zero VisibleTo restricts the results to
+// the projects the user owns, belongs to through its team or has an assigned task in.
+type SearchFilter struct {
+	Query     string
+	Types     []common.SearchResultType
+	ProjectID int64
+	Statuses  []common.TaskStatus
+	Tags      []string
+	VisibleTo int64
+	Limit     int
+	Offset    int
+}
+
+// SuggestFilter selects up to Limit records of each type whose name starts with Prefix,
+// compared case-insensitively. Empty Types suggests every type. ProjectID restricts tasks to
+// the project and tags to those used in it, VisibleTo works as in SearchFilter and restricts
+// tags to those used in the visible projects.
+type SuggestFilter struct {
+	Prefix    string
+	Types     []common.SuggestionType
+	ProjectID int64
+	VisibleTo int64
+	Limit     int
+}
+
+type SearchRepository interface {
+	Search(ctx context.Context, filter SearchFilter) ([]*entities.SearchResult, error)
+	Suggest(ctx context.Context, filter SuggestFilter) ([]*entities.Suggestion, error)
+	// Reindex moves the tasks and comments of the project, or of every project when projectID
+	// is zero, to their project's search language and returns how many were reindexed
+	Reindex(ctx context.Context, projectID int64) (int64, error)
+}
@@ -0,0 +1,18 @@
+package repositories
+
+import (
+	"context"
+	"task-engine/internal/domain/entities"
+)
+
+type SubscriptionRepository interface {
+	// Create returns ErrConflict when the user already has a subscription to the target
+	Create(ctx context.Context, subscription *entities.Subscription) error
+	Find(ctx context.Context, userID int64, target entities.SubscriptionTarget) (*entities.Subscription, error)
+	Update(ctx context.Context, subscription *entities.Subscription) error
+	ListByUser(ctx context.Context, userID int64) ([]*entities.Subscription, error)
+	// ListByTarget returns the active subscriptions to the target
+	ListByTarget(ctx context.Context, target entities.SubscriptionTarget) ([]*entities.Subscription, error)
+	// ListForEvent returns every subscription, unsubscribed ones included, to the task or the project
+	ListForEvent(ctx context.Context, projectID, taskID int64) ([]*entities.Subscription, error)
+}
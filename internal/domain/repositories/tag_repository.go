@@ -0,0 +1,10 @@
+package repositories
+
+import "context"
+
+type TagRepository interface {
+	// ListByTasks returns the tag names of the given tasks keyed by task id
+	ListByTasks(ctx context.Context, taskIDs []int64) (map[int64][]string, error)
+	// SetTaskTags replaces the tags of a task, creating unknown tags
+	SetTaskTags(ctx context.Context, taskID int64, names []string) error
+}
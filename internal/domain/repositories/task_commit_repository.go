@@ -0,0 +1,17 @@
+package repositories
+
+import (
+	"context"
+	"task-engine/internal/domain/entities"
+)
+
+type TaskCommitRepository interface {
+	// Create links the commit to the task and reports whether it was new. A commit already
+	// linked to the task is left unchanged.
+	Create(ctx context.Context, link *entities.TaskCommit) (bool, error)
+	// SetComment records the comment added for the link
+	SetComment(ctx context.Context, id, commentID int64) error
+	Delete(ctx context.Context, id int64) error
+	// ListByTask returns the commits linked to the task, newest first
+	ListByTask(ctx context.Context, taskID int64) ([]*entities.TaskCommit, error)
+}
@@ -0,0 +1,13 @@
+package repositories
+
+import (
+	"context"
+	"task-engine/internal/domain/entities"
+)
+
+type TaskEmailRepository interface {
+	// Create returns ErrConflict when an email with the same message id was already ingested
+	Create(ctx context.Context, email *entities.TaskEmail) error
+	// FindByMessageIDs returns the ingested emails found, keyed by message id
+	FindByMessageIDs(ctx context.Context, messageIDs []string) (map[string]*entities.TaskEmail, error)
+}
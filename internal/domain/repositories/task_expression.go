@@ -0,0 +1,69 @@
+package repositories
+
+import "time"
+
+// Task columns a TaskExpression can test
+const (
+	TaskColumnStatus    = "status"
+	TaskColumnCategory  = "status_category"
+	TaskColumnPriority  = "priority"
+	TaskColumnDueDate   = "due_date"
+	TaskColumnCreatedAt = "created_at"
+	TaskColumnUpdatedAt = "updated_at"
+)
+
+// TaskExpression is a condition on tasks, such as a task query once its values are resolved.
+// Ordered comparisons on enums are resolved into the values they allow, so every condition
+// can use the indexes on the tested column.
+type TaskExpression interface {
+	isTaskExpression()
+}
+
+type TaskAnd struct {
+	Operands []TaskExpression
+}
+
+type TaskOr struct {
+	Operands []TaskExpression
+}
+
+// TaskNot matches the tasks its operand does not, including those where it is unknown
+type TaskNot struct {
+	Operand TaskExpression
+}
+
+// TaskValueCondition matches tasks whose column holds one of the values
+type TaskValueCondition struct {
+	Column string
+	Values []string
+}
+
+// TaskDateCondition matches tasks whose date column is in [From, To), a zero bound is open.
+// IsNull matches the tasks without a date instead.
+type TaskDateCondition struct {
+	Column string
+	From   time.Time
+	To     time.Time
+	IsNull bool
+}
+
+// TaskTagCondition matches tasks tagged with any of the names, compared case-insensitively
+type TaskTagCondition struct {
+	Names []string
+}
+
+// TaskAssigneeCondition matches tasks assigned to any of the users, or to nobody when
+// Unassigned is set
+type TaskAssigneeCondition struct {
+	UserIDs    []int64
+	Unassigned bool
+}
+
+func (TaskAnd) isTaskExpression()               {}
+func (TaskOr) isTaskExpression()                {}
+func (TaskNot) isTaskExpression()               {}
+func (TaskValueCondition) isTaskExpression()    {}
+func (TaskDateCondition) isTaskExpression()     {}
+func (TaskTagCondition) isTaskExpression()      {}
+func (TaskAssigneeCondition) isTaskExpression() {}
+func (CustomFieldCondition) isTaskExpression()  {}
@@ -0,0 +1,94 @@
+package repositories
+
+import (
+	"task-engine/internal/domain/entities"
+	"task-engine/internal/domain/entities/common"
+)
+
+// FilterOperator compares a column or custom field against a value
+type FilterOperator string
+
+const (
+	FilterOpEqual          FilterOperator = "eq"
+	FilterOpNotEqual       FilterOperator = "ne"
+	FilterOpGreater        FilterOperator = "gt"
+	FilterOpGreaterOrEqual FilterOperator = "gte"
+	FilterOpLess           FilterOperator = "lt"
+	FilterOpLessOrEqual    FilterOperator = "lte"
+	FilterOpContains       FilterOperator = "contains"
+)
+
+func (o FilterOperator) IsValid() bool {
+	switch o {
+	case FilterOpEqual, FilterOpNotEqual, FilterOpGreater, FilterOpGreaterOrEqual,
+		FilterOpLess, FilterOpLessOrEqual, FilterOpContains:
+		return true
+	}
+	return false
+}
+
+// Sortable task columns
+const (
+	TaskSortCreatedAt = "created_at"
+	TaskSortUpdatedAt = "updated_at"
+	TaskSortDueDate   = "due_date"
+	TaskSortPriority  = "priority"
+	TaskSortStatus    = "status"
+	TaskSortTitle     = "title"
+)
+
+func IsTaskSortColumn(column string) bool {
+	switch column {
+	case TaskSortCreatedAt, TaskSortUpdatedAt, TaskSortDueDate, TaskSortPriority, TaskSortStatus, TaskSortTitle:
+		return true
+	}
+	return false
+}
+
+// IsTaskKeysetColumn reports whether pages sorted by the column can be keyed by a TaskKeyset
+func IsTaskKeysetColumn(column string) bool {
+	switch column {
+	case TaskSortCreatedAt, TaskSortDueDate, TaskSortPriority:
+		return true
+	}
+	return false
+}
+
+// CustomFieldCondition restricts tasks by the value they hold for a custom field
+type CustomFieldCondition struct {
+	Field    *entities.CustomFieldDefinition
+	Operator FilterOperator
+	Value    *entities.CustomFieldValue
+}
+
+// TaskSort orders tasks by a column or, when CustomField is set, by a custom field value
+type TaskSort struct {
+	Column      string
+	CustomField *entities.CustomFieldDefinition
+	Descending  bool
+}
+
+// TaskKeyset starts a page after the task with the given sort value and id, instead of
+// skipping Offset tasks. It needs a single sort by created_at, due_date or priority, or the
+// default order. Value is a time.Time for dates, a common.TaskPriority for priorities and
+// nil for a task without a due date.
+type TaskKeyset struct {
+	Value interface{}
+	ID    int64
+}
+
+// TaskFilter selects tasks. A non-zero VisibleTo restricts them to the projects the user
+// owns, belongs to through its team or has an assigned task in, as in SearchFilter.
+type TaskFilter struct {
+	ProjectID    int64
+	VisibleTo    int64
+	Statuses     []common.TaskStatus
+	Categories   []common.StatusCategory
+	Priorities   []common.TaskPriority
+	CustomFields []CustomFieldCondition
+	Expression   TaskExpression
+	Sort         []TaskSort
+	After        *TaskKeyset
+	Limit        int
+	Offset       int
+}
@@ -0,0 +1,33 @@
+package repositories
+
+import (
+	"context"
+	"task-engine/internal/domain/entities"
+	"task-engine/internal/domain/entities/common"
+	"time"
+)
+
+type TaskRepository interface {
+	Create(ctx context.Context, task *entities.Task) error
+	FindByID(ctx context.Context, id int64) (*entities.Task, error)
+	// FindByIDForUpdate locks the task row until the transaction of the context ends
+	FindByIDForUpdate(ctx context.Context, id int64) (*entities.Task, error)
+	ListByProject(ctx context.Context, projectID int64) ([]*entities.Task, error)
+	ListSubtasks(ctx context.Context, parentID int64) ([]*entities.Task, error)
+	// ListDueByAssignee returns the open tasks assigned to the user that are due before the given time
+	ListDueByAssignee(ctx context.Context, userID int64, dueBefore time.Time) ([]*entities.Task, error)
+	List(ctx context.Context, filter TaskFilter) ([]*entities.Task, error)
+	// Count returns how many tasks the filter selects, ignoring its sort, limit and offset
+	Count(ctx context.Context, filter TaskFilter) (int, error)
+	// CountEach returns how many tasks each of the filters selects, in one round trip
+	CountEach(ctx context.Context, filters []TaskFilter) ([]int, error)
+	// CountFacets counts the tasks the filter selects by each of the facets
+	CountFacets(ctx context.Context, filter TaskFilter, facets []common.TaskFacet) (entities.TaskFacets, error)
+	Update(ctx context.Context, task *entities.Task) error
+	// Delete hard-deletes the task together with its subtasks, comments and attachments
+	Delete(ctx context.Context, id int64) error
+
+	CountByStatus(ctx context.Context, projectID int64) (map[common.TaskStatus]int, error)
+	// UpdateStatusCategories re-categorises the project's tasks after their workflow changed
+	UpdateStatusCategories(ctx context.Context, projectID int64, categories map[common.TaskStatus]common.StatusCategory) error
+}
@@ -0,0 +1,19 @@
+package repositories
+
+import (
+	"context"
+	"task-engine/internal/domain/entities"
+)
+
+type TeamRepository interface {
+	// Create returns ErrConflict when the handle is taken
+	Create(ctx context.Context, team *entities.Team) error
+	FindByID(ctx context.Context, id int64) (*entities.Team, error)
+	List(ctx context.Context) ([]*entities.Team, error)
+	// FindByHandles returns the teams found, keyed by handle. Handles are matched case-insensitively.
+	FindByHandles(ctx context.Context, handles []string) (map[string]*entities.Team, error)
+	// ListMembers returns the member ids of the given teams keyed by team id
+	ListMembers(ctx context.Context, teamIDs []int64) (map[int64][]int64, error)
+	AddMember(ctx context.Context, teamID, userID int64) error
+	RemoveMember(ctx context.Context, teamID, userID int64) error
+}
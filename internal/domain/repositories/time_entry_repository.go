@@ -0,0 +1,12 @@
+package repositories
+
+import (
+	"context"
+	"task-engine/internal/domain/entities"
+)
+
+type TimeEntryRepository interface {
+	Create(ctx context.Context, entry *entities.TimeEntry) error
+	ListByTask(ctx context.Context, taskID int64) ([]*entities.TimeEntry, error)
+	ListByProject(ctx context.Context, projectID int64) ([]*entities.TimeEntry, error)
+}
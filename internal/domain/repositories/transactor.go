@@ -0,0 +1,14 @@
+package repositories
+
+import "context"
+
+// Transactor runs fn inside a database transaction. Repositories called with the
+// context passed to fn take part in the transaction, which is committed when fn
+// returns nil and rolled back otherwise.
+type Transactor interface {
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+	// AfterCommit runs fn once the transaction of ctx is committed, with a context outside
+	// of it, and drops fn when the transaction is rolled back. Without a transaction fn runs
+	// right away.
+	AfterCommit(ctx context.Context, fn func(ctx context.Context))
+}
@@ -0,0 +1,19 @@
+package repositories
+
+import (
+	"context"
+	"task-engine/internal/domain/entities"
+)
+
+type UserRepository interface {
+	FindByID(ctx context.Context, id int64) (*entities.User, error)
+	// FindByIDs returns the users found, keyed by id. Unknown ids are skipped.
+	FindByIDs(ctx context.Context, ids []int64) (map[int64]*entities.User, error)
+	// FindByUsernames returns the users found, keyed by lowercased username. Usernames are
+	// matched case-insensitively.
+	FindByUsernames(ctx context.Context, usernames []string) (map[string]*entities.User, error)
+	// FindByEmails returns the users found, keyed by lowercased email. Emails are matched
+	// case-insensitively.
+	FindByEmails(ctx context.Context, emails []string) (map[string]*entities.User, error)
+	Update(ctx context.Context, user *entities.User) error
+}
@@ -0,0 +1,48 @@
+package repositories
+
+import (
+	"context"
+	"task-engine/internal/domain/entities"
+	"task-engine/internal/domain/entities/common"
+	"time"
+)
+
+// WebhookFilter selects webhooks, a zero ProjectID lists every webhook
+type WebhookFilter struct {
+	ProjectID int64
+}
+
+// WebhookDeliveryFilter selects the deliveries of a webhook, newest first. An empty status
+// lists every delivery.
+type WebhookDeliveryFilter struct {
+	WebhookID int64
+	Status    common.WebhookDeliveryStatus
+	Limit     int
+	Offset    int
+}
+
+type WebhookRepository interface {
+	Create(ctx context.Context, webhook *entities.Webhook) error
+	FindByID(ctx context.Context, id int64) (*entities.Webhook, error)
+	// FindByIDs returns the webhooks found, keyed by id
+	FindByIDs(ctx context.Context, ids []int64) (map[int64]*entities.Webhook, error)
+	List(ctx context.Context, filter WebhookFilter) ([]*entities.Webhook, error)
+	// ListForProject returns the active webhooks of the project and the organisation-wide ones
+	ListForProject(ctx context.Context, projectID int64) ([]*entities.Webhook, error)
+	Update(ctx context.Context, webhook *entities.Webhook) error
+	Delete(ctx context.Context, id int64) error
+
+	CreateDelivery(ctx context.Context, delivery *entities.WebhookDelivery) error
+	FindDelivery(ctx context.Context, id int64) (*entities.WebhookDelivery, error)
+	ListDeliveries(ctx context.Context, filter WebhookDeliveryFilter) ([]*entities.WebhookDelivery, error)
+	// ClaimDueDeliveries takes up to limit pending deliveries of active webhooks whose next
+	// attempt is due and postpones their next attempt to leaseUntil, so other dispatchers skip
+	// them while they are sent. A delivery whose dispatcher stops before recording the attempt
+	// is due again once the lease expires.
+	ClaimDueDeliveries(ctx context.Context, now, leaseUntil time.Time, limit int) ([]*entities.WebhookDelivery, error)
+	UpdateDelivery(ctx context.Context, delivery *entities.WebhookDelivery) error
+
+	CreateAttempt(ctx context.Context, attempt *entities.WebhookAttempt) error
+	// ListAttempts returns the attempts of the delivery, oldest first
+	ListAttempts(ctx context.Context, deliveryID int64) ([]*entities.WebhookAttempt, error)
+}
@@ -0,0 +1,12 @@
+package repositories
+
+import (
+	"context"
+	"task-engine/internal/domain/entities"
+)
+
+// WebhookSender posts webhook requests, e.g. over HTTP. An error means no response was
+// received, any status code the receiver answered with is returned as a response.
+type WebhookSender interface {
+	Send(ctx context.Context, request *entities.WebhookRequest) (*entities.WebhookResponse, error)
+}
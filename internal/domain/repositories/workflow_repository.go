@@ -0,0 +1,12 @@
+package repositories
+
+import (
+	"context"
+	"task-engine/internal/domain/entities"
+)
+
+type WorkflowRepository interface {
+	// FindByProject returns ErrNotFound when the project still uses the default workflow
+	FindByProject(ctx context.Context, projectID int64) (*entities.Workflow, error)
+	Save(ctx context.Context, workflow *entities.Workflow) error
+}
@@ -0,0 +1,93 @@
+package mail
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"task-engine/internal/domain/entities"
+	"task-engine/pkg/logger"
+
+	"go.uber.org/zap"
+)
+
+// Maildir info flags set when a message is moved out of new/: seen, and flagged for the
+// messages that could not be ingested
+const (
+	maildirDoneInfo   = ":2,S"
+	maildirFailedInfo = ":2,FS"
+)
+
+// Maildir reads inbound emails from a Maildir. Messages are taken from new/ and moved to
+// cur/ once handled, flagged when they failed, so a mail client shows which need a look.
+type Maildir struct {
+	path string
+}
+
+func NewMaildir(path string) (*Maildir, error) {
+	for _, dir := range []string{"new", "cur", "tmp"} {
+		if err := os.MkdirAll(filepath.Join(path, dir), 0o750); err != nil {
+			return nil, err
+		}
+	}
+	return &Maildir{path: path}, nil
+}
+
+func (m *Maildir) Fetch(ctx context.Context, limit int) ([]*entities.InboundEmail, error) {
+	entries, err := os.ReadDir(filepath.Join(m.path, "new"))
+	if err != nil {
+		return nil, err
+	}
+	// Maildir names start with the delivery time, so sorting them gives delivery order
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+
+	var emails []*entities.InboundEmail
+	for _, entry := range entries {
+		if len(emails) >= limit || ctx.Err() != nil {
+			break
+		}
+		if !entry.Type().IsRegular() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+
+		email, err := m.read(entry.Name())
+		if err != nil {
+			logger.Warn("unparseable email in maildir", zap.String("file", entry.Name()), zap.Error(err))
+			if err := m.move(entry.Name(), maildirFailedInfo); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		emails = append(emails, email)
+	}
+	return emails, ctx.Err()
+}
+
+func (m *Maildir) MarkDone(ctx context.Context, email *entities.InboundEmail) error {
+	return m.move(email.Source, maildirDoneInfo)
+}
+
+func (m *Maildir) MarkFailed(ctx context.Context, email *entities.InboundEmail, reason error) error {
+	logger.Warn("email not ingested", zap.String("file", email.Source), zap.String("message_id", email.MessageID), zap.Error(reason))
+	return m.move(email.Source, maildirFailedInfo)
+}
+
+func (m *Maildir) read(name string) (*entities.InboundEmail, error) {
+	file, err := os.Open(filepath.Join(m.path, "new", name))
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	email, err := ParseMessage(file)
+	if err != nil {
+		return nil, err
+	}
+	email.Source = name
+	return email, nil
+}
+
+func (m *Maildir) move(name, info string) error {
+	return os.Rename(filepath.Join(m.path, "new", name), filepath.Join(m.path, "cur", name+info))
+}
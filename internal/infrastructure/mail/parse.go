@@ -0,0 +1,259 @@
+package mail
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"html"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	netmail "net/mail"
+	"path"
+	"regexp"
+	"strings"
+	"task-engine/internal/domain/entities"
+
+	"golang.org/x/text/encoding/htmlindex"
+)
+
+// maxMIMEDepth bounds how deeply nested multiparts are followed
+const maxMIMEDepth = 10
+
+var (
+	htmlBreakPattern = regexp.MustCompile(`(?i)<br\s*/?>|</(p|div|li|tr|h[1-6])>`)
+	htmlTagPattern   = regexp.MustCompile(`(?s)<[^>]*>`)
+	htmlDropPattern  = regexp.MustCompile(`(?is)<(style|script|head)[^>]*>.*?</(style|script|head)>`)
+	blankRunPattern  = regexp.MustCompile(`\n{3,}`)
+)
+
+var wordDecoder = &mime.WordDecoder{CharsetReader: charsetReader}
+
+// ParseMessage reads an RFC 822 message. The text comes from the first text/plain part,
+// or from the first text/html part with its markup removed. Parts with a filename, or
+// marked as attachments, become attachments.
+func ParseMessage(r io.Reader) (*entities.InboundEmail, error) {
+	message, err := netmail.ReadMessage(r)
+	if err != nil {
+		return nil, err
+	}
+	header := message.Header
+
+	email := &entities.InboundEmail{
+		MessageID:  firstMessageID(header.Get("Message-ID")),
+		InReplyTo:  firstMessageID(header.Get("In-Reply-To")),
+		References: messageIDs(header.Get("References")),
+		Subject:    decodeHeader(header.Get("Subject")),
+	}
+	if date, err := header.Date(); err == nil {
+		email.Date = date
+	}
+
+	parser := netmail.AddressParser{WordDecoder: wordDecoder}
+	from, err := parser.ParseList(header.Get("From"))
+	if err != nil || len(from) == 0 {
+		return nil, fmt.Errorf("invalid From header: %q", header.Get("From"))
+	}
+	email.From = strings.ToLower(from[0].Address)
+	email.FromName = from[0].Name
+
+	seen := make(map[string]bool)
+	for _, name := range []string{"To", "Cc", "Delivered-To", "X-Original-To"} {
+		for _, value := range header[name] {
+			addresses, err := parser.ParseList(value)
+			if err != nil {
+				continue
+			}
+			for _, address := range addresses {
+				lowered := strings.ToLower(address.Address)
+				if !seen[lowered] {
+					seen[lowered] = true
+					email.Recipients = append(email.Recipients, lowered)
+				}
+			}
+		}
+	}
+
+	var body parsedBody
+	if err := body.walk(header, message.Body, 0); err != nil {
+		return nil, err
+	}
+	email.Text = body.text()
+	email.Attachments = body.attachments
+	return email, nil
+}
+
+// parsedBody collects what the MIME parts of a message hold
+type parsedBody struct {
+	plain, html *string
+	attachments []*entities.InboundAttachment
+}
+
+func (b *parsedBody) text() string {
+	switch {
+	case b.plain != nil:
+		return strings.TrimSpace(strings.ReplaceAll(*b.plain, "\r\n", "\n"))
+	case b.html != nil:
+		return htmlToText(*b.html)
+	}
+	return ""
+}
+
+func (b *parsedBody) walk(header map[string][]string, body io.Reader, depth int) error {
+	get := func(name string) string {
+		if values := header[name]; len(values) > 0 {
+			return values[0]
+		}
+		return ""
+	}
+
+	mediaType, params, err := mime.ParseMediaType(get("Content-Type"))
+	if err != nil {
+		mediaType, params = "text/plain", map[string]string{}
+	}
+
+	if strings.HasPrefix(mediaType, "multipart/") && depth < maxMIMEDepth {
+		reader := multipart.NewReader(body, params["boundary"])
+		for {
+			part, err := reader.NextRawPart()
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			if err := b.walk(part.Header, part, depth+1); err != nil {
+				return err
+			}
+		}
+	}
+
+	content, err := io.ReadAll(decodeTransfer(get("Content-Transfer-Encoding"), body))
+	if err != nil {
+		return err
+	}
+
+	disposition, dispositionParams, _ := mime.ParseMediaType(get("Content-Disposition"))
+	filename := dispositionParams["filename"]
+	if filename == "" {
+		filename = params["name"]
+	}
+	filename = path.Base(strings.ReplaceAll(decodeHeader(filename), `\`, "/"))
+	if filename == "." || filename == "/" {
+		filename = ""
+	}
+
+	isText := mediaType == "text/plain" || mediaType == "text/html"
+	if disposition == "attachment" || filename != "" || !isText {
+		if filename == "" {
+			filename = "attachment"
+			if extensions, _ := mime.ExtensionsByType(mediaType); len(extensions) > 0 {
+				filename += extensions[0]
+			}
+		}
+		b.attachments = append(b.attachments, &entities.InboundAttachment{
+			Filename:    filename,
+			ContentType: mediaType,
+			Content:     content,
+		})
+		return nil
+	}
+
+	text := decodeCharset(params["charset"], content)
+	if mediaType == "text/html" && b.html == nil {
+		b.html = &text
+	} else if mediaType == "text/plain" && b.plain == nil {
+		b.plain = &text
+	}
+	return nil
+}
+
+func decodeTransfer(encoding string, body io.Reader) io.Reader {
+	switch strings.ToLower(strings.TrimSpace(encoding)) {
+	case "base64":
+		return base64.NewDecoder(base64.StdEncoding, &base64Cleaner{r: body})
+	case "quoted-printable":
+		return quotedprintable.NewReader(body)
+	}
+	return body
+}
+
+// base64Cleaner drops the characters that are not part of the base64 alphabet, as some
+// mailers pad lines with spaces
+type base64Cleaner struct {
+	r io.Reader
+}
+
+func (c *base64Cleaner) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	kept := 0
+	for _, ch := range p[:n] {
+		if ch >= 'A' && ch <= 'Z' || ch >= 'a' && ch <= 'z' || ch >= '0' && ch <= '9' || ch == '+' || ch == '/' || ch == '=' {
+			p[kept] = ch
+			kept++
+		}
+	}
+	if kept == 0 && n > 0 && err == nil {
+		return c.Read(p)
+	}
+	return kept, err
+}
+
+func charsetReader(charset string, input io.Reader) (io.Reader, error) {
+	encoding, err := htmlindex.Get(charset)
+	if err != nil {
+		return nil, err
+	}
+	return encoding.NewDecoder().Reader(input), nil
+}
+
+// decodeCharset converts text to UTF-8, unknown charsets are read as UTF-8
+func decodeCharset(charset string, content []byte) string {
+	if charset != "" && !strings.EqualFold(charset, "utf-8") && !strings.EqualFold(charset, "us-ascii") {
+		if reader, err := charsetReader(charset, bytes.NewReader(content)); err == nil {
+			if decoded, err := io.ReadAll(reader); err == nil {
+				content = decoded
+			}
+		}
+	}
+	return strings.ToValidUTF8(strings.ReplaceAll(string(content), "\x00", ""), "�")
+}
+
+func decodeHeader(value string) string {
+	decoded, err := wordDecoder.DecodeHeader(value)
+	if err != nil {
+		return value
+	}
+	return decoded
+}
+
+func htmlToText(markup string) string {
+	markup = htmlDropPattern.ReplaceAllString(markup, "")
+	markup = htmlBreakPattern.ReplaceAllString(markup, "\n")
+	text := html.UnescapeString(htmlTagPattern.ReplaceAllString(markup, ""))
+
+	lines := strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace(line)
+	}
+	return strings.TrimSpace(blankRunPattern.ReplaceAllString(strings.Join(lines, "\n"), "\n\n"))
+}
+
+// messageIDs returns the ids of a header listing message ids, without angle brackets
+func messageIDs(value string) []string {
+	var ids []string
+	for _, field := range strings.Fields(value) {
+		if id := strings.Trim(field, "<>,"); id != "" {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+func firstMessageID(value string) string {
+	if ids := messageIDs(value); len(ids) > 0 {
+		return ids[0]
+	}
+	return ""
+}
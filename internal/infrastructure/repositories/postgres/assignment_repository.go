@@ -0,0 +1,58 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/lib/pq"
+)
+
+type AssignmentRepository struct {
+	db DBTX
+}
+
+func NewAssignmentRepository(db DBTX) *AssignmentRepository {
+	return &AssignmentRepository{db: db}
+}
+
+func (r *AssignmentRepository) ListByTasks(ctx context.Context, taskIDs []int64) (map[int64][]int64, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT DISTINCT task_id, user_id
+		FROM assignments
+		WHERE task_id = ANY($1)
+		ORDER BY task_id, user_id`, pq.Array(taskIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	assignees := make(map[int64][]int64)
+	for rows.Next() {
+		var taskID, userID int64
+		if err := rows.Scan(&taskID, &userID); err != nil {
+			return nil, err
+		}
+		assignees[taskID] = append(assignees[taskID], userID)
+	}
+	return assignees, rows.Err()
+}
+
+func (r *AssignmentRepository) Assign(ctx context.Context, taskID, userID int64) (bool, error) {
+	result, err := r.db.ExecContext(ctx, `
+		INSERT INTO assignments (task_id, user_id)
+		SELECT $1, $2
+		WHERE NOT EXISTS (SELECT 1 FROM assignments WHERE task_id = $1 AND user_id = $2)`,
+		taskID, userID)
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	return affected > 0, err
+}
+
+func (r *AssignmentRepository) Unassign(ctx context.Context, taskID, userID int64) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM assignments WHERE task_id = $1 AND user_id = $2`, taskID, userID)
+	if err != nil {
+		return err
+	}
+	return expectAffected(result)
+}
@@ -0,0 +1,146 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"task-engine/internal/domain/entities"
+	"task-engine/internal/domain/repositories"
+
+	"github.com/lib/pq"
+)
+
+const attachmentColumns = `id, task_id, comment_id, blob_key, filename, content_type, size_bytes, uploaded_by, created_at`
+
+type AttachmentRepository struct {
+	db DBTX
+}
+
+func NewAttachmentRepository(db DBTX) *AttachmentRepository {
+	return &AttachmentRepository{db: db}
+}
+
+func (r *AttachmentRepository) Create(ctx context.Context, attachment *entities.Attachment) error {
+	return r.db.QueryRowContext(ctx, `
+		INSERT INTO attachments (task_id, comment_id, blob_key, filename, content_type, size_bytes, uploaded_by, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id`,
+		attachment.TaskID,
+		nullInt64(attachment.CommentID),
+		attachment.BlobKey,
+		attachment.Filename,
+		attachment.ContentType,
+		attachment.SizeBytes,
+		nullInt64(attachment.UploadedBy),
+		attachment.CreatedAt,
+	).Scan(&attachment.ID)
+}
+
+func (r *AttachmentRepository) FindByID(ctx context.Context, id int64) (*entities.Attachment, error) {
+	row := r.db.QueryRowContext(ctx, `SELECT `+attachmentColumns+` FROM attachments WHERE id = $1`, id)
+	attachment, err := scanAttachment(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, repositories.ErrNotFound
+	}
+	return attachment, err
+}
+
+func (r *AttachmentRepository) ListByTask(ctx context.Context, taskID int64) ([]*entities.Attachment, error) {
+	return r.list(ctx, `SELECT `+attachmentColumns+` FROM attachments WHERE task_id = $1 ORDER BY created_at, id`, taskID)
+}
+
+func (r *AttachmentRepository) ListByComment(ctx context.Context, commentID int64) ([]*entities.Attachment, error) {
+	return r.list(ctx, `SELECT `+attachmentColumns+` FROM attachments WHERE comment_id = $1 ORDER BY created_at, id`, commentID)
+}
+
+func (r *AttachmentRepository) list(ctx context.Context, query string, args ...interface{}) ([]*entities.Attachment, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var attachments []*entities.Attachment
+	for rows.Next() {
+		attachment, err := scanAttachment(rows)
+		if err != nil {
+			return nil, err
+		}
+		attachments = append(attachments, attachment)
+	}
+	return attachments, rows.Err()
+}
+
+func (r *AttachmentRepository) Delete(ctx context.Context, id int64) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM attachments WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	return expectAffected(result)
+}
+
+func (r *AttachmentRepository) BlobKeysByTask(ctx context.Context, taskID int64) ([]string, error) {
+	return r.keys(ctx, `
+		SELECT DISTINCT blob_key
+		FROM attachments
+		WHERE task_id = $1 OR task_id IN (SELECT id FROM tasks WHERE parent_id = $1)`, taskID)
+}
+
+func (r *AttachmentRepository) ReferencedKeys(ctx context.Context, keys []string) (map[string]bool, error) {
+	found, err := r.keys(ctx, `SELECT DISTINCT blob_key FROM attachments WHERE blob_key = ANY($1)`, pq.Array(keys))
+	if err != nil {
+		return nil, err
+	}
+
+	referenced := make(map[string]bool, len(found))
+	for _, key := range found {
+		referenced[key] = true
+	}
+	return referenced, nil
+}
+
+func (r *AttachmentRepository) keys(ctx context.Context, query string, args ...interface{}) ([]string, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var keys []string
+	for rows.Next() {
+		var key string
+		if err := rows.Scan(&key); err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	return keys, rows.Err()
+}
+
+func scanAttachment(row rowScanner) (*entities.Attachment, error) {
+	var (
+		attachment            entities.Attachment
+		commentID, uploadedBy sql.NullInt64
+		createdAt             sql.NullTime
+	)
+
+	err := row.Scan(
+		&attachment.ID,
+		&attachment.TaskID,
+		&commentID,
+		&attachment.BlobKey,
+		&attachment.Filename,
+		&attachment.ContentType,
+		&attachment.SizeBytes,
+		&uploadedBy,
+		&createdAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	attachment.CommentID = commentID.Int64
+	attachment.UploadedBy = uploadedBy.Int64
+	attachment.CreatedAt = createdAt.Time
+	return &attachment, nil
+}
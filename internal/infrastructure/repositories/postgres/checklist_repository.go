@@ -0,0 +1,147 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"task-engine/internal/domain/entities"
+	"task-engine/internal/domain/repositories"
+
+	"github.com/lib/pq"
+)
+
+const checklistItemColumns = `id, task_id, text, position, checked_by, checked_at, created_at, updated_at`
+
+type ChecklistRepository struct {
+	db DBTX
+}
+
+func NewChecklistRepository(db DBTX) *ChecklistRepository {
+	return &ChecklistRepository{db: db}
+}
+
+// Create appends the item at the end of the task checklist
+func (r *ChecklistRepository) Create(ctx context.Context, item *entities.ChecklistItem) error {
+	return r.db.QueryRowContext(ctx, `
+		INSERT INTO task_checklist_items (task_id, text, position, created_at, updated_at)
+		VALUES ($1, $2,
+			COALESCE((SELECT MAX(position) + 1 FROM task_checklist_items WHERE task_id = $1), 0),
+			$3, $4)
+		RETURNING id, position`,
+		item.TaskID,
+		item.Text,
+		item.CreatedAt,
+		item.UpdatedAt,
+	).Scan(&item.ID, &item.Position)
+}
+
+func (r *ChecklistRepository) FindByID(ctx context.Context, id int64) (*entities.ChecklistItem, error) {
+	row := r.db.QueryRowContext(ctx, `SELECT `+checklistItemColumns+` FROM task_checklist_items WHERE id = $1`, id)
+	item, err := scanChecklistItem(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, repositories.ErrNotFound
+	}
+	return item, err
+}
+
+func (r *ChecklistRepository) ListByTask(ctx context.Context, taskID int64) ([]*entities.ChecklistItem, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT `+checklistItemColumns+`
+		FROM task_checklist_items
+		WHERE task_id = $1
+		ORDER BY position, id`, taskID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var items []*entities.ChecklistItem
+	for rows.Next() {
+		item, err := scanChecklistItem(rows)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return items, rows.Err()
+}
+
+func (r *ChecklistRepository) Update(ctx context.Context, item *entities.ChecklistItem) error {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE task_checklist_items SET
+			text = $2, position = $3, checked_by = $4, checked_at = $5, updated_at = $6
+		WHERE id = $1`,
+		item.ID,
+		item.Text,
+		item.Position,
+		nullInt64(item.CheckedBy),
+		nullTime(item.CheckedAt),
+		item.UpdatedAt,
+	)
+	if err != nil {
+		return err
+	}
+	return expectAffected(result)
+}
+
+func (r *ChecklistRepository) Delete(ctx context.Context, id int64) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM task_checklist_items WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	return expectAffected(result)
+}
+
+func (r *ChecklistRepository) SummarizeByTasks(ctx context.Context, taskIDs []int64) (map[int64]entities.ChecklistSummary, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT task_id, COUNT(*), COUNT(checked_at)
+		FROM task_checklist_items
+		WHERE task_id = ANY($1)
+		GROUP BY task_id`, pq.Array(taskIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	summaries := make(map[int64]entities.ChecklistSummary)
+	for rows.Next() {
+		var (
+			taskID  int64
+			summary entities.ChecklistSummary
+		)
+		if err := rows.Scan(&taskID, &summary.Total, &summary.Checked); err != nil {
+			return nil, err
+		}
+		summaries[taskID] = summary
+	}
+	return summaries, rows.Err()
+}
+
+func scanChecklistItem(row rowScanner) (*entities.ChecklistItem, error) {
+	var (
+		item                 entities.ChecklistItem
+		checkedBy            sql.NullInt64
+		checkedAt            sql.NullTime
+		createdAt, updatedAt sql.NullTime
+	)
+
+	err := row.Scan(
+		&item.ID,
+		&item.TaskID,
+		&item.Text,
+		&item.Position,
+		&checkedBy,
+		&checkedAt,
+		&createdAt,
+		&updatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	item.CheckedBy = checkedBy.Int64
+	item.CheckedAt = checkedAt.Time
+	item.CreatedAt = createdAt.Time
+	item.UpdatedAt = updatedAt.Time
+	return &item, nil
+}
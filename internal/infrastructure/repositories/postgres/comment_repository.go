@@ -0,0 +1,262 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"task-engine/internal/domain/entities"
+	"task-engine/internal/domain/repositories"
+
+	"github.com/lib/pq"
+)
+
+const commentColumns = `id, task_id, parent_id, user_id, content, created_at, edited_at, deleted_at, deleted_by`
+
+const commentMentionColumns = `id, comment_id, kind, user_id, team_id, handle, start_offset, end_offset`
+
+type CommentRepository struct {
+	db DBTX
+}
+
+func NewCommentRepository(db DBTX) *CommentRepository {
+	return &CommentRepository{db: db}
+}
+
+// Create indexes the comment for search in the language of its task's project
+func (r *CommentRepository) Create(ctx context.Context, comment *entities.Comment) error {
+	return r.db.QueryRowContext(ctx, `
+		INSERT INTO comments (task_id, parent_id, user_id, content, created_at, search_language)
+		VALUES ($1, $2, $3, $4, $5,
+			(SELECT p.search_language FROM tasks t JOIN projects p ON p.id = t.project_id WHERE t.id = $1))
+		RETURNING id`,
+		comment.TaskID,
+		nullInt64(comment.ParentID),
+		comment.UserID,
+		comment.Content,
+		comment.CreatedAt,
+	).Scan(&comment.ID)
+}
+
+func (r *CommentRepository) FindByID(ctx context.Context, id int64) (*entities.Comment, error) {
+	row := r.db.QueryRowContext(ctx, `SELECT `+commentColumns+` FROM comments WHERE id = $1`, id)
+	comment, err := scanComment(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, repositories.ErrNotFound
+	}
+	return comment, err
+}
+
+func (r *CommentRepository) ListByTask(ctx context.Context, taskID int64) ([]*entities.Comment, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT `+commentColumns+`
+		FROM comments
+		WHERE task_id = $1
+		ORDER BY created_at, id`, taskID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var comments []*entities.Comment
+	for rows.Next() {
+		comment, err := scanComment(rows)
+		if err != nil {
+			return nil, err
+		}
+		comments = append(comments, comment)
+	}
+	return comments, rows.Err()
+}
+
+func (r *CommentRepository) Update(ctx context.Context, comment *entities.Comment) error {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE comments SET
+			content = $2, edited_at = $3, deleted_at = $4, deleted_by = $5
+		WHERE id = $1`,
+		comment.ID,
+		comment.Content,
+		nullTime(comment.EditedAt),
+		nullTime(comment.DeletedAt),
+		nullInt64(comment.DeletedBy),
+	)
+	if err != nil {
+		return err
+	}
+	return expectAffected(result)
+}
+
+func (r *CommentRepository) CreateMentions(ctx context.Context, mentions []*entities.CommentMention) error {
+	for _, mention := range mentions {
+		if err := r.db.QueryRowContext(ctx, `
+			INSERT INTO comment_mentions (comment_id, kind, user_id, team_id, handle, start_offset, end_offset)
+			VALUES ($1, $2, $3, $4, $5, $6, $7)
+			RETURNING id`,
+			mention.CommentID,
+			mention.Kind,
+			nullInt64(mention.UserID),
+			nullInt64(mention.TeamID),
+			mention.Handle,
+			mention.Start,
+			mention.End,
+		).Scan(&mention.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *CommentRepository) ListMentions(ctx context.Context, commentIDs []int64) (map[int64][]*entities.CommentMention, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT `+commentMentionColumns+`
+		FROM comment_mentions
+		WHERE comment_id = ANY($1)
+		ORDER BY comment_id, start_offset`, pq.Array(commentIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	mentions := make(map[int64][]*entities.CommentMention)
+	for rows.Next() {
+		var (
+			mention        entities.CommentMention
+			userID, teamID sql.NullInt64
+		)
+		if err := rows.Scan(&mention.ID, &mention.CommentID, &mention.Kind, &userID, &teamID,
+			&mention.Handle, &mention.Start, &mention.End); err != nil {
+			return nil, err
+		}
+		mention.UserID = userID.Int64
+		mention.TeamID = teamID.Int64
+		mentions[mention.CommentID] = append(mentions[mention.CommentID], &mention)
+	}
+	return mentions, rows.Err()
+}
+
+func (r *CommentRepository) DeleteMentions(ctx context.Context, commentID int64) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM comment_mentions WHERE comment_id = $1`, commentID)
+	return err
+}
+
+func (r *CommentRepository) CreateRevision(ctx context.Context, revision *entities.CommentRevision) error {
+	return r.db.QueryRowContext(ctx, `
+		INSERT INTO comment_revisions (comment_id, content, edited_by, created_at)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id`,
+		revision.CommentID,
+		revision.Content,
+		nullInt64(revision.EditedBy),
+		revision.CreatedAt,
+	).Scan(&revision.ID)
+}
+
+func (r *CommentRepository) ListRevisions(ctx context.Context, commentID int64) ([]*entities.CommentRevision, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, comment_id, content, edited_by, created_at
+		FROM comment_revisions
+		WHERE comment_id = $1
+		ORDER BY created_at, id`, commentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var revisions []*entities.CommentRevision
+	for rows.Next() {
+		var (
+			revision  entities.CommentRevision
+			editedBy  sql.NullInt64
+			createdAt sql.NullTime
+		)
+		if err := rows.Scan(&revision.ID, &revision.CommentID, &revision.Content, &editedBy, &createdAt); err != nil {
+			return nil, err
+		}
+		revision.EditedBy = editedBy.Int64
+		revision.CreatedAt = createdAt.Time
+		revisions = append(revisions, &revision)
+	}
+	return revisions, rows.Err()
+}
+
+func (r *CommentRepository) AddReaction(ctx context.Context, reaction *entities.CommentReaction) (bool, error) {
+	result, err := r.db.ExecContext(ctx, `
+		INSERT INTO comment_reactions (comment_id, user_id, emoji, created_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT DO NOTHING`,
+		reaction.CommentID,
+		reaction.UserID,
+		reaction.Emoji,
+		reaction.CreatedAt,
+	)
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	return affected > 0, err
+}
+
+func (r *CommentRepository) RemoveReaction(ctx context.Context, commentID, userID int64, emoji string) error {
+	result, err := r.db.ExecContext(ctx, `
+		DELETE FROM comment_reactions WHERE comment_id = $1 AND user_id = $2 AND emoji = $3`,
+		commentID, userID, emoji)
+	if err != nil {
+		return err
+	}
+	return expectAffected(result)
+}
+
+func (r *CommentRepository) ListReactions(ctx context.Context, commentIDs []int64) (map[int64][]*entities.CommentReaction, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT comment_id, user_id, emoji, created_at
+		FROM comment_reactions
+		WHERE comment_id = ANY($1)
+		ORDER BY comment_id, created_at, user_id`, pq.Array(commentIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	reactions := make(map[int64][]*entities.CommentReaction)
+	for rows.Next() {
+		var (
+			reaction  entities.CommentReaction
+			createdAt sql.NullTime
+		)
+		if err := rows.Scan(&reaction.CommentID, &reaction.UserID, &reaction.Emoji, &createdAt); err != nil {
+			return nil, err
+		}
+		reaction.CreatedAt = createdAt.Time
+		reactions[reaction.CommentID] = append(reactions[reaction.CommentID], &reaction)
+	}
+	return reactions, rows.Err()
+}
+
+func scanComment(row rowScanner) (*entities.Comment, error) {
+	var (
+		comment                        entities.Comment
+		parentID, deletedBy            sql.NullInt64
+		createdAt, editedAt, deletedAt sql.NullTime
+	)
+
+	err := row.Scan(
+		&comment.ID,
+		&comment.TaskID,
+		&parentID,
+		&comment.UserID,
+		&comment.Content,
+		&createdAt,
+		&editedAt,
+		&deletedAt,
+		&deletedBy,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	comment.ParentID = parentID.Int64
+	comment.CreatedAt = createdAt.Time
+	comment.EditedAt = editedAt.Time
+	comment.DeletedAt = deletedAt.Time
+	comment.DeletedBy = deletedBy.Int64
+	return &comment, nil
+}
@@ -0,0 +1,223 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"task-engine/internal/domain/entities"
+	"task-engine/internal/domain/repositories"
+
+	"github.com/lib/pq"
+)
+
+const customFieldDefinitionColumns = `id, project_id, key, name, field_type, options, required, position, created_at, updated_at`
+
+type CustomFieldRepository struct {
+	db DBTX
+}
+
+func NewCustomFieldRepository(db DBTX) *CustomFieldRepository {
+	return &CustomFieldRepository{db: db}
+}
+
+func (r *CustomFieldRepository) CreateDefinition(ctx context.Context, definition *entities.CustomFieldDefinition) error {
+	err := r.db.QueryRowContext(ctx, `
+		INSERT INTO custom_field_definitions
+			(project_id, key, name, field_type, options, required, position, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6,
+			COALESCE((SELECT MAX(position) + 1 FROM custom_field_definitions WHERE project_id = $1), 0),
+			$7, $8)
+		RETURNING id, position`,
+		definition.ProjectID,
+		definition.Key,
+		definition.Name,
+		definition.Type,
+		pq.Array(definition.Options),
+		definition.Required,
+		definition.CreatedAt,
+		definition.UpdatedAt,
+	).Scan(&definition.ID, &definition.Position)
+	if isUniqueViolation(err) {
+		return repositories.ErrConflict
+	}
+	return err
+}
+
+func (r *CustomFieldRepository) UpdateDefinition(ctx context.Context, definition *entities.CustomFieldDefinition) error {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE custom_field_definitions SET
+			name = $2, options = $3, required = $4, position = $5, updated_at = $6
+		WHERE id = $1`,
+		definition.ID,
+		definition.Name,
+		pq.Array(definition.Options),
+		definition.Required,
+		definition.Position,
+		definition.UpdatedAt,
+	)
+	if err != nil {
+		return err
+	}
+	return expectAffected(result)
+}
+
+func (r *CustomFieldRepository) DeleteDefinition(ctx context.Context, id int64) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM custom_field_definitions WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	return expectAffected(result)
+}
+
+func (r *CustomFieldRepository) FindDefinition(ctx context.Context, id int64) (*entities.CustomFieldDefinition, error) {
+	row := r.db.QueryRowContext(ctx, `SELECT `+customFieldDefinitionColumns+` FROM custom_field_definitions WHERE id = $1`, id)
+	definition, err := scanCustomFieldDefinition(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, repositories.ErrNotFound
+	}
+	return definition, err
+}
+
+func (r *CustomFieldRepository) ListDefinitions(ctx context.Context, projectID int64) ([]*entities.CustomFieldDefinition, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT `+customFieldDefinitionColumns+`
+		FROM custom_field_definitions
+		WHERE project_id = $1
+		ORDER BY position, id`, projectID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var definitions []*entities.CustomFieldDefinition
+	for rows.Next() {
+		definition, err := scanCustomFieldDefinition(rows)
+		if err != nil {
+			return nil, err
+		}
+		definitions = append(definitions, definition)
+	}
+	return definitions, rows.Err()
+}
+
+func (r *CustomFieldRepository) ListValues(ctx context.Context, taskIDs []int64) (map[int64][]*entities.CustomFieldValue, error) {
+	values := make(map[int64][]*entities.CustomFieldValue, len(taskIDs))
+	if len(taskIDs) == 0 {
+		return values, nil
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT v.task_id, v.field_id, d.key, d.field_type,
+			v.value_text, v.value_number, v.value_date, v.value_options, v.value_user_id
+		FROM task_custom_field_values v
+		JOIN custom_field_definitions d ON d.id = v.field_id
+		WHERE v.task_id = ANY($1)
+		ORDER BY v.task_id, d.position, d.id`, pq.Array(taskIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			value   entities.CustomFieldValue
+			text    sql.NullString
+			number  sql.NullFloat64
+			date    sql.NullTime
+			options pq.StringArray
+			userID  sql.NullInt64
+		)
+		err := rows.Scan(&value.TaskID, &value.FieldID, &value.Key, &value.Type, &text, &number, &date, &options, &userID)
+		if err != nil {
+			return nil, err
+		}
+		value.Text = text.String
+		value.Number = number.Float64
+		value.Date = date.Time
+		value.Options = options
+		value.UserID = userID.Int64
+		values[value.TaskID] = append(values[value.TaskID], &value)
+	}
+	return values, rows.Err()
+}
+
+func (r *CustomFieldRepository) SetValues(ctx context.Context, taskID int64, values []*entities.CustomFieldValue) error {
+	for _, value := range values {
+		var (
+			text    sql.NullString
+			number  sql.NullFloat64
+			date    sql.NullTime
+			options interface{}
+			userID  sql.NullInt64
+		)
+		switch customFieldColumn(value.Type) {
+		case "value_number":
+			number = sql.NullFloat64{Float64: value.Number, Valid: true}
+		case "value_date":
+			date = sql.NullTime{Time: value.Date, Valid: true}
+		case "value_user_id":
+			userID = sql.NullInt64{Int64: value.UserID, Valid: true}
+		case "value_options":
+			options = pq.Array(value.Options)
+		default:
+			text = sql.NullString{String: value.Text, Valid: true}
+		}
+
+		_, err := r.db.ExecContext(ctx, `
+			INSERT INTO task_custom_field_values
+				(task_id, field_id, value_text, value_number, value_date, value_options, value_user_id)
+			VALUES ($1, $2, $3, $4, $5, $6, $7)
+			ON CONFLICT (task_id, field_id) DO UPDATE SET
+				value_text = EXCLUDED.value_text,
+				value_number = EXCLUDED.value_number,
+				value_date = EXCLUDED.value_date,
+				value_options = EXCLUDED.value_options,
+				value_user_id = EXCLUDED.value_user_id`,
+			taskID, value.FieldID, text, number, date, options, userID,
+		)
+		if err != nil {
+			return err
+		}
+		value.TaskID = taskID
+	}
+	return nil
+}
+
+func (r *CustomFieldRepository) UnsetValues(ctx context.Context, taskID int64, fieldIDs []int64) error {
+	if len(fieldIDs) == 0 {
+		return nil
+	}
+	_, err := r.db.ExecContext(ctx, `
+		DELETE FROM task_custom_field_values
+		WHERE task_id = $1 AND field_id = ANY($2)`, taskID, pq.Array(fieldIDs))
+	return err
+}
+
+func scanCustomFieldDefinition(row rowScanner) (*entities.CustomFieldDefinition, error) {
+	var (
+		definition           entities.CustomFieldDefinition
+		options              pq.StringArray
+		createdAt, updatedAt sql.NullTime
+	)
+
+	err := row.Scan(
+		&definition.ID,
+		&definition.ProjectID,
+		&definition.Key,
+		&definition.Name,
+		&definition.Type,
+		&options,
+		&definition.Required,
+		&definition.Position,
+		&createdAt,
+		&updatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	definition.Options = options
+	definition.CreatedAt = createdAt.Time
+	definition.UpdatedAt = updatedAt.Time
+	return &definition, nil
+}
@@ -0,0 +1,103 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"task-engine/internal/domain/entities"
+	"task-engine/internal/domain/repositories"
+	"time"
+)
+
+const digestScheduleColumns = `user_id, frequency, hour, weekday, next_run_at, last_sent_at, created_at, updated_at`
+
+type DigestScheduleRepository struct {
+	db DBTX
+}
+
+func NewDigestScheduleRepository(db DBTX) *DigestScheduleRepository {
+	return &DigestScheduleRepository{db: db}
+}
+
+func (r *DigestScheduleRepository) Find(ctx context.Context, userID int64) (*entities.DigestSchedule, error) {
+	row := r.db.QueryRowContext(ctx, `SELECT `+digestScheduleColumns+` FROM digest_schedules WHERE user_id = $1`, userID)
+	schedule, err := scanDigestSchedule(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, repositories.ErrNotFound
+	}
+	return schedule, err
+}
+
+func (r *DigestScheduleRepository) Save(ctx context.Context, schedule *entities.DigestSchedule) error {
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO digest_schedules (user_id, frequency, hour, weekday, next_run_at, last_sent_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (user_id) DO UPDATE SET
+			frequency = EXCLUDED.frequency,
+			hour = EXCLUDED.hour,
+			weekday = EXCLUDED.weekday,
+			next_run_at = EXCLUDED.next_run_at,
+			last_sent_at = EXCLUDED.last_sent_at,
+			updated_at = EXCLUDED.updated_at`,
+		schedule.UserID,
+		schedule.Frequency,
+		schedule.Hour,
+		int(schedule.Weekday),
+		schedule.NextRunAt,
+		nullTime(schedule.LastSentAt),
+		schedule.CreatedAt,
+		schedule.UpdatedAt,
+	)
+	return err
+}
+
+func (r *DigestScheduleRepository) Delete(ctx context.Context, userID int64) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM digest_schedules WHERE user_id = $1`, userID)
+	if err != nil {
+		return err
+	}
+	return expectAffected(result)
+}
+
+func (r *DigestScheduleRepository) ClaimDue(ctx context.Context, now time.Time) (*entities.DigestSchedule, error) {
+	row := r.db.QueryRowContext(ctx, `
+		SELECT `+digestScheduleColumns+`
+		FROM digest_schedules
+		WHERE next_run_at <= $1
+		ORDER BY next_run_at
+		LIMIT 1
+		FOR UPDATE SKIP LOCKED`, now.UTC())
+	schedule, err := scanDigestSchedule(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, repositories.ErrNotFound
+	}
+	return schedule, err
+}
+
+func scanDigestSchedule(row rowScanner) (*entities.DigestSchedule, error) {
+	var (
+		schedule                         entities.DigestSchedule
+		weekday                          int
+		lastSentAt, createdAt, updatedAt sql.NullTime
+	)
+
+	err := row.Scan(
+		&schedule.UserID,
+		&schedule.Frequency,
+		&schedule.Hour,
+		&weekday,
+		&schedule.NextRunAt,
+		&lastSentAt,
+		&createdAt,
+		&updatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	schedule.Weekday = time.Weekday(weekday)
+	schedule.LastSentAt = lastSentAt.Time
+	schedule.CreatedAt = createdAt.Time
+	schedule.UpdatedAt = updatedAt.Time
+	return &schedule, nil
+}
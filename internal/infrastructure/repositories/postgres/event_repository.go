@@ -0,0 +1,80 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"task-engine/internal/domain/entities"
+)
+
+const eventColumns = `id, project_id, task_id, actor_id, event_type, payload, created_at`
+
+type EventRepository struct {
+	db DBTX
+}
+
+func NewEventRepository(db DBTX) *EventRepository {
+	return &EventRepository{db: db}
+}
+
+func (r *EventRepository) Create(ctx context.Context, event *entities.Event) error {
+	var payload interface{}
+	if len(event.Payload) > 0 {
+		payload = []byte(event.Payload)
+	}
+
+	return r.db.QueryRowContext(ctx, `
+		INSERT INTO events (project_id, task_id, actor_id, event_type, payload, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id`,
+		nullInt64(event.ProjectID),
+		nullInt64(event.TaskID),
+		nullInt64(event.ActorID),
+		event.Type,
+		payload,
+		event.CreatedAt,
+	).Scan(&event.ID)
+}
+
+func (r *EventRepository) ListByProject(ctx context.Context, projectID int64, limit int) ([]*entities.Event, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT `+eventColumns+`
+		FROM events
+		WHERE project_id = $1
+		ORDER BY created_at DESC, id DESC
+		LIMIT $2`, projectID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []*entities.Event
+	for rows.Next() {
+		event, err := scanEvent(rows)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+	return events, rows.Err()
+}
+
+func scanEvent(row rowScanner) (*entities.Event, error) {
+	var (
+		event             entities.Event
+		projectID, taskID sql.NullInt64
+		actorID           sql.NullInt64
+		payload           []byte
+		createdAt         sql.NullTime
+	)
+
+	if err := row.Scan(&event.ID, &projectID, &taskID, &actorID, &event.Type, &payload, &createdAt); err != nil {
+		return nil, err
+	}
+
+	event.ProjectID = projectID.Int64
+	event.TaskID = taskID.Int64
+	event.ActorID = actorID.Int64
+	event.Payload = payload
+	event.CreatedAt = createdAt.Time
+	return &event, nil
+}
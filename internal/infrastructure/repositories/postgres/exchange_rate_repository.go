@@ -0,0 +1,89 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"task-engine/internal/domain/entities"
+	"task-engine/internal/domain/entities/common"
+	"task-engine/internal/domain/repositories"
+	"time"
+)
+
+const exchangeRateColumns = `id, base_currency, quote_currency, rate::text, rate_date, created_at`
+
+type ExchangeRateRepository struct {
+	db DBTX
+}
+
+func NewExchangeRateRepository(db DBTX) *ExchangeRateRepository {
+	return &ExchangeRateRepository{db: db}
+}
+
+func (r *ExchangeRateRepository) Upsert(ctx context.Context, rate *entities.ExchangeRate) error {
+	return r.db.QueryRowContext(ctx, `
+		INSERT INTO exchange_rates (base_currency, quote_currency, rate, rate_date, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (base_currency, quote_currency, rate_date)
+		DO UPDATE SET rate = EXCLUDED.rate
+		RETURNING id`,
+		rate.BaseCurrency,
+		rate.QuoteCurrency,
+		rate.Rate,
+		rate.RateDate,
+		rate.CreatedAt,
+	).Scan(&rate.ID)
+}
+
+func (r *ExchangeRateRepository) FindLatest(ctx context.Context, base, quote common.Currency, asOf time.Time) (*entities.ExchangeRate, error) {
+	row := r.db.QueryRowContext(ctx, `
+		SELECT `+exchangeRateColumns+`
+		FROM exchange_rates
+		WHERE base_currency = $1 AND quote_currency = $2 AND rate_date <= $3
+		ORDER BY rate_date DESC
+		LIMIT 1`, base, quote, asOf)
+	rate, err := scanExchangeRate(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, repositories.ErrNotFound
+	}
+	return rate, err
+}
+
+func (r *ExchangeRateRepository) List(ctx context.Context, base, quote common.Currency) ([]*entities.ExchangeRate, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT `+exchangeRateColumns+`
+		FROM exchange_rates
+		WHERE ($1 = '' OR base_currency = $1) AND ($2 = '' OR quote_currency = $2)
+		ORDER BY base_currency, quote_currency, rate_date DESC`, string(base), string(quote))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var rates []*entities.ExchangeRate
+	for rows.Next() {
+		rate, err := scanExchangeRate(rows)
+		if err != nil {
+			return nil, err
+		}
+		rates = append(rates, rate)
+	}
+	return rates, rows.Err()
+}
+
+func scanExchangeRate(row rowScanner) (*entities.ExchangeRate, error) {
+	var (
+		rate        entities.ExchangeRate
+		base, quote string
+		createdAt   sql.NullTime
+	)
+
+	if err := row.Scan(&rate.ID, &base, &quote, &rate.Rate, &rate.RateDate, &createdAt); err != nil {
+		return nil, err
+	}
+
+	rate.BaseCurrency = common.Currency(base)
+	rate.QuoteCurrency = common.Currency(quote)
+	rate.CreatedAt = createdAt.Time
+	return &rate, nil
+}
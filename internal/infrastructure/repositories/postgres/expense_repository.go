@@ -0,0 +1,111 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"task-engine/internal/domain/entities"
+	"task-engine/internal/domain/entities/common"
+	"task-engine/internal/domain/repositories"
+)
+
+const expenseColumns = `id, project_id, kind, category, COALESCE(description, ''), amount, currency,
+	incurred_on, created_by, created_at, updated_at`
+
+type ExpenseRepository struct {
+	db DBTX
+}
+
+func NewExpenseRepository(db DBTX) *ExpenseRepository {
+	return &ExpenseRepository{db: db}
+}
+
+func (r *ExpenseRepository) Create(ctx context.Context, expense *entities.Expense) error {
+	return r.db.QueryRowContext(ctx, `
+		INSERT INTO project_expenses
+			(project_id, kind, category, description, amount, currency, incurred_on, created_by, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING id`,
+		expense.ProjectID,
+		expense.Kind,
+		expense.Category,
+		expense.Description,
+		expense.Amount.Amount,
+		expense.Amount.Currency,
+		expense.IncurredOn,
+		nullInt64(expense.CreatedBy),
+		expense.CreatedAt,
+		expense.UpdatedAt,
+	).Scan(&expense.ID)
+}
+
+func (r *ExpenseRepository) FindByID(ctx context.Context, id int64) (*entities.Expense, error) {
+	row := r.db.QueryRowContext(ctx, `SELECT `+expenseColumns+` FROM project_expenses WHERE id = $1`, id)
+	expense, err := scanExpense(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, repositories.ErrNotFound
+	}
+	return expense, err
+}
+
+func (r *ExpenseRepository) ListByProject(ctx context.Context, projectID int64) ([]*entities.Expense, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT `+expenseColumns+`
+		FROM project_expenses
+		WHERE project_id = $1
+		ORDER BY incurred_on DESC, id DESC`, projectID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var expenses []*entities.Expense
+	for rows.Next() {
+		expense, err := scanExpense(rows)
+		if err != nil {
+			return nil, err
+		}
+		expenses = append(expenses, expense)
+	}
+	return expenses, rows.Err()
+}
+
+func (r *ExpenseRepository) Delete(ctx context.Context, id int64) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM project_expenses WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	return expectAffected(result)
+}
+
+func scanExpense(row rowScanner) (*entities.Expense, error) {
+	var (
+		expense              entities.Expense
+		currency             string
+		createdBy            sql.NullInt64
+		createdAt, updatedAt sql.NullTime
+	)
+
+	err := row.Scan(
+		&expense.ID,
+		&expense.ProjectID,
+		&expense.Kind,
+		&expense.Category,
+		&expense.Description,
+		&expense.Amount.Amount,
+		&currency,
+		&expense.IncurredOn,
+		&createdBy,
+		&createdAt,
+		&updatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	expense.Amount.Currency = common.Currency(currency)
+	expense.CreatedBy = createdBy.Int64
+	expense.CreatedAt = createdAt.Time
+	expense.UpdatedAt = updatedAt.Time
+	return &expense, nil
+}
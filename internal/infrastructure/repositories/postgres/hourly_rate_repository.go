@@ -0,0 +1,67 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"task-engine/internal/domain/entities"
+	"task-engine/internal/domain/entities/common"
+	"task-engine/internal/domain/repositories"
+)
+
+type HourlyRateRepository struct {
+	db DBTX
+}
+
+func NewHourlyRateRepository(db DBTX) *HourlyRateRepository {
+	return &HourlyRateRepository{db: db}
+}
+
+func (r *HourlyRateRepository) Create(ctx context.Context, rate *entities.HourlyRate) error {
+	err := r.db.QueryRowContext(ctx, `
+		INSERT INTO hourly_rates (user_id, role, rate_amount, rate_currency, effective_from, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id`,
+		nullInt64(rate.UserID),
+		nullString(string(rate.Role)),
+		rate.Rate.Amount,
+		rate.Rate.Currency,
+		rate.EffectiveFrom,
+		rate.CreatedAt,
+	).Scan(&rate.ID)
+	if isUniqueViolation(err) {
+		return repositories.ErrConflict
+	}
+	return err
+}
+
+func (r *HourlyRateRepository) List(ctx context.Context) ([]*entities.HourlyRate, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, user_id, role, rate_amount, rate_currency, effective_from, created_at
+		FROM hourly_rates
+		ORDER BY effective_from DESC, id DESC`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var rates []*entities.HourlyRate
+	for rows.Next() {
+		var (
+			rate      entities.HourlyRate
+			userID    sql.NullInt64
+			role      sql.NullString
+			currency  string
+			createdAt sql.NullTime
+		)
+		err := rows.Scan(&rate.ID, &userID, &role, &rate.Rate.Amount, &currency, &rate.EffectiveFrom, &createdAt)
+		if err != nil {
+			return nil, err
+		}
+		rate.UserID = userID.Int64
+		rate.Role = common.UserRole(role.String)
+		rate.Rate.Currency = common.Currency(currency)
+		rate.CreatedAt = createdAt.Time
+		rates = append(rates, &rate)
+	}
+	return rates, rows.Err()
+}
@@ -0,0 +1,64 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"task-engine/internal/domain/entities"
+	"task-engine/internal/domain/entities/common"
+
+	"github.com/lib/pq"
+)
+
+type ImportMappingRepository struct {
+	db DBTX
+}
+
+func NewImportMappingRepository(db DBTX) *ImportMappingRepository {
+	return &ImportMappingRepository{db: db}
+}
+
+func (r *ImportMappingRepository) FindByExternalIDs(ctx context.Context, source common.ImportSource, kind common.ImportEntityKind, externalIDs []string) (map[string]*entities.ImportMapping, error) {
+	mappings := make(map[string]*entities.ImportMapping, len(externalIDs))
+	if len(externalIDs) == 0 {
+		return mappings, nil
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT source, kind, external_id, entity_id, created_at, updated_at
+		FROM import_mappings
+		WHERE source = $1 AND kind = $2 AND external_id = ANY($3)`,
+		source, kind, pq.Array(externalIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			mapping              entities.ImportMapping
+			createdAt, updatedAt sql.NullTime
+		)
+		if err := rows.Scan(&mapping.Source, &mapping.Kind, &mapping.ExternalID, &mapping.EntityID, &createdAt, &updatedAt); err != nil {
+			return nil, err
+		}
+		mapping.CreatedAt = createdAt.Time
+		mapping.UpdatedAt = updatedAt.Time
+		mappings[mapping.ExternalID] = &mapping
+	}
+	return mappings, rows.Err()
+}
+
+func (r *ImportMappingRepository) Save(ctx context.Context, mapping *entities.ImportMapping) error {
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO import_mappings (source, kind, external_id, entity_id, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (source, kind, external_id) DO UPDATE SET entity_id = EXCLUDED.entity_id, updated_at = EXCLUDED.updated_at`,
+		mapping.Source,
+		mapping.Kind,
+		mapping.ExternalID,
+		mapping.EntityID,
+		mapping.CreatedAt,
+		mapping.UpdatedAt,
+	)
+	return err
+}
@@ -0,0 +1,183 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"task-engine/internal/domain/entities"
+
+	"github.com/lib/pq"
+)
+
+// preparer is implemented by *sql.Tx and by DB, COPY needs a prepared statement
+type preparer interface {
+	PrepareContext(ctx context.Context, query string) (*sql.Stmt, error)
+}
+
+// ImportRepository loads rows with the COPY protocol, which lib/pq only runs in a transaction
+type ImportRepository struct {
+	db DBTX
+}
+
+func NewImportRepository(db DBTX) *ImportRepository {
+	return &ImportRepository{db: db}
+}
+
+func (r *ImportRepository) ReserveTaskIDs(ctx context.Context, n int) ([]int64, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT nextval(pg_get_serial_sequence('tasks', 'id')) FROM generate_series(1, $1)`, n)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ids := make([]int64, 0, n)
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+func (r *ImportRepository) CopyTasks(ctx context.Context, tasks []*entities.Task) error {
+	// COPY cannot look the language up per row as Create does
+	projectIDs := make([]int64, 0, len(tasks))
+	for _, task := range tasks {
+		projectIDs = append(projectIDs, task.ProjectID)
+	}
+	languages, err := r.searchLanguages(ctx, projectIDs)
+	if err != nil {
+		return err
+	}
+
+	rows := make([][]interface{}, len(tasks))
+	for i, task := range tasks {
+		rows[i] = []interface{}{
+			task.ID,
+			task.ProjectID,
+			nullInt64(task.ParentID),
+			task.Title,
+			task.Description,
+			task.Status,
+			task.StatusCategory,
+			task.Priority,
+			nullTime(task.DueDate),
+			task.EstimatedMinutes,
+			nullInt64Ptr(task.RemainingMinutes),
+			task.CreatedAt,
+			task.UpdatedAt,
+			languages[task.ProjectID],
+		}
+	}
+	return copyIn(ctx, r.db, "tasks", []string{
+		"id", "project_id", "parent_id", "title", "description", "status", "status_category", "priority", "due_date",
+		"estimated_minutes", "remaining_minutes", "created_at", "updated_at", "search_language",
+	}, rows)
+}
+
+func (r *ImportRepository) CopyTaskTags(ctx context.Context, tasks []*entities.Task) error {
+	var names []string
+	for _, task := range tasks {
+		names = append(names, task.Tags...)
+	}
+	if len(names) == 0 {
+		return nil
+	}
+	if _, err := r.db.ExecContext(ctx, `
+		INSERT INTO tags (name)
+		SELECT DISTINCT UNNEST($1::text[])
+		ON CONFLICT (name) DO NOTHING`, pq.Array(names)); err != nil {
+		return err
+	}
+
+	tagIDs := make(map[string]int64)
+	result, err := r.db.QueryContext(ctx, `SELECT id, name FROM tags WHERE name = ANY($1)`, pq.Array(names))
+	if err != nil {
+		return err
+	}
+	defer result.Close()
+	for result.Next() {
+		var (
+			id   int64
+			name string
+		)
+		if err := result.Scan(&id, &name); err != nil {
+			return err
+		}
+		tagIDs[name] = id
+	}
+	if err := result.Err(); err != nil {
+		return err
+	}
+
+	var rows [][]interface{}
+	for _, task := range tasks {
+		for _, tag := range task.Tags {
+			rows = append(rows, []interface{}{task.ID, tagIDs[tag]})
+		}
+	}
+	return copyIn(ctx, r.db, "task_tags", []string{"task_id", "tag_id"}, rows)
+}
+
+func (r *ImportRepository) CopyAssignments(ctx context.Context, tasks []*entities.Task) error {
+	var rows [][]interface{}
+	for _, task := range tasks {
+		for _, userID := range task.AssigneeIDs {
+			rows = append(rows, []interface{}{task.ID, userID, task.CreatedAt})
+		}
+	}
+	return copyIn(ctx, r.db, "assignments", []string{"task_id", "user_id", "assigned_at"}, rows)
+}
+
+func (r *ImportRepository) searchLanguages(ctx context.Context, projectIDs []int64) (map[int64]string, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, search_language::text FROM projects WHERE id = ANY($1)`, pq.Array(projectIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	languages := make(map[int64]string)
+	for rows.Next() {
+		var (
+			id       int64
+			language string
+		)
+		if err := rows.Scan(&id, &language); err != nil {
+			return nil, err
+		}
+		languages[id] = language
+	}
+	return languages, rows.Err()
+}
+
+// copyIn streams the rows into the table in a single COPY
+func copyIn(ctx context.Context, db DBTX, table string, columns []string, rows [][]interface{}) error {
+	if len(rows) == 0 {
+		return nil
+	}
+	p, ok := db.(preparer)
+	if !ok {
+		return errors.New("COPY needs a connection that can prepare statements")
+	}
+
+	stmt, err := p.PrepareContext(ctx, pq.CopyIn(table, columns...))
+	if err != nil {
+		return err
+	}
+	for _, row := range rows {
+		if _, err := stmt.ExecContext(ctx, row...); err != nil {
+			_ = stmt.Close()
+			return err
+		}
+	}
+	// the final Exec without arguments flushes the buffered rows
+	if _, err := stmt.ExecContext(ctx); err != nil {
+		_ = stmt.Close()
+		return err
+	}
+	return stmt.Close()
+}
@@ -0,0 +1,61 @@
+package postgres
+
+import (
+	"context"
+	"task-engine/internal/domain/entities"
+
+	"github.com/lib/pq"
+)
+
+const notificationPreferenceColumns = `user_id, event_type, channel, enabled`
+
+type NotificationPreferenceRepository struct {
+	db DBTX
+}
+
+func NewNotificationPreferenceRepository(db DBTX) *NotificationPreferenceRepository {
+	return &NotificationPreferenceRepository{db: db}
+}
+
+func (r *NotificationPreferenceRepository) ListByUser(ctx context.Context, userID int64) ([]*entities.NotificationPreference, error) {
+	preferences, err := r.ListByUsers(ctx, []int64{userID})
+	if err != nil {
+		return nil, err
+	}
+	return preferences[userID], nil
+}
+
+func (r *NotificationPreferenceRepository) ListByUsers(ctx context.Context, userIDs []int64) (map[int64][]*entities.NotificationPreference, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT `+notificationPreferenceColumns+`
+		FROM notification_preferences
+		WHERE user_id = ANY($1)
+		ORDER BY user_id, event_type, channel`, pq.Array(userIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	preferences := make(map[int64][]*entities.NotificationPreference)
+	for rows.Next() {
+		var preference entities.NotificationPreference
+		if err := rows.Scan(&preference.UserID, &preference.EventType, &preference.Channel, &preference.Enabled); err != nil {
+			return nil, err
+		}
+		preferences[preference.UserID] = append(preferences[preference.UserID], &preference)
+	}
+	return preferences, rows.Err()
+}
+
+func (r *NotificationPreferenceRepository) Save(ctx context.Context, userID int64, preferences []*entities.NotificationPreference) error {
+	for _, preference := range preferences {
+		if _, err := r.db.ExecContext(ctx, `
+			INSERT INTO notification_preferences (user_id, event_type, channel, enabled)
+			VALUES ($1, $2, $3, $4)
+			ON CONFLICT (user_id, event_type, channel) DO UPDATE SET enabled = EXCLUDED.enabled`,
+			userID, preference.EventType, preference.Channel, preference.Enabled); err != nil {
+			return err
+		}
+	}
+	return nil
+}
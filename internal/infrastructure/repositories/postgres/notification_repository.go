@@ -0,0 +1,266 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"task-engine/internal/domain/entities"
+	"task-engine/internal/domain/entities/common"
+	"task-engine/internal/domain/repositories"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+const notificationColumns = `id, user_id, event_id, event_type, project_id, task_id, actor_id, title, body, in_app, read_at, created_at`
+
+const deliveryColumns = `d.id, d.notification_id, d.channel, d.status, d.attempts, d.last_error, d.sent_at, d.created_at`
+
+type NotificationRepository struct {
+	db DBTX
+}
+
+func NewNotificationRepository(db DBTX) *NotificationRepository {
+	return &NotificationRepository{db: db}
+}
+
+func (r *NotificationRepository) Create(ctx context.Context, notification *entities.Notification) error {
+	return r.db.QueryRowContext(ctx, `
+		INSERT INTO notifications (user_id, event_id, event_type, project_id, task_id, actor_id, title, body, in_app, read_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		RETURNING id`,
+		notification.UserID,
+		nullInt64(notification.EventID),
+		notification.EventType,
+		nullInt64(notification.ProjectID),
+		nullInt64(notification.TaskID),
+		nullInt64(notification.ActorID),
+		notification.Title,
+		notification.Body,
+		notification.InApp,
+		nullTime(notification.ReadAt),
+		notification.CreatedAt,
+	).Scan(&notification.ID)
+}
+
+func (r *NotificationRepository) FindByID(ctx context.Context, id int64) (*entities.Notification, error) {
+	row := r.db.QueryRowContext(ctx, `SELECT `+notificationColumns+` FROM notifications WHERE id = $1`, id)
+	notification, err := scanNotification(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, repositories.ErrNotFound
+	}
+	return notification, err
+}
+
+func (r *NotificationRepository) FindByIDs(ctx context.Context, ids []int64) (map[int64]*entities.Notification, error) {
+	notifications := make(map[int64]*entities.Notification, len(ids))
+	if len(ids) == 0 {
+		return notifications, nil
+	}
+
+	list, err := r.list(ctx, `SELECT `+notificationColumns+` FROM notifications WHERE id = ANY($1)`, pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+	for _, notification := range list {
+		notifications[notification.ID] = notification
+	}
+	return notifications, nil
+}
+
+func (r *NotificationRepository) ListInbox(ctx context.Context, filter repositories.InboxFilter) ([]*entities.Notification, error) {
+	return r.list(ctx, `
+		SELECT `+notificationColumns+`
+		FROM notifications
+		WHERE user_id = $1 AND in_app AND (NOT $2 OR read_at IS NULL)
+		ORDER BY created_at DESC, id DESC
+		LIMIT $3 OFFSET $4`,
+		filter.UserID, filter.UnreadOnly, filter.Limit, filter.Offset)
+}
+
+func (r *NotificationRepository) CountUnread(ctx context.Context, userID int64) (int, error) {
+	var count int
+	err := r.db.QueryRowContext(ctx, `
+		SELECT COUNT(*) FROM notifications WHERE user_id = $1 AND in_app AND read_at IS NULL`,
+		userID).Scan(&count)
+	return count, err
+}
+
+func (r *NotificationRepository) Update(ctx context.Context, notification *entities.Notification) error {
+	result, err := r.db.ExecContext(ctx, `UPDATE notifications SET read_at = $2 WHERE id = $1`,
+		notification.ID, nullTime(notification.ReadAt))
+	if err != nil {
+		return err
+	}
+	return expectAffected(result)
+}
+
+func (r *NotificationRepository) MarkAllRead(ctx context.Context, userID int64, at time.Time) (int64, error) {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE notifications SET read_at = $2
+		WHERE user_id = $1 AND in_app AND read_at IS NULL`, userID, at)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+func (r *NotificationRepository) CreateDelivery(ctx context.Context, delivery *entities.NotificationDelivery) error {
+	return r.db.QueryRowContext(ctx, `
+		INSERT INTO notification_deliveries (notification_id, channel, status, attempts, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id`,
+		delivery.NotificationID,
+		delivery.Channel,
+		delivery.Status,
+		delivery.Attempts,
+		delivery.CreatedAt,
+	).Scan(&delivery.ID)
+}
+
+func (r *NotificationRepository) ClaimPendingDelivery(ctx context.Context, channel common.NotificationChannel, afterID int64) (*entities.NotificationDelivery, error) {
+	digestFilter := ""
+	if channel == common.NotificationChannelEmail {
+		digestFilter = `AND NOT EXISTS (
+			SELECT 1 FROM notifications n
+			JOIN digest_schedules s ON s.user_id = n.user_id
+			WHERE n.id = d.notification_id)`
+	}
+
+	row := r.db.QueryRowContext(ctx, `
+		SELECT `+deliveryColumns+`
+		FROM notification_deliveries d
+		WHERE d.channel = $1 AND d.status = $2 AND d.id > $3 `+digestFilter+`
+		ORDER BY d.id
+		LIMIT 1
+		FOR UPDATE SKIP LOCKED`, channel, common.DeliveryStatusPending, afterID)
+	delivery, err := scanDelivery(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, repositories.ErrNotFound
+	}
+	return delivery, err
+}
+
+func (r *NotificationRepository) ListPendingDeliveriesByUser(ctx context.Context, userID int64, channel common.NotificationChannel) ([]*entities.NotificationDelivery, error) {
+	return r.listDeliveries(ctx, `
+		SELECT `+deliveryColumns+`
+		FROM notification_deliveries d
+		JOIN notifications n ON n.id = d.notification_id
+		WHERE n.user_id = $1 AND d.channel = $2 AND d.status = $3
+		ORDER BY d.id
+		FOR UPDATE OF d`, userID, channel, common.DeliveryStatusPending)
+}
+
+func (r *NotificationRepository) UpdateDelivery(ctx context.Context, delivery *entities.NotificationDelivery) error {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE notification_deliveries SET
+			status = $2, attempts = $3, last_error = $4, sent_at = $5
+		WHERE id = $1`,
+		delivery.ID,
+		delivery.Status,
+		delivery.Attempts,
+		nullString(delivery.LastError),
+		nullTime(delivery.SentAt),
+	)
+	if err != nil {
+		return err
+	}
+	return expectAffected(result)
+}
+
+func (r *NotificationRepository) list(ctx context.Context, query string, args ...interface{}) ([]*entities.Notification, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var notifications []*entities.Notification
+	for rows.Next() {
+		notification, err := scanNotification(rows)
+		if err != nil {
+			return nil, err
+		}
+		notifications = append(notifications, notification)
+	}
+	return notifications, rows.Err()
+}
+
+func (r *NotificationRepository) listDeliveries(ctx context.Context, query string, args ...interface{}) ([]*entities.NotificationDelivery, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var deliveries []*entities.NotificationDelivery
+	for rows.Next() {
+		delivery, err := scanDelivery(rows)
+		if err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, delivery)
+	}
+	return deliveries, rows.Err()
+}
+
+func scanNotification(row rowScanner) (*entities.Notification, error) {
+	var (
+		notification                        entities.Notification
+		eventID, projectID, taskID, actorID sql.NullInt64
+		readAt, createdAt                   sql.NullTime
+	)
+
+	err := row.Scan(
+		&notification.ID,
+		&notification.UserID,
+		&eventID,
+		&notification.EventType,
+		&projectID,
+		&taskID,
+		&actorID,
+		&notification.Title,
+		&notification.Body,
+		&notification.InApp,
+		&readAt,
+		&createdAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	notification.EventID = eventID.Int64
+	notification.ProjectID = projectID.Int64
+	notification.TaskID = taskID.Int64
+	notification.ActorID = actorID.Int64
+	notification.ReadAt = readAt.Time
+	notification.CreatedAt = createdAt.Time
+	return &notification, nil
+}
+
+func scanDelivery(row rowScanner) (*entities.NotificationDelivery, error) {
+	var (
+		delivery          entities.NotificationDelivery
+		lastError         sql.NullString
+		sentAt, createdAt sql.NullTime
+	)
+
+	err := row.Scan(
+		&delivery.ID,
+		&delivery.NotificationID,
+		&delivery.Channel,
+		&delivery.Status,
+		&delivery.Attempts,
+		&lastError,
+		&sentAt,
+		&createdAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	delivery.LastError = lastError.String
+	delivery.SentAt = sentAt.Time
+	delivery.CreatedAt = createdAt.Time
+	return &delivery, nil
+}
@@ -0,0 +1,253 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"strings"
+	"task-engine/internal/domain/entities"
+	"task-engine/internal/domain/entities/common"
+	"task-engine/internal/domain/repositories"
+
+	"github.com/lib/pq"
+)
+
+const projectColumns = `id, name, key, inbound_email, search_language::text, COALESCE(description, ''), status, priority, owner_id, team_id,
+	start_date, end_date, budget_amount, budget_currency, settings, created_at, updated_at, deleted_at`
+
+type ProjectRepository struct {
+	db DBTX
+}
+
+func NewProjectRepository(db DBTX) *ProjectRepository {
+	return &ProjectRepository{db: db}
+}
+
+func (r *ProjectRepository) Create(ctx context.Context, project *entities.Project) error {
+	settings, err := json.Marshal(project.Settings)
+	if err != nil {
+		return err
+	}
+
+	err = r.db.QueryRowContext(ctx, `
+		INSERT INTO projects
+			(name, key, inbound_email, search_language, description, status, priority, owner_id, team_id, start_date, end_date,
+			budget_amount, budget_currency, settings, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)
+		RETURNING id`,
+		project.Name,
+		nullString(project.Key),
+		nullString(project.InboundEmail),
+		project.SearchLanguage,
+		project.Description,
+		project.Status,
+		project.Priority,
+		nullInt64(project.OwnerID),
+		nullInt64(project.TeamID),
+		nullTime(project.StartDate),
+		nullTime(project.EndDate),
+		project.Budget.Amount,
+		project.Budget.Currency,
+		settings,
+		project.CreatedAt,
+		project.UpdatedAt,
+	).Scan(&project.ID)
+	if isUniqueViolation(err) {
+		return repositories.ErrConflict
+	}
+	return err
+}
+
+func (r *ProjectRepository) FindByID(ctx context.Context, id int64) (*entities.Project, error) {
+	row := r.db.QueryRowContext(ctx, `SELECT `+projectColumns+` FROM projects WHERE id = $1`, id)
+	project, err := scanProject(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, repositories.ErrNotFound
+	}
+	return project, err
+}
+
+func (r *ProjectRepository) FindByIDForUpdate(ctx context.Context, id int64) (*entities.Project, error) {
+	row := r.db.QueryRowContext(ctx, `SELECT `+projectColumns+` FROM projects WHERE id = $1 FOR UPDATE`, id)
+	project, err := scanProject(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, repositories.ErrNotFound
+	}
+	return project, err
+}
+
+func (r *ProjectRepository) FindByIDForShare(ctx context.Context, id int64) (*entities.Project, error) {
+	row := r.db.QueryRowContext(ctx, `SELECT `+projectColumns+` FROM projects WHERE id = $1 FOR SHARE`, id)
+	project, err := scanProject(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, repositories.ErrNotFound
+	}
+	return project, err
+}
+
+func (r *ProjectRepository) IsVisibleTo(ctx context.Context, projectID, userID int64) (bool, error) {
+	var visible bool
+	err := r.db.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM projects p WHERE p.id = $1 AND `+projectVisibleTo("$2")+`)`,
+		projectID, userID).Scan(&visible)
+	return visible, err
+}
+
+func (r *ProjectRepository) Update(ctx context.Context, project *entities.Project) error {
+	settings, err := json.Marshal(project.Settings)
+	if err != nil {
+		return err
+	}
+
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE projects SET
+			name = $2, description = $3, status = $4, priority = $5, owner_id = $6, team_id = $7,
+			start_date = $8, end_date = $9, budget_amount = $10, budget_currency = $11,
+			settings = $12, updated_at = $13, deleted_at = $14, key = $15, inbound_email = $16, search_language = $17
+		WHERE id = $1`,
+		project.ID,
+		project.Name,
+		project.Description,
+		project.Status,
+		project.Priority,
+		nullInt64(project.OwnerID),
+		nullInt64(project.TeamID),
+		nullTime(project.StartDate),
+		nullTime(project.EndDate),
+		project.Budget.Amount,
+		project.Budget.Currency,
+		settings,
+		project.UpdatedAt,
+		nullTime(project.DeletedAt),
+		nullString(project.Key),
+		nullString(project.InboundEmail),
+		project.SearchLanguage,
+	)
+	if isUniqueViolation(err) {
+		return repositories.ErrConflict
+	}
+	if err != nil {
+		return err
+	}
+	return expectAffected(result)
+}
+
+func (r *ProjectRepository) FindByKeys(ctx context.Context, keys []string) (map[string]*entities.Project, error) {
+	projects := make(map[string]*entities.Project, len(keys))
+	if len(keys) == 0 {
+		return projects, nil
+	}
+
+	list, err := r.list(ctx, `SELECT `+projectColumns+` FROM projects WHERE key = ANY($1)`, pq.Array(keys))
+	if err != nil {
+		return nil, err
+	}
+	for _, project := range list {
+		projects[project.Key] = project
+	}
+	return projects, nil
+}
+
+func (r *ProjectRepository) FindByInboundEmails(ctx context.Context, addresses []string) (map[string]*entities.Project, error) {
+	projects := make(map[string]*entities.Project, len(addresses))
+	if len(addresses) == 0 {
+		return projects, nil
+	}
+
+	lowered := make([]string, len(addresses))
+	for i, address := range addresses {
+		lowered[i] = strings.ToLower(address)
+	}
+	list, err := r.list(ctx, `SELECT `+projectColumns+` FROM projects WHERE inbound_email = ANY($1)`, pq.Array(lowered))
+	if err != nil {
+		return nil, err
+	}
+	for _, project := range list {
+		projects[project.InboundEmail] = project
+	}
+	return projects, nil
+}
+
+func (r *ProjectRepository) list(ctx context.Context, query string, args ...interface{}) ([]*entities.Project, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var projects []*entities.Project
+	for rows.Next() {
+		project, err := scanProject(rows)
+		if err != nil {
+			return nil, err
+		}
+		projects = append(projects, project)
+	}
+	return projects, rows.Err()
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanProject(row rowScanner) (*entities.Project, error) {
+	var (
+		project                       entities.Project
+		ownerID, teamID               sql.NullInt64
+		key, inboundEmail             sql.NullString
+		startDate, endDate, deletedAt sql.NullTime
+		createdAt, updatedAt          sql.NullTime
+		budgetCurrency                string
+		settings                      []byte
+	)
+
+	err := row.Scan(
+		&project.ID,
+		&project.Name,
+		&key,
+		&inboundEmail,
+		&project.SearchLanguage,
+		&project.Description,
+		&project.Status,
+		&project.Priority,
+		&ownerID,
+		&teamID,
+		&startDate,
+		&endDate,
+		&project.Budget.Amount,
+		&budgetCurrency,
+		&settings,
+		&createdAt,
+		&updatedAt,
+		&deletedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(settings, &project.Settings); err != nil {
+		return nil, err
+	}
+	project.Key = key.String
+	project.InboundEmail = inboundEmail.String
+	project.Budget.Currency = common.Currency(budgetCurrency)
+	project.OwnerID = ownerID.Int64
+	project.TeamID = teamID.Int64
+	project.StartDate = startDate.Time
+	project.EndDate = endDate.Time
+	project.CreatedAt = createdAt.Time
+	project.UpdatedAt = updatedAt.Time
+	project.DeletedAt = deletedAt.Time
+	return &project, nil
+}
+
+func expectAffected(result sql.Result) error {
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return repositories.ErrNotFound
+	}
+	return nil
+}
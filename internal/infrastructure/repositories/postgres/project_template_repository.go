@@ -0,0 +1,107 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"task-engine/internal/domain/entities"
+	"task-engine/internal/domain/repositories"
+)
+
+const projectTemplateColumns = `id, name, COALESCE(description, ''), source_project_id, content, created_by, created_at, updated_at`
+
+type ProjectTemplateRepository struct {
+	db DBTX
+}
+
+func NewProjectTemplateRepository(db DBTX) *ProjectTemplateRepository {
+	return &ProjectTemplateRepository{db: db}
+}
+
+func (r *ProjectTemplateRepository) Create(ctx context.Context, template *entities.ProjectTemplate) error {
+	content, err := json.Marshal(template.Content)
+	if err != nil {
+		return err
+	}
+
+	return r.db.QueryRowContext(ctx, `
+		INSERT INTO project_templates (name, description, source_project_id, content, created_by, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id`,
+		template.Name,
+		template.Description,
+		nullInt64(template.SourceProjectID),
+		content,
+		nullInt64(template.CreatedBy),
+		template.CreatedAt,
+		template.UpdatedAt,
+	).Scan(&template.ID)
+}
+
+func (r *ProjectTemplateRepository) FindByID(ctx context.Context, id int64) (*entities.ProjectTemplate, error) {
+	row := r.db.QueryRowContext(ctx, `SELECT `+projectTemplateColumns+` FROM project_templates WHERE id = $1`, id)
+	template, err := scanProjectTemplate(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, repositories.ErrNotFound
+	}
+	return template, err
+}
+
+func (r *ProjectTemplateRepository) List(ctx context.Context) ([]*entities.ProjectTemplate, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT `+projectTemplateColumns+` FROM project_templates ORDER BY name, id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var templates []*entities.ProjectTemplate
+	for rows.Next() {
+		template, err := scanProjectTemplate(rows)
+		if err != nil {
+			return nil, err
+		}
+		templates = append(templates, template)
+	}
+	return templates, rows.Err()
+}
+
+func (r *ProjectTemplateRepository) Delete(ctx context.Context, id int64) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM project_templates WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	return expectAffected(result)
+}
+
+func scanProjectTemplate(row rowScanner) (*entities.ProjectTemplate, error) {
+	var (
+		template                   entities.ProjectTemplate
+		sourceProjectID, createdBy sql.NullInt64
+		content                    []byte
+		createdAt, updatedAt       sql.NullTime
+	)
+
+	err := row.Scan(
+		&template.ID,
+		&template.Name,
+		&template.Description,
+		&sourceProjectID,
+		&content,
+		&createdBy,
+		&createdAt,
+		&updatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(content, &template.Content); err != nil {
+		return nil, err
+	}
+	template.SourceProjectID = sourceProjectID.Int64
+	template.CreatedBy = createdBy.Int64
+	template.CreatedAt = createdAt.Time
+	template.UpdatedAt = updatedAt.Time
+	return &template, nil
+}
@@ -0,0 +1,166 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"task-engine/internal/domain/entities"
+	"task-engine/internal/domain/entities/common"
+	"task-engine/internal/domain/repositories"
+
+	"github.com/lib/pq"
+)
+
+const savedViewColumns = `v.id, v.name, v.owner_id, v.project_id, v.visibility, v.team_id, v.query, v.sort, v.group_by, v.columns,
+	v.created_at, v.updated_at`
+
+type SavedViewRepository struct {
+	db DBTX
+}
+
+func NewSavedViewRepository(db DBTX) *SavedViewRepository {
+	return &SavedViewRepository{db: db}
+}
+
+func (r *SavedViewRepository) Create(ctx context.Context, view *entities.SavedView) error {
+	return r.db.QueryRowContext(ctx, `
+		INSERT INTO saved_views (name, owner_id, project_id, visibility, team_id, query, sort, group_by, columns, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		RETURNING id`,
+		view.Name,
+		view.OwnerID,
+		nullInt64(view.ProjectID),
+		view.Visibility,
+		nullInt64(view.TeamID),
+		view.Query,
+		pq.Array(view.Sort),
+		nullString(string(view.GroupBy)),
+		pq.Array(view.Columns),
+		view.CreatedAt,
+		view.UpdatedAt,
+	).Scan(&view.ID)
+}
+
+// FindByID leaves Pinned unset, see IsPinned
+func (r *SavedViewRepository) FindByID(ctx context.Context, id int64) (*entities.SavedView, error) {
+	row := r.db.QueryRowContext(ctx, `SELECT `+savedViewColumns+`, FALSE FROM saved_views v WHERE v.id = $1`, id)
+	view, err := scanSavedView(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, repositories.ErrNotFound
+	}
+	return view, err
+}
+
+func (r *SavedViewRepository) List(ctx context.Context, filter repositories.SavedViewFilter) ([]*entities.SavedView, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT `+savedViewColumns+`, p.user_id IS NOT NULL AS pinned
+		FROM saved_views v
+		LEFT JOIN saved_view_pins p ON p.view_id = v.id AND p.user_id = $1
+		WHERE (v.owner_id = $1
+			OR v.visibility = $3
+			OR (v.visibility = $4 AND v.team_id IN (SELECT tm.team_id FROM team_members tm WHERE tm.user_id = $1)))
+			AND ($2 = 0 OR v.project_id = $2)
+		ORDER BY pinned DESC, LOWER(v.name), v.id`,
+		filter.UserID, filter.ProjectID, common.SavedViewVisibilityProject, common.SavedViewVisibilityTeam)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	views := []*entities.SavedView{}
+	for rows.Next() {
+		view, err := scanSavedView(rows)
+		if err != nil {
+			return nil, err
+		}
+		views = append(views, view)
+	}
+	return views, rows.Err()
+}
+
+func (r *SavedViewRepository) Update(ctx context.Context, view *entities.SavedView) error {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE saved_views SET
+			name = $2, visibility = $3, team_id = $4, query = $5, sort = $6, group_by = $7, columns = $8, updated_at = $9
+		WHERE id = $1`,
+		view.ID,
+		view.Name,
+		view.Visibility,
+		nullInt64(view.TeamID),
+		view.Query,
+		pq.Array(view.Sort),
+		nullString(string(view.GroupBy)),
+		pq.Array(view.Columns),
+		view.UpdatedAt,
+	)
+	if err != nil {
+		return err
+	}
+	return expectAffected(result)
+}
+
+func (r *SavedViewRepository) Delete(ctx context.Context, id int64) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM saved_views WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	return expectAffected(result)
+}
+
+func (r *SavedViewRepository) Pin(ctx context.Context, viewID, userID int64) error {
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO saved_view_pins (view_id, user_id) VALUES ($1, $2)
+		ON CONFLICT DO NOTHING`, viewID, userID)
+	return err
+}
+
+func (r *SavedViewRepository) Unpin(ctx context.Context, viewID, userID int64) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM saved_view_pins WHERE view_id = $1 AND user_id = $2`, viewID, userID)
+	return err
+}
+
+func (r *SavedViewRepository) IsPinned(ctx context.Context, viewID, userID int64) (bool, error) {
+	var pinned bool
+	err := r.db.QueryRowContext(ctx, `
+		SELECT EXISTS (SELECT 1 FROM saved_view_pins WHERE view_id = $1 AND user_id = $2)`,
+		viewID, userID).Scan(&pinned)
+	return pinned, err
+}
+
+func scanSavedView(row rowScanner) (*entities.SavedView, error) {
+	var (
+		view                 entities.SavedView
+		projectID, teamID    sql.NullInt64
+		groupBy              sql.NullString
+		sort, columns        pq.StringArray
+		createdAt, updatedAt sql.NullTime
+	)
+
+	err := row.Scan(
+		&view.ID,
+		&view.Name,
+		&view.OwnerID,
+		&projectID,
+		&view.Visibility,
+		&teamID,
+		&view.Query,
+		&sort,
+		&groupBy,
+		&columns,
+		&createdAt,
+		&updatedAt,
+		&view.Pinned,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	view.ProjectID = projectID.Int64
+	view.TeamID = teamID.Int64
+	view.GroupBy = common.TaskGrouping(groupBy.String)
+	view.Sort = []string(sort)
+	view.Columns = []string(columns)
+	view.CreatedAt = createdAt.Time
+	view.UpdatedAt = updatedAt.Time
+	return &view, nil
+}
@@ -0,0 +1,348 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"task-engine/internal/domain/entities"
+	"task-engine/internal/domain/entities/common"
+	"task-engine/internal/domain/repositories"
+
+	"github.com/lib/pq"
+)
+
+// The documents searched, the text the search_vector columns are generated from
+const (
+	taskSearchDocument    = `t.title || ' ' || COALESCE(t.description, '')`
+	projectSearchDocument = `p.name || ' ' || COALESCE(p.description, '')`
+	commentSearchDocument = `c.content`
+)
+
+// searchHeadlineOptions shape the snippets: up to two fragments of the document with the
+// matched words in <mark> tags
+const searchHeadlineOptions = `StartSel=<mark>, StopSel=</mark>, MaxWords=35, MinWords=15, MaxFragments=2, FragmentDelimiter=" … "`
+
+type SearchRepository struct {
+	db DBTX
+}
+
+func NewSearchRepository(db DBTX) *SearchRepository {
+	return &SearchRepository{db: db}
+}
+
+func (r *SearchRepository) Search(ctx context.Context, filter repositories.SearchFilter) ([]*entities.SearchResult, error) {
+	languages, err := r.languages(ctx, filter.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+	query, args := buildSearchQuery(filter, languages)
+	if query == "" {
+		return []*entities.SearchResult{}, nil
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	results := []*entities.SearchResult{}
+	for rows.Next() {
+		var (
+			result entities.SearchResult
+			taskID sql.NullInt64
+		)
+		if err := rows.Scan(
+			&result.Type,
+			&result.ID,
+			&result.ProjectID,
+			&taskID,
+			&result.Title,
+			&result.Status,
+			&result.Snippet,
+			&result.Rank,
+		); err != nil {
+			return nil, err
+		}
+		result.TaskID = taskID.Int64
+		results = append(results, &result)
+	}
+	return results, rows.Err()
+}
+
+// Reindex moves the tasks and comments of the project, or of every project when projectID is
+// zero, to their project's search language. Their search vectors are generated again.
+func (r *SearchRepository) Reindex(ctx context.Context, projectID int64) (int64, error) {
+	var reindexed int64
+	for _, query := range []string{`
+		UPDATE tasks t SET search_language = p.search_language
+		FROM projects p
+		WHERE p.id = t.project_id AND t.search_language <> p.search_language AND ($1 = 0 OR p.id = $1)`, `
+		UPDATE comments c SET search_language = p.search_language
+		FROM tasks t JOIN projects p ON p.id = t.project_id
+		WHERE t.id = c.task_id AND c.search_language <> p.search_language AND ($1 = 0 OR p.id = $1)`,
+	} {
+		result, err := r.db.ExecContext(ctx, query, projectID)
+		if err != nil {
+			return reindexed, err
+		}
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return reindexed, err
+		}
+		reindexed += affected
+	}
+	return reindexed, nil
+}
+
+// languages returns the search languages of the project, or of every project when projectID
+// is zero
+func (r *SearchRepository) languages(ctx context.Context, projectID int64) ([]string, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT DISTINCT search_language::text FROM projects
+		WHERE deleted_at IS NULL AND ($1 = 0 OR id = $1)`, projectID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var languages []string
+	for rows.Next() {
+		var language string
+		if err := rows.Scan(&language); err != nil {
+			return nil, err
+		}
+		languages = append(languages, language)
+	}
+	return languages, rows.Err()
+}
+
+func (r *SearchRepository) Suggest(ctx context.Context, filter repositories.SuggestFilter) ([]*entities.Suggestion, error) {
+	query, args := buildSuggestQuery(filter)
+	if query == "" {
+		return []*entities.Suggestion{}, nil
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	suggestions := []*entities.Suggestion{}
+	for rows.Next() {
+		var (
+			suggestion entities.Suggestion
+			projectID  sql.NullInt64
+			detail     sql.NullString
+		)
+		if err := rows.Scan(&suggestion.Type, &suggestion.ID, &projectID, &suggestion.Label, &detail); err != nil {
+			return nil, err
+		}
+		suggestion.ProjectID = projectID.Int64
+		suggestion.Detail = detail.String
+		suggestions = append(suggestions, &suggestion)
+	}
+	return suggestions, rows.Err()
+}
+
+// projectVisibleTo restricts the projects p to those the user owns, belongs to through its
+// team or has an assigned task in
+func projectVisibleTo(user string) string {
+	return fmt.Sprintf(`(p.owner_id = %[1]s
+		OR p.team_id IN (SELECT tm.team_id FROM team_members tm WHERE tm.user_id = %[1]s)
+		OR EXISTS (SELECT 1 FROM tasks vt JOIN assignments va ON va.task_id = vt.id WHERE vt.project_id = p.id AND va.user_id = %[1]s))`, user)
+}
+
+// buildSearchQuery unions a ranked query per searched type and highlights the page of
+// results only, as ts_headline is costly. Rows are matched against the query parsed in each
+// of the languages, restricted to the rows of that language, so every language uses the
+// search_vector index. It returns an empty query when there is nothing to search.
+func buildSearchQuery(filter repositories.SearchFilter, languages []string) (string, []interface{}) {
+	if len(languages) == 0 {
+		return "", nil
+	}
+
+	var args queryArgs
+	text := args.add(filter.Query)
+	languageParams := make([]string, len(languages))
+	for i, language := range languages {
+		languageParams[i] = args.add(language) + "::regconfig"
+	}
+	matches := func(alias string) string {
+		clauses := make([]string, len(languageParams))
+		for i, language := range languageParams {
+			clauses[i] = fmt.Sprintf("(%[1]s.search_language = %[2]s AND %[1]s.search_vector @@ websearch_to_tsquery(%[2]s, %[3]s))", alias, language, text)
+		}
+		return "(" + strings.Join(clauses, " OR ") + ")"
+	}
+	rank := func(alias string) string {
+		return fmt.Sprintf("ts_rank_cd(%[1]s.search_vector, websearch_to_tsquery(%[1]s.search_language, %[2]s))", alias, text)
+	}
+
+	// conditions shared by every type, on the project p
+	projectWhere := []string{"p.deleted_at IS NULL"}
+	if filter.ProjectID != 0 {
+		projectWhere = append(projectWhere, "p.id = "+args.add(filter.ProjectID))
+	}
+	if filter.VisibleTo != 0 {
+		projectWhere = append(projectWhere, projectVisibleTo(args.add(filter.VisibleTo)))
+	}
+
+	// conditions on the task t, for tasks and comments
+	taskWhere := append([]string{}, projectWhere...)
+	if len(filter.Statuses) > 0 {
+		statuses := make([]string, len(filter.Statuses))
+		for i, status := range filter.Statuses {
+			statuses[i] = string(status)
+		}
+		taskWhere = append(taskWhere, "t.status = ANY("+args.add(pq.Array(statuses))+")")
+	}
+	if len(filter.Tags) > 0 {
+		tags := make([]string, len(filter.Tags))
+		for i, tag := range filter.Tags {
+			tags[i] = strings.ToLower(tag)
+		}
+		taskWhere = append(taskWhere, `EXISTS (SELECT 1 FROM task_tags tt JOIN tags g ON g.id = tt.tag_id
+			WHERE tt.task_id = t.id AND LOWER(g.name) = ANY(`+args.add(pq.Array(tags))+`))`)
+	}
+
+	searched := func(resultType common.SearchResultType) bool {
+		if len(filter.Types) == 0 {
+			return true
+		}
+		for _, t := range filter.Types {
+			if t == resultType {
+				return true
+			}
+		}
+		return false
+	}
+
+	var branches []string
+	if searched(common.SearchResultTypeTask) {
+		branches = append(branches, fmt.Sprintf(`
+			SELECT 'task' AS type, t.id, t.project_id, NULL::integer AS task_id, t.title, t.status::text AS status,
+				t.search_language AS language, %s AS document, %s AS rank
+			FROM tasks t JOIN projects p ON p.id = t.project_id
+			WHERE %s AND %s`,
+			taskSearchDocument, rank("t"), matches("t"), strings.Join(taskWhere, " AND ")))
+	}
+	if searched(common.SearchResultTypeProject) && len(filter.Statuses) == 0 && len(filter.Tags) == 0 {
+		branches = append(branches, fmt.Sprintf(`
+			SELECT 'project', p.id, p.id, NULL::integer, p.name, p.status::text,
+				p.search_language, %s, %s
+			FROM projects p
+			WHERE %s AND %s`,
+			projectSearchDocument, rank("p"), matches("p"), strings.Join(projectWhere, " AND ")))
+	}
+	if searched(common.SearchResultTypeComment) {
+		branches = append(branches, fmt.Sprintf(`
+			SELECT 'comment', c.id, t.project_id, t.id, t.title, '',
+				c.search_language, %s, %s
+			FROM comments c JOIN tasks t ON t.id = c.task_id JOIN projects p ON p.id = t.project_id
+			WHERE %s AND c.deleted_at IS NULL AND %s`,
+			commentSearchDocument, rank("c"), matches("c"), strings.Join(taskWhere, " AND ")))
+	}
+	if len(branches) == 0 {
+		return "", nil
+	}
+
+	page := " LIMIT " + args.add(filter.Limit)
+	if filter.Offset > 0 {
+		page += " OFFSET " + args.add(filter.Offset)
+	}
+	// the document is escaped before highlighting, so only the <mark> tags are markup
+	query := fmt.Sprintf(`
+		SELECT r.type, r.id, r.project_id, r.task_id, r.title, r.status,
+			ts_headline(r.language, replace(replace(replace(r.document, '&', '&amp;'), '<', '&lt;'), '>', '&gt;'),
+				websearch_to_tsquery(r.language, %s), %s),
+			r.rank
+		FROM (%s
+			ORDER BY rank DESC, type, id%s
+		) r
+		ORDER BY r.rank DESC, r.type, r.id`,
+		text, args.add(searchHeadlineOptions), strings.Join(branches, "\n\t\t\tUNION ALL"), page)
+	return query, args
+}
+
+// buildSuggestQuery unions the first names of each type in alphabetical order. Names are
+// matched with LIKE 'prefix%' on LOWER(name), which the text_pattern_ops indexes
+// idx_tasks_title_lower, idx_projects_name_lower and idx_users_username_lower serve.
+func buildSuggestQuery(filter repositories.SuggestFilter) (string, []interface{}) {
+	var args queryArgs
+	prefix := args.add(escapeLike(strings.ToLower(filter.Prefix)) + "%")
+	limit := args.add(filter.Limit)
+
+	projectWhere := []string{"p.deleted_at IS NULL"}
+	if filter.VisibleTo != 0 {
+		projectWhere = append(projectWhere, projectVisibleTo(args.add(filter.VisibleTo)))
+	}
+	taskWhere := append([]string{}, projectWhere...)
+	var project string
+	if filter.ProjectID != 0 {
+		project = args.add(filter.ProjectID)
+		taskWhere = append(taskWhere, "t.project_id = "+project)
+	}
+
+	suggested := func(suggestionType common.SuggestionType) bool {
+		if len(filter.Types) == 0 {
+			return true
+		}
+		for _, t := range filter.Types {
+			if t == suggestionType {
+				return true
+			}
+		}
+		return false
+	}
+
+	var branches []string
+	if suggested(common.SuggestionTypeTask) {
+		branches = append(branches, fmt.Sprintf(`(
+			SELECT 'task', t.id, t.project_id, t.title, NULL::text
+			FROM tasks t JOIN projects p ON p.id = t.project_id
+			WHERE LOWER(t.title) LIKE %s AND %s
+			ORDER BY LOWER(t.title), t.id LIMIT %s)`,
+			prefix, strings.Join(taskWhere, " AND "), limit))
+	}
+	if suggested(common.SuggestionTypeProject) {
+		branches = append(branches, fmt.Sprintf(`(
+			SELECT 'project', p.id, p.id, p.name, NULL::text
+			FROM projects p
+			WHERE LOWER(p.name) LIKE %s AND %s
+			ORDER BY LOWER(p.name), p.id LIMIT %s)`,
+			prefix, strings.Join(projectWhere, " AND "), limit))
+	}
+	if suggested(common.SuggestionTypeTag) {
+		// tags are suggested from the tasks the user sees, of the project when set
+		used := ""
+		if project != "" || filter.VisibleTo != 0 {
+			used = " AND EXISTS (SELECT 1 FROM task_tags tt JOIN tasks t ON t.id = tt.task_id JOIN projects p ON p.id = t.project_id WHERE tt.tag_id = g.id AND " + strings.Join(taskWhere, " AND ") + ")"
+		}
+		branches = append(branches, fmt.Sprintf(`(
+			SELECT 'tag', g.id, NULL::integer, g.name, NULL::text
+			FROM tags g
+			WHERE LOWER(g.name) LIKE %s%s
+			ORDER BY LOWER(g.name), g.id LIMIT %s)`,
+			prefix, used, limit))
+	}
+	if suggested(common.SuggestionTypeUser) {
+		branches = append(branches, fmt.Sprintf(`(
+			SELECT 'user', u.id, NULL::integer, u.name, u.username
+			FROM users u
+			WHERE (LOWER(u.username) LIKE %[1]s OR LOWER(u.name) LIKE %[1]s) AND u.status = %[2]s
+			ORDER BY LOWER(u.username), u.id LIMIT %[3]s)`,
+			prefix, args.add(common.UserStatusActive), limit))
+	}
+	if len(branches) == 0 {
+		return "", nil
+	}
+	return strings.Join(branches, "\n\t\tUNION ALL "), args
+}
+
+// escapeLike escapes the LIKE wildcards of s so it matches literally
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
@@ -0,0 +1,167 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"task-engine/internal/domain/entities"
+	"task-engine/internal/domain/entities/common"
+	"task-engine/internal/domain/repositories"
+
+	"github.com/lib/pq"
+)
+
+const subscriptionColumns = `id, user_id, project_id, task_id, reason, muted_event_types, unsubscribed_at, created_at, updated_at`
+
+type SubscriptionRepository struct {
+	db DBTX
+}
+
+func NewSubscriptionRepository(db DBTX) *SubscriptionRepository {
+	return &SubscriptionRepository{db: db}
+}
+
+// Create skips existing subscriptions with ON CONFLICT rather than failing on the unique
+// index, so an automatic watch never aborts the surrounding transaction
+func (r *SubscriptionRepository) Create(ctx context.Context, subscription *entities.Subscription) error {
+	err := r.db.QueryRowContext(ctx, `
+		INSERT INTO subscriptions (user_id, project_id, task_id, reason, muted_event_types, unsubscribed_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT DO NOTHING
+		RETURNING id`,
+		subscription.UserID,
+		nullInt64(subscription.ProjectID),
+		nullInt64(subscription.TaskID),
+		subscription.Reason,
+		pq.Array(eventTypeStrings(subscription.MutedEventTypes)),
+		nullTime(subscription.UnsubscribedAt),
+		subscription.CreatedAt,
+		subscription.UpdatedAt,
+	).Scan(&subscription.ID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return repositories.ErrConflict
+	}
+	return err
+}
+
+func (r *SubscriptionRepository) Find(ctx context.Context, userID int64, target entities.SubscriptionTarget) (*entities.Subscription, error) {
+	row := r.db.QueryRowContext(ctx, `
+		SELECT `+subscriptionColumns+`
+		FROM subscriptions
+		WHERE user_id = $1 AND project_id IS NOT DISTINCT FROM $2 AND task_id IS NOT DISTINCT FROM $3`,
+		userID, nullInt64(target.ProjectID), nullInt64(target.TaskID))
+	subscription, err := scanSubscription(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, repositories.ErrNotFound
+	}
+	return subscription, err
+}
+
+func (r *SubscriptionRepository) Update(ctx context.Context, subscription *entities.Subscription) error {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE subscriptions SET
+			reason = $2, muted_event_types = $3, unsubscribed_at = $4, updated_at = $5
+		WHERE id = $1`,
+		subscription.ID,
+		subscription.Reason,
+		pq.Array(eventTypeStrings(subscription.MutedEventTypes)),
+		nullTime(subscription.UnsubscribedAt),
+		subscription.UpdatedAt,
+	)
+	if err != nil {
+		return err
+	}
+	return expectAffected(result)
+}
+
+func (r *SubscriptionRepository) ListByUser(ctx context.Context, userID int64) ([]*entities.Subscription, error) {
+	return r.list(ctx, `
+		SELECT `+subscriptionColumns+`
+		FROM subscriptions
+		WHERE user_id = $1
+		ORDER BY created_at, id`, userID)
+}
+
+func (r *SubscriptionRepository) ListByTarget(ctx context.Context, target entities.SubscriptionTarget) ([]*entities.Subscription, error) {
+	if target.IsTask() {
+		return r.list(ctx, `
+			SELECT `+subscriptionColumns+`
+			FROM subscriptions
+			WHERE task_id = $1 AND unsubscribed_at IS NULL
+			ORDER BY created_at, id`, target.TaskID)
+	}
+	return r.list(ctx, `
+		SELECT `+subscriptionColumns+`
+		FROM subscriptions
+		WHERE project_id = $1 AND unsubscribed_at IS NULL
+		ORDER BY created_at, id`, target.ProjectID)
+}
+
+func (r *SubscriptionRepository) ListForEvent(ctx context.Context, projectID, taskID int64) ([]*entities.Subscription, error) {
+	return r.list(ctx, `
+		SELECT `+subscriptionColumns+`
+		FROM subscriptions
+		WHERE project_id = $1 OR task_id = $2`, projectID, taskID)
+}
+
+func (r *SubscriptionRepository) list(ctx context.Context, query string, args ...interface{}) ([]*entities.Subscription, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var subscriptions []*entities.Subscription
+	for rows.Next() {
+		subscription, err := scanSubscription(rows)
+		if err != nil {
+			return nil, err
+		}
+		subscriptions = append(subscriptions, subscription)
+	}
+	return subscriptions, rows.Err()
+}
+
+func scanSubscription(row rowScanner) (*entities.Subscription, error) {
+	var (
+		subscription         entities.Subscription
+		projectID, taskID    sql.NullInt64
+		muted                pq.StringArray
+		unsubscribedAt       sql.NullTime
+		createdAt, updatedAt sql.NullTime
+	)
+
+	err := row.Scan(
+		&subscription.ID,
+		&subscription.UserID,
+		&projectID,
+		&taskID,
+		&subscription.Reason,
+		&muted,
+		&unsubscribedAt,
+		&createdAt,
+		&updatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	subscription.ProjectID = projectID.Int64
+	subscription.TaskID = taskID.Int64
+	subscription.MutedEventTypes = make([]common.EventType, len(muted))
+	for i, eventType := range muted {
+		subscription.MutedEventTypes[i] = common.EventType(eventType)
+	}
+	subscription.UnsubscribedAt = unsubscribedAt.Time
+	subscription.CreatedAt = createdAt.Time
+	subscription.UpdatedAt = updatedAt.Time
+	return &subscription, nil
+}
+
+func eventTypeStrings(eventTypes []common.EventType) []string {
+	values := make([]string, len(eventTypes))
+	for i, eventType := range eventTypes {
+		values[i] = string(eventType)
+	}
+	return values
+}
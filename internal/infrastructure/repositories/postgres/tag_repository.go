@@ -0,0 +1,59 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/lib/pq"
+)
+
+type TagRepository struct {
+	db DBTX
+}
+
+func NewTagRepository(db DBTX) *TagRepository {
+	return &TagRepository{db: db}
+}
+
+func (r *TagRepository) ListByTasks(ctx context.Context, taskIDs []int64) (map[int64][]string, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT tt.task_id, tg.name
+		FROM task_tags tt
+		JOIN tags tg ON tg.id = tt.tag_id
+		WHERE tt.task_id = ANY($1)
+		ORDER BY tt.task_id, tg.name`, pq.Array(taskIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tags := make(map[int64][]string)
+	for rows.Next() {
+		var (
+			taskID int64
+			name   string
+		)
+		if err := rows.Scan(&taskID, &name); err != nil {
+			return nil, err
+		}
+		tags[taskID] = append(tags[taskID], name)
+	}
+	return tags, rows.Err()
+}
+
+func (r *TagRepository) SetTaskTags(ctx context.Context, taskID int64, names []string) error {
+	if _, err := r.db.ExecContext(ctx, `
+		INSERT INTO tags (name)
+		SELECT UNNEST($1::text[])
+		ON CONFLICT (name) DO NOTHING`, pq.Array(names)); err != nil {
+		return err
+	}
+
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM task_tags WHERE task_id = $1`, taskID); err != nil {
+		return err
+	}
+
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO task_tags (task_id, tag_id)
+		SELECT $1, id FROM tags WHERE name = ANY($2)`, taskID, pq.Array(names))
+	return err
+}
@@ -0,0 +1,110 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"task-engine/internal/domain/entities"
+)
+
+const taskCommitColumns = `id, task_id, sha, repository, branch, message, author_name, author_email, url, committed_at, comment_id, created_at`
+
+type TaskCommitRepository struct {
+	db DBTX
+}
+
+func NewTaskCommitRepository(db DBTX) *TaskCommitRepository {
+	return &TaskCommitRepository{db: db}
+}
+
+func (r *TaskCommitRepository) Create(ctx context.Context, link *entities.TaskCommit) (bool, error) {
+	err := r.db.QueryRowContext(ctx, `
+		INSERT INTO task_commits (task_id, sha, repository, branch, message, author_name, author_email, url, committed_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		ON CONFLICT (task_id, sha) DO NOTHING
+		RETURNING id`,
+		link.TaskID,
+		link.SHA,
+		link.Repository,
+		link.Branch,
+		link.Message,
+		link.AuthorName,
+		link.AuthorEmail,
+		link.URL,
+		nullTime(link.CommittedAt),
+		link.CreatedAt,
+	).Scan(&link.ID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func (r *TaskCommitRepository) SetComment(ctx context.Context, id, commentID int64) error {
+	result, err := r.db.ExecContext(ctx, `UPDATE task_commits SET comment_id = $2 WHERE id = $1`, id, commentID)
+	if err != nil {
+		return err
+	}
+	return expectAffected(result)
+}
+
+func (r *TaskCommitRepository) Delete(ctx context.Context, id int64) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM task_commits WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	return expectAffected(result)
+}
+
+func (r *TaskCommitRepository) ListByTask(ctx context.Context, taskID int64) ([]*entities.TaskCommit, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT `+taskCommitColumns+`
+		FROM task_commits
+		WHERE task_id = $1
+		ORDER BY COALESCE(committed_at, created_at) DESC, id DESC`, taskID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var links []*entities.TaskCommit
+	for rows.Next() {
+		link, err := scanTaskCommit(rows)
+		if err != nil {
+			return nil, err
+		}
+		links = append(links, link)
+	}
+	return links, rows.Err()
+}
+
+func scanTaskCommit(row rowScanner) (*entities.TaskCommit, error) {
+	var (
+		link                   entities.TaskCommit
+		commentID              sql.NullInt64
+		committedAt, createdAt sql.NullTime
+	)
+
+	err := row.Scan(
+		&link.ID,
+		&link.TaskID,
+		&link.SHA,
+		&link.Repository,
+		&link.Branch,
+		&link.Message,
+		&link.AuthorName,
+		&link.AuthorEmail,
+		&link.URL,
+		&committedAt,
+		&commentID,
+		&createdAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	link.CommittedAt = committedAt.Time
+	link.CommentID = commentID.Int64
+	link.CreatedAt = createdAt.Time
+	return &link, nil
+}
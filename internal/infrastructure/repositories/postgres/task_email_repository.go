@@ -0,0 +1,92 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"task-engine/internal/domain/entities"
+	"task-engine/internal/domain/repositories"
+
+	"github.com/lib/pq"
+)
+
+const taskEmailColumns = `id, message_id, task_id, comment_id, from_address, subject, received_at, created_at`
+
+type TaskEmailRepository struct {
+	db DBTX
+}
+
+func NewTaskEmailRepository(db DBTX) *TaskEmailRepository {
+	return &TaskEmailRepository{db: db}
+}
+
+// Create skips an already ingested message id with ON CONFLICT rather than failing on the
+// unique index, so the surrounding transaction is not aborted
+func (r *TaskEmailRepository) Create(ctx context.Context, email *entities.TaskEmail) error {
+	err := r.db.QueryRowContext(ctx, `
+		INSERT INTO task_emails (message_id, task_id, comment_id, from_address, subject, received_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT DO NOTHING
+		RETURNING id`,
+		email.MessageID,
+		email.TaskID,
+		nullInt64(email.CommentID),
+		email.From,
+		email.Subject,
+		nullTime(email.ReceivedAt),
+		email.CreatedAt,
+	).Scan(&email.ID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return repositories.ErrConflict
+	}
+	return err
+}
+
+func (r *TaskEmailRepository) FindByMessageIDs(ctx context.Context, messageIDs []string) (map[string]*entities.TaskEmail, error) {
+	emails := make(map[string]*entities.TaskEmail, len(messageIDs))
+	if len(messageIDs) == 0 {
+		return emails, nil
+	}
+
+	rows, err := r.db.QueryContext(ctx, `SELECT `+taskEmailColumns+` FROM task_emails WHERE message_id = ANY($1)`, pq.Array(messageIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		email, err := scanTaskEmail(rows)
+		if err != nil {
+			return nil, err
+		}
+		emails[email.MessageID] = email
+	}
+	return emails, rows.Err()
+}
+
+func scanTaskEmail(row rowScanner) (*entities.TaskEmail, error) {
+	var (
+		email                 entities.TaskEmail
+		commentID             sql.NullInt64
+		receivedAt, createdAt sql.NullTime
+	)
+
+	err := row.Scan(
+		&email.ID,
+		&email.MessageID,
+		&email.TaskID,
+		&commentID,
+		&email.From,
+		&email.Subject,
+		&receivedAt,
+		&createdAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	email.CommentID = commentID.Int64
+	email.ReceivedAt = receivedAt.Time
+	email.CreatedAt = createdAt.Time
+	return &email, nil
+}
@@ -0,0 +1,462 @@
+package postgres
+
+import (
+	"fmt"
+	"strings"
+	"task-engine/internal/domain/entities"
+	"task-engine/internal/domain/entities/common"
+	"task-engine/internal/domain/repositories"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// taskPriorityRank orders priorities by importance instead of alphabetically
+const taskPriorityRank = `CASE t.priority WHEN 'low' THEN 1 WHEN 'medium' THEN 2 WHEN 'high' THEN 3 WHEN 'urgent' THEN 4 END`
+
+// taskPriorityRanks are the ranks taskPriorityRank gives each priority
+var taskPriorityRanks = map[common.TaskPriority]int{
+	common.TaskPriorityLow:    1,
+	common.TaskPriorityMedium: 2,
+	common.TaskPriorityHigh:   3,
+	common.TaskPriorityUrgent: 4,
+}
+
+// taskStatusRank orders statuses by how far along their category is, as status names vary per workflow
+const taskStatusRank = `CASE t.status_category WHEN 'todo' THEN 1 WHEN 'doing' THEN 2 WHEN 'done' THEN 3 WHEN 'cancelled' THEN 4 END`
+
+var taskSortColumns = map[string]string{
+	repositories.TaskSortCreatedAt: "t.created_at",
+	repositories.TaskSortUpdatedAt: "t.updated_at",
+	repositories.TaskSortDueDate:   "t.due_date",
+	repositories.TaskSortPriority:  taskPriorityRank,
+	repositories.TaskSortStatus:    taskStatusRank,
+	repositories.TaskSortTitle:     "LOWER(t.title)",
+}
+
+// queryArgs collects positional parameters while a query is being assembled
+type queryArgs []interface{}
+
+func (a *queryArgs) add(v interface{}) string {
+	*a = append(*a, v)
+	return fmt.Sprintf("$%d", len(*a))
+}
+
+func buildTaskListQuery(filter repositories.TaskFilter) (string, []interface{}, error) {
+	var (
+		args  queryArgs
+		joins []string
+		order []string
+	)
+
+	where, err := taskFilterConditions(&args, filter)
+	if err != nil {
+		return "", nil, err
+	}
+	if filter.After != nil {
+		clause, err := taskKeysetCondition(&args, filter)
+		if err != nil {
+			return "", nil, err
+		}
+		where = append(where, clause)
+	}
+
+	for i, sort := range filter.Sort {
+		direction := "ASC"
+		if sort.Descending {
+			direction = "DESC"
+		}
+
+		if sort.CustomField != nil {
+			alias := fmt.Sprintf("cfs%d", i)
+			joins = append(joins, fmt.Sprintf(
+				"LEFT JOIN task_custom_field_values %s ON %s.task_id = t.id AND %s.field_id = %s",
+				alias, alias, alias, args.add(sort.CustomField.ID),
+			))
+			order = append(order, fmt.Sprintf("%s.%s %s NULLS LAST", alias, customFieldColumn(sort.CustomField.Type), direction))
+			continue
+		}
+
+		column, ok := taskSortColumns[sort.Column]
+		if !ok {
+			return "", nil, &common.FieldValidationError{Field: "sort", Message: fmt.Sprintf("cannot sort by %q", sort.Column)}
+		}
+		order = append(order, fmt.Sprintf("%s %s NULLS LAST", column, direction))
+	}
+
+	if len(order) == 0 {
+		order = append(order, "t.created_at DESC", "t.id DESC")
+	} else if filter.Sort[len(filter.Sort)-1].Descending {
+		order = append(order, "t.id DESC")
+	} else {
+		order = append(order, "t.id ASC")
+	}
+
+	var query strings.Builder
+	query.WriteString("SELECT " + taskColumns + " FROM tasks t")
+	for _, join := range joins {
+		query.WriteString(" " + join)
+	}
+	if len(where) > 0 {
+		query.WriteString(" WHERE " + strings.Join(where, " AND "))
+	}
+	query.WriteString(" ORDER BY " + strings.Join(order, ", "))
+	if filter.Limit > 0 {
+		query.WriteString(" LIMIT " + args.add(filter.Limit))
+	}
+	if filter.Offset > 0 {
+		query.WriteString(" OFFSET " + args.add(filter.Offset))
+	}
+
+	return query.String(), args, nil
+}
+
+// taskKeysetCondition selects the tasks sorted after filter.After. Tasks are ordered by the
+// sort column with NULLS LAST and then by id in the same direction, so the following tasks
+// are those further along the column, those with the same value and a further id, and for
+// a nullable column those without a value. The default order by created_at DESC keeps
+// reading idx_tasks_project_created_at.
+func taskKeysetCondition(args *queryArgs, filter repositories.TaskFilter) (string, error) {
+	sort := repositories.TaskSort{Column: repositories.TaskSortCreatedAt, Descending: true}
+	if len(filter.Sort) > 0 {
+		sort = filter.Sort[0]
+	}
+	if len(filter.Sort) > 1 || sort.CustomField != nil || !repositories.IsTaskKeysetColumn(sort.Column) {
+		return "", &common.FieldValidationError{Field: "cursor", Message: "cursors need a single sort by created_at, due_date or priority"}
+	}
+
+	column := taskSortColumns[sort.Column]
+	value := filter.After.Value
+	switch sort.Column {
+	case repositories.TaskSortPriority:
+		priority, _ := value.(common.TaskPriority)
+		rank, ok := taskPriorityRanks[priority]
+		if !ok {
+			return "", fmt.Errorf("invalid priority keyset value %v", value)
+		}
+		value = rank
+	case repositories.TaskSortDueDate:
+		if due, ok := value.(time.Time); ok {
+			value = due.Format("2006-01-02")
+		}
+	}
+
+	after := ">"
+	if sort.Descending {
+		after = "<"
+	}
+	id := args.add(filter.After.ID)
+	if value == nil {
+		return fmt.Sprintf("(%s IS NULL AND t.id %s %s)", column, after, id), nil
+	}
+	param := args.add(value)
+	if sort.Column == repositories.TaskSortDueDate {
+		param += "::date"
+	}
+	// the bound on the column alone lets the index on it be scanned from the cursor on
+	return fmt.Sprintf("((%[1]s %[2]s= %[3]s AND (%[1]s %[2]s %[3]s OR t.id %[2]s %[4]s)) OR %[1]s IS NULL)", column, after, param, id), nil
+}
+
+// taskFilterConditions returns the conditions on t selecting the tasks of the filter
+func taskFilterConditions(args *queryArgs, filter repositories.TaskFilter) ([]string, error) {
+	var where []string
+	if filter.ProjectID != 0 {
+		where = append(where, "t.project_id = "+args.add(filter.ProjectID))
+	}
+	if filter.VisibleTo != 0 {
+		where = append(where, "EXISTS (SELECT 1 FROM projects p WHERE p.id = t.project_id AND "+projectVisibleTo(args.add(filter.VisibleTo))+")")
+	}
+	if len(filter.Statuses) > 0 {
+		statuses := make([]string, len(filter.Statuses))
+		for i, status := range filter.Statuses {
+			statuses[i] = string(status)
+		}
+		where = append(where, "t.status = ANY("+args.add(pq.Array(statuses))+")")
+	}
+	if len(filter.Categories) > 0 {
+		categories := make([]string, len(filter.Categories))
+		for i, category := range filter.Categories {
+			categories[i] = string(category)
+		}
+		where = append(where, "t.status_category = ANY("+args.add(pq.Array(categories))+")")
+	}
+	if len(filter.Priorities) > 0 {
+		priorities := make([]string, len(filter.Priorities))
+		for i, priority := range filter.Priorities {
+			priorities[i] = string(priority)
+		}
+		where = append(where, "t.priority = ANY("+args.add(pq.Array(priorities))+")")
+	}
+
+	for _, condition := range filter.CustomFields {
+		clause, err := customFieldCondition(args, condition)
+		if err != nil {
+			return nil, err
+		}
+		where = append(where, clause)
+	}
+	if filter.Expression != nil {
+		clause, err := taskExpressionClause(args, filter.Expression)
+		if err != nil {
+			return nil, err
+		}
+		where = append(where, clause)
+	}
+	return where, nil
+}
+
+// buildTaskCountQuery counts the tasks of the filter, ignoring its sort and page
+func buildTaskCountQuery(filter repositories.TaskFilter) (string, []interface{}, error) {
+	var args queryArgs
+	where, err := taskFilterConditions(&args, filter)
+	if err != nil {
+		return "", nil, err
+	}
+	query := "SELECT COUNT(*) FROM tasks t"
+	if len(where) > 0 {
+		query += " WHERE " + strings.Join(where, " AND ")
+	}
+	return query, args, nil
+}
+
+// buildTaskCountsQuery counts the tasks of every filter in one row, a column per filter
+func buildTaskCountsQuery(filters []repositories.TaskFilter) (string, []interface{}, error) {
+	var args queryArgs
+	columns := make([]string, len(filters))
+	for i, filter := range filters {
+		where, err := taskFilterConditions(&args, filter)
+		if err != nil {
+			return "", nil, err
+		}
+		column := "(SELECT COUNT(*) FROM tasks t"
+		if len(where) > 0 {
+			column += " WHERE " + strings.Join(where, " AND ")
+		}
+		columns[i] = column + ")"
+	}
+	return "SELECT " + strings.Join(columns, ",\n\t"), args, nil
+}
+
+// taskFacetQueries count the matched tasks m by each facet. Tags and assignees are left
+// joined so the tasks without any are counted under "none".
+var taskFacetQueries = map[common.TaskFacet]string{
+	common.TaskFacetStatus:   `SELECT 'status', m.status::text, COUNT(*) FROM matched m GROUP BY m.status`,
+	common.TaskFacetPriority: `SELECT 'priority', m.priority::text, COUNT(*) FROM matched m GROUP BY m.priority`,
+	common.TaskFacetTag: `SELECT 'tag', COALESCE(g.name, 'none'), COUNT(*) FROM matched m
+		LEFT JOIN task_tags tt ON tt.task_id = m.id LEFT JOIN tags g ON g.id = tt.tag_id GROUP BY g.name`,
+	common.TaskFacetAssignee: `SELECT 'assignee', COALESCE(a.user_id::text, 'none'), COUNT(*) FROM matched m
+		LEFT JOIN assignments a ON a.task_id = m.id GROUP BY a.user_id`,
+}
+
+// buildTaskFacetsQuery counts the tasks of the filter by every facet in one query. The
+// matched tasks are selected once, Postgres materialises the CTE as every facet reads it.
+func buildTaskFacetsQuery(filter repositories.TaskFilter, facets []common.TaskFacet) (string, []interface{}, error) {
+	var args queryArgs
+	where, err := taskFilterConditions(&args, filter)
+	if err != nil {
+		return "", nil, err
+	}
+	matched := "SELECT t.id, t.status, t.priority FROM tasks t"
+	if len(where) > 0 {
+		matched += " WHERE " + strings.Join(where, " AND ")
+	}
+
+	branches := make([]string, len(facets))
+	for i, facet := range facets {
+		branch, ok := taskFacetQueries[facet]
+		if !ok {
+			return "", nil, fmt.Errorf("unsupported task facet %q", facet)
+		}
+		branches[i] = branch
+	}
+	query := fmt.Sprintf(`
+		WITH matched AS (%s)
+		SELECT facet, value, count FROM (%s) f(facet, value, count)
+		ORDER BY facet, count DESC, value`,
+		matched, strings.Join(branches, "\n\t\tUNION ALL "))
+	return query, args, nil
+}
+
+// TaskListSQL returns the query and arguments TaskRepository.List runs for the filter, so
+// the SQL a task query compiles to can be inspected
+func TaskListSQL(filter repositories.TaskFilter) (string, []interface{}, error) {
+	return buildTaskListQuery(filter)
+}
+
+// taskExpressionColumns are the columns task expressions may test
+var taskExpressionColumns = map[string]string{
+	repositories.TaskColumnStatus:    "t.status",
+	repositories.TaskColumnCategory:  "t.status_category",
+	repositories.TaskColumnPriority:  "t.priority",
+	repositories.TaskColumnDueDate:   "t.due_date",
+	repositories.TaskColumnCreatedAt: "t.created_at",
+	repositories.TaskColumnUpdatedAt: "t.updated_at",
+}
+
+// taskExpressionClause compiles the expression into a condition on t. Columns are compared
+// directly with parameters, never wrapped in functions, so that together with the project
+// condition the composite indexes such as idx_tasks_project_status apply.
+func taskExpressionClause(args *queryArgs, expression repositories.TaskExpression) (string, error) {
+	switch e := expression.(type) {
+	case repositories.TaskAnd:
+		return joinTaskExpressions(args, e.Operands, " AND ")
+	case repositories.TaskOr:
+		return joinTaskExpressions(args, e.Operands, " OR ")
+	case repositories.TaskNot:
+		clause, err := taskExpressionClause(args, e.Operand)
+		if err != nil {
+			return "", err
+		}
+		// IS NOT TRUE also matches the rows where the operand is NULL, e.g. tasks without a due date
+		return "(" + clause + ") IS NOT TRUE", nil
+	case repositories.TaskValueCondition:
+		column, ok := taskExpressionColumns[e.Column]
+		if !ok {
+			return "", fmt.Errorf("cannot filter tasks by %q", e.Column)
+		}
+		if len(e.Values) == 1 {
+			return column + " = " + args.add(e.Values[0]), nil
+		}
+		return column + " = ANY(" + args.add(pq.Array(e.Values)) + ")", nil
+	case repositories.TaskDateCondition:
+		column, ok := taskExpressionColumns[e.Column]
+		if !ok {
+			return "", fmt.Errorf("cannot filter tasks by %q", e.Column)
+		}
+		if e.IsNull {
+			return column + " IS NULL", nil
+		}
+		bound := func(t time.Time) string { return args.add(t) }
+		if e.Column == repositories.TaskColumnDueDate {
+			// compared as dates so the index on the DATE column applies, a date is taken as its midnight
+			bound = func(t time.Time) string { return args.add(ceilToDay(t).Format("2006-01-02")) + "::date" }
+		}
+		var bounds []string
+		if !e.From.IsZero() {
+			bounds = append(bounds, column+" >= "+bound(e.From))
+		}
+		if !e.To.IsZero() {
+			bounds = append(bounds, column+" < "+bound(e.To))
+		}
+		if len(bounds) == 0 {
+			return column + " IS NOT NULL", nil
+		}
+		return "(" + strings.Join(bounds, " AND ") + ")", nil
+	case repositories.TaskTagCondition:
+		names := make([]string, len(e.Names))
+		for i, name := range e.Names {
+			names[i] = strings.ToLower(name)
+		}
+		return `EXISTS (SELECT 1 FROM task_tags qtt JOIN tags qg ON qg.id = qtt.tag_id
+			WHERE qtt.task_id = t.id AND LOWER(qg.name) = ANY(` + args.add(pq.Array(names)) + `))`, nil
+	case repositories.TaskAssigneeCondition:
+		var clauses []string
+		if len(e.UserIDs) > 0 {
+			clauses = append(clauses, "EXISTS (SELECT 1 FROM assignments qa WHERE qa.task_id = t.id AND qa.user_id = ANY("+args.add(pq.Array(e.UserIDs))+"))")
+		}
+		if e.Unassigned || len(clauses) == 0 {
+			clauses = append(clauses, "NOT EXISTS (SELECT 1 FROM assignments qa WHERE qa.task_id = t.id)")
+		}
+		return "(" + strings.Join(clauses, " OR ") + ")", nil
+	case repositories.CustomFieldCondition:
+		return customFieldCondition(args, e)
+	}
+	return "", fmt.Errorf("unsupported task expression %T", expression)
+}
+
+func joinTaskExpressions(args *queryArgs, operands []repositories.TaskExpression, operator string) (string, error) {
+	clauses := make([]string, len(operands))
+	for i, operand := range operands {
+		clause, err := taskExpressionClause(args, operand)
+		if err != nil {
+			return "", err
+		}
+		clauses[i] = clause
+	}
+	return "(" + strings.Join(clauses, operator) + ")", nil
+}
+
+// ceilToDay returns t when it is a midnight, the next midnight otherwise
+func ceilToDay(t time.Time) time.Time {
+	midnight := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	if midnight.Equal(t) {
+		return midnight
+	}
+	return midnight.AddDate(0, 0, 1)
+}
+
+// customFieldColumn returns the task_custom_field_values column holding values of the given type
+func customFieldColumn(fieldType common.CustomFieldType) string {
+	switch fieldType {
+	case common.CustomFieldTypeNumber:
+		return "value_number"
+	case common.CustomFieldTypeDate:
+		return "value_date"
+	case common.CustomFieldTypeUser:
+		return "value_user_id"
+	case common.CustomFieldTypeMultiSelect:
+		return "value_options"
+	}
+	return "value_text"
+}
+
+func customFieldParam(args *queryArgs, value *entities.CustomFieldValue) string {
+	switch value.Type {
+	case common.CustomFieldTypeNumber:
+		return args.add(value.Number)
+	case common.CustomFieldTypeDate:
+		return args.add(value.Date)
+	case common.CustomFieldTypeUser:
+		return args.add(value.UserID)
+	case common.CustomFieldTypeMultiSelect:
+		return args.add(pq.Array(value.Options)) + "::text[]"
+	}
+	return args.add(value.Text)
+}
+
+var comparisonOperators = map[repositories.FilterOperator]string{
+	repositories.FilterOpEqual:          "=",
+	repositories.FilterOpGreater:        ">",
+	repositories.FilterOpGreaterOrEqual: ">=",
+	repositories.FilterOpLess:           "<",
+	repositories.FilterOpLessOrEqual:    "<=",
+}
+
+func customFieldCondition(args *queryArgs, condition repositories.CustomFieldCondition) (string, error) {
+	field := condition.Field
+	column := "v." + customFieldColumn(field.Type)
+	exists := "EXISTS (SELECT 1 FROM task_custom_field_values v WHERE v.task_id = t.id AND v.field_id = " + args.add(field.ID) + " AND %s)"
+	invalid := &common.FieldValidationError{
+		Field:   "custom_fields." + field.Key,
+		Message: fmt.Sprintf("operator %q is not supported for %s fields", condition.Operator, field.Type),
+	}
+
+	switch condition.Operator {
+	case repositories.FilterOpContains:
+		switch field.Type {
+		case common.CustomFieldTypeMultiSelect:
+			return fmt.Sprintf(exists, column+" @> "+customFieldParam(args, condition.Value)), nil
+		case common.CustomFieldTypeText:
+			return fmt.Sprintf(exists, column+" ILIKE "+args.add("%"+escapeLike(condition.Value.Text)+"%")), nil
+		}
+		return "", invalid
+	case repositories.FilterOpNotEqual:
+		return "NOT " + fmt.Sprintf(exists, equalityClause(args, column, condition.Value)), nil
+	case repositories.FilterOpEqual:
+		return fmt.Sprintf(exists, equalityClause(args, column, condition.Value)), nil
+	}
+
+	operator, ok := comparisonOperators[condition.Operator]
+	if !ok || field.IsSelect() || field.Type == common.CustomFieldTypeUser {
+		return "", invalid
+	}
+	return fmt.Sprintf(exists, column+" "+operator+" "+customFieldParam(args, condition.Value)), nil
+}
+
+// equalityClause matches multi-select values holding every given option, other types by equality
+func equalityClause(args *queryArgs, column string, value *entities.CustomFieldValue) string {
+	if value.Type == common.CustomFieldTypeMultiSelect {
+		return column + " @> " + customFieldParam(args, value)
+	}
+	return column + " = " + customFieldParam(args, value)
+}
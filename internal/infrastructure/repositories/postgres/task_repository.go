@@ -0,0 +1,304 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"task-engine/internal/domain/entities"
+	"task-engine/internal/domain/entities/common"
+	"task-engine/internal/domain/repositories"
+	"time"
+)
+
+const taskColumns = `t.id, t.project_id, t.parent_id, t.title, COALESCE(t.description, ''), t.status, t.status_category, t.priority, t.due_date,
+	t.estimated_minutes, t.remaining_minutes, t.created_at, t.updated_at`
+
+type TaskRepository struct {
+	db DBTX
+}
+
+func NewTaskRepository(db DBTX) *TaskRepository {
+	return &TaskRepository{db: db}
+}
+
+// Create indexes the task for search in its project's language
+func (r *TaskRepository) Create(ctx context.Context, task *entities.Task) error {
+	return r.db.QueryRowContext(ctx, `
+		INSERT INTO tasks
+			(project_id, parent_id, title, description, status, status_category, priority, due_date,
+			estimated_minutes, remaining_minutes, created_at, updated_at, search_language)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12,
+			(SELECT search_language FROM projects WHERE id = $1))
+		RETURNING id`,
+		task.ProjectID,
+		nullInt64(task.ParentID),
+		task.Title,
+		task.Description,
+		task.Status,
+		task.StatusCategory,
+		task.Priority,
+		nullTime(task.DueDate),
+		task.EstimatedMinutes,
+		nullInt64Ptr(task.RemainingMinutes),
+		task.CreatedAt,
+		task.UpdatedAt,
+	).Scan(&task.ID)
+}
+
+func (r *TaskRepository) FindByID(ctx context.Context, id int64) (*entities.Task, error) {
+	row := r.db.QueryRowContext(ctx, `SELECT `+taskColumns+` FROM tasks t WHERE t.id = $1`, id)
+	task, err := scanTask(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, repositories.ErrNotFound
+	}
+	return task, err
+}
+
+func (r *TaskRepository) FindByIDForUpdate(ctx context.Context, id int64) (*entities.Task, error) {
+	row := r.db.QueryRowContext(ctx, `SELECT `+taskColumns+` FROM tasks t WHERE t.id = $1 FOR UPDATE`, id)
+	task, err := scanTask(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, repositories.ErrNotFound
+	}
+	return task, err
+}
+
+func (r *TaskRepository) ListByProject(ctx context.Context, projectID int64) ([]*entities.Task, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT `+taskColumns+`
+		FROM tasks t
+		WHERE t.project_id = $1
+		ORDER BY t.created_at DESC, t.id DESC`, projectID)
+	if err != nil {
+		return nil, err
+	}
+	return scanTasks(rows)
+}
+
+func (r *TaskRepository) ListSubtasks(ctx context.Context, parentID int64) ([]*entities.Task, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT `+taskColumns+`
+		FROM tasks t
+		WHERE t.parent_id = $1
+		ORDER BY t.created_at, t.id`, parentID)
+	if err != nil {
+		return nil, err
+	}
+	return scanTasks(rows)
+}
+
+func (r *TaskRepository) ListDueByAssignee(ctx context.Context, userID int64, dueBefore time.Time) ([]*entities.Task, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT `+taskColumns+`
+		FROM tasks t
+		JOIN assignments a ON a.task_id = t.id
+		WHERE a.user_id = $1 AND t.due_date < $2 AND t.status_category NOT IN ($3, $4)
+		ORDER BY t.due_date, t.id`,
+		userID, dueBefore, common.StatusCategoryDone, common.StatusCategoryCancelled)
+	if err != nil {
+		return nil, err
+	}
+	return scanTasks(rows)
+}
+
+func (r *TaskRepository) List(ctx context.Context, filter repositories.TaskFilter) ([]*entities.Task, error) {
+	query, args, err := buildTaskListQuery(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	return scanTasks(rows)
+}
+
+func (r *TaskRepository) Count(ctx context.Context, filter repositories.TaskFilter) (int, error) {
+	query, args, err := buildTaskCountQuery(filter)
+	if err != nil {
+		return 0, err
+	}
+
+	var count int
+	err = r.db.QueryRowContext(ctx, query, args...).Scan(&count)
+	return count, err
+}
+
+func (r *TaskRepository) CountEach(ctx context.Context, filters []repositories.TaskFilter) ([]int, error) {
+	counts := make([]int, len(filters))
+	if len(filters) == 0 {
+		return counts, nil
+	}
+	query, args, err := buildTaskCountsQuery(filters)
+	if err != nil {
+		return nil, err
+	}
+
+	dest := make([]interface{}, len(counts))
+	for i := range counts {
+		dest[i] = &counts[i]
+	}
+	if err := r.db.QueryRowContext(ctx, query, args...).Scan(dest...); err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
+
+func (r *TaskRepository) CountFacets(ctx context.Context, filter repositories.TaskFilter, facets []common.TaskFacet) (entities.TaskFacets, error) {
+	counts := make(entities.TaskFacets, len(facets))
+	if len(facets) == 0 {
+		return counts, nil
+	}
+	query, args, err := buildTaskFacetsQuery(filter, facets)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for _, facet := range facets {
+		counts[facet] = []entities.FacetCount{}
+	}
+	for rows.Next() {
+		var (
+			facet common.TaskFacet
+			count entities.FacetCount
+		)
+		if err := rows.Scan(&facet, &count.Value, &count.Count); err != nil {
+			return nil, err
+		}
+		counts[facet] = append(counts[facet], count)
+	}
+	return counts, rows.Err()
+}
+
+func scanTasks(rows *sql.Rows) ([]*entities.Task, error) {
+	defer rows.Close()
+
+	var tasks []*entities.Task
+	for rows.Next() {
+		task, err := scanTask(rows)
+		if err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, task)
+	}
+	return tasks, rows.Err()
+}
+
+// Update also picks up the search language of the task's project, which may have changed
+func (r *TaskRepository) Update(ctx context.Context, task *entities.Task) error {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE tasks SET
+			project_id = $2, parent_id = $3, title = $4, description = $5, status = $6, status_category = $7,
+			priority = $8, due_date = $9, estimated_minutes = $10, remaining_minutes = $11, updated_at = $12,
+			search_language = (SELECT search_language FROM projects WHERE id = $2)
+		WHERE id = $1`,
+		task.ID,
+		task.ProjectID,
+		nullInt64(task.ParentID),
+		task.Title,
+		task.Description,
+		task.Status,
+		task.StatusCategory,
+		task.Priority,
+		nullTime(task.DueDate),
+		task.EstimatedMinutes,
+		nullInt64Ptr(task.RemainingMinutes),
+		task.UpdatedAt,
+	)
+	if err != nil {
+		return err
+	}
+	return expectAffected(result)
+}
+
+// Delete removes the task for good, its subtasks and the rows owned by it are removed by cascade
+func (r *TaskRepository) Delete(ctx context.Context, id int64) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM tasks WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	return expectAffected(result)
+}
+
+func (r *TaskRepository) CountByStatus(ctx context.Context, projectID int64) (map[common.TaskStatus]int, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT status, COUNT(*)
+		FROM tasks
+		WHERE project_id = $1
+		GROUP BY status`, projectID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := make(map[common.TaskStatus]int)
+	for rows.Next() {
+		var (
+			status common.TaskStatus
+			count  int
+		)
+		if err := rows.Scan(&status, &count); err != nil {
+			return nil, err
+		}
+		counts[status] = count
+	}
+	return counts, rows.Err()
+}
+
+func (r *TaskRepository) UpdateStatusCategories(ctx context.Context, projectID int64, categories map[common.TaskStatus]common.StatusCategory) error {
+	for status, category := range categories {
+		if _, err := r.db.ExecContext(ctx, `
+			UPDATE tasks SET status_category = $3
+			WHERE project_id = $1 AND status = $2 AND status_category <> $3`,
+			projectID, status, category,
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func scanTask(row rowScanner) (*entities.Task, error) {
+	var (
+		task                 entities.Task
+		parentID             sql.NullInt64
+		dueDate              sql.NullTime
+		remainingMinutes     sql.NullInt64
+		createdAt, updatedAt sql.NullTime
+	)
+
+	err := row.Scan(
+		&task.ID,
+		&task.ProjectID,
+		&parentID,
+		&task.Title,
+		&task.Description,
+		&task.Status,
+		&task.StatusCategory,
+		&task.Priority,
+		&dueDate,
+		&task.EstimatedMinutes,
+		&remainingMinutes,
+		&createdAt,
+		&updatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	task.ParentID = parentID.Int64
+	task.DueDate = dueDate.Time
+	if remainingMinutes.Valid {
+		task.RemainingMinutes = &remainingMinutes.Int64
+	}
+	task.CreatedAt = createdAt.Time
+	task.UpdatedAt = updatedAt.Time
+	return &task, nil
+}
@@ -0,0 +1,173 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"task-engine/internal/domain/entities"
+	"task-engine/internal/domain/repositories"
+
+	"github.com/lib/pq"
+)
+
+const teamColumns = `id, name, handle, created_at, updated_at`
+
+type TeamRepository struct {
+	db DBTX
+}
+
+func NewTeamRepository(db DBTX) *TeamRepository {
+	return &TeamRepository{db: db}
+}
+
+func (r *TeamRepository) Create(ctx context.Context, team *entities.Team) error {
+	err := r.db.QueryRowContext(ctx, `
+		INSERT INTO teams (name, handle, created_at, updated_at)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id`,
+		team.Name,
+		team.Handle,
+		team.CreatedAt,
+		team.UpdatedAt,
+	).Scan(&team.ID)
+	if isUniqueViolation(err) {
+		return repositories.ErrConflict
+	}
+	return err
+}
+
+func (r *TeamRepository) FindByID(ctx context.Context, id int64) (*entities.Team, error) {
+	row := r.db.QueryRowContext(ctx, `SELECT `+teamColumns+` FROM teams WHERE id = $1`, id)
+	team, err := scanTeam(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, repositories.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	members, err := r.ListMembers(ctx, []int64{team.ID})
+	if err != nil {
+		return nil, err
+	}
+	team.MemberIDs = members[team.ID]
+	return team, nil
+}
+
+func (r *TeamRepository) List(ctx context.Context) ([]*entities.Team, error) {
+	teams, err := r.list(ctx, `SELECT `+teamColumns+` FROM teams ORDER BY handle`)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.loadMembers(ctx, teams); err != nil {
+		return nil, err
+	}
+	return teams, nil
+}
+
+func (r *TeamRepository) FindByHandles(ctx context.Context, handles []string) (map[string]*entities.Team, error) {
+	teams := make(map[string]*entities.Team, len(handles))
+	if len(handles) == 0 {
+		return teams, nil
+	}
+
+	lowered := make([]string, len(handles))
+	for i, handle := range handles {
+		lowered[i] = strings.ToLower(handle)
+	}
+	list, err := r.list(ctx, `SELECT `+teamColumns+` FROM teams WHERE handle = ANY($1)`, pq.Array(lowered))
+	if err != nil {
+		return nil, err
+	}
+	for _, team := range list {
+		teams[team.Handle] = team
+	}
+	return teams, nil
+}
+
+func (r *TeamRepository) ListMembers(ctx context.Context, teamIDs []int64) (map[int64][]int64, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT team_id, user_id
+		FROM team_members
+		WHERE team_id = ANY($1)
+		ORDER BY team_id, user_id`, pq.Array(teamIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	members := make(map[int64][]int64)
+	for rows.Next() {
+		var teamID, userID int64
+		if err := rows.Scan(&teamID, &userID); err != nil {
+			return nil, err
+		}
+		members[teamID] = append(members[teamID], userID)
+	}
+	return members, rows.Err()
+}
+
+func (r *TeamRepository) AddMember(ctx context.Context, teamID, userID int64) error {
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO team_members (team_id, user_id)
+		VALUES ($1, $2)
+		ON CONFLICT DO NOTHING`, teamID, userID)
+	return err
+}
+
+func (r *TeamRepository) RemoveMember(ctx context.Context, teamID, userID int64) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM team_members WHERE team_id = $1 AND user_id = $2`, teamID, userID)
+	if err != nil {
+		return err
+	}
+	return expectAffected(result)
+}
+
+func (r *TeamRepository) loadMembers(ctx context.Context, teams []*entities.Team) error {
+	ids := make([]int64, len(teams))
+	for i, team := range teams {
+		ids[i] = team.ID
+	}
+	members, err := r.ListMembers(ctx, ids)
+	if err != nil {
+		return err
+	}
+	for _, team := range teams {
+		team.MemberIDs = members[team.ID]
+	}
+	return nil
+}
+
+func (r *TeamRepository) list(ctx context.Context, query string, args ...interface{}) ([]*entities.Team, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var teams []*entities.Team
+	for rows.Next() {
+		team, err := scanTeam(rows)
+		if err != nil {
+			return nil, err
+		}
+		teams = append(teams, team)
+	}
+	return teams, rows.Err()
+}
+
+func scanTeam(row rowScanner) (*entities.Team, error) {
+	var (
+		team                 entities.Team
+		createdAt, updatedAt sql.NullTime
+	)
+
+	if err := row.Scan(&team.ID, &team.Name, &team.Handle, &createdAt, &updatedAt); err != nil {
+		return nil, err
+	}
+
+	team.CreatedAt = createdAt.Time
+	team.UpdatedAt = updatedAt.Time
+	return &team, nil
+}
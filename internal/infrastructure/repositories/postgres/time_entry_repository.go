@@ -0,0 +1,79 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"task-engine/internal/domain/entities"
+)
+
+const timeEntryColumns = `te.id, te.task_id, te.user_id, te.minutes, te.spent_on, COALESCE(te.description, ''), te.created_at`
+
+type TimeEntryRepository struct {
+	db DBTX
+}
+
+func NewTimeEntryRepository(db DBTX) *TimeEntryRepository {
+	return &TimeEntryRepository{db: db}
+}
+
+func (r *TimeEntryRepository) Create(ctx context.Context, entry *entities.TimeEntry) error {
+	return r.db.QueryRowContext(ctx, `
+		INSERT INTO time_entries (task_id, user_id, minutes, spent_on, description, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id`,
+		entry.TaskID,
+		entry.UserID,
+		entry.Minutes,
+		entry.SpentOn,
+		entry.Description,
+		entry.CreatedAt,
+	).Scan(&entry.ID)
+}
+
+func (r *TimeEntryRepository) ListByTask(ctx context.Context, taskID int64) ([]*entities.TimeEntry, error) {
+	return r.list(ctx, `
+		SELECT `+timeEntryColumns+`
+		FROM time_entries te
+		WHERE te.task_id = $1
+		ORDER BY te.spent_on DESC, te.id DESC`, taskID)
+}
+
+func (r *TimeEntryRepository) ListByProject(ctx context.Context, projectID int64) ([]*entities.TimeEntry, error) {
+	return r.list(ctx, `
+		SELECT `+timeEntryColumns+`
+		FROM time_entries te
+		JOIN tasks t ON t.id = te.task_id
+		WHERE t.project_id = $1
+		ORDER BY te.spent_on, te.id`, projectID)
+}
+
+func (r *TimeEntryRepository) list(ctx context.Context, query string, args ...interface{}) ([]*entities.TimeEntry, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []*entities.TimeEntry
+	for rows.Next() {
+		var (
+			entry     entities.TimeEntry
+			createdAt sql.NullTime
+		)
+		err := rows.Scan(
+			&entry.ID,
+			&entry.TaskID,
+			&entry.UserID,
+			&entry.Minutes,
+			&entry.SpentOn,
+			&entry.Description,
+			&createdAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		entry.CreatedAt = createdAt.Time
+		entries = append(entries, &entry)
+	}
+	return entries, rows.Err()
+}
@@ -0,0 +1,85 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+)
+
+type txKey struct{}
+
+// txState is the transaction of a context with what runs once it is committed
+type txState struct {
+	tx          *sql.Tx
+	afterCommit []func(ctx context.Context)
+}
+
+// DB wraps a connection pool so that repositories built on it join the transaction
+// started by WithinTransaction for the same context.
+type DB struct {
+	pool *sql.DB
+}
+
+func NewDB(pool *sql.DB) *DB {
+	return &DB{pool: pool}
+}
+
+func (d *DB) conn(ctx context.Context) DBTX {
+	if state, ok := ctx.Value(txKey{}).(*txState); ok {
+		return state.tx
+	}
+	return d.pool
+}
+
+func (d *DB) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return d.conn(ctx).ExecContext(ctx, query, args...)
+}
+
+func (d *DB) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	return d.conn(ctx).QueryContext(ctx, query, args...)
+}
+
+func (d *DB) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	return d.conn(ctx).QueryRowContext(ctx, query, args...)
+}
+
+// PrepareContext prepares the statement on the transaction of the context when there is one
+func (d *DB) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	if state, ok := ctx.Value(txKey{}).(*txState); ok {
+		return state.tx.PrepareContext(ctx, query)
+	}
+	return d.pool.PrepareContext(ctx, query)
+}
+
+// WithinTransaction implements repositories.Transactor. Nested calls reuse the outer transaction.
+func (d *DB) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txKey{}).(*txState); ok {
+		return fn(ctx)
+	}
+
+	tx, err := d.pool.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	state := &txState{tx: tx}
+	if err := fn(context.WithValue(ctx, txKey{}, state)); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	for _, after := range state.afterCommit {
+		after(ctx)
+	}
+	return nil
+}
+
+// AfterCommit implements repositories.Transactor. Functions registered by nested calls wait
+// for the outermost transaction.
+func (d *DB) AfterCommit(ctx context.Context, fn func(ctx context.Context)) {
+	if state, ok := ctx.Value(txKey{}).(*txState); ok {
+		state.afterCommit = append(state.afterCommit, fn)
+		return
+	}
+	fn(ctx)
+}
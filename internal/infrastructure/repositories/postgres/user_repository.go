@@ -0,0 +1,152 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"task-engine/internal/domain/entities"
+	"task-engine/internal/domain/repositories"
+
+	"github.com/lib/pq"
+)
+
+const userColumns = `id, name, username, email, role, status, locale, timezone, created_at, updated_at`
+
+type UserRepository struct {
+	db DBTX
+}
+
+func NewUserRepository(db DBTX) *UserRepository {
+	return &UserRepository{db: db}
+}
+
+func (r *UserRepository) FindByID(ctx context.Context, id int64) (*entities.User, error) {
+	row := r.db.QueryRowContext(ctx, `SELECT `+userColumns+` FROM users WHERE id = $1`, id)
+	user, err := scanUser(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, repositories.ErrNotFound
+	}
+	return user, err
+}
+
+func (r *UserRepository) FindByIDs(ctx context.Context, ids []int64) (map[int64]*entities.User, error) {
+	users := make(map[int64]*entities.User, len(ids))
+	if len(ids) == 0 {
+		return users, nil
+	}
+
+	list, err := r.list(ctx, `SELECT `+userColumns+` FROM users WHERE id = ANY($1)`, pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+	for _, user := range list {
+		users[user.ID] = user
+	}
+	return users, nil
+}
+
+func (r *UserRepository) FindByUsernames(ctx context.Context, usernames []string) (map[string]*entities.User, error) {
+	users := make(map[string]*entities.User, len(usernames))
+	if len(usernames) == 0 {
+		return users, nil
+	}
+
+	lowered := make([]string, len(usernames))
+	for i, username := range usernames {
+		lowered[i] = strings.ToLower(username)
+	}
+	list, err := r.list(ctx, `SELECT `+userColumns+` FROM users WHERE LOWER(username) = ANY($1)`, pq.Array(lowered))
+	if err != nil {
+		return nil, err
+	}
+	for _, user := range list {
+		users[strings.ToLower(user.Username)] = user
+	}
+	return users, nil
+}
+
+func (r *UserRepository) FindByEmails(ctx context.Context, emails []string) (map[string]*entities.User, error) {
+	users := make(map[string]*entities.User, len(emails))
+	if len(emails) == 0 {
+		return users, nil
+	}
+
+	lowered := make([]string, len(emails))
+	for i, email := range emails {
+		lowered[i] = strings.ToLower(email)
+	}
+	list, err := r.list(ctx, `SELECT `+userColumns+` FROM users WHERE LOWER(email) = ANY($1)`, pq.Array(lowered))
+	if err != nil {
+		return nil, err
+	}
+	for _, user := range list {
+		users[strings.ToLower(user.Email)] = user
+	}
+	return users, nil
+}
+
+func (r *UserRepository) Update(ctx context.Context, user *entities.User) error {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE users SET
+			name = $2, email = $3, role = $4, status = $5, locale = $6, timezone = $7, updated_at = $8
+		WHERE id = $1`,
+		user.ID,
+		user.Name,
+		user.Email,
+		user.Role,
+		user.Status,
+		user.Locale,
+		user.Timezone,
+		user.UpdatedAt,
+	)
+	if err != nil {
+		return err
+	}
+	return expectAffected(result)
+}
+
+func (r *UserRepository) list(ctx context.Context, query string, args ...interface{}) ([]*entities.User, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var users []*entities.User
+	for rows.Next() {
+		user, err := scanUser(rows)
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, user)
+	}
+	return users, rows.Err()
+}
+
+func scanUser(row rowScanner) (*entities.User, error) {
+	var (
+		user                 entities.User
+		createdAt, updatedAt sql.NullTime
+	)
+
+	err := row.Scan(
+		&user.ID,
+		&user.Name,
+		&user.Username,
+		&user.Email,
+		&user.Role,
+		&user.Status,
+		&user.Locale,
+		&user.Timezone,
+		&createdAt,
+		&updatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	user.CreatedAt = createdAt.Time
+	user.UpdatedAt = updatedAt.Time
+	return &user, nil
+}
@@ -0,0 +1,363 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"task-engine/internal/domain/entities"
+	"task-engine/internal/domain/entities/common"
+	"task-engine/internal/domain/repositories"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+const webhookColumns = `id, project_id, url, secret, event_types, active, created_by, created_at, updated_at`
+
+const webhookDeliveryColumns = `d.id, d.webhook_id, d.event_id, d.event_type, d.request_body, d.status, d.attempts, d.next_attempt_at,
+	d.response_status, d.last_error, d.delivered_at, d.replay_of, d.created_at`
+
+const webhookAttemptColumns = `id, delivery_id, request_headers, response_status, response_body, error, duration_ms, attempted_at`
+
+type WebhookRepository struct {
+	db DBTX
+}
+
+func NewWebhookRepository(db DBTX) *WebhookRepository {
+	return &WebhookRepository{db: db}
+}
+
+func (r *WebhookRepository) Create(ctx context.Context, webhook *entities.Webhook) error {
+	return r.db.QueryRowContext(ctx, `
+		INSERT INTO webhooks (project_id, url, secret, event_types, active, created_by, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id`,
+		nullInt64(webhook.ProjectID),
+		webhook.URL,
+		webhook.Secret,
+		pq.Array(eventTypeStrings(webhook.EventTypes)),
+		webhook.Active,
+		nullInt64(webhook.CreatedBy),
+		webhook.CreatedAt,
+		webhook.UpdatedAt,
+	).Scan(&webhook.ID)
+}
+
+func (r *WebhookRepository) FindByID(ctx context.Context, id int64) (*entities.Webhook, error) {
+	row := r.db.QueryRowContext(ctx, `SELECT `+webhookColumns+` FROM webhooks WHERE id = $1`, id)
+	webhook, err := scanWebhook(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, repositories.ErrNotFound
+	}
+	return webhook, err
+}
+
+func (r *WebhookRepository) FindByIDs(ctx context.Context, ids []int64) (map[int64]*entities.Webhook, error) {
+	webhooks := make(map[int64]*entities.Webhook, len(ids))
+	if len(ids) == 0 {
+		return webhooks, nil
+	}
+
+	list, err := r.list(ctx, `SELECT `+webhookColumns+` FROM webhooks WHERE id = ANY($1)`, pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+	for _, webhook := range list {
+		webhooks[webhook.ID] = webhook
+	}
+	return webhooks, nil
+}
+
+func (r *WebhookRepository) List(ctx context.Context, filter repositories.WebhookFilter) ([]*entities.Webhook, error) {
+	return r.list(ctx, `
+		SELECT `+webhookColumns+`
+		FROM webhooks
+		WHERE $1 = 0 OR project_id = $1
+		ORDER BY id`, filter.ProjectID)
+}
+
+func (r *WebhookRepository) ListForProject(ctx context.Context, projectID int64) ([]*entities.Webhook, error) {
+	return r.list(ctx, `
+		SELECT `+webhookColumns+`
+		FROM webhooks
+		WHERE active AND (project_id = $1 OR project_id IS NULL)
+		ORDER BY id`, projectID)
+}
+
+func (r *WebhookRepository) Update(ctx context.Context, webhook *entities.Webhook) error {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE webhooks SET
+			url = $2, event_types = $3, active = $4, updated_at = $5
+		WHERE id = $1`,
+		webhook.ID,
+		webhook.URL,
+		pq.Array(eventTypeStrings(webhook.EventTypes)),
+		webhook.Active,
+		webhook.UpdatedAt,
+	)
+	if err != nil {
+		return err
+	}
+	return expectAffected(result)
+}
+
+func (r *WebhookRepository) Delete(ctx context.Context, id int64) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM webhooks WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	return expectAffected(result)
+}
+
+func (r *WebhookRepository) CreateDelivery(ctx context.Context, delivery *entities.WebhookDelivery) error {
+	return r.db.QueryRowContext(ctx, `
+		INSERT INTO webhook_deliveries (webhook_id, event_id, event_type, request_body, status, attempts, next_attempt_at, replay_of, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING id`,
+		delivery.WebhookID,
+		nullInt64(delivery.EventID),
+		delivery.EventType,
+		[]byte(delivery.RequestBody),
+		delivery.Status,
+		delivery.Attempts,
+		nullTime(delivery.NextAttemptAt),
+		nullInt64(delivery.ReplayOf),
+		delivery.CreatedAt,
+	).Scan(&delivery.ID)
+}
+
+func (r *WebhookRepository) FindDelivery(ctx context.Context, id int64) (*entities.WebhookDelivery, error) {
+	row := r.db.QueryRowContext(ctx, `SELECT `+webhookDeliveryColumns+` FROM webhook_deliveries d WHERE d.id = $1`, id)
+	delivery, err := scanWebhookDelivery(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, repositories.ErrNotFound
+	}
+	return delivery, err
+}
+
+func (r *WebhookRepository) ListDeliveries(ctx context.Context, filter repositories.WebhookDeliveryFilter) ([]*entities.WebhookDelivery, error) {
+	return r.listDeliveries(ctx, `
+		SELECT `+webhookDeliveryColumns+`
+		FROM webhook_deliveries d
+		WHERE d.webhook_id = $1 AND ($2 = '' OR d.status = $2)
+		ORDER BY d.id DESC
+		LIMIT $3 OFFSET $4`,
+		filter.WebhookID, string(filter.Status), filter.Limit, filter.Offset)
+}
+
+func (r *WebhookRepository) ClaimDueDeliveries(ctx context.Context, now, leaseUntil time.Time, limit int) ([]*entities.WebhookDelivery, error) {
+	return r.listDeliveries(ctx, `
+		UPDATE webhook_deliveries d SET next_attempt_at = $3
+		WHERE d.id IN (
+			SELECT due.id
+			FROM webhook_deliveries due
+			JOIN webhooks w ON w.id = due.webhook_id
+			WHERE due.status = $1 AND due.next_attempt_at <= $2 AND w.active
+			ORDER BY due.next_attempt_at, due.id
+			LIMIT $4
+			FOR UPDATE OF due SKIP LOCKED)
+		RETURNING `+webhookDeliveryColumns, common.WebhookDeliveryStatusPending, now, leaseUntil, limit)
+}
+
+func (r *WebhookRepository) UpdateDelivery(ctx context.Context, delivery *entities.WebhookDelivery) error {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE webhook_deliveries SET
+			status = $2, attempts = $3, next_attempt_at = $4, response_status = $5,
+			last_error = $6, delivered_at = $7
+		WHERE id = $1`,
+		delivery.ID,
+		delivery.Status,
+		delivery.Attempts,
+		nullTime(delivery.NextAttemptAt),
+		nullInt64(int64(delivery.ResponseStatus)),
+		nullString(delivery.LastError),
+		nullTime(delivery.DeliveredAt),
+	)
+	if err != nil {
+		return err
+	}
+	return expectAffected(result)
+}
+
+func (r *WebhookRepository) CreateAttempt(ctx context.Context, attempt *entities.WebhookAttempt) error {
+	headers, err := json.Marshal(attempt.RequestHeaders)
+	if err != nil {
+		return err
+	}
+
+	return r.db.QueryRowContext(ctx, `
+		INSERT INTO webhook_attempts (delivery_id, request_headers, response_status, response_body, error, duration_ms, attempted_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id`,
+		attempt.DeliveryID,
+		headers,
+		nullInt64(int64(attempt.ResponseStatus)),
+		nullString(attempt.ResponseBody),
+		nullString(attempt.Error),
+		attempt.DurationMs,
+		attempt.AttemptedAt,
+	).Scan(&attempt.ID)
+}
+
+func (r *WebhookRepository) ListAttempts(ctx context.Context, deliveryID int64) ([]*entities.WebhookAttempt, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT `+webhookAttemptColumns+`
+		FROM webhook_attempts
+		WHERE delivery_id = $1
+		ORDER BY id`, deliveryID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var attempts []*entities.WebhookAttempt
+	for rows.Next() {
+		attempt, err := scanWebhookAttempt(rows)
+		if err != nil {
+			return nil, err
+		}
+		attempts = append(attempts, attempt)
+	}
+	return attempts, rows.Err()
+}
+
+func (r *WebhookRepository) list(ctx context.Context, query string, args ...interface{}) ([]*entities.Webhook, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var webhooks []*entities.Webhook
+	for rows.Next() {
+		webhook, err := scanWebhook(rows)
+		if err != nil {
+			return nil, err
+		}
+		webhooks = append(webhooks, webhook)
+	}
+	return webhooks, rows.Err()
+}
+
+func (r *WebhookRepository) listDeliveries(ctx context.Context, query string, args ...interface{}) ([]*entities.WebhookDelivery, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var deliveries []*entities.WebhookDelivery
+	for rows.Next() {
+		delivery, err := scanWebhookDelivery(rows)
+		if err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, delivery)
+	}
+	return deliveries, rows.Err()
+}
+
+func scanWebhook(row rowScanner) (*entities.Webhook, error) {
+	var (
+		webhook              entities.Webhook
+		projectID, createdBy sql.NullInt64
+		eventTypes           pq.StringArray
+		createdAt, updatedAt sql.NullTime
+	)
+
+	err := row.Scan(
+		&webhook.ID,
+		&projectID,
+		&webhook.URL,
+		&webhook.Secret,
+		&eventTypes,
+		&webhook.Active,
+		&createdBy,
+		&createdAt,
+		&updatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	webhook.ProjectID = projectID.Int64
+	webhook.EventTypes = make([]common.EventType, len(eventTypes))
+	for i, eventType := range eventTypes {
+		webhook.EventTypes[i] = common.EventType(eventType)
+	}
+	webhook.CreatedBy = createdBy.Int64
+	webhook.CreatedAt = createdAt.Time
+	webhook.UpdatedAt = updatedAt.Time
+	return &webhook, nil
+}
+
+func scanWebhookDelivery(row rowScanner) (*entities.WebhookDelivery, error) {
+	var (
+		delivery                            entities.WebhookDelivery
+		eventID, responseStatus, replayOf   sql.NullInt64
+		body                                []byte
+		lastError                           sql.NullString
+		nextAttemptAt, deliveredAt, created sql.NullTime
+	)
+
+	err := row.Scan(
+		&delivery.ID,
+		&delivery.WebhookID,
+		&eventID,
+		&delivery.EventType,
+		&body,
+		&delivery.Status,
+		&delivery.Attempts,
+		&nextAttemptAt,
+		&responseStatus,
+		&lastError,
+		&deliveredAt,
+		&replayOf,
+		&created,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	delivery.EventID = eventID.Int64
+	delivery.RequestBody = body
+	delivery.NextAttemptAt = nextAttemptAt.Time
+	delivery.ResponseStatus = int(responseStatus.Int64)
+	delivery.LastError = lastError.String
+	delivery.DeliveredAt = deliveredAt.Time
+	delivery.ReplayOf = replayOf.Int64
+	delivery.CreatedAt = created.Time
+	return &delivery, nil
+}
+
+func scanWebhookAttempt(row rowScanner) (*entities.WebhookAttempt, error) {
+	var (
+		attempt        entities.WebhookAttempt
+		headers        []byte
+		responseStatus sql.NullInt64
+		body, errText  sql.NullString
+	)
+
+	err := row.Scan(
+		&attempt.ID,
+		&attempt.DeliveryID,
+		&headers,
+		&responseStatus,
+		&body,
+		&errText,
+		&attempt.DurationMs,
+		&attempt.AttemptedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(headers, &attempt.RequestHeaders); err != nil {
+		return nil, err
+	}
+	attempt.ResponseStatus = int(responseStatus.Int64)
+	attempt.ResponseBody = body.String
+	attempt.Error = errText.String
+	return &attempt, nil
+}
@@ -0,0 +1,95 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"task-engine/internal/domain/entities"
+	"task-engine/internal/domain/repositories"
+)
+
+const workflowColumns = `id, project_id, name, initial_status, statuses, transitions, created_at, updated_at`
+
+type WorkflowRepository struct {
+	db DBTX
+}
+
+func NewWorkflowRepository(db DBTX) *WorkflowRepository {
+	return &WorkflowRepository{db: db}
+}
+
+func (r *WorkflowRepository) FindByProject(ctx context.Context, projectID int64) (*entities.Workflow, error) {
+	row := r.db.QueryRowContext(ctx, `SELECT `+workflowColumns+` FROM workflows WHERE project_id = $1`, projectID)
+	workflow, err := scanWorkflow(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, repositories.ErrNotFound
+	}
+	return workflow, err
+}
+
+// Save creates the project's workflow or replaces the existing one
+func (r *WorkflowRepository) Save(ctx context.Context, workflow *entities.Workflow) error {
+	statuses, err := json.Marshal(workflow.Statuses)
+	if err != nil {
+		return err
+	}
+	transitions, err := json.Marshal(workflow.Transitions)
+	if err != nil {
+		return err
+	}
+	if workflow.Transitions == nil {
+		transitions = []byte("[]")
+	}
+
+	return r.db.QueryRowContext(ctx, `
+		INSERT INTO workflows (project_id, name, initial_status, statuses, transitions, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (project_id) DO UPDATE SET
+			name = EXCLUDED.name,
+			initial_status = EXCLUDED.initial_status,
+			statuses = EXCLUDED.statuses,
+			transitions = EXCLUDED.transitions,
+			updated_at = EXCLUDED.updated_at
+		RETURNING id, created_at`,
+		workflow.ProjectID,
+		workflow.Name,
+		workflow.InitialStatus,
+		statuses,
+		transitions,
+		workflow.CreatedAt,
+		workflow.UpdatedAt,
+	).Scan(&workflow.ID, &workflow.CreatedAt)
+}
+
+func scanWorkflow(row rowScanner) (*entities.Workflow, error) {
+	var (
+		workflow              entities.Workflow
+		statuses, transitions []byte
+		createdAt, updatedAt  sql.NullTime
+	)
+
+	err := row.Scan(
+		&workflow.ID,
+		&workflow.ProjectID,
+		&workflow.Name,
+		&workflow.InitialStatus,
+		&statuses,
+		&transitions,
+		&createdAt,
+		&updatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(statuses, &workflow.Statuses); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(transitions, &workflow.Transitions); err != nil {
+		return nil, err
+	}
+	workflow.CreatedAt = createdAt.Time
+	workflow.UpdatedAt = updatedAt.Time
+	return &workflow, nil
+}
@@ -0,0 +1,139 @@
+package storage
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"task-engine/internal/domain/repositories"
+	"time"
+)
+
+// LocalBlobStore keeps blobs as files under root, sharded by the first bytes of their
+// SHA-256 key: root/ab/cd/abcd... Content is written to a temporary file while it is
+// hashed and renamed into place once complete, so readers never see partial blobs.
+type LocalBlobStore struct {
+	root string
+}
+
+func NewLocalBlobStore(root string) (*LocalBlobStore, error) {
+	if err := os.MkdirAll(filepath.Join(root, "tmp"), 0o750); err != nil {
+		return nil, err
+	}
+	return &LocalBlobStore{root: root}, nil
+}
+
+func (s *LocalBlobStore) Put(ctx context.Context, r io.Reader) (string, int64, error) {
+	tmp, err := os.CreateTemp(filepath.Join(s.root, "tmp"), "upload-*")
+	if err != nil {
+		return "", 0, err
+	}
+	defer os.Remove(tmp.Name())
+
+	hash := sha256.New()
+	size, err := io.Copy(io.MultiWriter(tmp, hash), r)
+	if err != nil {
+		tmp.Close()
+		return "", 0, err
+	}
+	if err := tmp.Close(); err != nil {
+		return "", 0, err
+	}
+
+	key := hex.EncodeToString(hash.Sum(nil))
+	target := s.path(key)
+
+	// the content is already stored, refresh its time so a concurrent sweep keeps it
+	if _, err := os.Stat(target); err == nil {
+		now := time.Now()
+		return key, size, os.Chtimes(target, now, now)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(target), 0o750); err != nil {
+		return "", 0, err
+	}
+	if err := os.Rename(tmp.Name(), target); err != nil {
+		return "", 0, err
+	}
+	return key, size, nil
+}
+
+func (s *LocalBlobStore) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	if !isBlobKey(key) {
+		return nil, repositories.ErrNotFound
+	}
+	file, err := os.Open(s.path(key))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, repositories.ErrNotFound
+	}
+	return file, err
+}
+
+func (s *LocalBlobStore) Delete(ctx context.Context, key string) error {
+	if !isBlobKey(key) {
+		return nil
+	}
+	err := os.Remove(s.path(key))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+func (s *LocalBlobStore) Modified(ctx context.Context, key string) (time.Time, error) {
+	if !isBlobKey(key) {
+		return time.Time{}, repositories.ErrNotFound
+	}
+	info, err := os.Stat(s.path(key))
+	if errors.Is(err, fs.ErrNotExist) {
+		return time.Time{}, repositories.ErrNotFound
+	}
+	if err != nil {
+		return time.Time{}, err
+	}
+	return info.ModTime(), nil
+}
+
+func (s *LocalBlobStore) Walk(ctx context.Context, fn func(key string, modified time.Time) error) error {
+	return filepath.WalkDir(s.root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			if entry.Name() == "tmp" && filepath.Dir(path) == s.root {
+				return filepath.SkipDir
+			}
+			return ctx.Err()
+		}
+		if !isBlobKey(entry.Name()) {
+			return nil
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		return fn(entry.Name(), info.ModTime())
+	})
+}
+
+func (s *LocalBlobStore) path(key string) string {
+	return filepath.Join(s.root, key[0:2], key[2:4], key)
+}
+
+// isBlobKey reports whether key is a lowercase hex SHA-256, which also keeps keys from
+// escaping the store root
+func isBlobKey(key string) bool {
+	if len(key) != sha256.Size*2 {
+		return false
+	}
+	for _, r := range key {
+		if (r < '0' || r > '9') && (r < 'a' || r > 'f') {
+			return false
+		}
+	}
+	return true
+}
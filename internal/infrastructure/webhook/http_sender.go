@@ -0,0 +1,58 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"task-engine/internal/domain/entities"
+	"time"
+)
+
+// maxResponseBody is how much of a receiver's answer is kept in the delivery log
+const maxResponseBody = 64 << 10
+
+// HTTPSender posts webhook requests over HTTP. Redirects are not followed, a receiver
+// that moved must have its webhook URL updated.
+type HTTPSender struct {
+	client *http.Client
+}
+
+func NewHTTPSender(timeout time.Duration) *HTTPSender {
+	return &HTTPSender{client: &http.Client{
+		Timeout: timeout,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}}
+}
+
+func (s *HTTPSender) Send(ctx context.Context, request *entities.WebhookRequest) (*entities.WebhookResponse, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, request.URL, bytes.NewReader(request.Body))
+	if err != nil {
+		return nil, err
+	}
+	for name, value := range request.Headers {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseBody))
+	if err != nil {
+		return nil, err
+	}
+	// Drain what is left so the connection can be reused
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	return &entities.WebhookResponse{
+		StatusCode: resp.StatusCode,
+		// PostgreSQL text rejects NUL bytes and invalid UTF-8
+		Body: strings.ReplaceAll(strings.ToValidUTF8(string(body), "�"), "\x00", ""),
+	}, nil
+}
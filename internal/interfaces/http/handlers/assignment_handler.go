@@ -0,0 +1,69 @@
+package handlers
+
+import (
+	"net/http"
+	"task-engine/internal/application/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AssignmentHandler struct {
+	assignments *services.AssignmentService
+}
+
+func NewAssignmentHandler(assignments *services.AssignmentService) *AssignmentHandler {
+	return &AssignmentHandler{assignments: assignments}
+}
+
+func (h *AssignmentHandler) RegisterRoutes(rg *gin.RouterGroup) {
+	rg.POST("/tasks/:id/assignees", h.Assign)
+	rg.DELETE("/tasks/:id/assignees/:userId", h.Unassign)
+}
+
+type assignRequest struct {
+	UserID int64 `json:"user_id"`
+}
+
+func (h *AssignmentHandler) Assign(c *gin.Context) {
+	taskID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	claims, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	var req assignRequest
+	if err := c.ShouldBindJSON(&req); err != nil || req.UserID <= 0 {
+		respondBadRequest(c, "user_id is required")
+		return
+	}
+
+	if err := h.assignments.Assign(c.Request.Context(), taskID, req.UserID, claims.UserID); err != nil {
+		respondError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func (h *AssignmentHandler) Unassign(c *gin.Context) {
+	taskID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	userID, ok := parseIDParam(c, "userId")
+	if !ok {
+		return
+	}
+	claims, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	if err := h.assignments.Unassign(c.Request.Context(), taskID, userID, claims.UserID); err != nil {
+		respondError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
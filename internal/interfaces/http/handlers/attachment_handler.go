@@ -0,0 +1,157 @@
+package handlers
+
+import (
+	"errors"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"task-engine/internal/application/services"
+	"task-engine/internal/domain/entities"
+	"task-engine/internal/domain/entities/common"
+
+	"github.com/gin-gonic/gin"
+)
+
+// multipartOverhead is allowed on top of the attachment size for the multipart framing
+const multipartOverhead = 64 << 10
+
+type AttachmentHandler struct {
+	attachments *services.AttachmentService
+}
+
+func NewAttachmentHandler(attachments *services.AttachmentService) *AttachmentHandler {
+	return &AttachmentHandler{attachments: attachments}
+}
+
+func (h *AttachmentHandler) RegisterRoutes(rg *gin.RouterGroup) {
+	rg.GET("/tasks/:id/attachments", h.ListAttachments)
+	rg.POST("/tasks/:id/attachments", h.UploadToTask)
+	rg.POST("/comments/:id/attachments", h.UploadToComment)
+	rg.GET("/attachments/:id/download", h.Download)
+	rg.DELETE("/attachments/:id", h.DeleteAttachment)
+}
+
+func (h *AttachmentHandler) ListAttachments(c *gin.Context) {
+	taskID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	attachments, err := h.attachments.ListByTask(c.Request.Context(), taskID)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"attachments": attachments})
+}
+
+// UploadToTask stores the "file" part of a multipart/form-data body as a task attachment
+func (h *AttachmentHandler) UploadToTask(c *gin.Context) {
+	taskID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	claims, ok := currentUser(c)
+	if !ok {
+		return
+	}
+	file, header, ok := h.formFile(c)
+	if !ok {
+		return
+	}
+	defer file.Close()
+
+	attachment, err := h.attachments.UploadToTask(c.Request.Context(), taskID, services.UploadInput{
+		Filename:   header.Filename,
+		UploadedBy: claims.UserID,
+		Content:    file,
+	})
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, attachment)
+}
+
+// UploadToComment stores the "file" part of a multipart/form-data body as a comment attachment
+func (h *AttachmentHandler) UploadToComment(c *gin.Context) {
+	commentID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	claims, ok := currentUser(c)
+	if !ok {
+		return
+	}
+	file, header, ok := h.formFile(c)
+	if !ok {
+		return
+	}
+	defer file.Close()
+
+	attachment, err := h.attachments.UploadToComment(c.Request.Context(), commentID, services.UploadInput{
+		Filename:   header.Filename,
+		UploadedBy: claims.UserID,
+		Content:    file,
+	})
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, attachment)
+}
+
+// Download always serves the file as an attachment with the sniffed content type, so
+// browsers never render uploaded content inline
+func (h *AttachmentHandler) Download(c *gin.Context) {
+	attachmentID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	attachment, content, err := h.attachments.Open(c.Request.Context(), attachmentID)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	defer content.Close()
+
+	c.DataFromReader(http.StatusOK, attachment.SizeBytes, attachment.ContentType, content, map[string]string{
+		"Content-Disposition":    mime.FormatMediaType("attachment", map[string]string{"filename": attachment.Filename}),
+		"X-Content-Type-Options": "nosniff",
+	})
+}
+
+func (h *AttachmentHandler) DeleteAttachment(c *gin.Context) {
+	attachmentID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	claims, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	if err := h.attachments.Delete(c.Request.Context(), attachmentID, claims.UserID, common.UserRole(claims.Role)); err != nil {
+		respondError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// formFile opens the "file" part of the request, rejecting bodies larger than the attachment limit
+func (h *AttachmentHandler) formFile(c *gin.Context) (multipart.File, *multipart.FileHeader, bool) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, h.attachments.MaxBytes()+multipartOverhead)
+
+	file, header, err := c.Request.FormFile("file")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			respondError(c, &entities.AttachmentTooLargeError{Limit: h.attachments.MaxBytes()})
+			return nil, nil, false
+		}
+		respondBadRequest(c, `a multipart "file" field is required`)
+		return nil, nil, false
+	}
+	return file, header, true
+}
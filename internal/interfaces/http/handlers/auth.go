@@ -0,0 +1,33 @@
+package handlers
+
+import (
+	"net/http"
+	"task-engine/internal/domain/entities/common"
+	"task-engine/pkg/auth"
+
+	"github.com/gin-gonic/gin"
+)
+
+// currentUser returns the authenticated caller, answering 401 when there is none
+func currentUser(c *gin.Context) (*auth.Claims, bool) {
+	claims, ok := auth.CurrentClaims(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "authentication required"})
+		return nil, false
+	}
+	return claims, true
+}
+
+// requireManager answers 403 unless the caller is an admin or a manager
+func requireManager(c *gin.Context) (*auth.Claims, bool) {
+	claims, ok := currentUser(c)
+	if !ok {
+		return nil, false
+	}
+	role := common.UserRole(claims.Role)
+	if role != common.UserRoleAdmin && role != common.UserRoleManager {
+		c.JSON(http.StatusForbidden, gin.H{"error": "manager role required"})
+		return nil, false
+	}
+	return claims, true
+}
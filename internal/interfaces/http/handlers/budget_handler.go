@@ -0,0 +1,173 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+	"task-engine/internal/application/services"
+	"task-engine/internal/domain/entities/common"
+
+	"github.com/gin-gonic/gin"
+)
+
+type BudgetHandler struct {
+	budgets *services.BudgetService
+}
+
+func NewBudgetHandler(budgets *services.BudgetService) *BudgetHandler {
+	return &BudgetHandler{budgets: budgets}
+}
+
+func (h *BudgetHandler) RegisterRoutes(rg *gin.RouterGroup) {
+	rg.GET("/projects/:id/budget", h.GetLedger)
+	rg.GET("/projects/:id/budget/report", h.GetReport)
+	rg.PUT("/projects/:id/budget", h.UpdateBudget)
+	rg.GET("/projects/:id/expenses", h.ListExpenses)
+	rg.POST("/projects/:id/expenses", h.RecordExpense)
+	rg.DELETE("/projects/:id/expenses/:expenseId", h.DeleteExpense)
+}
+
+type recordExpenseRequest struct {
+	Kind        common.ExpenseKind     `json:"kind"`
+	Category    common.ExpenseCategory `json:"category"`
+	Description string                 `json:"description"`
+	Amount      int64                  `json:"amount"`
+	Currency    common.Currency        `json:"currency"`
+	IncurredOn  string                 `json:"incurred_on"`
+}
+
+func (h *BudgetHandler) GetLedger(c *gin.Context) {
+	if _, ok := currentUser(c); !ok {
+		return
+	}
+	projectID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	ledger, err := h.budgets.GetLedger(c.Request.Context(), projectID)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, ledger)
+}
+
+// GetReport converts the ledger into ?currency= using the rates dated on or before ?as_of=
+func (h *BudgetHandler) GetReport(c *gin.Context) {
+	if _, ok := currentUser(c); !ok {
+		return
+	}
+	projectID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	asOf, err := parseDate(c.Query("as_of"))
+	if err != nil {
+		respondBadRequest(c, "as_of must use the YYYY-MM-DD format")
+		return
+	}
+
+	currency := common.Currency(strings.ToUpper(c.Query("currency")))
+	report, err := h.budgets.GetReport(c.Request.Context(), projectID, currency, asOf)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, report)
+}
+
+func (h *BudgetHandler) UpdateBudget(c *gin.Context) {
+	if _, ok := requireManager(c); !ok {
+		return
+	}
+	projectID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var req common.Money
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBadRequest(c, "invalid request body")
+		return
+	}
+
+	project, err := h.budgets.UpdateBudget(c.Request.Context(), projectID, req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, project)
+}
+
+func (h *BudgetHandler) ListExpenses(c *gin.Context) {
+	if _, ok := currentUser(c); !ok {
+		return
+	}
+	projectID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	expenses, err := h.budgets.ListExpenses(c.Request.Context(), projectID)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"expenses": expenses})
+}
+
+func (h *BudgetHandler) RecordExpense(c *gin.Context) {
+	claims, ok := currentUser(c)
+	if !ok {
+		return
+	}
+	projectID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var req recordExpenseRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBadRequest(c, "invalid request body")
+		return
+	}
+	incurredOn, err := parseDate(req.IncurredOn)
+	if err != nil {
+		respondBadRequest(c, "incurred_on must use the YYYY-MM-DD format")
+		return
+	}
+
+	expense, err := h.budgets.RecordExpense(c.Request.Context(), projectID, services.RecordExpenseInput{
+		Kind:        req.Kind,
+		Category:    req.Category,
+		Description: req.Description,
+		Amount:      common.NewMoney(req.Amount, req.Currency),
+		IncurredOn:  incurredOn,
+		CreatedBy:   claims.UserID,
+	})
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, expense)
+}
+
+func (h *BudgetHandler) DeleteExpense(c *gin.Context) {
+	if _, ok := currentUser(c); !ok {
+		return
+	}
+	projectID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	expenseID, ok := parseIDParam(c, "expenseId")
+	if !ok {
+		return
+	}
+
+	if err := h.budgets.DeleteExpense(c.Request.Context(), projectID, expenseID); err != nil {
+		respondError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
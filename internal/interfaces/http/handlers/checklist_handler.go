@@ -0,0 +1,141 @@
+package handlers
+
+import (
+	"net/http"
+	"task-engine/internal/application/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ChecklistHandler struct {
+	checklists *services.ChecklistService
+}
+
+func NewChecklistHandler(checklists *services.ChecklistService) *ChecklistHandler {
+	return &ChecklistHandler{checklists: checklists}
+}
+
+func (h *ChecklistHandler) RegisterRoutes(rg *gin.RouterGroup) {
+	rg.GET("/tasks/:id/checklist", h.ListItems)
+	rg.POST("/tasks/:id/checklist", h.AddItem)
+	rg.PUT("/tasks/:id/checklist/:itemId", h.UpdateItem)
+	rg.DELETE("/tasks/:id/checklist/:itemId", h.DeleteItem)
+	rg.POST("/tasks/:id/checklist/:itemId/check", h.CheckItem)
+	rg.DELETE("/tasks/:id/checklist/:itemId/check", h.UncheckItem)
+}
+
+type checklistItemRequest struct {
+	Text     string `json:"text"`
+	Position int    `json:"position"`
+}
+
+func (h *ChecklistHandler) ListItems(c *gin.Context) {
+	taskID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	items, err := h.checklists.ListItems(c.Request.Context(), taskID)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"checklist": items})
+}
+
+func (h *ChecklistHandler) AddItem(c *gin.Context) {
+	taskID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var req checklistItemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBadRequest(c, "invalid request body")
+		return
+	}
+
+	item, err := h.checklists.AddItem(c.Request.Context(), taskID, req.Text)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, item)
+}
+
+func (h *ChecklistHandler) UpdateItem(c *gin.Context) {
+	taskID, itemID, ok := parseChecklistParams(c)
+	if !ok {
+		return
+	}
+
+	var req checklistItemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBadRequest(c, "invalid request body")
+		return
+	}
+
+	item, err := h.checklists.UpdateItem(c.Request.Context(), taskID, itemID, req.Text, req.Position)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, item)
+}
+
+func (h *ChecklistHandler) DeleteItem(c *gin.Context) {
+	taskID, itemID, ok := parseChecklistParams(c)
+	if !ok {
+		return
+	}
+
+	if err := h.checklists.DeleteItem(c.Request.Context(), taskID, itemID); err != nil {
+		respondError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func (h *ChecklistHandler) CheckItem(c *gin.Context) {
+	taskID, itemID, ok := parseChecklistParams(c)
+	if !ok {
+		return
+	}
+	claims, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	item, err := h.checklists.CheckItem(c.Request.Context(), taskID, itemID, claims.UserID)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, item)
+}
+
+func (h *ChecklistHandler) UncheckItem(c *gin.Context) {
+	taskID, itemID, ok := parseChecklistParams(c)
+	if !ok {
+		return
+	}
+
+	item, err := h.checklists.UncheckItem(c.Request.Context(), taskID, itemID)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, item)
+}
+
+func parseChecklistParams(c *gin.Context) (int64, int64, bool) {
+	taskID, ok := parseIDParam(c, "id")
+	if !ok {
+		return 0, 0, false
+	}
+	itemID, ok := parseIDParam(c, "itemId")
+	if !ok {
+		return 0, 0, false
+	}
+	return taskID, itemID, true
+}
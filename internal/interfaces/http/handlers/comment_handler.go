@@ -0,0 +1,189 @@
+package handlers
+
+import (
+	"net/http"
+	"task-engine/internal/application/services"
+	"task-engine/internal/domain/entities/common"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CommentHandler struct {
+	comments *services.CommentService
+}
+
+func NewCommentHandler(comments *services.CommentService) *CommentHandler {
+	return &CommentHandler{comments: comments}
+}
+
+func (h *CommentHandler) RegisterRoutes(rg *gin.RouterGroup) {
+	rg.GET("/tasks/:id/comments", h.ListComments)
+	rg.POST("/tasks/:id/comments", h.AddComment)
+
+	rg.PUT("/comments/:id", h.EditComment)
+	rg.DELETE("/comments/:id", h.DeleteComment)
+	rg.GET("/comments/:id/revisions", h.ListRevisions)
+	rg.POST("/comments/:id/reactions", h.React)
+	rg.DELETE("/comments/:id/reactions/:emoji", h.Unreact)
+}
+
+// commentRequest adds a comment, parent_id makes it a reply
+type commentRequest struct {
+	Content  string `json:"content"`
+	ParentID int64  `json:"parent_id"`
+}
+
+type editCommentRequest struct {
+	Content string `json:"content"`
+}
+
+type reactionRequest struct {
+	Emoji string `json:"emoji"`
+}
+
+func (h *CommentHandler) ListComments(c *gin.Context) {
+	taskID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	claims, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	comments, err := h.comments.ListComments(c.Request.Context(), taskID, claims.UserID, common.UserRole(claims.Role))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"comments": comments})
+}
+
+func (h *CommentHandler) AddComment(c *gin.Context) {
+	taskID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	claims, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	var req commentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBadRequest(c, "invalid request body")
+		return
+	}
+
+	comment, err := h.comments.AddComment(c.Request.Context(), services.AddCommentInput{
+		TaskID:   taskID,
+		UserID:   claims.UserID,
+		ParentID: req.ParentID,
+		Content:  req.Content,
+	})
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, comment)
+}
+
+// EditComment replaces the content, only the author or a manager may edit
+func (h *CommentHandler) EditComment(c *gin.Context) {
+	commentID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	claims, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	var req editCommentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBadRequest(c, "invalid request body")
+		return
+	}
+
+	comment, err := h.comments.EditComment(c.Request.Context(), commentID, claims.UserID, common.UserRole(claims.Role), req.Content)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, comment)
+}
+
+// DeleteComment leaves a tombstone, only the author or a manager may delete
+func (h *CommentHandler) DeleteComment(c *gin.Context) {
+	commentID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	claims, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	if err := h.comments.DeleteComment(c.Request.Context(), commentID, claims.UserID, common.UserRole(claims.Role)); err != nil {
+		respondError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func (h *CommentHandler) ListRevisions(c *gin.Context) {
+	commentID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	revisions, err := h.comments.ListRevisions(c.Request.Context(), commentID)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"revisions": revisions})
+}
+
+func (h *CommentHandler) React(c *gin.Context) {
+	commentID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	claims, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	var req reactionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBadRequest(c, "invalid request body")
+		return
+	}
+
+	reactions, err := h.comments.React(c.Request.Context(), commentID, claims.UserID, req.Emoji)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"reactions": reactions})
+}
+
+func (h *CommentHandler) Unreact(c *gin.Context) {
+	commentID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	claims, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	reactions, err := h.comments.Unreact(c.Request.Context(), commentID, claims.UserID, c.Param("emoji"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"reactions": reactions})
+}
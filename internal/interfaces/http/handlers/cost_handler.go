@@ -0,0 +1,151 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+	"task-engine/internal/application/services"
+	"task-engine/internal/domain/entities/common"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CostHandler struct {
+	costs *services.CostService
+}
+
+func NewCostHandler(costs *services.CostService) *CostHandler {
+	return &CostHandler{costs: costs}
+}
+
+func (h *CostHandler) RegisterRoutes(rg *gin.RouterGroup) {
+	rg.GET("/projects/:id/costs", h.GetCostReport)
+	rg.GET("/tasks/:id/time-entries", h.ListTimeEntries)
+	rg.POST("/tasks/:id/time-entries", h.LogTime)
+	rg.GET("/hourly-rates", h.ListHourlyRates)
+	rg.POST("/hourly-rates", h.SetHourlyRate)
+}
+
+type logTimeRequest struct {
+	Minutes     int64  `json:"minutes"`
+	SpentOn     string `json:"spent_on"`
+	Description string `json:"description"`
+}
+
+type setHourlyRateRequest struct {
+	UserID        int64           `json:"user_id"`
+	Role          common.UserRole `json:"role"`
+	Amount        int64           `json:"amount"`
+	Currency      common.Currency `json:"currency"`
+	EffectiveFrom string          `json:"effective_from"`
+}
+
+// GetCostReport prices logged time in ?currency= using exchange rates dated on or before ?as_of=
+func (h *CostHandler) GetCostReport(c *gin.Context) {
+	projectID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	asOf, err := parseDate(c.Query("as_of"))
+	if err != nil {
+		respondBadRequest(c, "as_of must use the YYYY-MM-DD format")
+		return
+	}
+
+	currency := common.Currency(strings.ToUpper(c.Query("currency")))
+	report, err := h.costs.GetCostReport(c.Request.Context(), projectID, currency, asOf)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, report)
+}
+
+func (h *CostHandler) ListTimeEntries(c *gin.Context) {
+	taskID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	entries, err := h.costs.ListTimeEntries(c.Request.Context(), taskID)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"time_entries": entries})
+}
+
+func (h *CostHandler) LogTime(c *gin.Context) {
+	taskID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	claims, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	var req logTimeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBadRequest(c, "invalid request body")
+		return
+	}
+	spentOn, err := parseDate(req.SpentOn)
+	if err != nil {
+		respondBadRequest(c, "spent_on must use the YYYY-MM-DD format")
+		return
+	}
+
+	entry, err := h.costs.LogTime(c.Request.Context(), taskID, services.LogTimeInput{
+		UserID:      claims.UserID,
+		Minutes:     req.Minutes,
+		SpentOn:     spentOn,
+		Description: req.Description,
+	})
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, entry)
+}
+
+func (h *CostHandler) ListHourlyRates(c *gin.Context) {
+	if _, ok := requireManager(c); !ok {
+		return
+	}
+
+	rates, err := h.costs.ListHourlyRates(c.Request.Context())
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"hourly_rates": rates})
+}
+
+func (h *CostHandler) SetHourlyRate(c *gin.Context) {
+	if _, ok := requireManager(c); !ok {
+		return
+	}
+
+	var req setHourlyRateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBadRequest(c, "invalid request body")
+		return
+	}
+	effectiveFrom, err := parseDate(req.EffectiveFrom)
+	if err != nil {
+		respondBadRequest(c, "effective_from must use the YYYY-MM-DD format")
+		return
+	}
+
+	rate, err := h.costs.SetHourlyRate(c.Request.Context(), services.SetHourlyRateInput{
+		UserID:        req.UserID,
+		Role:          req.Role,
+		Rate:          common.NewMoney(req.Amount, req.Currency),
+		EffectiveFrom: effectiveFrom,
+	})
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, rate)
+}
@@ -0,0 +1,134 @@
+package handlers
+
+import (
+	"net/http"
+	"task-engine/internal/application/services"
+	"task-engine/internal/domain/entities/common"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CustomFieldHandler struct {
+	customFields *services.CustomFieldService
+}
+
+func NewCustomFieldHandler(customFields *services.CustomFieldService) *CustomFieldHandler {
+	return &CustomFieldHandler{customFields: customFields}
+}
+
+func (h *CustomFieldHandler) RegisterRoutes(rg *gin.RouterGroup) {
+	rg.GET("/projects/:id/custom-fields", h.ListDefinitions)
+	rg.POST("/projects/:id/custom-fields", h.CreateDefinition)
+	rg.PUT("/projects/:id/custom-fields/:fieldId", h.UpdateDefinition)
+	rg.DELETE("/projects/:id/custom-fields/:fieldId", h.DeleteDefinition)
+}
+
+type createCustomFieldRequest struct {
+	Key      string                 `json:"key"`
+	Name     string                 `json:"name"`
+	Type     common.CustomFieldType `json:"type"`
+	Options  []string               `json:"options"`
+	Required bool                   `json:"required"`
+}
+
+type updateCustomFieldRequest struct {
+	Name     string   `json:"name"`
+	Options  []string `json:"options"`
+	Required bool     `json:"required"`
+	Position int      `json:"position"`
+}
+
+func (h *CustomFieldHandler) ListDefinitions(c *gin.Context) {
+	projectID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	definitions, err := h.customFields.ListDefinitions(c.Request.Context(), projectID)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"custom_fields": definitions})
+}
+
+func (h *CustomFieldHandler) CreateDefinition(c *gin.Context) {
+	projectID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	if _, ok := requireManager(c); !ok {
+		return
+	}
+
+	var req createCustomFieldRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBadRequest(c, "invalid request body")
+		return
+	}
+
+	definition, err := h.customFields.CreateDefinition(c.Request.Context(), projectID, services.CreateCustomFieldInput{
+		Key:      req.Key,
+		Name:     req.Name,
+		Type:     req.Type,
+		Options:  req.Options,
+		Required: req.Required,
+	})
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, definition)
+}
+
+func (h *CustomFieldHandler) UpdateDefinition(c *gin.Context) {
+	projectID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	fieldID, ok := parseIDParam(c, "fieldId")
+	if !ok {
+		return
+	}
+	if _, ok := requireManager(c); !ok {
+		return
+	}
+
+	var req updateCustomFieldRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBadRequest(c, "invalid request body")
+		return
+	}
+
+	definition, err := h.customFields.UpdateDefinition(c.Request.Context(), projectID, fieldID, services.UpdateCustomFieldInput{
+		Name:     req.Name,
+		Options:  req.Options,
+		Required: req.Required,
+		Position: req.Position,
+	})
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, definition)
+}
+
+func (h *CustomFieldHandler) DeleteDefinition(c *gin.Context) {
+	projectID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	fieldID, ok := parseIDParam(c, "fieldId")
+	if !ok {
+		return
+	}
+	if _, ok := requireManager(c); !ok {
+		return
+	}
+
+	if err := h.customFields.DeleteDefinition(c.Request.Context(), projectID, fieldID); err != nil {
+		respondError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,84 @@
+package handlers
+
+import (
+	"net/http"
+	"task-engine/internal/application/services"
+	"task-engine/internal/domain/entities/common"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type DigestHandler struct {
+	digests *services.DigestService
+}
+
+func NewDigestHandler(digests *services.DigestService) *DigestHandler {
+	return &DigestHandler{digests: digests}
+}
+
+func (h *DigestHandler) RegisterRoutes(rg *gin.RouterGroup) {
+	rg.GET("/notification-digest", h.GetSchedule)
+	rg.PUT("/notification-digest", h.UpdateSchedule)
+	rg.DELETE("/notification-digest", h.DisableSchedule)
+}
+
+// updateDigestRequest sets when digests are sent, hour is in the user's timezone and
+// weekday (0 is Sunday) is only used by weekly digests
+type updateDigestRequest struct {
+	Frequency common.DigestFrequency `json:"frequency"`
+	Hour      int                    `json:"hour"`
+	Weekday   time.Weekday           `json:"weekday"`
+}
+
+func (h *DigestHandler) GetSchedule(c *gin.Context) {
+	claims, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	schedule, err := h.digests.GetSchedule(c.Request.Context(), claims.UserID)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, schedule)
+}
+
+// UpdateSchedule turns digests on or changes their schedule, emails are no longer sent per event
+func (h *DigestHandler) UpdateSchedule(c *gin.Context) {
+	claims, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	var req updateDigestRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBadRequest(c, "invalid request body")
+		return
+	}
+
+	schedule, err := h.digests.UpdateSchedule(c.Request.Context(), claims.UserID, services.UpdateDigestScheduleInput{
+		Frequency: req.Frequency,
+		Hour:      req.Hour,
+		Weekday:   req.Weekday,
+	})
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, schedule)
+}
+
+func (h *DigestHandler) DisableSchedule(c *gin.Context) {
+	claims, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	if err := h.digests.DisableSchedule(c.Request.Context(), claims.UserID); err != nil {
+		respondError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
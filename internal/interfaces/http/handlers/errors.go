@@ -0,0 +1,99 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"task-engine/internal/domain/entities"
+	"task-engine/internal/domain/entities/common"
+	"task-engine/internal/domain/repositories"
+	"task-engine/pkg/logger"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type fieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// respondError maps domain and repository errors to HTTP responses
+func respondError(c *gin.Context, err error) {
+	var (
+		validationErrs common.ValidationErrors
+		fieldErr       *common.FieldValidationError
+		missingRateErr *entities.MissingExchangeRateError
+		transitionErr  *entities.TransitionNotAllowedError
+		forbiddenErr   *entities.ForbiddenError
+		tooLargeErr    *entities.AttachmentTooLargeError
+		queryErr       *entities.TaskQueryError
+	)
+
+	switch {
+	case errors.As(err, &validationErrs):
+		details := make([]fieldError, 0, len(validationErrs))
+		for _, e := range validationErrs {
+			details = append(details, fieldError{Field: e.Field, Message: e.Message})
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": "validation failed", "details": details})
+	case errors.As(err, &fieldErr):
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "validation failed",
+			"details": []fieldError{{Field: fieldErr.Field, Message: fieldErr.Message}},
+		})
+	case errors.As(err, &queryErr):
+		c.JSON(http.StatusBadRequest, gin.H{"error": queryErr.Error(), "position": queryErr.Column})
+	case errors.As(err, &missingRateErr):
+		c.JSON(http.StatusUnprocessableEntity, gin.H{
+			"error": missingRateErr.Error(),
+			"from":  missingRateErr.From,
+			"to":    missingRateErr.To,
+			"as_of": missingRateErr.AsOf.Format("2006-01-02"),
+		})
+	case errors.As(err, &transitionErr):
+		c.JSON(http.StatusUnprocessableEntity, gin.H{
+			"error":  transitionErr.Error(),
+			"from":   transitionErr.From,
+			"to":     transitionErr.To,
+			"reason": transitionErr.Reason,
+		})
+	case errors.As(err, &forbiddenErr):
+		c.JSON(http.StatusForbidden, gin.H{"error": forbiddenErr.Error()})
+	case errors.As(err, &tooLargeErr):
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": tooLargeErr.Error(), "limit_bytes": tooLargeErr.Limit})
+	case errors.Is(err, repositories.ErrNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, repositories.ErrConflict):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		logger.Error("request failed",
+			zap.String("path", c.Request.URL.Path),
+			zap.Error(err),
+		)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+	}
+}
+
+func respondBadRequest(c *gin.Context, message string) {
+	c.JSON(http.StatusBadRequest, gin.H{"error": message})
+}
+
+// parseIDParam reads a positive integer path parameter, answering 400 when it is invalid
+func parseIDParam(c *gin.Context, name string) (int64, bool) {
+	id, err := strconv.ParseInt(c.Param(name), 10, 64)
+	if err != nil || id <= 0 {
+		respondBadRequest(c, "invalid "+name)
+		return 0, false
+	}
+	return id, true
+}
+
+// parseDate parses an optional YYYY-MM-DD value, returning the zero time when empty
+func parseDate(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse("2006-01-02", value)
+}
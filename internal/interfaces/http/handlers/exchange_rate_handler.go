@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"task-engine/internal/application/services"
+	"task-engine/internal/domain/entities/common"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ExchangeRateHandler struct {
+	rates *services.ExchangeRateService
+}
+
+func NewExchangeRateHandler(rates *services.ExchangeRateService) *ExchangeRateHandler {
+	return &ExchangeRateHandler{rates: rates}
+}
+
+func (h *ExchangeRateHandler) RegisterRoutes(rg *gin.RouterGroup) {
+	rg.GET("/exchange-rates", h.List)
+	rg.POST("/exchange-rates/import", h.Import)
+}
+
+func (h *ExchangeRateHandler) List(c *gin.Context) {
+	base := common.Currency(strings.ToUpper(c.Query("base")))
+	quote := common.Currency(strings.ToUpper(c.Query("quote")))
+
+	rates, err := h.rates.List(c.Request.Context(), base, quote)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"exchange_rates": rates})
+}
+
+// Import accepts either a multipart "file" field or a raw text/csv body. The rates apply to
+// the reports of every project, so only managers import them.
+func (h *ExchangeRateHandler) Import(c *gin.Context) {
+	if _, ok := requireManager(c); !ok {
+		return
+	}
+	var body io.Reader = c.Request.Body
+	if file, err := c.FormFile("file"); err == nil {
+		f, err := file.Open()
+		if err != nil {
+			respondBadRequest(c, "cannot read uploaded file")
+			return
+		}
+		defer f.Close()
+		body = f
+	}
+
+	imported, err := h.rates.ImportCSV(c.Request.Context(), body)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"imported": imported})
+}
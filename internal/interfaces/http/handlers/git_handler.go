@@ -0,0 +1,109 @@
+package handlers
+
+import (
+	"net/http"
+	"task-engine/internal/application/services"
+	"task-engine/internal/domain/entities"
+	"task-engine/internal/domain/entities/common"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type GitHandler struct {
+	git *services.GitIntegrationService
+}
+
+func NewGitHandler(git *services.GitIntegrationService) *GitHandler {
+	return &GitHandler{git: git}
+}
+
+func (h *GitHandler) RegisterRoutes(rg *gin.RouterGroup) {
+	rg.POST("/integrations/git/push", h.Push)
+	rg.GET("/tasks/:id/commits", h.ListCommits)
+}
+
+// gitPushRequest is a generic push event. Its fields follow the push payloads of the common
+// git hosts, which a CI job or relay holding an API token can forward here. The route needs
+// a token, so git host webhooks cannot call it by themselves.
+type gitPushRequest struct {
+	Ref        string               `json:"ref"`
+	Repository gitRepositoryRequest `json:"repository"`
+	Commits    []gitCommitRequest   `json:"commits"`
+}
+
+type gitRepositoryRequest struct {
+	Name     string `json:"name"`
+	FullName string `json:"full_name"`
+}
+
+type gitCommitRequest struct {
+	ID        string    `json:"id"`
+	Message   string    `json:"message"`
+	Timestamp time.Time `json:"timestamp"`
+	URL       string    `json:"url"`
+	Author    struct {
+		Name  string `json:"name"`
+		Email string `json:"email"`
+	} `json:"author"`
+}
+
+// Push links the pushed commits to the tasks they reference. Tasks they close are completed
+// by the caller, whatever author the commits name.
+func (h *GitHandler) Push(c *gin.Context) {
+	claims, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	var req gitPushRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBadRequest(c, "invalid request body")
+		return
+	}
+
+	repository := req.Repository.FullName
+	if repository == "" {
+		repository = req.Repository.Name
+	}
+	commits := make([]*entities.GitCommit, len(req.Commits))
+	for i, commit := range req.Commits {
+		commits[i] = &entities.GitCommit{
+			SHA:         commit.ID,
+			Message:     commit.Message,
+			AuthorName:  commit.Author.Name,
+			AuthorEmail: commit.Author.Email,
+			URL:         commit.URL,
+			CommittedAt: commit.Timestamp,
+		}
+	}
+	push, err := entities.NewGitPush(repository, req.Ref, commits)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	result, err := h.git.ProcessPush(c.Request.Context(), push, entities.TransitionActor{
+		UserID: claims.UserID,
+		Role:   common.UserRole(claims.Role),
+	})
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+func (h *GitHandler) ListCommits(c *gin.Context) {
+	taskID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	commits, err := h.git.ListCommits(c.Request.Context(), taskID)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"commits": commits})
+}
@@ -0,0 +1,209 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"task-engine/internal/application/services"
+	"task-engine/internal/domain/entities"
+	"task-engine/internal/domain/entities/common"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ImportHandler struct {
+	imports         *services.ImportService
+	externalImports *services.ExternalImportService
+}
+
+func NewImportHandler(imports *services.ImportService, externalImports *services.ExternalImportService) *ImportHandler {
+	return &ImportHandler{imports: imports, externalImports: externalImports}
+}
+
+func (h *ImportHandler) RegisterRoutes(rg *gin.RouterGroup) {
+	rg.POST("/imports", h.Import)
+	rg.POST("/imports/trello", h.ImportTrello)
+	rg.POST("/imports/jira", h.ImportJira)
+}
+
+// Import loads projects and tasks from a multipart "file" field or a raw body, in CSV or
+// JSON as told by ?format=, the file extension or the content type. CSV columns are mapped
+// with ?map.<field>=<header>. ?dry_run=true stores nothing and reports every invalid row.
+func (h *ImportHandler) Import(c *gin.Context) {
+	claims, ok := requireManager(c)
+	if !ok {
+		return
+	}
+
+	body, format, ok := importFile(c)
+	if !ok {
+		return
+	}
+	defer body.Close()
+	if format == "" && strings.Contains(c.ContentType(), "json") {
+		format = "json"
+	}
+
+	var (
+		projects []*services.ImportProject
+		err      error
+	)
+	switch format {
+	case "json":
+		projects, err = services.ParseImportJSON(body)
+	case "csv", "":
+		mapping := make(map[string]string)
+		for param, values := range c.Request.URL.Query() {
+			if field, ok := strings.CutPrefix(param, "map."); ok && len(values) > 0 {
+				mapping[field] = values[0]
+			}
+		}
+		projects, err = services.ParseImportCSV(body, mapping)
+	default:
+		respondBadRequest(c, "format must be csv or json")
+		return
+	}
+	var fieldErr *common.FieldValidationError
+	if errors.As(err, &fieldErr) {
+		respondError(c, err)
+		return
+	} else if err != nil {
+		// the file itself is malformed
+		respondBadRequest(c, err.Error())
+		return
+	}
+
+	report, err := h.imports.Import(c.Request.Context(), services.ImportInput{
+		OwnerID:  claims.UserID,
+		Projects: projects,
+		DryRun:   c.Query("dry_run") == "true",
+	})
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	details := make([]fieldError, 0, len(report.Errors))
+	for _, e := range report.Errors {
+		details = append(details, fieldError{Field: e.Field, Message: e.Message})
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"dry_run":           report.DryRun,
+		"projects_created":  report.ProjectsCreated,
+		"projects_existing": report.ProjectsExisting,
+		"tasks_imported":    report.TasksImported,
+		"errors":            details,
+	})
+}
+
+// ImportTrello imports a board exported as JSON from Trello, sent as a multipart "file"
+// field or a raw body. Lists are mapped to statuses with ?status.<list name>=<status>.
+// Importing the board again updates what the first import created.
+func (h *ImportHandler) ImportTrello(c *gin.Context) {
+	claims, ok := requireManager(c)
+	if !ok {
+		return
+	}
+	body, _, ok := importFile(c)
+	if !ok {
+		return
+	}
+	defer body.Close()
+
+	project, err := services.ParseTrelloBoard(body)
+	if err != nil {
+		respondBadRequest(c, err.Error())
+		return
+	}
+	h.importExternal(c, claims.UserID, []*entities.ExternalProject{project})
+}
+
+// ImportJira imports issues exported from Jira as XML or CSV, as told by ?format=, the file
+// extension or the content type. Jira statuses are mapped with ?status.<name>=<status>.
+// Importing the issues again updates what the first import created.
+func (h *ImportHandler) ImportJira(c *gin.Context) {
+	claims, ok := requireManager(c)
+	if !ok {
+		return
+	}
+	body, format, ok := importFile(c)
+	if !ok {
+		return
+	}
+	defer body.Close()
+	if format == "" && strings.Contains(c.ContentType(), "xml") {
+		format = "xml"
+	}
+
+	var (
+		projects []*entities.ExternalProject
+		err      error
+	)
+	switch format {
+	case "xml":
+		projects, err = services.ParseJiraXML(body)
+	case "csv", "":
+		projects, err = services.ParseJiraCSV(body)
+	default:
+		respondBadRequest(c, "format must be xml or csv")
+		return
+	}
+	if err != nil {
+		respondBadRequest(c, err.Error())
+		return
+	}
+	h.importExternal(c, claims.UserID, projects)
+}
+
+func (h *ImportHandler) importExternal(c *gin.Context, ownerID int64, projects []*entities.ExternalProject) {
+	statusMap := make(map[string]common.TaskStatus)
+	for param, values := range c.Request.URL.Query() {
+		if name, ok := strings.CutPrefix(param, "status."); ok && len(values) > 0 {
+			statusMap[name] = common.TaskStatus(values[0])
+		}
+	}
+
+	report, err := h.externalImports.Import(c.Request.Context(), services.ExternalImportInput{
+		OwnerID:   ownerID,
+		Projects:  projects,
+		StatusMap: statusMap,
+	})
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	unknownUsers := report.UnknownUsers
+	if unknownUsers == nil {
+		unknownUsers = []string{}
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"projects_created": report.ProjectsCreated,
+		"projects_updated": report.ProjectsUpdated,
+		"tasks_created":    report.TasksCreated,
+		"tasks_updated":    report.TasksUpdated,
+		"comments_created": report.CommentsCreated,
+		"unknown_users":    unknownUsers,
+	})
+}
+
+// importFile returns the uploaded multipart "file" field, or the request body when there is
+// none, with the format named by ?format= or the file extension
+func importFile(c *gin.Context) (io.ReadCloser, string, bool) {
+	format := c.Query("format")
+	file, err := c.FormFile("file")
+	if err != nil {
+		return c.Request.Body, format, true
+	}
+	f, err := file.Open()
+	if err != nil {
+		respondBadRequest(c, "cannot read uploaded file")
+		return nil, "", false
+	}
+	if format == "" {
+		format = strings.TrimPrefix(strings.ToLower(filepath.Ext(file.Filename)), ".")
+	}
+	return f, format, true
+}
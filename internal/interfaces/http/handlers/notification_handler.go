@@ -0,0 +1,148 @@
+package handlers
+
+import (
+	"net/http"
+	"task-engine/internal/application/services"
+	"task-engine/internal/domain/entities"
+
+	"github.com/gin-gonic/gin"
+)
+
+type NotificationHandler struct {
+	notifications *services.NotificationService
+}
+
+func NewNotificationHandler(notifications *services.NotificationService) *NotificationHandler {
+	return &NotificationHandler{notifications: notifications}
+}
+
+func (h *NotificationHandler) RegisterRoutes(rg *gin.RouterGroup) {
+	rg.GET("/notifications", h.ListInbox)
+	rg.POST("/notifications/read-all", h.MarkAllRead)
+	rg.POST("/notifications/:id/read", h.MarkRead)
+	rg.DELETE("/notifications/:id/read", h.MarkUnread)
+
+	rg.GET("/notification-preferences", h.GetPreferences)
+	rg.PUT("/notification-preferences", h.UpdatePreferences)
+}
+
+// updatePreferencesRequest changes the locale and timezone when set and the listed preferences only
+type updatePreferencesRequest struct {
+	Locale      *string                            `json:"locale"`
+	Timezone    *string                            `json:"timezone"`
+	Preferences []*entities.NotificationPreference `json:"preferences"`
+}
+
+// ListInbox returns the caller's in-app notifications, newest first, with the unread count
+func (h *NotificationHandler) ListInbox(c *gin.Context) {
+	claims, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	input := services.InboxInput{UserID: claims.UserID, UnreadOnly: c.Query("unread") == "true"}
+	var err error
+	if input.Limit, err = parseIntQuery(c, "limit"); err != nil {
+		respondBadRequest(c, "limit must be a number")
+		return
+	}
+	if input.Offset, err = parseIntQuery(c, "offset"); err != nil {
+		respondBadRequest(c, "offset must be a number")
+		return
+	}
+
+	notifications, unread, err := h.notifications.ListInbox(c.Request.Context(), input)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"notifications": notifications, "unread_count": unread})
+}
+
+func (h *NotificationHandler) MarkRead(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	claims, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	notification, err := h.notifications.MarkRead(c.Request.Context(), claims.UserID, id)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, notification)
+}
+
+func (h *NotificationHandler) MarkUnread(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	claims, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	notification, err := h.notifications.MarkUnread(c.Request.Context(), claims.UserID, id)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, notification)
+}
+
+func (h *NotificationHandler) MarkAllRead(c *gin.Context) {
+	claims, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	updated, err := h.notifications.MarkAllRead(c.Request.Context(), claims.UserID)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"updated": updated})
+}
+
+func (h *NotificationHandler) GetPreferences(c *gin.Context) {
+	claims, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	settings, err := h.notifications.GetSettings(c.Request.Context(), claims.UserID)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, settings)
+}
+
+func (h *NotificationHandler) UpdatePreferences(c *gin.Context) {
+	claims, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	var req updatePreferencesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBadRequest(c, "invalid request body")
+		return
+	}
+
+	settings, err := h.notifications.UpdateSettings(c.Request.Context(), claims.UserID, services.UpdateNotificationSettingsInput{
+		Locale:      req.Locale,
+		Timezone:    req.Timezone,
+		Preferences: req.Preferences,
+	})
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, settings)
+}
@@ -0,0 +1,148 @@
+package handlers
+
+import (
+	"net/http"
+	"task-engine/internal/application/services"
+	"task-engine/internal/domain/entities"
+	"task-engine/internal/domain/entities/common"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ProjectHandler struct {
+	projects *services.ProjectService
+}
+
+func NewProjectHandler(projects *services.ProjectService) *ProjectHandler {
+	return &ProjectHandler{projects: projects}
+}
+
+func (h *ProjectHandler) RegisterRoutes(rg *gin.RouterGroup) {
+	rg.GET("/projects/:id", h.GetProject)
+	rg.PUT("/projects/:id/settings", h.UpdateSettings)
+	rg.PUT("/projects/:id/key", h.SetKey)
+	rg.PUT("/projects/:id/inbound-email", h.SetInboundEmail)
+	rg.PUT("/projects/:id/search-language", h.SetSearchLanguage)
+}
+
+type projectKeyRequest struct {
+	Key string `json:"key"`
+}
+
+type inboundEmailRequest struct {
+	Address string `json:"address"`
+}
+
+type searchLanguageRequest struct {
+	Language common.SearchLanguage `json:"language"`
+}
+
+func (h *ProjectHandler) GetProject(c *gin.Context) {
+	projectID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	project, err := h.projects.GetProject(c.Request.Context(), projectID)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, project)
+}
+
+func (h *ProjectHandler) UpdateSettings(c *gin.Context) {
+	projectID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	if _, ok := requireManager(c); !ok {
+		return
+	}
+
+	var req entities.ProjectSettings
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBadRequest(c, "invalid request body")
+		return
+	}
+
+	project, err := h.projects.UpdateSettings(c.Request.Context(), projectID, req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, project)
+}
+
+// SetKey sets the project key, an empty key removes it
+func (h *ProjectHandler) SetKey(c *gin.Context) {
+	projectID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	if _, ok := requireManager(c); !ok {
+		return
+	}
+
+	var req projectKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBadRequest(c, "invalid request body")
+		return
+	}
+
+	project, err := h.projects.SetKey(c.Request.Context(), projectID, req.Key)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, project)
+}
+
+// SetInboundEmail sets the address whose emails become tasks, an empty address removes it
+func (h *ProjectHandler) SetInboundEmail(c *gin.Context) {
+	projectID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	if _, ok := requireManager(c); !ok {
+		return
+	}
+
+	var req inboundEmailRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBadRequest(c, "invalid request body")
+		return
+	}
+
+	project, err := h.projects.SetInboundEmail(c.Request.Context(), projectID, req.Address)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, project)
+}
+
+// SetSearchLanguage changes the language the project is searched in. Its existing tasks and
+// comments are moved to the new language by the reindex-search command.
+func (h *ProjectHandler) SetSearchLanguage(c *gin.Context) {
+	projectID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	if _, ok := requireManager(c); !ok {
+		return
+	}
+
+	var req searchLanguageRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBadRequest(c, "invalid request body")
+		return
+	}
+
+	project, err := h.projects.SetSearchLanguage(c.Request.Context(), projectID, req.Language)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, project)
+}
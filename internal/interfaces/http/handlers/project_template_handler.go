@@ -0,0 +1,190 @@
+package handlers
+
+import (
+	"net/http"
+	"task-engine/internal/application/services"
+	"task-engine/internal/domain/entities"
+	"task-engine/internal/domain/entities/common"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ProjectTemplateHandler struct {
+	templates *services.ProjectTemplateService
+}
+
+func NewProjectTemplateHandler(templates *services.ProjectTemplateService) *ProjectTemplateHandler {
+	return &ProjectTemplateHandler{templates: templates}
+}
+
+func (h *ProjectTemplateHandler) RegisterRoutes(rg *gin.RouterGroup) {
+	rg.POST("/projects/:id/template", h.SaveAsTemplate)
+	rg.POST("/projects/:id/clone", h.CloneProject)
+	rg.GET("/project-templates", h.ListTemplates)
+	rg.GET("/project-templates/:id", h.GetTemplate)
+	rg.DELETE("/project-templates/:id", h.DeleteTemplate)
+	rg.POST("/project-templates/:id/projects", h.InstantiateTemplate)
+}
+
+type saveTemplateRequest struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+type instantiateTemplateRequest struct {
+	Name          string                    `json:"name"`
+	OwnerID       int64                     `json:"owner_id"`
+	StartDate     string                    `json:"start_date"`
+	RoleAssignees map[common.UserRole]int64 `json:"role_assignees"`
+}
+
+type cloneProjectRequest struct {
+	Name      string                `json:"name"`
+	OwnerID   int64                 `json:"owner_id"`
+	StartDate string                `json:"start_date"`
+	Copy      *entities.CopyOptions `json:"copy"`
+}
+
+func (h *ProjectTemplateHandler) SaveAsTemplate(c *gin.Context) {
+	projectID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	claims, ok := requireManager(c)
+	if !ok {
+		return
+	}
+
+	var req saveTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBadRequest(c, "invalid request body")
+		return
+	}
+
+	template, err := h.templates.SaveAsTemplate(c.Request.Context(), projectID, services.SaveTemplateInput{
+		Name:        req.Name,
+		Description: req.Description,
+		CreatedBy:   claims.UserID,
+	})
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, template)
+}
+
+// CloneProject copies a project. Without a "copy" object everything is copied.
+func (h *ProjectTemplateHandler) CloneProject(c *gin.Context) {
+	projectID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	if _, ok := requireManager(c); !ok {
+		return
+	}
+
+	var req cloneProjectRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBadRequest(c, "invalid request body")
+		return
+	}
+	startDate, err := parseDate(req.StartDate)
+	if err != nil {
+		respondBadRequest(c, "start_date must use the YYYY-MM-DD format")
+		return
+	}
+	options := entities.TemplateCopyOptions
+	if req.Copy != nil {
+		options = *req.Copy
+	}
+
+	project, err := h.templates.CloneProject(c.Request.Context(), projectID, services.CloneProjectInput{
+		Name:      req.Name,
+		OwnerID:   req.OwnerID,
+		StartDate: startDate,
+		Options:   options,
+	})
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, project)
+}
+
+func (h *ProjectTemplateHandler) ListTemplates(c *gin.Context) {
+	templates, err := h.templates.ListTemplates(c.Request.Context())
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"project_templates": templates})
+}
+
+func (h *ProjectTemplateHandler) GetTemplate(c *gin.Context) {
+	templateID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	template, err := h.templates.GetTemplate(c.Request.Context(), templateID)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, template)
+}
+
+func (h *ProjectTemplateHandler) DeleteTemplate(c *gin.Context) {
+	templateID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	if _, ok := requireManager(c); !ok {
+		return
+	}
+
+	if err := h.templates.DeleteTemplate(c.Request.Context(), templateID); err != nil {
+		respondError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// InstantiateTemplate creates a project from the template, owned by the caller unless owner_id is given
+func (h *ProjectTemplateHandler) InstantiateTemplate(c *gin.Context) {
+	templateID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	claims, ok := requireManager(c)
+	if !ok {
+		return
+	}
+
+	var req instantiateTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBadRequest(c, "invalid request body")
+		return
+	}
+	startDate, err := parseDate(req.StartDate)
+	if err != nil {
+		respondBadRequest(c, "start_date must use the YYYY-MM-DD format")
+		return
+	}
+	ownerID := req.OwnerID
+	if ownerID == 0 {
+		ownerID = claims.UserID
+	}
+
+	project, err := h.templates.InstantiateTemplate(c.Request.Context(), templateID, services.InstantiateTemplateInput{
+		Name:          req.Name,
+		OwnerID:       ownerID,
+		StartDate:     startDate,
+		RoleAssignees: req.RoleAssignees,
+	})
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, project)
+}
@@ -0,0 +1,275 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"task-engine/internal/application/services"
+	"task-engine/internal/domain/entities"
+	"task-engine/internal/domain/entities/common"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SavedViewHandler struct {
+	views *services.SavedViewService
+	// basePath prefixes the URL of every view
+	basePath string
+}
+
+func NewSavedViewHandler(views *services.SavedViewService) *SavedViewHandler {
+	return &SavedViewHandler{views: views}
+}
+
+func (h *SavedViewHandler) RegisterRoutes(rg *gin.RouterGroup) {
+	h.basePath = rg.BasePath()
+
+	rg.GET("/views", h.ListViews)
+	rg.POST("/views", h.CreateView)
+	rg.GET("/views/:id", h.GetView)
+	rg.PUT("/views/:id", h.UpdateView)
+	rg.DELETE("/views/:id", h.DeleteView)
+	rg.GET("/views/:id/tasks", h.ViewTasks)
+	rg.PUT("/views/:id/pin", h.PinView)
+	rg.DELETE("/views/:id/pin", h.UnpinView)
+}
+
+// createViewRequest leaves project_id out for a view spanning every project
+type createViewRequest struct {
+	ProjectID  int64                      `json:"project_id"`
+	Name       string                     `json:"name"`
+	Visibility common.SavedViewVisibility `json:"visibility"`
+	TeamID     int64                      `json:"team_id"`
+	entities.TaskListSettings
+}
+
+// updateViewRequest replaces the query, sort, grouping and columns together when any is set
+type updateViewRequest struct {
+	Name       *string                     `json:"name"`
+	Visibility *common.SavedViewVisibility `json:"visibility"`
+	TeamID     *int64                      `json:"team_id"`
+	Query      *string                     `json:"query"`
+	Sort       *[]string                   `json:"sort"`
+	GroupBy    *common.TaskGrouping        `json:"group_by"`
+	Columns    *[]string                   `json:"columns"`
+}
+
+// viewResponse adds the stable URL of the view, which does not change when it is renamed
+type viewResponse struct {
+	*entities.SavedView
+	URL string `json:"url"`
+}
+
+func (h *SavedViewHandler) response(view *entities.SavedView) viewResponse {
+	return viewResponse{SavedView: view, URL: h.basePath + "/views/" + strconv.FormatInt(view.ID, 10)}
+}
+
+// ListViews returns the views the caller sees with their task counts, pinned ones first,
+// restricted to a project with ?project_id=
+func (h *SavedViewHandler) ListViews(c *gin.Context) {
+	claims, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	var projectID int64
+	if value := c.Query("project_id"); value != "" {
+		var err error
+		if projectID, err = strconv.ParseInt(value, 10, 64); err != nil || projectID <= 0 {
+			respondBadRequest(c, "project_id must be a positive number")
+			return
+		}
+	}
+
+	views, err := h.views.ListViews(c.Request.Context(), claims.UserID, common.UserRole(claims.Role), projectID)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	responses := make([]viewResponse, len(views))
+	for i, view := range views {
+		responses[i] = h.response(view)
+	}
+	c.JSON(http.StatusOK, gin.H{"views": responses})
+}
+
+func (h *SavedViewHandler) CreateView(c *gin.Context) {
+	claims, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	var req createViewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBadRequest(c, "invalid request body")
+		return
+	}
+
+	view, err := h.views.CreateView(c.Request.Context(), services.CreateSavedViewInput{
+		UserID:     claims.UserID,
+		Role:       common.UserRole(claims.Role),
+		ProjectID:  req.ProjectID,
+		Name:       req.Name,
+		Visibility: req.Visibility,
+		TeamID:     req.TeamID,
+		Settings:   req.TaskListSettings,
+	})
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, h.response(view))
+}
+
+func (h *SavedViewHandler) GetView(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	claims, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	view, err := h.views.GetView(c.Request.Context(), id, claims.UserID)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, h.response(view))
+}
+
+func (h *SavedViewHandler) UpdateView(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	claims, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	var req updateViewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBadRequest(c, "invalid request body")
+		return
+	}
+
+	input := services.UpdateSavedViewInput{
+		UserID:     claims.UserID,
+		Role:       common.UserRole(claims.Role),
+		Name:       req.Name,
+		Visibility: req.Visibility,
+		TeamID:     req.TeamID,
+	}
+	if req.Query != nil || req.Sort != nil || req.GroupBy != nil || req.Columns != nil {
+		// the fields left out are cleared
+		settings := entities.TaskListSettings{}
+		if req.Query != nil {
+			settings.Query = *req.Query
+		}
+		if req.Sort != nil {
+			settings.Sort = *req.Sort
+		}
+		if req.GroupBy != nil {
+			settings.GroupBy = *req.GroupBy
+		}
+		if req.Columns != nil {
+			settings.Columns = *req.Columns
+		}
+		input.Settings = &settings
+	}
+
+	view, err := h.views.UpdateView(c.Request.Context(), id, input)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, h.response(view))
+}
+
+func (h *SavedViewHandler) DeleteView(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	claims, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	if err := h.views.DeleteView(c.Request.Context(), id, claims.UserID, common.UserRole(claims.Role)); err != nil {
+		respondError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// ViewTasks returns a page of the tasks of the view with the total, "me" in its query is
+// the caller. The next page is read with ?cursor=<next_cursor> or ?offset=.
+func (h *SavedViewHandler) ViewTasks(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	claims, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	limit, err := parseIntQuery(c, "limit")
+	if err != nil {
+		respondBadRequest(c, "limit must be a number")
+		return
+	}
+	offset, err := parseIntQuery(c, "offset")
+	if err != nil {
+		respondBadRequest(c, "offset must be a number")
+		return
+	}
+
+	result, err := h.views.ViewTasks(c.Request.Context(), id, claims.UserID, common.UserRole(claims.Role), limit, offset, c.Query("cursor"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	response := gin.H{"view": h.response(result.View), "tasks": result.Tasks, "total": result.Total}
+	if result.NextCursor != "" {
+		response["next_cursor"] = result.NextCursor
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// PinView pins the view for the caller, pinned views are listed first
+func (h *SavedViewHandler) PinView(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	claims, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	if err := h.views.PinView(c.Request.Context(), id, claims.UserID); err != nil {
+		respondError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func (h *SavedViewHandler) UnpinView(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	claims, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	if err := h.views.UnpinView(c.Request.Context(), id, claims.UserID); err != nil {
+		respondError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
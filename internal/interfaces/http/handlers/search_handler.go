@@ -0,0 +1,107 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"task-engine/internal/application/services"
+	"task-engine/internal/domain/entities/common"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SearchHandler struct {
+	search *services.SearchService
+}
+
+func NewSearchHandler(search *services.SearchService) *SearchHandler {
+	return &SearchHandler{search: search}
+}
+
+func (h *SearchHandler) RegisterRoutes(rg *gin.RouterGroup) {
+	rg.GET("/search", h.Search)
+	rg.GET("/typeahead", h.Typeahead)
+}
+
+// Search finds tasks, projects and comments matching q. The type, status and tag filters
+// take comma-separated lists.
+func (h *SearchHandler) Search(c *gin.Context) {
+	claims, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	input := services.SearchInput{
+		Query:  c.Query("q"),
+		Tags:   splitList(c.Query("tag")),
+		UserID: claims.UserID,
+		Role:   common.UserRole(claims.Role),
+	}
+	for _, resultType := range splitList(c.Query("type")) {
+		input.Types = append(input.Types, common.SearchResultType(resultType))
+	}
+	for _, status := range splitList(c.Query("status")) {
+		input.Statuses = append(input.Statuses, common.TaskStatus(status))
+	}
+	if value := c.Query("project_id"); value != "" {
+		var err error
+		if input.ProjectID, err = strconv.ParseInt(value, 10, 64); err != nil || input.ProjectID <= 0 {
+			respondBadRequest(c, "project_id must be a positive number")
+			return
+		}
+	}
+
+	var err error
+	if input.Limit, err = parseIntQuery(c, "limit"); err != nil {
+		respondBadRequest(c, "limit must be a number")
+		return
+	}
+	if input.Offset, err = parseIntQuery(c, "offset"); err != nil {
+		respondBadRequest(c, "offset must be a number")
+		return
+	}
+
+	results, err := h.search.Search(c.Request.Context(), input)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"results": results})
+}
+
+// Typeahead suggests tasks, projects, tags and users whose name starts with q. The type
+// filter takes a comma-separated list, limit applies to each type.
+func (h *SearchHandler) Typeahead(c *gin.Context) {
+	claims, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	input := services.SuggestInput{
+		Prefix: c.Query("q"),
+		UserID: claims.UserID,
+		Role:   common.UserRole(claims.Role),
+	}
+	for _, suggestionType := range splitList(c.Query("type")) {
+		input.Types = append(input.Types, common.SuggestionType(suggestionType))
+	}
+	if value := c.Query("project_id"); value != "" {
+		var err error
+		if input.ProjectID, err = strconv.ParseInt(value, 10, 64); err != nil || input.ProjectID <= 0 {
+			respondBadRequest(c, "project_id must be a positive number")
+			return
+		}
+	}
+
+	var err error
+	if input.Limit, err = parseIntQuery(c, "limit"); err != nil {
+		respondBadRequest(c, "limit must be a number")
+		return
+	}
+
+	suggestions, err := h.search.Suggest(c.Request.Context(), input)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"suggestions": suggestions})
+}
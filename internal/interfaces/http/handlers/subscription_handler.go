@@ -0,0 +1,136 @@
+package handlers
+
+import (
+	"net/http"
+	"task-engine/internal/application/services"
+	"task-engine/internal/domain/entities"
+	"task-engine/internal/domain/entities/common"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SubscriptionHandler struct {
+	subscriptions *services.SubscriptionService
+}
+
+func NewSubscriptionHandler(subscriptions *services.SubscriptionService) *SubscriptionHandler {
+	return &SubscriptionHandler{subscriptions: subscriptions}
+}
+
+func (h *SubscriptionHandler) RegisterRoutes(rg *gin.RouterGroup) {
+	rg.GET("/subscriptions", h.ListSubscriptions)
+
+	rg.GET("/projects/:id/subscription", h.GetSubscription(entities.ProjectTarget))
+	rg.PUT("/projects/:id/subscription", h.Watch(entities.ProjectTarget))
+	rg.DELETE("/projects/:id/subscription", h.Unwatch(entities.ProjectTarget))
+	rg.GET("/projects/:id/watchers", h.ListWatchers(entities.ProjectTarget))
+
+	rg.GET("/tasks/:id/subscription", h.GetSubscription(entities.TaskTarget))
+	rg.PUT("/tasks/:id/subscription", h.Watch(entities.TaskTarget))
+	rg.DELETE("/tasks/:id/subscription", h.Unwatch(entities.TaskTarget))
+	rg.GET("/tasks/:id/watchers", h.ListWatchers(entities.TaskTarget))
+}
+
+// watchRequest is optional, muted_event_types is left unchanged when absent
+type watchRequest struct {
+	MutedEventTypes []common.EventType `json:"muted_event_types"`
+}
+
+// ListSubscriptions returns the caller's subscriptions, unsubscribed ones included
+func (h *SubscriptionHandler) ListSubscriptions(c *gin.Context) {
+	claims, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	subscriptions, err := h.subscriptions.ListSubscriptions(c.Request.Context(), claims.UserID)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"subscriptions": subscriptions})
+}
+
+func (h *SubscriptionHandler) GetSubscription(target func(int64) entities.SubscriptionTarget) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := parseIDParam(c, "id")
+		if !ok {
+			return
+		}
+		claims, ok := currentUser(c)
+		if !ok {
+			return
+		}
+
+		subscription, err := h.subscriptions.GetSubscription(c.Request.Context(), claims.UserID, target(id))
+		if err != nil {
+			respondError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, subscription)
+	}
+}
+
+// Watch subscribes the caller, the body may set the event types to mute
+func (h *SubscriptionHandler) Watch(target func(int64) entities.SubscriptionTarget) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := parseIDParam(c, "id")
+		if !ok {
+			return
+		}
+		claims, ok := currentUser(c)
+		if !ok {
+			return
+		}
+
+		var req watchRequest
+		if c.Request.ContentLength != 0 {
+			if err := c.ShouldBindJSON(&req); err != nil {
+				respondBadRequest(c, "invalid request body")
+				return
+			}
+		}
+
+		subscription, err := h.subscriptions.Watch(c.Request.Context(), claims.UserID, target(id), req.MutedEventTypes)
+		if err != nil {
+			respondError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, subscription)
+	}
+}
+
+func (h *SubscriptionHandler) Unwatch(target func(int64) entities.SubscriptionTarget) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := parseIDParam(c, "id")
+		if !ok {
+			return
+		}
+		claims, ok := currentUser(c)
+		if !ok {
+			return
+		}
+
+		if err := h.subscriptions.Unwatch(c.Request.Context(), claims.UserID, target(id)); err != nil {
+			respondError(c, err)
+			return
+		}
+		c.Status(http.StatusNoContent)
+	}
+}
+
+func (h *SubscriptionHandler) ListWatchers(target func(int64) entities.SubscriptionTarget) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := parseIDParam(c, "id")
+		if !ok {
+			return
+		}
+
+		watchers, err := h.subscriptions.ListWatchers(c.Request.Context(), target(id))
+		if err != nil {
+			respondError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"watchers": watchers})
+	}
+}
@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"net/http"
+	"task-engine/internal/application/services"
+	"task-engine/internal/domain/entities"
+	"task-engine/internal/domain/entities/common"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TaskBulkHandler struct {
+	bulk *services.TaskBulkService
+}
+
+func NewTaskBulkHandler(bulk *services.TaskBulkService) *TaskBulkHandler {
+	return &TaskBulkHandler{bulk: bulk}
+}
+
+func (h *TaskBulkHandler) RegisterRoutes(rg *gin.RouterGroup) {
+	rg.POST("/tasks/bulk", h.ApplyBulk)
+}
+
+// bulkTaskRequest sets only the field the action reads, an empty due_date clears the due dates
+type bulkTaskRequest struct {
+	TaskIDs   []int64               `json:"task_ids"`
+	Action    common.BulkTaskAction `json:"action"`
+	Mode      common.BulkMode       `json:"mode"`
+	Status    common.TaskStatus     `json:"status"`
+	Priority  common.TaskPriority   `json:"priority"`
+	DueDate   string                `json:"due_date"`
+	UserID    int64                 `json:"user_id"`
+	Tags      []string              `json:"tags"`
+	ProjectID int64                 `json:"project_id"`
+}
+
+type bulkTaskResult struct {
+	TaskID  int64        `json:"task_id"`
+	Applied bool         `json:"applied"`
+	Errors  []fieldError `json:"errors,omitempty"`
+}
+
+// ApplyBulk changes up to 100 tasks with one action. The response has a result per task;
+// an atomic request in which any task failed changes nothing and answers 422.
+func (h *TaskBulkHandler) ApplyBulk(c *gin.Context) {
+	claims, ok := requireManager(c)
+	if !ok {
+		return
+	}
+
+	var req bulkTaskRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBadRequest(c, "invalid request body")
+		return
+	}
+	dueDate, err := parseDate(req.DueDate)
+	if err != nil {
+		respondBadRequest(c, "due_date must use the YYYY-MM-DD format")
+		return
+	}
+
+	report, err := h.bulk.ApplyBulk(c.Request.Context(), services.BulkTaskInput{
+		Actor:     entities.TransitionActor{UserID: claims.UserID, Role: common.UserRole(claims.Role)},
+		TaskIDs:   req.TaskIDs,
+		Action:    req.Action,
+		Mode:      req.Mode,
+		Status:    req.Status,
+		Priority:  req.Priority,
+		DueDate:   dueDate,
+		UserID:    req.UserID,
+		Tags:      req.Tags,
+		ProjectID: req.ProjectID,
+	})
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	results := make([]bulkTaskResult, len(report.Results))
+	for i, result := range report.Results {
+		results[i] = bulkTaskResult{TaskID: result.TaskID, Applied: result.Applied}
+		for _, e := range result.Errors {
+			results[i].Errors = append(results[i].Errors, fieldError{Field: e.Field, Message: e.Message})
+		}
+	}
+
+	status := http.StatusOK
+	if report.Mode == common.BulkModeAtomic && report.Failed > 0 {
+		status = http.StatusUnprocessableEntity
+	}
+	c.JSON(status, gin.H{
+		"mode":    report.Mode,
+		"applied": report.Applied,
+		"failed":  report.Failed,
+		"results": results,
+	})
+}
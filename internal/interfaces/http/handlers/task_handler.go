@@ -0,0 +1,290 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"task-engine/internal/application/services"
+	"task-engine/internal/domain/entities"
+	"task-engine/internal/domain/entities/common"
+	"task-engine/internal/domain/repositories"
+	"task-engine/pkg/auth"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TaskHandler struct {
+	tasks *services.TaskService
+}
+
+func NewTaskHandler(tasks *services.TaskService) *TaskHandler {
+	return &TaskHandler{tasks: tasks}
+}
+
+func (h *TaskHandler) RegisterRoutes(rg *gin.RouterGroup) {
+	rg.GET("/projects/:id/tasks", h.ListTasks)
+	rg.POST("/projects/:id/tasks", h.CreateTask)
+	rg.GET("/projects/:id/metrics", h.GetMetrics)
+	rg.GET("/tasks/:id", h.GetTask)
+	rg.DELETE("/tasks/:id", h.DeleteTask)
+	rg.POST("/tasks/:id/transitions", h.TransitionTask)
+	rg.PUT("/tasks/:id/custom-fields", h.SetCustomFields)
+}
+
+type createTaskRequest struct {
+	ParentID         int64                  `json:"parent_id"`
+	Title            string                 `json:"title"`
+	Description      string                 `json:"description"`
+	Priority         common.TaskPriority    `json:"priority"`
+	DueDate          string                 `json:"due_date"`
+	EstimatedMinutes int64                  `json:"estimated_minutes"`
+	Tags             []string               `json:"tags"`
+	AssigneeIDs      []int64                `json:"assignee_ids"`
+	CustomFields     map[string]interface{} `json:"custom_fields"`
+}
+
+type transitionTaskRequest struct {
+	Status common.TaskStatus `json:"status"`
+}
+
+func (h *TaskHandler) CreateTask(c *gin.Context) {
+	projectID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	claims, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	var req createTaskRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBadRequest(c, "invalid request body")
+		return
+	}
+	dueDate, err := parseDate(req.DueDate)
+	if err != nil {
+		respondBadRequest(c, "due_date must use the YYYY-MM-DD format")
+		return
+	}
+
+	task, err := h.tasks.CreateTask(c.Request.Context(), projectID, services.CreateTaskInput{
+		CreatedBy:        claims.UserID,
+		ParentID:         req.ParentID,
+		Title:            req.Title,
+		Description:      req.Description,
+		Priority:         req.Priority,
+		DueDate:          dueDate,
+		EstimatedMinutes: req.EstimatedMinutes,
+		Tags:             req.Tags,
+		AssigneeIDs:      req.AssigneeIDs,
+		CustomFields:     req.CustomFields,
+	})
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, task)
+}
+
+func (h *TaskHandler) GetTask(c *gin.Context) {
+	taskID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	claims, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	task, err := h.tasks.GetVisibleTask(c.Request.Context(), taskID, claims.UserID, common.UserRole(claims.Role))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, task)
+}
+
+// DeleteTask removes the task for good together with its subtasks, comments and attachments
+func (h *TaskHandler) DeleteTask(c *gin.Context) {
+	taskID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	if _, ok := requireManager(c); !ok {
+		return
+	}
+
+	if err := h.tasks.DeleteTask(c.Request.Context(), taskID); err != nil {
+		respondError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// TransitionTask moves the task to another status, subject to the project's workflow
+func (h *TaskHandler) TransitionTask(c *gin.Context) {
+	taskID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	claims, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	var req transitionTaskRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBadRequest(c, "invalid request body")
+		return
+	}
+
+	task, err := h.tasks.TransitionTask(c.Request.Context(), taskID, req.Status, entities.TransitionActor{
+		UserID: claims.UserID,
+		Role:   common.UserRole(claims.Role),
+	})
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, task)
+}
+
+func (h *TaskHandler) GetMetrics(c *gin.Context) {
+	projectID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	metrics, err := h.tasks.GetMetrics(c.Request.Context(), projectID)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, metrics)
+}
+
+// ListTasks supports ?status=, ?category=, ?priority= and ?sort= as comma separated lists, ?limit=, ?offset=
+// and custom field filters written as ?cf.<key>=<value> or ?cf.<key>[<operator>]=<value>.
+// Sorting by a custom field uses ?sort=cf.<key>, a leading "-" sorts descending. Lists in the
+// default order or sorted by created_at, due_date or priority return a next_cursor, passed
+// as ?cursor= to read the following page.
+func (h *TaskHandler) ListTasks(c *gin.Context) {
+	projectID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	input := services.ListTasksInput{ProjectID: projectID}
+	for _, status := range splitList(c.Query("status")) {
+		input.Statuses = append(input.Statuses, common.TaskStatus(status))
+	}
+	for _, category := range splitList(c.Query("category")) {
+		input.Categories = append(input.Categories, common.StatusCategory(category))
+	}
+	for _, priority := range splitList(c.Query("priority")) {
+		input.Priorities = append(input.Priorities, common.TaskPriority(priority))
+	}
+	input.Sort = splitList(c.Query("sort"))
+	// q is a task query, where "me" is the caller
+	input.Query = c.Query("q")
+	if claims, ok := auth.CurrentClaims(c); ok {
+		input.UserID, input.Role = claims.UserID, common.UserRole(claims.Role)
+	}
+
+	var err error
+	if input.Limit, err = parseIntQuery(c, "limit"); err != nil {
+		respondBadRequest(c, "limit must be a number")
+		return
+	}
+	if input.Offset, err = parseIntQuery(c, "offset"); err != nil {
+		respondBadRequest(c, "offset must be a number")
+		return
+	}
+	input.Cursor = c.Query("cursor")
+
+	for param, values := range c.Request.URL.Query() {
+		key, ok := strings.CutPrefix(param, "cf.")
+		if !ok {
+			continue
+		}
+		var operator repositories.FilterOperator
+		if open := strings.Index(key, "["); open > 0 && strings.HasSuffix(key, "]") {
+			operator = repositories.FilterOperator(key[open+1 : len(key)-1])
+			key = key[:open]
+		}
+		for _, value := range values {
+			input.CustomFields = append(input.CustomFields, services.CustomFieldFilter{
+				Key:      key,
+				Operator: operator,
+				Value:    value,
+			})
+		}
+	}
+
+	page, err := h.tasks.ListTasksPage(c.Request.Context(), input)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	response := gin.H{"tasks": page.Tasks}
+	if page.NextCursor != "" {
+		response["next_cursor"] = page.NextCursor
+	}
+
+	// facets lists the fields to count every matching task by, not only the page returned
+	if names := splitList(c.Query("facets")); len(names) > 0 {
+		facets := make([]common.TaskFacet, len(names))
+		for i, name := range names {
+			facets[i] = common.TaskFacet(name)
+		}
+		counts, err := h.tasks.CountFacets(c.Request.Context(), input, facets)
+		if err != nil {
+			respondError(c, err)
+			return
+		}
+		response["facets"] = counts
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// SetCustomFields sets the custom fields present in the body, a null value clears the field
+func (h *TaskHandler) SetCustomFields(c *gin.Context) {
+	taskID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var req map[string]interface{}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBadRequest(c, "invalid request body")
+		return
+	}
+
+	task, err := h.tasks.SetCustomFields(c.Request.Context(), taskID, req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, task)
+}
+
+// splitList splits a comma separated query value, ignoring empty items
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// parseIntQuery reads an optional integer query parameter, returning 0 when absent
+func parseIntQuery(c *gin.Context, name string) (int, error) {
+	value := c.Query(name)
+	if value == "" {
+		return 0, nil
+	}
+	return strconv.Atoi(value)
+}
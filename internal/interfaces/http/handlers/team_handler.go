@@ -0,0 +1,118 @@
+package handlers
+
+import (
+	"net/http"
+	"task-engine/internal/application/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TeamHandler struct {
+	teams *services.TeamService
+}
+
+func NewTeamHandler(teams *services.TeamService) *TeamHandler {
+	return &TeamHandler{teams: teams}
+}
+
+func (h *TeamHandler) RegisterRoutes(rg *gin.RouterGroup) {
+	rg.GET("/teams", h.ListTeams)
+	rg.POST("/teams", h.CreateTeam)
+	rg.GET("/teams/:id", h.GetTeam)
+	rg.POST("/teams/:id/members", h.AddMember)
+	rg.DELETE("/teams/:id/members/:userId", h.RemoveMember)
+}
+
+type createTeamRequest struct {
+	Name   string `json:"name"`
+	Handle string `json:"handle"`
+}
+
+type addMemberRequest struct {
+	UserID int64 `json:"user_id"`
+}
+
+func (h *TeamHandler) ListTeams(c *gin.Context) {
+	teams, err := h.teams.ListTeams(c.Request.Context())
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"teams": teams})
+}
+
+func (h *TeamHandler) CreateTeam(c *gin.Context) {
+	if _, ok := requireManager(c); !ok {
+		return
+	}
+
+	var req createTeamRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBadRequest(c, "invalid request body")
+		return
+	}
+
+	team, err := h.teams.CreateTeam(c.Request.Context(), req.Name, req.Handle)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, team)
+}
+
+func (h *TeamHandler) GetTeam(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	team, err := h.teams.GetTeam(c.Request.Context(), id)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, team)
+}
+
+func (h *TeamHandler) AddMember(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	if _, ok := requireManager(c); !ok {
+		return
+	}
+
+	var req addMemberRequest
+	if err := c.ShouldBindJSON(&req); err != nil || req.UserID <= 0 {
+		respondBadRequest(c, "user_id is required")
+		return
+	}
+
+	team, err := h.teams.AddMember(c.Request.Context(), id, req.UserID)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, team)
+}
+
+func (h *TeamHandler) RemoveMember(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	userID, ok := parseIDParam(c, "userId")
+	if !ok {
+		return
+	}
+	if _, ok := requireManager(c); !ok {
+		return
+	}
+
+	if err := h.teams.RemoveMember(c.Request.Context(), id, userID); err != nil {
+		respondError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,225 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"task-engine/internal/application/services"
+	"task-engine/internal/domain/entities"
+	"task-engine/internal/domain/entities/common"
+
+	"github.com/gin-gonic/gin"
+)
+
+type WebhookHandler struct {
+	webhooks *services.WebhookService
+}
+
+func NewWebhookHandler(webhooks *services.WebhookService) *WebhookHandler {
+	return &WebhookHandler{webhooks: webhooks}
+}
+
+// RegisterRoutes registers the webhook routes, all of them require a manager as webhooks
+// carry signing secrets and project data
+func (h *WebhookHandler) RegisterRoutes(rg *gin.RouterGroup) {
+	rg.GET("/webhooks", h.ListWebhooks)
+	rg.POST("/webhooks", h.CreateWebhook)
+	rg.GET("/webhooks/:id", h.GetWebhook)
+	rg.PUT("/webhooks/:id", h.UpdateWebhook)
+	rg.DELETE("/webhooks/:id", h.DeleteWebhook)
+	rg.GET("/webhooks/:id/deliveries", h.ListDeliveries)
+
+	rg.GET("/webhook-deliveries/:id", h.GetDelivery)
+	rg.POST("/webhook-deliveries/:id/replay", h.ReplayDelivery)
+}
+
+// createWebhookRequest leaves project_id out for an organisation-wide webhook
+type createWebhookRequest struct {
+	ProjectID  int64              `json:"project_id"`
+	URL        string             `json:"url"`
+	EventTypes []common.EventType `json:"event_types"`
+}
+
+type updateWebhookRequest struct {
+	URL        *string             `json:"url"`
+	EventTypes *[]common.EventType `json:"event_types"`
+	Active     *bool               `json:"active"`
+}
+
+// createdWebhookResponse is the only response that shows the secret
+type createdWebhookResponse struct {
+	*entities.Webhook
+	Secret string `json:"secret"`
+}
+
+// ListWebhooks returns every webhook, or those of one project with ?project_id=
+func (h *WebhookHandler) ListWebhooks(c *gin.Context) {
+	if _, ok := requireManager(c); !ok {
+		return
+	}
+
+	var projectID int64
+	if value := c.Query("project_id"); value != "" {
+		var err error
+		if projectID, err = strconv.ParseInt(value, 10, 64); err != nil || projectID <= 0 {
+			respondBadRequest(c, "project_id must be a positive number")
+			return
+		}
+	}
+
+	webhooks, err := h.webhooks.ListWebhooks(c.Request.Context(), projectID)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"webhooks": webhooks})
+}
+
+func (h *WebhookHandler) CreateWebhook(c *gin.Context) {
+	claims, ok := requireManager(c)
+	if !ok {
+		return
+	}
+
+	var req createWebhookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBadRequest(c, "invalid request body")
+		return
+	}
+
+	webhook, err := h.webhooks.CreateWebhook(c.Request.Context(), services.CreateWebhookInput{
+		ProjectID:  req.ProjectID,
+		URL:        req.URL,
+		EventTypes: req.EventTypes,
+		CreatedBy:  claims.UserID,
+	})
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, createdWebhookResponse{Webhook: webhook, Secret: webhook.Secret})
+}
+
+func (h *WebhookHandler) GetWebhook(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	if _, ok := requireManager(c); !ok {
+		return
+	}
+
+	webhook, err := h.webhooks.GetWebhook(c.Request.Context(), id)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, webhook)
+}
+
+func (h *WebhookHandler) UpdateWebhook(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	if _, ok := requireManager(c); !ok {
+		return
+	}
+
+	var req updateWebhookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBadRequest(c, "invalid request body")
+		return
+	}
+
+	webhook, err := h.webhooks.UpdateWebhook(c.Request.Context(), id, services.UpdateWebhookInput{
+		URL:        req.URL,
+		EventTypes: req.EventTypes,
+		Active:     req.Active,
+	})
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, webhook)
+}
+
+func (h *WebhookHandler) DeleteWebhook(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	if _, ok := requireManager(c); !ok {
+		return
+	}
+
+	if err := h.webhooks.DeleteWebhook(c.Request.Context(), id); err != nil {
+		respondError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// ListDeliveries returns the delivery log of a webhook, newest first, filtered with ?status=
+func (h *WebhookHandler) ListDeliveries(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	if _, ok := requireManager(c); !ok {
+		return
+	}
+
+	input := services.WebhookDeliveriesInput{WebhookID: id, Status: common.WebhookDeliveryStatus(c.Query("status"))}
+	var err error
+	if input.Limit, err = parseIntQuery(c, "limit"); err != nil {
+		respondBadRequest(c, "limit must be a number")
+		return
+	}
+	if input.Offset, err = parseIntQuery(c, "offset"); err != nil {
+		respondBadRequest(c, "offset must be a number")
+		return
+	}
+
+	deliveries, err := h.webhooks.ListDeliveries(c.Request.Context(), input)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"deliveries": deliveries})
+}
+
+// GetDelivery returns a delivery with the request headers and response of every attempt
+func (h *WebhookHandler) GetDelivery(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	if _, ok := requireManager(c); !ok {
+		return
+	}
+
+	delivery, err := h.webhooks.GetDelivery(c.Request.Context(), id)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, delivery)
+}
+
+// ReplayDelivery queues the delivery's body again, the new delivery is sent by the dispatcher
+func (h *WebhookHandler) ReplayDelivery(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	if _, ok := requireManager(c); !ok {
+		return
+	}
+
+	delivery, err := h.webhooks.ReplayDelivery(c.Request.Context(), id)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusAccepted, delivery)
+}
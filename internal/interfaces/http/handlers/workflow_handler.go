@@ -0,0 +1,72 @@
+package handlers
+
+import (
+	"net/http"
+	"task-engine/internal/application/services"
+	"task-engine/internal/domain/entities"
+	"task-engine/internal/domain/entities/common"
+
+	"github.com/gin-gonic/gin"
+)
+
+type WorkflowHandler struct {
+	workflows *services.WorkflowService
+}
+
+func NewWorkflowHandler(workflows *services.WorkflowService) *WorkflowHandler {
+	return &WorkflowHandler{workflows: workflows}
+}
+
+func (h *WorkflowHandler) RegisterRoutes(rg *gin.RouterGroup) {
+	rg.GET("/projects/:id/workflow", h.GetWorkflow)
+	rg.PUT("/projects/:id/workflow", h.UpdateWorkflow)
+}
+
+type updateWorkflowRequest struct {
+	Name          string                        `json:"name"`
+	InitialStatus common.TaskStatus             `json:"initial_status"`
+	Statuses      []entities.WorkflowStatus     `json:"statuses"`
+	Transitions   []entities.WorkflowTransition `json:"transitions"`
+}
+
+func (h *WorkflowHandler) GetWorkflow(c *gin.Context) {
+	projectID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	workflow, err := h.workflows.GetWorkflow(c.Request.Context(), projectID)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, workflow)
+}
+
+func (h *WorkflowHandler) UpdateWorkflow(c *gin.Context) {
+	projectID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	if _, ok := requireManager(c); !ok {
+		return
+	}
+
+	var req updateWorkflowRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBadRequest(c, "invalid request body")
+		return
+	}
+
+	workflow, err := h.workflows.UpdateWorkflow(c.Request.Context(), projectID, services.UpdateWorkflowInput{
+		Name:          req.Name,
+		InitialStatus: req.InitialStatus,
+		Statuses:      req.Statuses,
+		Transitions:   req.Transitions,
+	})
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, workflow)
+}
@@ -0,0 +1,10 @@
+ALTER TABLE projects
+    DROP COLUMN IF EXISTS status,
+    DROP COLUMN IF EXISTS priority,
+    DROP COLUMN IF EXISTS owner_id,
+    DROP COLUMN IF EXISTS team_id,
+    DROP COLUMN IF EXISTS start_date,
+    DROP COLUMN IF EXISTS end_date,
+    DROP COLUMN IF EXISTS budget_amount,
+    DROP COLUMN IF EXISTS budget_currency,
+    DROP COLUMN IF EXISTS deleted_at;
\ No newline at end of file
@@ -0,0 +1,10 @@
+ALTER TABLE projects
+    ADD COLUMN status VARCHAR(50) NOT NULL DEFAULT 'active',
+    ADD COLUMN priority VARCHAR(50) NOT NULL DEFAULT 'medium',
+    ADD COLUMN owner_id INTEGER,
+    ADD COLUMN team_id INTEGER,
+    ADD COLUMN start_date DATE,
+    ADD COLUMN end_date DATE,
+    ADD COLUMN budget_amount BIGINT NOT NULL DEFAULT 0,      -- Budget in currency minor units (e.g. cents)
+    ADD COLUMN budget_currency CHAR(3) NOT NULL DEFAULT 'BRL', -- ISO 4217 currency code
+    ADD COLUMN deleted_at TIMESTAMP;
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS project_expenses;
\ No newline at end of file
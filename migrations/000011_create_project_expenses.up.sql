@@ -0,0 +1,16 @@
+CREATE TABLE project_expenses (
+    id SERIAL PRIMARY KEY,
+    project_id INTEGER NOT NULL REFERENCES projects(id),
+    kind VARCHAR(20) NOT NULL,              -- planned | actual
+    category VARCHAR(50) NOT NULL,
+    description VARCHAR(500),
+    amount BIGINT NOT NULL,                 -- Amount in currency minor units
+    currency CHAR(3) NOT NULL,
+    incurred_on DATE NOT NULL,
+    created_by INTEGER,
+    created_at TIMESTAMP DEFAULT NOW(),
+    updated_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX idx_project_expenses_project_kind ON project_expenses(project_id, kind); -- For ledger totals per project
+CREATE INDEX idx_project_expenses_incurred_on ON project_expenses(project_id, incurred_on DESC); -- For listing recent expenses
\ No newline at end of file
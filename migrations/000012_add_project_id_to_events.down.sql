@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_events_project_created_at;
+ALTER TABLE events DROP COLUMN IF EXISTS project_id;
\ No newline at end of file
@@ -0,0 +1,3 @@
+ALTER TABLE events ADD COLUMN project_id INTEGER REFERENCES projects(id);
+
+CREATE INDEX idx_events_project_created_at ON events(project_id, created_at DESC); -- For filtering recent events by project
\ No newline at end of file
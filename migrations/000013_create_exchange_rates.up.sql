@@ -0,0 +1,11 @@
+CREATE TABLE exchange_rates (
+    id SERIAL PRIMARY KEY,
+    base_currency CHAR(3) NOT NULL,
+    quote_currency CHAR(3) NOT NULL,
+    rate NUMERIC(20, 10) NOT NULL,           -- Units of quote_currency per unit of base_currency
+    rate_date DATE NOT NULL,
+    created_at TIMESTAMP DEFAULT NOW(),
+    CONSTRAINT chk_exchange_rates_positive CHECK (rate > 0)
+);
+
+CREATE UNIQUE INDEX idx_exchange_rates_pair_date ON exchange_rates(base_currency, quote_currency, rate_date DESC); -- One rate per pair and day, latest first
\ No newline at end of file
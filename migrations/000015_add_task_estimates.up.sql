@@ -0,0 +1,3 @@
+ALTER TABLE tasks
+    ADD COLUMN estimated_minutes INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN remaining_minutes INTEGER;   -- NULL means estimated_minutes minus logged time
\ No newline at end of file
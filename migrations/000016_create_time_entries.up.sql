@@ -0,0 +1,12 @@
+CREATE TABLE time_entries (
+    id SERIAL PRIMARY KEY,
+    task_id INTEGER NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id),
+    minutes INTEGER NOT NULL CHECK (minutes > 0),
+    spent_on DATE NOT NULL,
+    description VARCHAR(500),
+    created_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX idx_time_entries_task_id ON time_entries(task_id);                      -- For summing effort per task
+CREATE INDEX idx_time_entries_user_spent_on ON time_entries(user_id, spent_on DESC); -- For listing a user's recent effort
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS hourly_rates;
\ No newline at end of file
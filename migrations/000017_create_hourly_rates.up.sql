@@ -0,0 +1,13 @@
+CREATE TABLE hourly_rates (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER REFERENCES users(id),
+    role VARCHAR(50),
+    rate_amount BIGINT NOT NULL CHECK (rate_amount > 0), -- Cost of one hour in currency minor units
+    rate_currency CHAR(3) NOT NULL,
+    effective_from DATE NOT NULL,
+    created_at TIMESTAMP DEFAULT NOW(),
+    CONSTRAINT chk_hourly_rates_subject CHECK ((user_id IS NULL) <> (role IS NULL))
+);
+
+CREATE UNIQUE INDEX idx_hourly_rates_user_effective ON hourly_rates(user_id, effective_from) WHERE user_id IS NOT NULL; -- One user rate per day
+CREATE UNIQUE INDEX idx_hourly_rates_role_effective ON hourly_rates(role, effective_from) WHERE role IS NOT NULL;       -- One role rate per day
\ No newline at end of file
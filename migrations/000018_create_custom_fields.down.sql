@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS task_custom_field_values;
+DROP TABLE IF EXISTS custom_field_definitions;
\ No newline at end of file
@@ -0,0 +1,31 @@
+CREATE TABLE custom_field_definitions (
+    id SERIAL PRIMARY KEY,
+    project_id INTEGER NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+    key VARCHAR(50) NOT NULL,                 -- Stable identifier used in the API (e.g. cf.severity)
+    name VARCHAR(100) NOT NULL,
+    field_type VARCHAR(20) NOT NULL,          -- text | number | date | single_select | multi_select | user
+    options TEXT[] NOT NULL DEFAULT '{}',     -- Allowed values for select fields
+    required BOOLEAN NOT NULL DEFAULT FALSE,
+    position INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP DEFAULT NOW(),
+    updated_at TIMESTAMP DEFAULT NOW(),
+    UNIQUE (project_id, key)
+);
+
+CREATE TABLE task_custom_field_values (
+    task_id INTEGER NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    field_id INTEGER NOT NULL REFERENCES custom_field_definitions(id) ON DELETE CASCADE,
+    value_text TEXT,
+    value_number NUMERIC,
+    value_date DATE,
+    value_options TEXT[],
+    value_user_id INTEGER REFERENCES users(id),
+    PRIMARY KEY (task_id, field_id)
+);
+
+-- Typed indexes for filtering and sorting tasks by custom field values
+CREATE INDEX idx_task_cf_values_text ON task_custom_field_values(field_id, value_text);
+CREATE INDEX idx_task_cf_values_number ON task_custom_field_values(field_id, value_number);
+CREATE INDEX idx_task_cf_values_date ON task_custom_field_values(field_id, value_date);
+CREATE INDEX idx_task_cf_values_user ON task_custom_field_values(field_id, value_user_id);
+CREATE INDEX idx_task_cf_values_options ON task_custom_field_values USING gin(value_options);
\ No newline at end of file
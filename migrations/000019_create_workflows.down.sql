@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_tasks_project_status_category;
+ALTER TABLE tasks DROP COLUMN IF EXISTS status_category;
+DROP TABLE IF EXISTS workflows;
@@ -0,0 +1,22 @@
+CREATE TABLE workflows (
+    id SERIAL PRIMARY KEY,
+    project_id INTEGER NOT NULL UNIQUE REFERENCES projects(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    initial_status VARCHAR(50) NOT NULL,
+    statuses JSONB NOT NULL,                  -- [{key, name, category}]
+    transitions JSONB NOT NULL DEFAULT '[]',  -- [{from, to, guards}], empty allows any move
+    created_at TIMESTAMP DEFAULT NOW(),
+    updated_at TIMESTAMP DEFAULT NOW()
+);
+
+-- Category of the task's status, kept in sync with the workflow so reports do not depend on status names
+ALTER TABLE tasks ADD COLUMN status_category VARCHAR(20) NOT NULL DEFAULT 'todo';
+
+UPDATE tasks SET status_category = CASE status
+    WHEN 'in_progress' THEN 'doing'
+    WHEN 'completed' THEN 'done'
+    WHEN 'cancelled' THEN 'cancelled'
+    ELSE 'todo'
+END;
+
+CREATE INDEX idx_tasks_project_status_category ON tasks(project_id, status_category);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS project_templates;
+DROP INDEX IF EXISTS idx_tasks_parent_id;
+ALTER TABLE tasks DROP COLUMN IF EXISTS parent_id;
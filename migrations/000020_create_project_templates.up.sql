@@ -0,0 +1,14 @@
+ALTER TABLE tasks ADD COLUMN parent_id INTEGER REFERENCES tasks(id) ON DELETE CASCADE;   -- Set for subtasks
+
+CREATE INDEX idx_tasks_parent_id ON tasks(parent_id);
+
+CREATE TABLE project_templates (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    description TEXT,
+    source_project_id INTEGER REFERENCES projects(id) ON DELETE SET NULL,
+    content JSONB NOT NULL,                   -- Workflow, custom fields and tasks with dates relative to the project start
+    created_by INTEGER REFERENCES users(id),
+    created_at TIMESTAMP DEFAULT NOW(),
+    updated_at TIMESTAMP DEFAULT NOW()
+);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS task_checklist_items;
+ALTER TABLE projects DROP COLUMN IF EXISTS settings;
@@ -0,0 +1,14 @@
+ALTER TABLE projects ADD COLUMN settings JSONB NOT NULL DEFAULT '{}';   -- Per-project options, see entities.ProjectSettings
+
+CREATE TABLE task_checklist_items (
+    id SERIAL PRIMARY KEY,
+    task_id INTEGER NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    text VARCHAR(500) NOT NULL,
+    position INTEGER NOT NULL DEFAULT 0,
+    checked_by INTEGER REFERENCES users(id),
+    checked_at TIMESTAMP,                     -- NULL while the item is open
+    created_at TIMESTAMP DEFAULT NOW(),
+    updated_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX idx_task_checklist_items_task_id ON task_checklist_items(task_id, position);
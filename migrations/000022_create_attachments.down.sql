@@ -0,0 +1,10 @@
+ALTER TABLE events DROP CONSTRAINT events_task_id_fkey,
+    ADD CONSTRAINT events_task_id_fkey FOREIGN KEY (task_id) REFERENCES tasks(id);
+ALTER TABLE comments DROP CONSTRAINT comments_task_id_fkey,
+    ADD CONSTRAINT comments_task_id_fkey FOREIGN KEY (task_id) REFERENCES tasks(id);
+ALTER TABLE task_tags DROP CONSTRAINT task_tags_task_id_fkey,
+    ADD CONSTRAINT task_tags_task_id_fkey FOREIGN KEY (task_id) REFERENCES tasks(id);
+ALTER TABLE assignments DROP CONSTRAINT assignments_task_id_fkey,
+    ADD CONSTRAINT assignments_task_id_fkey FOREIGN KEY (task_id) REFERENCES tasks(id);
+
+DROP TABLE IF EXISTS attachments;
@@ -0,0 +1,25 @@
+CREATE TABLE attachments (
+    id SERIAL PRIMARY KEY,
+    task_id INTEGER NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    comment_id INTEGER REFERENCES comments(id) ON DELETE CASCADE,   -- NULL when attached to the task itself
+    blob_key CHAR(64) NOT NULL,                                     -- SHA-256 of the content, see repositories.BlobStore
+    filename VARCHAR(255) NOT NULL,
+    content_type VARCHAR(255) NOT NULL,                             -- Sniffed from the content, not taken from the client
+    size_bytes BIGINT NOT NULL,
+    uploaded_by INTEGER REFERENCES users(id),
+    created_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX idx_attachments_task_id ON attachments(task_id, created_at);
+CREATE INDEX idx_attachments_comment_id ON attachments(comment_id);
+CREATE INDEX idx_attachments_blob_key ON attachments(blob_key);   -- For blob garbage collection
+
+-- Tasks can be hard-deleted: rows owned by the task go with it, events are kept for the project history
+ALTER TABLE assignments DROP CONSTRAINT assignments_task_id_fkey,
+    ADD CONSTRAINT assignments_task_id_fkey FOREIGN KEY (task_id) REFERENCES tasks(id) ON DELETE CASCADE;
+ALTER TABLE task_tags DROP CONSTRAINT task_tags_task_id_fkey,
+    ADD CONSTRAINT task_tags_task_id_fkey FOREIGN KEY (task_id) REFERENCES tasks(id) ON DELETE CASCADE;
+ALTER TABLE comments DROP CONSTRAINT comments_task_id_fkey,
+    ADD CONSTRAINT comments_task_id_fkey FOREIGN KEY (task_id) REFERENCES tasks(id) ON DELETE CASCADE;
+ALTER TABLE events DROP CONSTRAINT events_task_id_fkey,
+    ADD CONSTRAINT events_task_id_fkey FOREIGN KEY (task_id) REFERENCES tasks(id) ON DELETE SET NULL;
@@ -0,0 +1,19 @@
+CREATE TABLE subscriptions (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    project_id INTEGER REFERENCES projects(id) ON DELETE CASCADE,
+    task_id INTEGER REFERENCES tasks(id) ON DELETE CASCADE,
+    reason VARCHAR(20) NOT NULL,                        -- watch, created, assigned, commented
+    muted_event_types TEXT[] NOT NULL DEFAULT '{}',
+    unsubscribed_at TIMESTAMP,                          -- Kept so automatic watching does not resubscribe
+    created_at TIMESTAMP DEFAULT NOW(),
+    updated_at TIMESTAMP DEFAULT NOW(),
+    CHECK ((project_id IS NULL) <> (task_id IS NULL))
+);
+
+CREATE UNIQUE INDEX idx_subscriptions_user_project ON subscriptions(user_id, project_id) WHERE project_id IS NOT NULL;
+CREATE UNIQUE INDEX idx_subscriptions_user_task ON subscriptions(user_id, task_id) WHERE task_id IS NOT NULL;
+CREATE INDEX idx_subscriptions_project_id ON subscriptions(project_id) WHERE project_id IS NOT NULL;
+CREATE INDEX idx_subscriptions_task_id ON subscriptions(task_id) WHERE task_id IS NOT NULL;
+
+ALTER TABLE events ADD COLUMN actor_id INTEGER REFERENCES users(id);   -- Who caused the event, NULL for system events
@@ -0,0 +1,14 @@
+ALTER TABLE users ADD COLUMN timezone VARCHAR(64) NOT NULL DEFAULT 'UTC';   -- IANA name, digests are sent in local time
+
+CREATE TABLE digest_schedules (
+    user_id INTEGER PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    frequency VARCHAR(10) NOT NULL,                     -- daily, weekly
+    hour SMALLINT NOT NULL CHECK (hour BETWEEN 0 AND 23),
+    weekday SMALLINT NOT NULL DEFAULT 1 CHECK (weekday BETWEEN 0 AND 6),   -- 0 is Sunday, weekly only
+    next_run_at TIMESTAMP NOT NULL,                     -- UTC
+    last_sent_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT NOW(),
+    updated_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX idx_digest_schedules_next_run_at ON digest_schedules(next_run_at);
@@ -0,0 +1,31 @@
+CREATE TABLE teams (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    handle VARCHAR(50) NOT NULL UNIQUE,                 -- Lowercase, mentioned as @handle
+    created_at TIMESTAMP DEFAULT NOW(),
+    updated_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE TABLE team_members (
+    team_id INTEGER NOT NULL REFERENCES teams(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMP DEFAULT NOW(),
+    PRIMARY KEY (team_id, user_id)
+);
+
+CREATE INDEX idx_team_members_user_id ON team_members(user_id);
+
+CREATE TABLE comment_mentions (
+    id SERIAL PRIMARY KEY,
+    comment_id INTEGER NOT NULL REFERENCES comments(id) ON DELETE CASCADE,
+    kind VARCHAR(10) NOT NULL,                          -- user, team
+    user_id INTEGER REFERENCES users(id) ON DELETE CASCADE,
+    team_id INTEGER REFERENCES teams(id) ON DELETE CASCADE,
+    handle VARCHAR(50) NOT NULL,                        -- As written in the comment
+    start_offset INTEGER NOT NULL,                      -- Code point offsets into the content, end excluded
+    end_offset INTEGER NOT NULL,
+    CHECK ((user_id IS NULL) <> (team_id IS NULL))
+);
+
+CREATE INDEX idx_comment_mentions_comment_id ON comment_mentions(comment_id);
+CREATE INDEX idx_comment_mentions_user_id ON comment_mentions(user_id) WHERE user_id IS NOT NULL;
@@ -0,0 +1,25 @@
+ALTER TABLE comments
+    ADD COLUMN parent_id INTEGER REFERENCES comments(id) ON DELETE CASCADE,   -- Top comment of the thread, NULL for top comments
+    ADD COLUMN edited_at TIMESTAMP,
+    ADD COLUMN deleted_at TIMESTAMP,                    -- Set on tombstones, the row is kept for the thread
+    ADD COLUMN deleted_by INTEGER REFERENCES users(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_comments_parent_id ON comments(parent_id) WHERE parent_id IS NOT NULL;
+
+CREATE TABLE comment_revisions (
+    id SERIAL PRIMARY KEY,
+    comment_id INTEGER NOT NULL REFERENCES comments(id) ON DELETE CASCADE,
+    content TEXT NOT NULL,                              -- Content before the edit
+    edited_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX idx_comment_revisions_comment_id ON comment_revisions(comment_id, created_at);
+
+CREATE TABLE comment_reactions (
+    comment_id INTEGER NOT NULL REFERENCES comments(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    emoji VARCHAR(32) NOT NULL,
+    created_at TIMESTAMP DEFAULT NOW(),
+    PRIMARY KEY (comment_id, user_id, emoji)
+);
@@ -0,0 +1,45 @@
+CREATE TABLE webhooks (
+    id SERIAL PRIMARY KEY,
+    project_id INTEGER REFERENCES projects(id) ON DELETE CASCADE,   -- NULL for webhooks receiving every project
+    url VARCHAR(2048) NOT NULL,
+    secret VARCHAR(255) NOT NULL,              -- HMAC-SHA256 key of the signature header
+    event_types TEXT[] NOT NULL DEFAULT '{}',  -- Empty means every event type
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP DEFAULT NOW(),
+    updated_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX idx_webhooks_project ON webhooks(project_id) WHERE active;
+
+CREATE TABLE webhook_deliveries (
+    id SERIAL PRIMARY KEY,
+    webhook_id INTEGER NOT NULL REFERENCES webhooks(id) ON DELETE CASCADE,
+    event_id INTEGER REFERENCES events(id) ON DELETE SET NULL,
+    event_type VARCHAR(100) NOT NULL,
+    request_body JSON NOT NULL,                -- JSON rather than JSONB keeps the exact bytes every attempt and replay sends
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP,
+    response_status INTEGER,
+    last_error TEXT,
+    delivered_at TIMESTAMP,
+    replay_of INTEGER REFERENCES webhook_deliveries(id) ON DELETE SET NULL,
+    created_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX idx_webhook_deliveries_webhook ON webhook_deliveries(webhook_id, id DESC);
+CREATE INDEX idx_webhook_deliveries_due ON webhook_deliveries(next_attempt_at) WHERE status = 'pending';
+
+CREATE TABLE webhook_attempts (
+    id SERIAL PRIMARY KEY,
+    delivery_id INTEGER NOT NULL REFERENCES webhook_deliveries(id) ON DELETE CASCADE,
+    request_headers JSONB NOT NULL DEFAULT '{}',
+    response_status INTEGER,
+    response_body TEXT,                        -- Truncated to the first 64 KiB
+    error TEXT,                                -- Set when no response was received
+    duration_ms INTEGER NOT NULL DEFAULT 0,
+    attempted_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_webhook_attempts_delivery ON webhook_attempts(delivery_id, id);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS task_commits;
+
+ALTER TABLE projects DROP COLUMN IF EXISTS key;
@@ -0,0 +1,17 @@
+ALTER TABLE projects ADD COLUMN key VARCHAR(10) UNIQUE;   -- Prefix of task references in commit messages, e.g. PROJ in PROJ-45
+
+CREATE TABLE task_commits (
+    id SERIAL PRIMARY KEY,
+    task_id INTEGER NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    sha VARCHAR(64) NOT NULL,
+    repository VARCHAR(255) NOT NULL DEFAULT '',
+    branch VARCHAR(255) NOT NULL DEFAULT '',           -- Branch the commit was first seen on
+    message TEXT NOT NULL,
+    author_name VARCHAR(255) NOT NULL DEFAULT '',
+    author_email VARCHAR(255) NOT NULL DEFAULT '',
+    url VARCHAR(2048) NOT NULL DEFAULT '',
+    committed_at TIMESTAMP,
+    comment_id INTEGER REFERENCES comments(id) ON DELETE SET NULL,
+    created_at TIMESTAMP DEFAULT NOW(),
+    UNIQUE (task_id, sha)
+);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS task_emails;
+
+ALTER TABLE projects DROP COLUMN IF EXISTS inbound_email;
@@ -0,0 +1,14 @@
+ALTER TABLE projects ADD COLUMN inbound_email VARCHAR(255) UNIQUE;   -- Lowercase address whose emails become tasks of the project
+
+CREATE TABLE task_emails (
+    id SERIAL PRIMARY KEY,
+    message_id VARCHAR(998) NOT NULL UNIQUE,   -- Message-ID header without angle brackets
+    task_id INTEGER NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    comment_id INTEGER REFERENCES comments(id) ON DELETE CASCADE,   -- Set when the email was a reply
+    from_address VARCHAR(255) NOT NULL,
+    subject VARCHAR(998) NOT NULL DEFAULT '',
+    received_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX idx_task_emails_task_id ON task_emails(task_id);
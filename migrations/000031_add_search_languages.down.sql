@@ -0,0 +1,21 @@
+DROP INDEX IF EXISTS idx_comments_search_vector;
+DROP INDEX IF EXISTS idx_tasks_search_vector;
+DROP INDEX IF EXISTS idx_projects_search_vector;
+
+ALTER TABLE comments DROP COLUMN IF EXISTS search_vector;
+ALTER TABLE tasks DROP COLUMN IF EXISTS search_vector;
+ALTER TABLE projects DROP COLUMN IF EXISTS search_vector;
+
+ALTER TABLE comments DROP COLUMN IF EXISTS search_language;
+ALTER TABLE tasks DROP COLUMN IF EXISTS search_language;
+ALTER TABLE projects DROP COLUMN IF EXISTS search_language;
+
+CREATE INDEX idx_tasks_fulltext ON tasks USING gin(
+  to_tsvector('portuguese', title || ' ' || COALESCE(description, ''))
+);
+CREATE INDEX idx_projects_fulltext ON projects USING gin(
+  to_tsvector('portuguese', name || ' ' || COALESCE(description, ''))
+);
+CREATE INDEX idx_comments_fulltext ON comments USING gin(
+  to_tsvector('portuguese', content)
+);
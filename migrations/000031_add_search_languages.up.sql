@@ -0,0 +1,24 @@
+-- ==== Per-project search languages
+-- Each row keeps the text search configuration of its project, so its search vector can be a
+-- generated column. Tasks and comments take their project's language when written, and are
+-- moved to a new one by the reindex-search command.
+
+ALTER TABLE projects ADD COLUMN search_language REGCONFIG NOT NULL DEFAULT 'portuguese';
+ALTER TABLE tasks ADD COLUMN search_language REGCONFIG NOT NULL DEFAULT 'portuguese';
+ALTER TABLE comments ADD COLUMN search_language REGCONFIG NOT NULL DEFAULT 'portuguese';
+
+ALTER TABLE projects ADD COLUMN search_vector TSVECTOR
+    GENERATED ALWAYS AS (to_tsvector(search_language, name || ' ' || COALESCE(description, ''))) STORED;
+ALTER TABLE tasks ADD COLUMN search_vector TSVECTOR
+    GENERATED ALWAYS AS (to_tsvector(search_language, title || ' ' || COALESCE(description, ''))) STORED;
+ALTER TABLE comments ADD COLUMN search_vector TSVECTOR
+    GENERATED ALWAYS AS (to_tsvector(search_language, content)) STORED;
+
+-- The Portuguese expression indexes are replaced by indexes on the stored vectors
+DROP INDEX IF EXISTS idx_tasks_fulltext;
+DROP INDEX IF EXISTS idx_projects_fulltext;
+DROP INDEX IF EXISTS idx_comments_fulltext;
+
+CREATE INDEX idx_projects_search_vector ON projects USING gin(search_vector);
+CREATE INDEX idx_tasks_search_vector ON tasks USING gin(search_vector);
+CREATE INDEX idx_comments_search_vector ON comments USING gin(search_vector);
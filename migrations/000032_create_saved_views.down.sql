@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS saved_view_pins;
+DROP TABLE IF EXISTS saved_views;
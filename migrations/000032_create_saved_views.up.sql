@@ -0,0 +1,26 @@
+CREATE TABLE saved_views (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    owner_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    project_id INTEGER REFERENCES projects(id) ON DELETE CASCADE,  -- NULL for views spanning every project
+    visibility VARCHAR(20) NOT NULL DEFAULT 'private',
+    team_id INTEGER REFERENCES teams(id) ON DELETE CASCADE,          -- Set for team views only
+    query TEXT NOT NULL DEFAULT '',                                 -- Task query, see the task query language
+    sort TEXT[] NOT NULL DEFAULT '{}',
+    group_by VARCHAR(20),
+    columns TEXT[] NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP DEFAULT NOW(),
+    updated_at TIMESTAMP DEFAULT NOW(),
+    CONSTRAINT saved_views_team CHECK ((visibility = 'team') = (team_id IS NOT NULL))
+);
+
+CREATE INDEX idx_saved_views_owner ON saved_views(owner_id);
+CREATE INDEX idx_saved_views_team ON saved_views(team_id) WHERE team_id IS NOT NULL;
+CREATE INDEX idx_saved_views_project ON saved_views(project_id) WHERE visibility = 'project';
+
+CREATE TABLE saved_view_pins (
+    view_id INTEGER NOT NULL REFERENCES saved_views(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMP DEFAULT NOW(),
+    PRIMARY KEY (user_id, view_id)
+);
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_tasks_title_lower;
+DROP INDEX IF EXISTS idx_projects_name_lower;
+DROP INDEX IF EXISTS idx_users_username_lower;
+
+CREATE INDEX idx_tasks_title_lower ON tasks(LOWER(title));
+CREATE INDEX idx_projects_name_lower ON projects(LOWER(name));
+CREATE INDEX idx_users_username_lower ON users(LOWER(username));
@@ -0,0 +1,10 @@
+-- LIKE 'prefix%' only uses a plain index under the C collation. text_pattern_ops indexes
+-- serve prefix matches under any collation, and equality lookups as before. They no longer
+-- serve ORDER BY LOWER(...), task lists sort the tasks of one project after filtering anyway.
+DROP INDEX IF EXISTS idx_tasks_title_lower;
+DROP INDEX IF EXISTS idx_projects_name_lower;
+DROP INDEX IF EXISTS idx_users_username_lower;
+
+CREATE INDEX idx_tasks_title_lower ON tasks(LOWER(title) text_pattern_ops);
+CREATE INDEX idx_projects_name_lower ON projects(LOWER(name) text_pattern_ops);
+CREATE INDEX idx_users_username_lower ON users(LOWER(username) text_pattern_ops);
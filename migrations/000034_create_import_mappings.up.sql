@@ -0,0 +1,9 @@
+CREATE TABLE import_mappings (
+    source VARCHAR(20) NOT NULL,          -- Tool the record was exported from: trello or jira
+    kind VARCHAR(20) NOT NULL,            -- project, task or comment
+    external_id VARCHAR(255) NOT NULL,    -- Id of the record in its tool, e.g. a Trello card id or a Jira issue id
+    entity_id INTEGER NOT NULL,           -- Id of the project, task or comment it was imported as
+    created_at TIMESTAMP DEFAULT NOW(),
+    updated_at TIMESTAMP DEFAULT NOW(),
+    PRIMARY KEY (source, kind, external_id)
+);
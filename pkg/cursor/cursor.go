@@ -0,0 +1,48 @@
+package cursor
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+)
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// signingContext keeps cursors and access tokens apart when they share a secret
+const signingContext = "cursor:"
+
+// Encode returns an opaque cursor holding v as JSON, signed with an HMAC-SHA256 of the
+// secret so that clients cannot forge or alter it. Cursors are not encrypted.
+func Encode(secret string, v interface{}) (string, error) {
+	payload, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	data := base64.RawURLEncoding.EncodeToString(payload)
+	return data + "." + sign(secret, data), nil
+}
+
+// Decode checks the signature of the cursor and reads it into v
+func Decode(secret, cursor string, v interface{}) error {
+	data, signature, ok := strings.Cut(cursor, ".")
+	if !ok || !hmac.Equal([]byte(sign(secret, data)), []byte(signature)) {
+		return ErrInvalidCursor
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(data)
+	if err != nil {
+		return ErrInvalidCursor
+	}
+	if err := json.Unmarshal(payload, v); err != nil {
+		return ErrInvalidCursor
+	}
+	return nil
+}
+
+func sign(secret, data string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(signingContext + data))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}